@@ -2,8 +2,10 @@ module github.com/edgexfoundry/device-sdk-go/v2
 
 require (
 	bitbucket.org/bertimus9/systemstat v0.0.0-20180207000608-0eeff89b0690
+	github.com/BurntSushi/toml v0.3.1
 	github.com/OneOfOne/xxhash v1.2.8
 	github.com/edgexfoundry/go-mod-bootstrap/v2 v2.0.0-dev.2
+	github.com/edgexfoundry/go-mod-configuration/v2 v2.0.0-dev.1
 	github.com/edgexfoundry/go-mod-core-contracts/v2 v2.0.0-dev.9
 	github.com/edgexfoundry/go-mod-registry/v2 v2.0.0-dev.1
 	github.com/fxamacker/cbor/v2 v2.2.0
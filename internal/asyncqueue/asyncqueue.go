@@ -0,0 +1,242 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asyncqueue bounds the asynchronous readings pending processing and applies
+// the configured overflow policy when the ProtocolDriver pushes them faster than the
+// Device Service processes them or than the configured rate.
+package asyncqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const drainPollInterval = 50 * time.Millisecond
+
+// The overflow policies of a full queue.
+const (
+	// PolicyBlock blocks the ProtocolDriver until there is room in the queue.
+	PolicyBlock = "block"
+	// PolicyDropOldest discards the oldest pending AsyncValues to make room.
+	PolicyDropOldest = "drop-oldest"
+	// PolicyDropNewest discards the incoming AsyncValues.
+	PolicyDropNewest = "drop-newest"
+)
+
+// Metrics are the counters of the queue.
+type Metrics struct {
+	Policy   string `json:"policy"`
+	Capacity int    `json:"capacity"`
+	Length   int    `json:"length"`
+	Received uint64 `json:"received"`
+	Dropped  uint64 `json:"dropped"`
+	// Throttled counts the AsyncValues dropped as they exceeded the rate limit.
+	Throttled uint64 `json:"throttled"`
+}
+
+// Queue moves the AsyncValues pushed by the ProtocolDriver to the bounded queue the
+// Device Service processes them from.
+type Queue struct {
+	// the counters are accessed atomically and kept first for their 64-bit alignment
+	received  uint64
+	dropped   uint64
+	throttled uint64
+	policy    string
+	limiter   *rateLimiter
+	out       chan *dsModels.AsyncValues
+	lc        logger.LoggingClient
+}
+
+var (
+	current *Queue
+	mutex   sync.RWMutex
+)
+
+// NewQueue initiates the queue with the capacity and the overflow policy, which defaults
+// to PolicyBlock. If rate is positive, at most rate AsyncValues per second are queued,
+// with bursts of up to burst AsyncValues: those in excess wait for their turn with
+// PolicyBlock, holding back the ProtocolDriver, are dropped with PolicyDropNewest, and
+// with PolicyDropOldest the latest wait for the next token, replacing those waiting.
+func NewQueue(capacity int, policy string, rate float64, burst int, lc logger.LoggingClient) (*Queue, error) {
+	switch policy {
+	case "":
+		policy = PolicyBlock
+	case PolicyBlock, PolicyDropOldest, PolicyDropNewest:
+	default:
+		return nil, fmt.Errorf("unknown async overflow policy %s", policy)
+	}
+	if capacity < 0 {
+		capacity = 0
+	}
+	// an unbuffered queue is never full, nothing to drop to make room
+	if capacity == 0 && policy != PolicyBlock {
+		return nil, fmt.Errorf("the async queue capacity must be positive with the %s policy", policy)
+	}
+
+	queue := &Queue{
+		policy:  policy,
+		limiter: newRateLimiter(rate, burst),
+		out:     make(chan *dsModels.AsyncValues, capacity),
+		lc:      lc,
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	current = queue
+	return queue, nil
+}
+
+// GetQueue returns the queue, which may be nil if the asynchronous readings are disabled.
+func GetQueue() *Queue {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// Out returns the channel the queued AsyncValues are received from.
+func (q *Queue) Out() <-chan *dsModels.AsyncValues {
+	return q.out
+}
+
+// Pump queues the AsyncValues received from in until the context is done.
+func (q *Queue) Pump(ctx context.Context, wg *sync.WaitGroup, in <-chan *dsModels.AsyncValues) {
+	wg.Add(1)
+	defer wg.Done()
+
+	// the AsyncValues waiting for the next token with PolicyDropOldest
+	var pending *dsModels.AsyncValues
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case acv := <-in:
+			atomic.AddUint64(&q.received, 1)
+			if q.limiter == nil || q.policy != PolicyDropOldest {
+				if q.admit(ctx, acv) {
+					q.push(ctx, acv)
+				}
+				continue
+			}
+			if pending, retry = q.release(ctx, pending); pending != nil {
+				q.throttle(pending)
+			}
+			pending, retry = q.release(ctx, acv)
+		case <-retry:
+			pending, retry = q.release(ctx, pending)
+		}
+	}
+}
+
+// release queues the AsyncValues, if any, if a token is available, or returns them with the
+// channel signalling the next token otherwise.
+func (q *Queue) release(ctx context.Context, acv *dsModels.AsyncValues) (*dsModels.AsyncValues, <-chan time.Time) {
+	if acv == nil {
+		return nil, nil
+	}
+	ok, wait := q.limiter.take()
+	if !ok {
+		return acv, clock.After(wait)
+	}
+	q.push(ctx, acv)
+	return nil, nil
+}
+
+// admit reports whether the AsyncValues are within the rate limit, waiting for the next
+// token with PolicyBlock.
+func (q *Queue) admit(ctx context.Context, acv *dsModels.AsyncValues) bool {
+	if q.limiter == nil {
+		return true
+	}
+	for {
+		ok, wait := q.limiter.take()
+		if ok {
+			return true
+		}
+		if q.policy != PolicyBlock {
+			q.throttle(acv)
+			return false
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-clock.After(wait):
+		}
+	}
+}
+
+func (q *Queue) throttle(acv *dsModels.AsyncValues) {
+	throttled := atomic.AddUint64(&q.throttled, 1)
+	q.lc.Debug(fmt.Sprintf("async rate limit exceeded, dropped AsyncValues of Device %s (%d throttled in total)", acv.DeviceName, throttled))
+}
+
+func (q *Queue) push(ctx context.Context, acv *dsModels.AsyncValues) {
+	switch q.policy {
+	case PolicyDropNewest:
+		select {
+		case q.out <- acv:
+		default:
+			q.drop(acv)
+		}
+	case PolicyDropOldest:
+		for {
+			select {
+			case q.out <- acv:
+				return
+			default:
+			}
+			select {
+			case oldest := <-q.out:
+				q.drop(oldest)
+			default:
+			}
+		}
+	default:
+		select {
+		case q.out <- acv:
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (q *Queue) drop(acv *dsModels.AsyncValues) {
+	dropped := atomic.AddUint64(&q.dropped, 1)
+	q.lc.Debug(fmt.Sprintf("async queue full, dropped AsyncValues of Device %s (%d dropped in total)", acv.DeviceName, dropped))
+}
+
+// Drain waits until the AsyncValues queued so far are taken for processing, or returns
+// the context error once the context is done.
+func (q *Queue) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for len(q.out) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Metrics returns the counters of the queue.
+func (q *Queue) Metrics() Metrics {
+	return Metrics{
+		Policy:    q.policy,
+		Capacity:  cap(q.out),
+		Length:    len(q.out),
+		Received:  atomic.LoadUint64(&q.received),
+		Dropped:   atomic.LoadUint64(&q.dropped),
+		Throttled: atomic.LoadUint64(&q.throttled),
+	}
+}
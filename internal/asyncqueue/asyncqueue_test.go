@@ -0,0 +1,269 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asyncqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func asyncValues(names ...string) []*dsModels.AsyncValues {
+	result := make([]*dsModels.AsyncValues, len(names))
+	for i, name := range names {
+		result[i] = &dsModels.AsyncValues{DeviceName: name}
+	}
+	return result
+}
+
+func queued(q *Queue) []string {
+	var names []string
+	for len(q.out) > 0 {
+		names = append(names, (<-q.out).DeviceName)
+	}
+	return names
+}
+
+func TestNewQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		policy   string
+		expected string
+		errMsg   string
+	}{
+		{"default policy", 2, "", PolicyBlock, ""},
+		{"unbuffered block", 0, PolicyBlock, PolicyBlock, ""},
+		{"negative capacity", -1, PolicyBlock, PolicyBlock, ""},
+		{"drop oldest", 2, PolicyDropOldest, PolicyDropOldest, ""},
+		{"unbuffered drop oldest", 0, PolicyDropOldest, "", "must be positive"},
+		{"unbuffered drop newest", 0, PolicyDropNewest, "", "must be positive"},
+		{"unknown policy", 2, "drop-all", "", "unknown async overflow policy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewQueue(tt.capacity, tt.policy, 0, 0, logger.NewMockClient())
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, q.Metrics().Policy)
+			assert.Equal(t, q, GetQueue())
+		})
+	}
+}
+
+func TestPushDropNewest(t *testing.T) {
+	q, err := NewQueue(2, PolicyDropNewest, 0, 0, logger.NewMockClient())
+	require.NoError(t, err)
+	for _, acv := range asyncValues("d1", "d2", "d3") {
+		q.push(context.Background(), acv)
+	}
+
+	metrics := q.Metrics()
+	assert.Equal(t, 2, metrics.Length)
+	assert.Equal(t, uint64(1), metrics.Dropped)
+	assert.Equal(t, []string{"d1", "d2"}, queued(q))
+}
+
+func TestPushDropOldest(t *testing.T) {
+	q, err := NewQueue(2, PolicyDropOldest, 0, 0, logger.NewMockClient())
+	require.NoError(t, err)
+	for _, acv := range asyncValues("d1", "d2", "d3", "d4") {
+		q.push(context.Background(), acv)
+	}
+
+	metrics := q.Metrics()
+	assert.Equal(t, 2, metrics.Length)
+	assert.Equal(t, uint64(2), metrics.Dropped)
+	assert.Equal(t, []string{"d3", "d4"}, queued(q))
+}
+
+func TestPushBlock(t *testing.T) {
+	q, err := NewQueue(1, PolicyBlock, 0, 0, logger.NewMockClient())
+	require.NoError(t, err)
+	values := asyncValues("d1", "d2", "d3")
+	q.push(context.Background(), values[0])
+
+	pushed := make(chan struct{})
+	go func() {
+		q.push(context.Background(), values[1])
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("the push didn't wait for room in the full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, "d1", (<-q.out).DeviceName)
+	<-pushed
+	assert.Equal(t, uint64(0), q.Metrics().Dropped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	q.push(ctx, values[2])
+	assert.Equal(t, []string{"d2"}, queued(q), "the push is abandoned once the context is done")
+}
+
+func TestPump(t *testing.T) {
+	q, err := NewQueue(4, PolicyBlock, 0, 0, logger.NewMockClient())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	in := make(chan *dsModels.AsyncValues)
+	go q.Pump(ctx, wg, in)
+
+	for _, acv := range asyncValues("d1", "d2") {
+		in <- acv
+	}
+	assert.Equal(t, "d1", (<-q.Out()).DeviceName)
+	assert.Equal(t, "d2", (<-q.Out()).DeviceName)
+	assert.Equal(t, uint64(2), q.Metrics().Received)
+	require.NoError(t, q.Drain(ctx))
+
+	cancel()
+	wg.Wait()
+}
+
+func TestDrain(t *testing.T) {
+	q, err := NewQueue(1, PolicyBlock, 0, 0, logger.NewMockClient())
+	require.NoError(t, err)
+	q.push(context.Background(), asyncValues("d1")[0])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, q.Drain(ctx), "the queued AsyncValues weren't taken")
+}
+
+func TestRateLimiter(t *testing.T) {
+	f := clock.NewFake(time.Now())
+	clock.Set(f)
+	defer clock.Set(nil)
+
+	assert.Nil(t, newRateLimiter(0, 5), "no limit")
+
+	r := newRateLimiter(2, 3)
+	for i := 0; i < 3; i++ {
+		ok, _ := r.take()
+		assert.True(t, ok, "the burst is available at once")
+	}
+	ok, wait := r.take()
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	f.Advance(500 * time.Millisecond)
+	ok, _ = r.take()
+	assert.True(t, ok, "a token is refilled every 1/rate second")
+
+	f.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _ = r.take()
+		assert.True(t, ok)
+	}
+	ok, _ = r.take()
+	assert.False(t, ok, "the tokens are refilled up to the burst only")
+
+	r = newRateLimiter(2.5, 0)
+	assert.Equal(t, float64(3), r.burst, "the burst defaults to the rate")
+}
+
+func TestAdmitThrottled(t *testing.T) {
+	f := clock.NewFake(time.Now())
+	clock.Set(f)
+	defer clock.Set(nil)
+
+	q, err := NewQueue(4, PolicyDropNewest, 1, 1, logger.NewMockClient())
+	require.NoError(t, err)
+	values := asyncValues("d1", "d2", "d3")
+	assert.True(t, q.admit(context.Background(), values[0]))
+	assert.False(t, q.admit(context.Background(), values[1]), "the AsyncValues over the rate are dropped")
+	assert.Equal(t, uint64(1), q.Metrics().Throttled)
+
+	f.Advance(time.Second)
+	assert.True(t, q.admit(context.Background(), values[2]))
+	assert.Equal(t, uint64(1), q.Metrics().Throttled)
+}
+
+func TestAdmitBlock(t *testing.T) {
+	f := clock.NewFake(time.Now())
+	clock.Set(f)
+	defer clock.Set(nil)
+
+	q, err := NewQueue(4, PolicyBlock, 1, 1, logger.NewMockClient())
+	require.NoError(t, err)
+	values := asyncValues("d1", "d2", "d3")
+	assert.True(t, q.admit(context.Background(), values[0]))
+
+	admitted := make(chan bool)
+	go func() {
+		admitted <- q.admit(context.Background(), values[1])
+	}()
+	// wait for the admission to wait for the next token before advancing the clock
+	for f.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	f.Advance(time.Second)
+	assert.True(t, <-admitted, "the AsyncValues over the rate are held back")
+	assert.Equal(t, uint64(0), q.Metrics().Throttled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, q.admit(ctx, values[2]), "the wait is abandoned once the context is done")
+}
+
+func TestPumpThrottled(t *testing.T) {
+	tests := []struct {
+		policy    string
+		queued    []string
+		throttled uint64
+	}{
+		{PolicyDropNewest, []string{"d1", "d4"}, 2},
+		{PolicyDropOldest, []string{"d1", "d3", "d4"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			f := clock.NewFake(time.Now())
+			clock.Set(f)
+			defer clock.Set(nil)
+
+			q, err := NewQueue(4, tt.policy, 1, 1, logger.NewMockClient())
+			require.NoError(t, err)
+			in := make(chan *dsModels.AsyncValues)
+			ctx, cancel := context.WithCancel(context.Background())
+			wg := &sync.WaitGroup{}
+			go q.Pump(ctx, wg, in)
+
+			for _, acv := range asyncValues("d1", "d2", "d3") {
+				in <- acv
+			}
+			// wait for the last AsyncValues to be taken before advancing the clock
+			assert.Eventually(t, func() bool { return q.Metrics().Received == 3 }, time.Second, time.Millisecond)
+			assert.Eventually(t, func() bool { return f.Waiters() > 0 || tt.policy != PolicyDropOldest }, time.Second, time.Millisecond)
+			f.Advance(time.Second)
+			if tt.policy == PolicyDropOldest {
+				assert.Eventually(t, func() bool { return len(q.out) == 2 }, time.Second, time.Millisecond)
+			}
+			f.Advance(time.Second)
+			in <- asyncValues("d4")[0]
+			cancel()
+			wg.Wait()
+
+			assert.Equal(t, tt.queued, queued(q))
+			assert.Equal(t, tt.throttled, q.Metrics().Throttled)
+		})
+	}
+}
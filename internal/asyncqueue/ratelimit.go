@@ -0,0 +1,45 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asyncqueue
+
+import (
+	"math"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+// rateLimiter is a token bucket refilled at rate tokens per second up to burst tokens.
+// It's used by the pump goroutine only.
+type rateLimiter struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: clock.Now()}
+}
+
+// take takes a token if one is available, or returns how long to wait for the next one.
+func (r *rateLimiter) take() (bool, time.Duration) {
+	now := clock.Now()
+	r.tokens = math.Min(r.burst, r.tokens+now.Sub(r.last).Seconds()*r.rate)
+	r.last = now
+	if r.tokens >= 1 {
+		r.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - r.tokens) / r.rate * float64(time.Second))
+}
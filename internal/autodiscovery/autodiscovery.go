@@ -13,6 +13,9 @@ import (
 	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cron"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -28,33 +31,65 @@ func BootstrapHandler(
 	configuration := container.ConfigurationFrom(dic.Get)
 	var runDiscovery bool = true
 
+	// the scans are re-run according to the Schedule cron expression if any, otherwise
+	// every Interval
+	var schedule *cron.Schedule
+	var duration time.Duration
+	var err error
 	if configuration.Device.Discovery.Enabled == false {
 		lc.Info("AutoDiscovery stopped: disabled by configuration")
 		runDiscovery = false
+	} else if configuration.Device.Discovery.Schedule != "" {
+		schedule, err = cron.Parse(configuration.Device.Discovery.Schedule)
+		if err != nil {
+			lc.Info(fmt.Sprintf("AutoDiscovery stopped: schedule error in configuration: %v", err))
+			runDiscovery = false
+		}
+	} else {
+		duration, err = time.ParseDuration(configuration.Device.Discovery.Interval)
+		if err != nil || duration <= 0 {
+			lc.Info("AutoDiscovery stopped: interval error in configuration")
+			runDiscovery = false
+		}
 	}
-	duration, err := time.ParseDuration(configuration.Device.Discovery.Interval)
-	if err != nil || duration <= 0 {
-		lc.Info("AutoDiscovery stopped: interval error in configuration")
-		runDiscovery = false
-	}
-	if discovery == nil {
-		lc.Info("AutoDiscovery stopped: ProtocolDiscovery not implemented")
+	if discovery == nil && !HasProviders() {
+		lc.Info("AutoDiscovery stopped: neither ProtocolDiscovery implemented nor DiscoveryProvider enabled")
 		runDiscovery = false
 	}
 
 	if runDiscovery {
+		wg.Add(1)
 		go func() {
-			wg.Add(1)
 			defer wg.Done()
+			defer setNextRun(time.Time{})
 
-			lc.Info(fmt.Sprintf("Starting auto-discovery with duration %v", duration))
-			DiscoveryWrapper(discovery, lc)
+			if schedule != nil {
+				lc.Info(fmt.Sprintf("Starting auto-discovery with schedule %s", configuration.Device.Discovery.Schedule))
+			} else {
+				lc.Info(fmt.Sprintf("Starting auto-discovery with duration %v", duration))
+			}
 			for {
+				if maintenance.ServiceMode() {
+					lc.Debug("AutoDiscovery paused in maintenance mode")
+				} else {
+					DiscoveryWrapper(discovery, lc)
+				}
+
+				wait := duration
+				if schedule != nil {
+					now := clock.Now()
+					next := schedule.Next(now)
+					if next.IsZero() {
+						lc.Info("AutoDiscovery stopped: schedule never matches again")
+						return
+					}
+					wait = next.Sub(now)
+				}
+				setNextRun(clock.Now().Add(wait))
 				select {
 				case <-ctx.Done():
 					return
-				case <-time.After(duration):
-					DiscoveryWrapper(discovery, lc)
+				case <-clock.After(wait):
 				}
 			}
 		}()
@@ -7,20 +7,59 @@
 package autodiscovery
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
-	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/systemevent"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// ResultCompleted is the result of a scan which ran to completion.
+	ResultCompleted = "completed"
+	// ResultStopped is the result of a scan stopped before its completion.
+	ResultStopped = "stopped"
+)
+
+var (
+	// ErrNotRunning is returned when stopping the discovery while no scan is running.
+	ErrNotRunning = errors.New("no device discovery running")
+	// ErrNotStoppable is returned when stopping a scan of a ProtocolDiscovery which doesn't
+	// implement ContextDiscovery.
+	ErrNotStoppable = errors.New("device discovery can't be stopped: ProtocolDiscovery doesn't implement ContextDiscovery")
 )
 
+// Status is the status of the device discovery.
+type Status struct {
+	Running bool `json:"running"`
+	// StartedAt and CompletedAt are the times of the last scan.
+	StartedAt   int64  `json:"startedAt,omitempty"`
+	CompletedAt int64  `json:"completedAt,omitempty"`
+	Elapsed     string `json:"elapsed,omitempty"`
+	// Result is the result of the last completed scan, ResultCompleted or ResultStopped.
+	Result string `json:"result,omitempty"`
+	// NextRunAt is the time of the next scheduled scan, if any.
+	NextRunAt int64 `json:"nextRunAt,omitempty"`
+}
+
 type discoveryLocker struct {
-	busy bool
-	mux  sync.Mutex
+	busy      bool
+	cancel    context.CancelFunc // nil unless the running scan can be stopped
+	startedAt time.Time
+	status    Status
+	mux       sync.Mutex
 }
 
 var locker discoveryLocker
 
+// DiscoveryWrapper runs a scan of the ProtocolDiscovery, which may be nil, and of the
+// enabled DiscoveryProviders, unless a scan is already running.
 func DiscoveryWrapper(discovery dsModels.ProtocolDiscovery, lc logger.LoggingClient) {
 	locker.mux.Lock()
 	if locker.busy {
@@ -28,14 +67,86 @@ func DiscoveryWrapper(discovery dsModels.ProtocolDiscovery, lc logger.LoggingCli
 		locker.mux.Unlock()
 		return
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cd, stoppable := discovery.(dsModels.ContextDiscovery)
+	if discovery == nil {
+		// the DiscoveryProviders are always stoppable
+		stoppable = true
+	}
 	locker.busy = true
+	locker.cancel = nil
+	if stoppable {
+		locker.cancel = cancel
+	}
+	locker.startedAt = clock.Now()
+	locker.status.Running = true
+	locker.status.StartedAt = locker.startedAt.UnixNano()
+	locker.status.CompletedAt = 0
+	locker.status.Elapsed = ""
 	locker.mux.Unlock()
 
 	lc.Debug(fmt.Sprintf("protocol discovery triggered"))
-	discovery.Discover()
+	resetSeen()
+	var wg sync.WaitGroup
+	if enabled := enabledProviders(); len(enabled) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runProviders(ctx, enabled, lc)
+		}()
+	}
+	if cd != nil {
+		cd.DiscoverContext(ctx)
+	} else if discovery != nil {
+		discovery.Discover()
+	}
+	wg.Wait()
 
 	// ReleaseLock
 	locker.mux.Lock()
+	now := clock.Now()
 	locker.busy = false
+	locker.cancel = nil
+	locker.status.Running = false
+	locker.status.CompletedAt = now.UnixNano()
+	locker.status.Elapsed = now.Sub(locker.startedAt).String()
+	result := ResultCompleted
+	if ctx.Err() != nil {
+		result = ResultStopped
+	}
+	locker.status.Result = result
 	locker.mux.Unlock()
+	lc.Debug(fmt.Sprintf("protocol discovery %s", result))
+	systemevent.Publish(dsModels.SystemEventTypeDiscovery, dsModels.SystemEventActionCompleted, "", GetStatus())
+}
+
+// Stop cancels the running scan.
+func Stop() error {
+	locker.mux.Lock()
+	defer locker.mux.Unlock()
+	if !locker.busy {
+		return ErrNotRunning
+	}
+	if locker.cancel == nil {
+		return ErrNotStoppable
+	}
+	locker.cancel()
+	return nil
+}
+
+// GetStatus returns the status of the device discovery.
+func GetStatus() Status {
+	locker.mux.Lock()
+	defer locker.mux.Unlock()
+	return locker.status
+}
+
+func setNextRun(t time.Time) {
+	locker.mux.Lock()
+	defer locker.mux.Unlock()
+	locker.status.NextRunAt = 0
+	if !t.IsZero() {
+		locker.status.NextRunAt = t.UnixNano()
+	}
 }
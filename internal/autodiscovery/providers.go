@@ -0,0 +1,138 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var (
+	providers []dsModels.DiscoveryProvider
+	// providerConfig are the configurations of the DiscoveryProviders, keyed by name.
+	providerConfig map[string]common.DiscoveryProviderInfo
+	deviceCh       chan<- []dsModels.DiscoveredDevice
+	// seen are the keys of the devices found by the running or last scan.
+	seen           = make(map[string]bool)
+	providersMutex sync.Mutex
+)
+
+// AddProvider adds the DiscoveryProvider run by the following scans, or returns an error
+// if one of the same name is already added.
+func AddProvider(provider dsModels.DiscoveryProvider) error {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	for _, existing := range providers {
+		if existing.Name() == provider.Name() {
+			return fmt.Errorf("DiscoveryProvider %s already added", provider.Name())
+		}
+	}
+	providers = append(providers, provider)
+	return nil
+}
+
+// ConfigureProviders sets the configurations of the DiscoveryProviders and the channel
+// their results are sent to.
+func ConfigureProviders(config map[string]common.DiscoveryProviderInfo, ch chan<- []dsModels.DiscoveredDevice) {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	providerConfig = config
+	deviceCh = ch
+}
+
+// HasProviders reports whether any DiscoveryProvider is enabled.
+func HasProviders() bool {
+	return len(enabledProviders()) > 0
+}
+
+// enabledProviders returns the DiscoveryProviders not disabled by their configuration.
+func enabledProviders() []dsModels.DiscoveryProvider {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	if deviceCh == nil {
+		return nil
+	}
+	var enabled []dsModels.DiscoveryProvider
+	for _, p := range providers {
+		if info, ok := providerConfig[p.Name()]; !ok || info.Enabled {
+			enabled = append(enabled, p)
+		}
+	}
+	return enabled
+}
+
+// runProviders runs the enabled DiscoveryProviders concurrently and sends their merged
+// results to the device channel.
+func runProviders(ctx context.Context, enabled []dsModels.DiscoveryProvider, lc logger.LoggingClient) {
+	results := make([][]dsModels.DiscoveredDevice, len(enabled))
+	var wg sync.WaitGroup
+	for i, p := range enabled {
+		wg.Add(1)
+		go func(i int, p dsModels.DiscoveryProvider) {
+			defer wg.Done()
+			devices, err := p.Discover(ctx)
+			if err != nil {
+				lc.Error(fmt.Sprintf("DiscoveryProvider %s failed: %v", p.Name(), err))
+			}
+			lc.Debug(fmt.Sprintf("DiscoveryProvider %s found %d devices", p.Name(), len(devices)))
+			results[i] = devices
+		}(i, p)
+	}
+	wg.Wait()
+
+	var merged []dsModels.DiscoveredDevice
+	for _, devices := range results {
+		merged = append(merged, devices...)
+	}
+	if len(merged) == 0 {
+		return
+	}
+	providersMutex.Lock()
+	ch := deviceCh
+	providersMutex.Unlock()
+	select {
+	case ch <- merged:
+	case <-ctx.Done():
+	}
+}
+
+// Deduplicate returns the devices not already found by the running or last scan, by
+// their protocol properties or, if they have none, by their name.
+func Deduplicate(devices []dsModels.DiscoveredDevice) []dsModels.DiscoveredDevice {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	var unique []dsModels.DiscoveredDevice
+	for _, d := range devices {
+		key := "name:" + d.Name
+		if len(d.Protocols) > 0 {
+			// the keys of the maps are encoded sorted
+			if data, err := json.Marshal(d.Protocols); err == nil {
+				key = string(data)
+			}
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, d)
+	}
+	return unique
+}
+
+// resetSeen forgets the devices found by the previous scan.
+func resetSeen() {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	seen = make(map[string]bool)
+}
@@ -13,10 +13,16 @@ import (
 	"time"
 
 	"github.com/OneOfOne/xxhash"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cron"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/handler"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
@@ -28,22 +34,31 @@ type Executor struct {
 	autoEvent    contract.AutoEvent
 	lastReadings map[string]interface{}
 	duration     time.Duration
+	cron         *cron.Schedule // set if Frequency is a cron expression
+	schedule     schedule
+	anchor       time.Time           // time on the grid of the intervals of the next execution
+	deadbands    map[string]deadband // key is deviceResource name
+	published    time.Time           // last publication of the AutoEvent with OnChange
+	store        store.Store         // persists the last readings of the AutoEvent with OnChange, if set
 	stop         bool
 	rwMutex      *sync.RWMutex
 }
 
-// Run triggers this Executor executes the handler for the resource periodically
+// Run triggers this Executor executes the handler for the resource periodically, or at the
+// times matching the cron expression of the AutoEvent
 func (e *Executor) Run(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) {
 	wg.Add(1)
 	defer wg.Done()
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
-	for {
+	delay, ok := e.firstDelay()
+	for ok {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(e.duration):
-			if e.stop {
+		case <-clock.After(delay):
+			delay, ok = e.nextDelay()
+			if e.stopped() {
 				return
 			}
 			ds := container.DeviceServiceFrom(dic.Get)
@@ -52,20 +67,42 @@ func (e *Executor) Run(ctx context.Context, wg *sync.WaitGroup, dic *di.Containe
 				return
 			}
 
+			if maintenance.ServiceMode() {
+				lc.Debug(fmt.Sprintf("AutoEvent - paused for device %s in maintenance mode", e.deviceName))
+				continue
+			}
+			device, _ := cache.Devices().ForName(e.deviceName)
+			if device.AdminState == contract.Locked || device.OperatingState == contract.Disabled {
+				lc.Debug(fmt.Sprintf("AutoEvent - skipped for locked or disabled device %s", e.deviceName))
+				continue
+			}
+			if maintenance.AutoEventsPaused(device) {
+				lc.Debug(fmt.Sprintf("AutoEvent - paused for device %s in maintenance window", e.deviceName))
+				continue
+			}
+
 			lc.Debug(fmt.Sprintf("AutoEvent - executing %v", e.autoEvent))
 			evt, appErr := readResource(e, dic)
 			if appErr != nil {
-				lc.Error(fmt.Sprintf("AutoEvent - error occurs when reading resource %s",
-					e.autoEvent.Resource))
+				if maintenance.Active(device) {
+					lc.Debug(fmt.Sprintf("AutoEvent - error occurs when reading resource %s of device in maintenance window",
+						e.autoEvent.Resource))
+				} else {
+					lc.Error(fmt.Sprintf("AutoEvent - error occurs when reading resource %s",
+						e.autoEvent.Resource))
+				}
 				continue
 			}
 
 			if evt != nil {
+				lastvalue.Record(evt.Readings, lastvalue.SourceAutoEvent)
 				if e.autoEvent.OnChange {
-					if compareReadings(e, evt.Readings, evt.HasBinaryValue(), lc) {
+					if compareReadings(e, evt.Readings, evt.HasBinaryValue(), lc) && !e.silenceExceeded() {
 						lc.Debug(fmt.Sprintf("AutoEvent - readings are the same as previous one %v", e.lastReadings))
 						continue
 					}
+					e.published = clock.Now()
+					e.saveState(lc)
 				}
 				if evt.HasBinaryValue() {
 					lc.Debug("AutoEvent - pushing CBOR event")
@@ -75,7 +112,10 @@ func (e *Executor) Run(ctx context.Context, wg *sync.WaitGroup, dic *di.Containe
 				event := &dsModels.Event{Event: evt.Event}
 				// Attach origin timestamp for events if none yet specified
 				if event.Origin == 0 {
-					event.Origin = common.GetUniqueOrigin()
+					event.Origin = common.NewEventOrigin(event.Device)
+				}
+				if event.ID == "" {
+					event.ID = common.NewEventId(event.Device)
 				}
 
 				// After the auto event executes a read command, it will create a goroutine to send out events.
@@ -92,21 +132,28 @@ func (e *Executor) Run(ctx context.Context, wg *sync.WaitGroup, dic *di.Containe
 			}
 		}
 	}
+	lc.Info(fmt.Sprintf("AutoEvent - stopped for device %s as cron expression %s no longer matches", e.deviceName, e.autoEvent.Frequency))
 }
 
 func readResource(e *Executor, dic *di.Container) (*dsModels.Event, common.AppError) {
+	if device, ok := cache.Devices().ForName(e.deviceName); ok {
+		if _, ok = handler.ResourceSet(device, e.autoEvent.Resource); ok {
+			return handler.ResourceSetHandler(e.deviceName, e.autoEvent.Resource, dic)
+		}
+	}
+
 	vars := make(map[string]string, 2)
 	vars[common.NameVar] = e.deviceName
 	vars[common.CommandVar] = e.autoEvent.Resource
 
-	evt, appErr := handler.CommandHandler(vars, "", common.GetCmdMethod, "", dic)
+	evt, appErr := handler.AutoEventHandler(vars, dic)
 	return evt, appErr
 }
 
 func compareReadings(e *Executor, readings []contract.Reading, hasBinary bool, lc logger.LoggingClient) bool {
 	var identical bool = true
-	e.rwMutex.RLock()
-	defer e.rwMutex.RUnlock()
+	e.rwMutex.Lock()
+	defer e.rwMutex.Unlock()
 	for _, r := range readings {
 		switch e.lastReadings[r.Name].(type) {
 		case uint64:
@@ -116,8 +163,7 @@ func compareReadings(e *Executor, readings []contract.Reading, hasBinary bool, l
 				identical = false
 			}
 		case string:
-			v, ok := e.lastReadings[r.Name]
-			if !ok || v != r.Value {
+			if e.changed(r, e.lastReadings[r.Name].(string)) {
 				e.lastReadings[r.Name] = r.Value
 				identical = false
 			}
@@ -138,15 +184,30 @@ func compareReadings(e *Executor, readings []contract.Reading, hasBinary bool, l
 
 // Stop marks this Executor stopped
 func (e *Executor) Stop() {
+	e.rwMutex.Lock()
+	defer e.rwMutex.Unlock()
 	e.stop = true
 }
 
+func (e *Executor) stopped() bool {
+	e.rwMutex.RLock()
+	defer e.rwMutex.RUnlock()
+	return e.stop
+}
+
 // NewExecutor creates an Executor for an AutoEvent
 func NewExecutor(deviceName string, ae contract.AutoEvent) (*Executor, error) {
-	// check Frequency
+	// check Frequency, either a duration or a cron expression
+	var schedule *cron.Schedule
 	duration, err := time.ParseDuration(ae.Frequency)
 	if err != nil {
-		return nil, err
+		var cronErr error
+		if schedule, cronErr = cron.Parse(ae.Frequency); cronErr != nil {
+			return nil, fmt.Errorf("frequency %s is neither a duration (%v) nor a cron expression (%v)", ae.Frequency, err, cronErr)
+		}
+		if schedule.Next(clock.Now()).IsZero() {
+			return nil, fmt.Errorf("cron expression %s never matches", ae.Frequency)
+		}
 	}
 
 	return &Executor{
@@ -154,6 +215,44 @@ func NewExecutor(deviceName string, ae contract.AutoEvent) (*Executor, error) {
 		autoEvent:    ae,
 		lastReadings: make(map[string]interface{}),
 		duration:     duration,
+		cron:         schedule,
 		stop:         false,
 		rwMutex:      &sync.RWMutex{}}, nil
 }
+
+// silenceExceeded reports whether the max silence of the AutoEvent with OnChange elapsed
+// since its last publication, forcing the unchanged readings to be published.
+func (e *Executor) silenceExceeded() bool {
+	return e.schedule.maxSilence > 0 && clock.Now().Sub(e.published) >= e.schedule.maxSilence
+}
+
+// firstDelay returns the delay of the first execution, and false if there's none.
+func (e *Executor) firstDelay() (time.Duration, bool) {
+	if e.cron != nil {
+		return e.untilNext()
+	}
+	delay := e.schedule.first(e.duration)
+	e.anchor = clock.Now().Add(delay)
+	return delay, true
+}
+
+// nextDelay returns the delay of the following execution, and false if there's none.
+func (e *Executor) nextDelay() (time.Duration, bool) {
+	if e.cron != nil {
+		d, ok := e.untilNext()
+		return d + e.schedule.spread(), ok
+	}
+	var delay time.Duration
+	delay, e.anchor = e.schedule.next(e.anchor, e.duration, clock.Now())
+	return delay, true
+}
+
+// untilNext returns the delay until the next time the cron expression matches.
+func (e *Executor) untilNext() (time.Duration, bool) {
+	now := clock.Now()
+	next := e.cron.Next(now)
+	if next.IsZero() {
+		return 0, false
+	}
+	return next.Sub(now), true
+}
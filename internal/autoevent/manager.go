@@ -10,11 +10,18 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/executor"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/handler"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
 
@@ -25,8 +32,14 @@ type Manager interface {
 	StopForDevice(deviceName string)
 }
 
+const (
+	defaultReadinessInitialBackoff = time.Second
+	defaultReadinessMaxBackoff     = time.Minute
+)
+
 type manager struct {
 	executorMap     map[string][]*Executor
+	ready           map[string]bool // Devices read successfully, key is Device name
 	ctx             context.Context
 	wg              *sync.WaitGroup
 	mutex           sync.Mutex
@@ -45,6 +58,7 @@ func NewManager(ctx context.Context, wg *sync.WaitGroup, bufferSize int, dic *di
 		ctx:             ctx,
 		wg:              wg,
 		executorMap:     make(map[string][]*Executor),
+		ready:           make(map[string]bool),
 		autoeventBuffer: make(chan bool, bufferSize),
 		dic:             dic}
 }
@@ -81,19 +95,155 @@ func (m *manager) triggerExecutors(deviceName string, autoEvents []contract.Auto
 	var executors []*Executor
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	device, _ := cache.Devices().ForName(deviceName)
 	for _, autoEvent := range autoEvents {
+		if writeOnly(device, autoEvent.Resource) {
+			lc.Warn(fmt.Sprintf("AutoEvent for resource %s of device %s is skipped as the resource is write-only", autoEvent.Resource, deviceName))
+			continue
+		}
 		executor, err := NewExecutor(deviceName, autoEvent)
 		if err != nil {
 			lc.Error(fmt.Sprintf("AutoEvent for resource %s cannot be created, %v", autoEvent.Resource, err))
 			// skip this AutoEvent if it causes error during creation
 			continue
 		}
+		executor.schedule, err = newSchedule(device, autoEvent, container.ConfigurationFrom(dic.Get).Device.AutoEventSchedule)
+		if err != nil {
+			lc.Warn(fmt.Sprintf("AutoEvent for resource %s of device %s ignores its invalid schedule, %v", autoEvent.Resource, deviceName, err))
+		}
+		if autoEvent.OnChange {
+			executor.deadbands = deadbands(device.Profile.Name, lc)
+			executor.loadState(container.StoreFrom(dic.Get), lc)
+		}
 		executors = append(executors, executor)
+	}
+
+	if len(executors) > 0 && !m.ready[deviceName] && container.ConfigurationFrom(dic.Get).Device.AutoEventReadiness.Enabled {
+		m.wg.Add(1)
+		go m.runWhenReady(deviceName, executors, dic)
+		return executors
+	}
+	for _, executor := range executors {
 		go executor.Run(m.ctx, m.wg, dic)
 	}
 	return executors
 }
 
+// runWhenReady runs the executors of the Device once a read of the resource of the first
+// of them succeeds, retrying with a backoff doubled after each failure up to
+// Device.AutoEventReadiness.MaxBackoff. It gives up if the executors are stopped meanwhile.
+// The caller adds it to the wait group.
+func (m *manager) runWhenReady(deviceName string, executors []*Executor, dic *di.Container) {
+	defer m.wg.Done()
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	info := container.ConfigurationFrom(dic.Get).Device.AutoEventReadiness
+	backoff, err := time.ParseDuration(info.InitialBackoff)
+	if err != nil || backoff <= 0 {
+		backoff = defaultReadinessInitialBackoff
+	}
+	maxBackoff, err := time.ParseDuration(info.MaxBackoff)
+	if err != nil || maxBackoff <= 0 {
+		maxBackoff = defaultReadinessMaxBackoff
+	}
+
+	probe := executors[0]
+	for attempt := 1; ; attempt++ {
+		if probe.stopped() {
+			return
+		}
+		if err := probeDevice(probe, dic); err == nil {
+			break
+		}
+		lc.Debug(fmt.Sprintf("AutoEvent - device %s not ready after %d attempts, retrying in %v", deviceName, attempt, backoff))
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-clock.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.ready[deviceName] = true
+	lc.Info(fmt.Sprintf("AutoEvent - device %s is ready, starting its AutoEvents", deviceName))
+	for _, executor := range executors {
+		if !executor.stopped() {
+			go executor.Run(m.ctx, m.wg, dic)
+		}
+	}
+}
+
+// probeDevice reads the deviceResources of the AutoEvent from the driver directly, so that
+// the failures while the Device isn't ready yet don't count towards disabling it.
+func probeDevice(e *Executor, dic *di.Container) error {
+	device, ok := cache.Devices().ForName(e.deviceName)
+	if !ok {
+		return fmt.Errorf("device %s not found", e.deviceName)
+	}
+	names, ok := handler.ResourceSet(device, e.autoEvent.Resource)
+	if !ok {
+		names = []string{e.autoEvent.Resource}
+		if ros, err := cache.Profiles().ResourceOperations(device.Profile.Name, e.autoEvent.Resource, common.GetCmdMethod); err == nil {
+			names = make([]string, len(ros))
+			for i, ro := range ros {
+				names[i] = ro.DeviceResource
+			}
+		}
+	}
+
+	reqs := make([]dsModels.CommandRequest, 0, len(names))
+	for _, name := range names {
+		dr, ok := cache.Profiles().DeviceResource(device.Profile.Name, name)
+		if !ok || dr.Properties.Value.ReadWrite == common.DeviceResourceWriteOnly {
+			continue
+		}
+		reqs = append(reqs, dsModels.CommandRequest{
+			DeviceResourceName: dr.Name,
+			Attributes:         dr.Attributes,
+			Type:               dr.Properties.Value.Type,
+		})
+	}
+	if len(reqs) == 0 {
+		return fmt.Errorf("no deviceResource to read for %s", e.autoEvent.Resource)
+	}
+	driver := executor.Background(container.ProtocolDriverFrom(dic.Get))
+	_, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	return err
+}
+
+// writeOnly reports whether the resource of an AutoEvent, either a resource set of the
+// Device, a deviceResource or a GET command, has nothing to read as all its deviceResources
+// are write-only.
+func writeOnly(device contract.Device, resource string) bool {
+	profileName := device.Profile.Name
+	if resources, ok := handler.ResourceSet(device, resource); ok {
+		for _, r := range resources {
+			dr, ok := cache.Profiles().DeviceResource(profileName, r)
+			if !ok || dr.Properties.Value.ReadWrite != common.DeviceResourceWriteOnly {
+				return false
+			}
+		}
+		return len(resources) > 0
+	}
+
+	ros, err := cache.Profiles().ResourceOperations(profileName, resource, common.GetCmdMethod)
+	if err != nil {
+		dr, ok := cache.Profiles().DeviceResource(profileName, resource)
+		return ok && dr.Properties.Value.ReadWrite == common.DeviceResourceWriteOnly
+	}
+	for _, ro := range ros {
+		dr, ok := cache.Profiles().DeviceResource(profileName, ro.DeviceResource)
+		if !ok || dr.Properties.Value.ReadWrite != common.DeviceResourceWriteOnly {
+			return false
+		}
+	}
+	return len(ros) > 0
+}
+
 // RestartForDevice restarts all the AutoEvents of the specific Device
 func (m *manager) RestartForDevice(deviceName string, dic *di.Container) {
 	dc := dic
@@ -0,0 +1,65 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"errors"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// probedDriver records the deviceResources read, failing as the device isn't ready.
+type probedDriver struct {
+	mock.DriverMock
+	read *[]string
+}
+
+func (d probedDriver) HandleReadCommands(_ string, _ map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	for _, req := range reqs {
+		*d.read = append(*d.read, req.DeviceResourceName)
+	}
+	return nil, dsModels.NewDriverError(dsModels.NotReachable, errors.New("not ready"))
+}
+
+func TestProbeDevice(t *testing.T) {
+	lc := logger.NewMockClient()
+	dc := &mock.DeviceClientMock{}
+	cache.InitCache("device-sdk-test", lc, &mock.ValueDescriptorMock{}, dc, &mock.ProvisionWatcherClientMock{})
+	var read []string
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return lc
+		},
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return probedDriver{read: &read}
+		},
+	})
+
+	e, err := NewExecutor("Random-Integer-Generator01", contract.AutoEvent{Resource: "RandomValue_Int8", Frequency: "1s"})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.Error(t, probeDevice(e, dic))
+	}
+	assert.Equal(t, []string{"RandomValue_Int8", "RandomValue_Int8", "RandomValue_Int8"}, read)
+	device, ok := cache.Devices().ForName("Random-Integer-Generator01")
+	require.True(t, ok)
+	assert.Equal(t, contract.OperatingState(contract.Enabled), device.OperatingState, "the probes don't disable the device")
+
+	e.Stop()
+	assert.True(t, e.stopped())
+}
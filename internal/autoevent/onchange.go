@@ -0,0 +1,122 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// DeadbandAttribute is the deviceResource attribute setting the change of its numeric
+// value below which the AutoEvents with OnChange consider it unchanged, either absolute,
+// e.g. "0.5", or relative to the last published value, e.g. "5%".
+const DeadbandAttribute = common.SDKReservedPrefix + "deadband"
+
+type deadband struct {
+	value   float64
+	percent bool
+}
+
+func parseDeadband(s string) (deadband, error) {
+	d := deadband{}
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		d.percent = true
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value < 0 || math.IsNaN(value) {
+		return d, fmt.Errorf("invalid %s %s, expecting a non-negative number or percentage", DeadbandAttribute, s)
+	}
+	d.value = value
+	return d, nil
+}
+
+// exceeded reports whether the change from the previous value to the current one exceeds
+// the deadband.
+func (d deadband) exceeded(previous float64, current float64) bool {
+	limit := d.value
+	if d.percent {
+		limit = math.Abs(previous) * d.value / 100
+	}
+	return math.Abs(current-previous) > limit
+}
+
+// deadbands returns the deadbands of the deviceResources of the Device Profile, keyed by
+// deviceResource name.
+func deadbands(profileName string, lc logger.LoggingClient) map[string]deadband {
+	profile, ok := cache.Profiles().ForName(profileName)
+	if !ok {
+		return nil
+	}
+	result := make(map[string]deadband)
+	for _, dr := range profile.DeviceResources {
+		value, ok := dr.Attributes[DeadbandAttribute]
+		if !ok {
+			continue
+		}
+		d, err := parseDeadband(value)
+		if err != nil {
+			lc.Warn(fmt.Sprintf("AutoEvent - ignoring deadband of deviceResource %s: %v", dr.Name, err))
+			continue
+		}
+		result[dr.Name] = d
+	}
+	return result
+}
+
+// changed reports whether the reading value changed from the previous one beyond the
+// deadband of its deviceResource, if any.
+func (e *Executor) changed(r contract.Reading, previous string) bool {
+	if r.Value == previous {
+		return false
+	}
+	d, ok := e.deadbands[r.Name]
+	if !ok {
+		return true
+	}
+	p, ok := readingFloat(r, previous)
+	if !ok {
+		return true
+	}
+	c, ok := readingFloat(r, r.Value)
+	if !ok {
+		return true
+	}
+	return d.exceeded(p, c)
+}
+
+// readingFloat parses the value of the numeric reading, which is Base64 encoded for the
+// floats with the Base64 float encoding.
+func readingFloat(r contract.Reading, value string) (float64, bool) {
+	if r.FloatEncoding == contract.Base64Encoding && (r.ValueType == v2.ValueTypeFloat32 || r.ValueType == v2.ValueTypeFloat64) {
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return 0, false
+		}
+		switch len(b) {
+		case 4:
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), true
+		case 8:
+			return math.Float64frombits(binary.BigEndian.Uint64(b)), true
+		}
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	return f, err == nil
+}
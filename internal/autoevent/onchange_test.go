@@ -0,0 +1,96 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeadband(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected deadband
+		valid    bool
+	}{
+		{"absolute", "0.5", deadband{value: 0.5}, true},
+		{"percentage", "5%", deadband{value: 5, percent: true}, true},
+		{"spaces", " 5 % ", deadband{value: 5, percent: true}, true},
+		{"zero", "0", deadband{}, true},
+		{"negative", "-1", deadband{}, false},
+		{"NaN", "NaN", deadband{}, false},
+		{"not a number", "high", deadband{}, false},
+		{"percent sign only", "%", deadband{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := parseDeadband(tt.value)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestDeadbandExceeded(t *testing.T) {
+	tests := []struct {
+		name     string
+		deadband deadband
+		previous float64
+		current  float64
+		expected bool
+	}{
+		{"absolute within", deadband{value: 0.5}, 20, 20.5, false},
+		{"absolute beyond", deadband{value: 0.5}, 20, 20.6, true},
+		{"absolute decrease", deadband{value: 0.5}, 20, 19.4, true},
+		{"percentage within", deadband{value: 5, percent: true}, 200, 210, false},
+		{"percentage beyond", deadband{value: 5, percent: true}, 200, 189, true},
+		{"percentage of negative", deadband{value: 5, percent: true}, -200, -209, false},
+		{"percentage of zero", deadband{value: 5, percent: true}, 0, 0.1, true},
+		{"zero", deadband{}, 1, 1.000001, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.deadband.exceeded(tt.previous, tt.current))
+		})
+	}
+}
+
+func base64Float32(f float32) string {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, math.Float32bits(f))
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func TestChanged(t *testing.T) {
+	e := &Executor{deadbands: map[string]deadband{"Temperature": {value: 0.5}}}
+
+	reading := contract.Reading{Name: "Temperature", ValueType: v2.ValueTypeFloat64, Value: "20.3"}
+	assert.False(t, e.changed(reading, "20.3"))
+	assert.False(t, e.changed(reading, "20"), "within the deadband")
+	assert.True(t, e.changed(reading, "19.5"))
+	assert.True(t, e.changed(reading, "unknown"), "the previous value isn't numeric")
+
+	reading = contract.Reading{Name: "Temperature", ValueType: v2.ValueTypeFloat32, FloatEncoding: contract.Base64Encoding, Value: base64Float32(20.3)}
+	assert.False(t, e.changed(reading, base64Float32(20)), "the Base64 floats are decoded")
+	assert.True(t, e.changed(reading, base64Float32(19.5)))
+	assert.True(t, e.changed(reading, "!"), "invalid Base64")
+
+	reading = contract.Reading{Name: "Humidity", ValueType: v2.ValueTypeFloat64, Value: "40.1"}
+	assert.True(t, e.changed(reading, "40"), "no deadband")
+}
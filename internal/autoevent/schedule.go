@@ -0,0 +1,150 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// ScheduleProtocol is the protocol of the Device whose properties spread the executions of
+// its AutoEvents, keyed by their resource. Each value is a comma-separated list of the
+// jitter, the maximum random delay of each execution after its time on the grid of the
+// intervals, the offset, the delay of the first execution, and for the AutoEvents with
+// OnChange the maxSilence, after which the unchanged readings are published anyway:
+//
+//	[DeviceList.Protocols.ds-autoEventSchedule]
+//	  Temperature = "jitter=500ms,offset=2s,maxSilence=15m"
+//
+// The AutoEvents whose Frequency is a cron expression ignore the offset.
+const ScheduleProtocol = common.SDKReservedPrefix + "autoEventSchedule"
+
+const (
+	scheduleJitter     = "jitter"
+	scheduleOffset     = "offset"
+	scheduleMaxSilence = "maxSilence"
+)
+
+var (
+	random      = rand.New(rand.NewSource(time.Now().UnixNano()))
+	randomMutex sync.Mutex
+)
+
+// schedule spreads the executions of an AutoEvent.
+type schedule struct {
+	jitter    time.Duration
+	offset    time.Duration
+	hasOffset bool
+	// maxSilence is the maximum interval between the publications of an AutoEvent with
+	// OnChange.
+	maxSilence time.Duration
+	// alignedStart runs the first execution after a full interval rather than a random
+	// fraction of it, when no offset is set.
+	alignedStart bool
+}
+
+// newSchedule returns the schedule of the AutoEvent of the Device, from Device.AutoEventSchedule
+// overridden by the ScheduleProtocol of the Device. If either is invalid, the schedule without
+// jitter, offset or maxSilence is returned along with the error.
+func newSchedule(device contract.Device, ae contract.AutoEvent, info common.AutoEventScheduleInfo) (schedule, error) {
+	s, err := parseSchedule(device, ae, info)
+	if err != nil {
+		return schedule{alignedStart: info.AlignedStart}, err
+	}
+	return s, nil
+}
+
+func parseSchedule(device contract.Device, ae contract.AutoEvent, info common.AutoEventScheduleInfo) (schedule, error) {
+	s := schedule{alignedStart: info.AlignedStart}
+	if info.Jitter != "" {
+		jitter, err := time.ParseDuration(info.Jitter)
+		if err != nil || jitter < 0 {
+			return s, fmt.Errorf("invalid Device.AutoEventSchedule.Jitter %s", info.Jitter)
+		}
+		s.jitter = jitter
+	}
+
+	value, ok := device.Protocols[ScheduleProtocol][ae.Resource]
+	if !ok {
+		return s, nil
+	}
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return s, fmt.Errorf("invalid AutoEvent schedule %s, expecting name=duration", field)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(kv[1]))
+		if err != nil || d < 0 {
+			return s, fmt.Errorf("invalid AutoEvent schedule %s, expecting a non-negative duration", field)
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case scheduleJitter:
+			s.jitter = d
+		case scheduleOffset:
+			s.offset = d
+			s.hasOffset = true
+		case scheduleMaxSilence:
+			s.maxSilence = d
+		default:
+			return s, fmt.Errorf("unknown AutoEvent schedule %s, expecting %s, %s or %s", kv[0], scheduleJitter, scheduleOffset, scheduleMaxSilence)
+		}
+	}
+	return s, nil
+}
+
+// first returns the delay of the first execution of the AutoEvent running every interval.
+func (s schedule) first(interval time.Duration) time.Duration {
+	switch {
+	case s.hasOffset:
+		return s.offset
+	case s.alignedStart || interval <= 0:
+		return interval
+	}
+	return randomDuration(interval)
+}
+
+// next returns the delay from now of the following execution of the AutoEvent running every
+// interval, and its time on the grid, an interval after anchor, the one of the previous
+// execution. The jitter delays the executions from the grid rather than lengthening the
+// intervals, so the AutoEvent still runs every interval on average. The times of the grid
+// already passed, e.g. while the previous execution overran, are skipped.
+func (s schedule) next(anchor time.Time, interval time.Duration, now time.Time) (time.Duration, time.Time) {
+	anchor = anchor.Add(interval)
+	if interval > 0 && anchor.Before(now) {
+		anchor = anchor.Add(now.Sub(anchor) / interval * interval)
+	}
+	delay := anchor.Add(s.spread()).Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, anchor
+}
+
+// spread returns the random delay of an execution, up to the jitter.
+func (s schedule) spread() time.Duration {
+	if s.jitter <= 0 {
+		return 0
+	}
+	return randomDuration(s.jitter)
+}
+
+// randomDuration returns a random duration in [0, max).
+func randomDuration(max time.Duration) time.Duration {
+	randomMutex.Lock()
+	defer randomMutex.Unlock()
+	return time.Duration(random.Int63n(int64(max)))
+}
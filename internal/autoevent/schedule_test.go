@@ -0,0 +1,154 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"testing"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+func scheduledDevice(schedule string) contract.Device {
+	return contract.Device{
+		Name:      "d1",
+		Protocols: map[string]contract.ProtocolProperties{ScheduleProtocol: {"Temperature": schedule}},
+	}
+}
+
+func TestNewSchedule(t *testing.T) {
+	ae := contract.AutoEvent{Frequency: "10s", Resource: "Temperature"}
+	tests := []struct {
+		name     string
+		info     common.AutoEventScheduleInfo
+		device   contract.Device
+		expected schedule
+		valid    bool
+	}{
+		{"none", common.AutoEventScheduleInfo{}, contract.Device{}, schedule{}, true},
+		{"service", common.AutoEventScheduleInfo{Jitter: "1s", AlignedStart: true}, contract.Device{},
+			schedule{jitter: time.Second, alignedStart: true}, true},
+		{"device", common.AutoEventScheduleInfo{Jitter: "1s"}, scheduledDevice("jitter=500ms, offset=0s,maxSilence=15m"),
+			schedule{jitter: 500 * time.Millisecond, hasOffset: true, maxSilence: 15 * time.Minute}, true},
+		{"other resource", common.AutoEventScheduleInfo{}, contract.Device{Protocols: map[string]contract.ProtocolProperties{ScheduleProtocol: {"Humidity": "offset=1s"}}},
+			schedule{}, true},
+		{"empty fields", common.AutoEventScheduleInfo{}, scheduledDevice(",offset=2s,"), schedule{offset: 2 * time.Second, hasOffset: true}, true},
+		{"invalid service jitter", common.AutoEventScheduleInfo{Jitter: "-1s"}, contract.Device{}, schedule{}, false},
+		{"not name=duration", common.AutoEventScheduleInfo{}, scheduledDevice("jitter"), schedule{}, false},
+		{"negative duration", common.AutoEventScheduleInfo{}, scheduledDevice("offset=-1s"), schedule{}, false},
+		{"unknown name", common.AutoEventScheduleInfo{}, scheduledDevice("delay=1s"), schedule{}, false},
+		{"partly invalid", common.AutoEventScheduleInfo{Jitter: "1s", AlignedStart: true}, scheduledDevice("offset=2s,delay=1s"),
+			schedule{alignedStart: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSchedule(tt.device, ae, tt.info)
+			if !tt.valid {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expected, s, "the invalid schedule is ignored as a whole")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s)
+		})
+	}
+}
+
+func TestScheduleFirst(t *testing.T) {
+	interval := 10 * time.Second
+	assert.Equal(t, 2*time.Second, schedule{offset: 2 * time.Second, hasOffset: true}.first(interval))
+	assert.Equal(t, time.Duration(0), schedule{hasOffset: true}.first(interval), "a zero offset runs at once")
+	assert.Equal(t, interval, schedule{alignedStart: true}.first(interval))
+	for i := 0; i < 100; i++ {
+		d := schedule{}.first(interval)
+		assert.True(t, d >= 0 && d < interval, "a random fraction of the interval, got %v", d)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	interval := 10 * time.Second
+	start := time.Unix(1000, 0)
+
+	delay, anchor := schedule{}.next(start, interval, start)
+	assert.Equal(t, interval, delay, "the intervals are exact without jitter")
+	assert.Equal(t, start.Add(interval), anchor)
+
+	s := schedule{jitter: time.Second}
+	now, anchor := start, start
+	for i := 1; i <= 100; i++ {
+		delay, anchor = s.next(anchor, interval, now)
+		assert.Equal(t, start.Add(time.Duration(i)*interval), anchor, "the grid doesn't drift with the jitter")
+		assert.True(t, delay >= anchor.Sub(now) && delay < anchor.Sub(now)+time.Second, "up to the jitter after the grid, got %v", delay)
+		now = now.Add(delay)
+	}
+
+	// the execution overran two intervals of the grid
+	delay, anchor = schedule{}.next(start, interval, start.Add(25*time.Second))
+	assert.Equal(t, start.Add(20*time.Second), anchor)
+	assert.Equal(t, time.Duration(0), delay, "the missed executions run once at once")
+}
+
+func TestExecutorDelays(t *testing.T) {
+	// Friday, January 29th 2021
+	start := time.Date(2021, 1, 29, 10, 15, 30, 0, time.UTC)
+	f := clock.NewFake(start)
+	clock.Set(f)
+	defer clock.Set(nil)
+
+	e, err := NewExecutor("d1", contract.AutoEvent{Frequency: "10s", Resource: "Temperature"})
+	require.NoError(t, err)
+	e.schedule = schedule{offset: 3 * time.Second, hasOffset: true, jitter: time.Second}
+	delay, ok := e.firstDelay()
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+	f.Advance(delay)
+	delay, ok = e.nextDelay()
+	assert.True(t, ok)
+	assert.True(t, delay >= 10*time.Second && delay < 11*time.Second)
+	f.Advance(delay)
+	jittered := delay - 10*time.Second
+	delay, ok = e.nextDelay()
+	assert.True(t, ok)
+	assert.True(t, delay >= 10*time.Second-jittered && delay < 11*time.Second-jittered, "the jitter of the previous execution isn't carried over, got %v", delay)
+
+	f = clock.NewFake(start)
+	clock.Set(f)
+	e, err = NewExecutor("d1", contract.AutoEvent{Frequency: "0 * * * *", Resource: "Temperature"})
+	require.NoError(t, err)
+	e.schedule = schedule{offset: 3 * time.Second, hasOffset: true}
+	delay, ok = e.firstDelay()
+	assert.True(t, ok)
+	assert.Equal(t, 44*time.Minute+30*time.Second, delay, "the cron expressions ignore the offset")
+
+	f.Advance(delay)
+	e.schedule.jitter = time.Second
+	delay, ok = e.nextDelay()
+	assert.True(t, ok)
+	assert.True(t, delay >= time.Hour && delay < time.Hour+time.Second, "the jitter is added to the cron expressions, got %v", delay)
+}
+
+func TestSilenceExceeded(t *testing.T) {
+	f := clock.NewFake(time.Unix(1000, 0))
+	clock.Set(f)
+	defer clock.Set(nil)
+
+	e, err := NewExecutor("d1", contract.AutoEvent{Frequency: "1s", Resource: "Temperature", OnChange: true})
+	require.NoError(t, err)
+	e.published = clock.Now()
+	assert.False(t, e.silenceExceeded(), "no max silence")
+
+	e.schedule.maxSilence = time.Minute
+	f.Advance(time.Minute - time.Second)
+	assert.False(t, e.silenceExceeded())
+	f.Advance(time.Second)
+	assert.True(t, e.silenceExceeded())
+}
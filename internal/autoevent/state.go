@@ -0,0 +1,99 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
+)
+
+// stateBucket is the bucket of the Store holding the last readings of the AutoEvents with
+// OnChange, so that the unchanged readings aren't published again after a restart.
+const stateBucket = "autoevent"
+
+// executorState is the stored state of an AutoEvent with OnChange.
+type executorState struct {
+	// Values are the last reading values, keyed by deviceResource name.
+	Values map[string]string `json:"values,omitempty"`
+	// Checksums are the checksums of the last binary readings, keyed by deviceResource name.
+	Checksums map[string]uint64 `json:"checksums,omitempty"`
+	// Published is the time of the last publication, in nanoseconds.
+	Published int64 `json:"published"`
+}
+
+// stateKey identifies the AutoEvent among those of the Device in the Store.
+func (e *Executor) stateKey() string {
+	return fmt.Sprintf("%s/%s/%s", e.deviceName, e.autoEvent.Resource, e.autoEvent.Frequency)
+}
+
+// loadState sets the Store persisting the state of the AutoEvent, and restores the state
+// stored by a previous run if any.
+func (e *Executor) loadState(s store.Store, lc logger.LoggingClient) {
+	e.store = s
+	if s == nil {
+		return
+	}
+	data, err := s.Get(stateBucket, e.stateKey())
+	if err == store.ErrNotFound {
+		return
+	}
+	var state executorState
+	if err == nil {
+		err = json.Unmarshal(data, &state)
+	}
+	if err != nil {
+		lc.Warn(fmt.Sprintf("AutoEvent - failed to restore the last readings of %s of device %s: %v", e.autoEvent.Resource, e.deviceName, err))
+		return
+	}
+
+	e.rwMutex.Lock()
+	defer e.rwMutex.Unlock()
+	for name, value := range state.Values {
+		e.lastReadings[name] = value
+	}
+	for name, checksum := range state.Checksums {
+		e.lastReadings[name] = checksum
+	}
+	e.published = time.Unix(0, state.Published)
+}
+
+// saveState stores the last readings and the last publication of the AutoEvent.
+func (e *Executor) saveState(lc logger.LoggingClient) {
+	if e.store == nil {
+		return
+	}
+	state := executorState{Published: e.published.UnixNano()}
+	e.rwMutex.RLock()
+	for name, last := range e.lastReadings {
+		switch v := last.(type) {
+		case string:
+			if state.Values == nil {
+				state.Values = make(map[string]string)
+			}
+			state.Values[name] = v
+		case uint64:
+			if state.Checksums == nil {
+				state.Checksums = make(map[string]uint64)
+			}
+			state.Checksums[name] = v
+		}
+	}
+	e.rwMutex.RUnlock()
+
+	data, err := json.Marshal(state)
+	if err == nil {
+		err = e.store.Put(stateBucket, e.stateKey(), data)
+	}
+	if err != nil {
+		lc.Warn(fmt.Sprintf("AutoEvent - failed to store the last readings of %s of device %s: %v", e.autoEvent.Resource, e.deviceName, err))
+	}
+}
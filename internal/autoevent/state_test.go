@@ -0,0 +1,63 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autoevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
+)
+
+func TestExecutorState(t *testing.T) {
+	lc := logger.NewMockClient()
+	s := store.NewMemory()
+	autoEvent := contract.AutoEvent{Frequency: "1s", Resource: "Temperature", OnChange: true}
+	readings := []contract.Reading{
+		{Name: "Temperature", Value: "10"},
+		{Name: "Image", BinaryValue: []byte("image")},
+	}
+
+	e, err := NewExecutor("d1", autoEvent)
+	require.NoError(t, err)
+	e.loadState(s, lc)
+	assert.False(t, compareReadings(e, readings, true, lc))
+	e.published = time.Unix(0, 1000)
+	e.saveState(lc)
+
+	// a new Executor after a restart doesn't publish the unchanged readings
+	restarted, err := NewExecutor("d1", autoEvent)
+	require.NoError(t, err)
+	restarted.loadState(s, lc)
+	assert.Equal(t, time.Unix(0, 1000), restarted.published)
+	assert.True(t, compareReadings(restarted, readings, true, lc))
+	readings[0].Value = "11"
+	assert.False(t, compareReadings(restarted, readings, true, lc))
+
+	// the other AutoEvents of the Device have their own state
+	other, err := NewExecutor("d1", contract.AutoEvent{Frequency: "1m", Resource: "Temperature", OnChange: true})
+	require.NoError(t, err)
+	other.loadState(s, lc)
+	assert.Empty(t, other.lastReadings)
+}
+
+func TestExecutorStateInvalid(t *testing.T) {
+	lc := logger.NewMockClient()
+	s := store.NewMemory()
+	e, err := NewExecutor("d1", contract.AutoEvent{Frequency: "1s", Resource: "Temperature", OnChange: true})
+	require.NoError(t, err)
+	require.NoError(t, s.Put(stateBucket, e.stateKey(), []byte("invalid")))
+
+	e.loadState(s, lc)
+	assert.Empty(t, e.lastReadings)
+	assert.True(t, e.published.IsZero())
+}
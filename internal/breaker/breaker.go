@@ -0,0 +1,154 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package breaker implements the circuit breakers failing the requests to an unavailable
+// service fast, instead of every request waiting for it to time out.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+// State is the state of a Breaker.
+type State string
+
+const (
+	// Closed lets the requests through.
+	Closed State = "closed"
+	// Open rejects the requests until the open timeout elapses.
+	Open State = "open"
+	// HalfOpen lets a single probe request through, closing the Breaker if it succeeds
+	// and opening it again otherwise.
+	HalfOpen State = "half-open"
+)
+
+// ErrOpen is returned for the requests rejected by an open Breaker.
+var ErrOpen = errors.New("circuit breaker open")
+
+// Status is the state of a Breaker.
+type Status struct {
+	State State `json:"state"`
+	// Failures is the number of consecutive failures.
+	Failures int `json:"failures"`
+	// OpenedAt is the time the Breaker last opened.
+	OpenedAt int64 `json:"openedAt,omitempty"`
+}
+
+// Breaker opens once the number of consecutive failures reaches the threshold, and lets
+// a probe request through once the open timeout elapses.
+type Breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	state       State
+	failures    int
+	openedAt    time.Time
+	probing     bool
+	mutex       sync.Mutex
+}
+
+var (
+	breakers = make(map[string]*Breaker) // key is the name of the service
+	mutex    sync.RWMutex
+)
+
+// New creates the Breaker of the named service, replacing its previous one.
+func New(name string, threshold int, openTimeout time.Duration) *Breaker {
+	b := &Breaker{threshold: threshold, openTimeout: openTimeout, state: Closed}
+	mutex.Lock()
+	defer mutex.Unlock()
+	breakers[name] = b
+	return b
+}
+
+// Reset removes the Breakers.
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	breakers = make(map[string]*Breaker)
+}
+
+// All returns the status of the Breakers keyed by the name of their service.
+func All() map[string]Status {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	if len(breakers) == 0 {
+		return nil
+	}
+	all := make(map[string]Status, len(breakers))
+	for name, b := range breakers {
+		all[name] = b.Status()
+	}
+	return all
+}
+
+// Allow returns ErrOpen if the request is rejected. Otherwise the outcome of the request
+// must be reported with Success, Failure or Abort.
+func (b *Breaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case Open:
+		if clock.Now().Sub(b.openedAt) < b.openTimeout {
+			return ErrOpen
+		}
+		b.state = HalfOpen
+		b.probing = true
+		return nil
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a successful request, closing the Breaker.
+func (b *Breaker) Success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.state = Closed
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure records a failed request, opening the Breaker if it's the probe request or the
+// threshold of consecutive failures is reached.
+func (b *Breaker) Failure() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.threshold {
+		b.state = Open
+		b.openedAt = clock.Now()
+	}
+	b.probing = false
+}
+
+// Abort records a request whose outcome doesn't tell whether the service is available,
+// e.g. cancelled by its client.
+func (b *Breaker) Abort() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+}
+
+// Status returns the state of the Breaker.
+func (b *Breaker) Status() Status {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	status := Status{State: b.state, Failures: b.failures}
+	if !b.openedAt.IsZero() {
+		status.OpenedAt = b.openedAt.UnixNano()
+	}
+	return status
+}
@@ -0,0 +1,119 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+const openTimeout = 10 * time.Second
+
+func newTestBreaker(t *testing.T) (*Breaker, *clock.Fake) {
+	f := clock.NewFake(time.Unix(1000, 0))
+	clock.Set(f)
+	t.Cleanup(func() {
+		clock.Set(nil)
+		Reset()
+	})
+	return New("core-data", 3, openTimeout), f
+}
+
+// open opens the Breaker with the threshold of consecutive failures.
+func open(t *testing.T, b *Breaker) {
+	for i := 0; i < 3; i++ {
+		require.NoError(t, b.Allow())
+		b.Failure()
+	}
+	require.Equal(t, Open, b.Status().State)
+}
+
+func TestBreakerOpens(t *testing.T) {
+	b, f := newTestBreaker(t)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, b.Allow())
+		b.Failure()
+	}
+	assert.Equal(t, Status{State: Closed, Failures: 2}, b.Status(), "the threshold isn't reached")
+	require.NoError(t, b.Allow())
+	b.Success()
+	assert.Equal(t, Status{State: Closed}, b.Status(), "the failures are consecutive only")
+
+	open(t, b)
+	assert.Equal(t, f.Now().UnixNano(), b.Status().OpenedAt)
+	assert.Equal(t, ErrOpen, b.Allow())
+	f.Advance(openTimeout - time.Nanosecond)
+	assert.Equal(t, ErrOpen, b.Allow(), "the requests fail fast until the open timeout elapses")
+}
+
+func TestBreakerProbeSucceeds(t *testing.T) {
+	b, f := newTestBreaker(t)
+	open(t, b)
+
+	f.Advance(openTimeout)
+	require.NoError(t, b.Allow(), "the probe request")
+	assert.Equal(t, HalfOpen, b.Status().State)
+	assert.Equal(t, ErrOpen, b.Allow(), "a single probe request at a time")
+
+	b.Success()
+	assert.Equal(t, Closed, b.Status().State)
+	assert.NoError(t, b.Allow())
+	assert.NoError(t, b.Allow())
+}
+
+func TestBreakerProbeFails(t *testing.T) {
+	b, f := newTestBreaker(t)
+	open(t, b)
+
+	f.Advance(openTimeout)
+	require.NoError(t, b.Allow())
+	b.Failure()
+	assert.Equal(t, Open, b.Status().State, "a failed probe opens the Breaker again")
+	assert.Equal(t, f.Now().UnixNano(), b.Status().OpenedAt)
+	assert.Equal(t, ErrOpen, b.Allow())
+
+	f.Advance(openTimeout)
+	assert.NoError(t, b.Allow(), "the open timeout starts over")
+}
+
+func TestBreakerProbeAborted(t *testing.T) {
+	b, f := newTestBreaker(t)
+	open(t, b)
+
+	f.Advance(openTimeout)
+	require.NoError(t, b.Allow())
+	b.Abort()
+	assert.Equal(t, HalfOpen, b.Status().State, "an aborted probe tells nothing")
+	require.NoError(t, b.Allow(), "another probe request is let through")
+	assert.Equal(t, ErrOpen, b.Allow())
+	b.Success()
+	assert.Equal(t, Closed, b.Status().State)
+
+	require.NoError(t, b.Allow())
+	b.Abort()
+	assert.Equal(t, Status{State: Closed, OpenedAt: b.Status().OpenedAt}, b.Status(), "an aborted request isn't a failure")
+}
+
+func TestAll(t *testing.T) {
+	b, _ := newTestBreaker(t)
+	open(t, b)
+	New("core-metadata", 3, openTimeout)
+
+	all := All()
+	assert.Len(t, all, 2)
+	assert.Equal(t, Open, all["core-data"].State)
+	assert.Equal(t, Closed, all["core-metadata"].State)
+
+	Reset()
+	assert.Nil(t, All())
+}
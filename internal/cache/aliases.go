@@ -0,0 +1,60 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"strings"
+	"sync"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// AliasLabelPrefix prefixes the Device labels declaring an alias name of the Device,
+// e.g. "alias:boiler-1".
+const AliasLabelPrefix = "alias:"
+
+var (
+	aliases    = make(map[string]string) // key is alias, and value is Device name
+	aliasMutex sync.RWMutex
+)
+
+// SetAliases replaces the locally maintained alias names of the Devices.
+func SetAliases(m map[string]string) {
+	aliasMutex.Lock()
+	defer aliasMutex.Unlock()
+	aliases = make(map[string]string, len(m))
+	for alias, name := range m {
+		aliases[alias] = name
+	}
+}
+
+// ForNameOrAlias returns the Device with the given name or, failing that, the Device
+// the given alias name is maintained locally or declared as label for.
+func (d *deviceCache) ForNameOrAlias(name string) (contract.Device, bool) {
+	if device, ok := d.ForName(name); ok {
+		return device, ok
+	}
+
+	aliasMutex.RLock()
+	deviceName, ok := aliases[name]
+	aliasMutex.RUnlock()
+	if ok {
+		return d.ForName(deviceName)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	label := AliasLabelPrefix + name
+	for _, device := range d.dMap {
+		for _, l := range device.Labels {
+			if strings.EqualFold(l, label) {
+				return *device, true
+			}
+		}
+	}
+	return contract.Device{}, false
+}
@@ -20,6 +20,7 @@ var (
 
 type DeviceCache interface {
 	ForName(name string) (contract.Device, bool)
+	ForNameOrAlias(name string) (contract.Device, bool)
 	ForId(id string) (contract.Device, bool)
 	All() []contract.Device
 	Add(device contract.Device) error
@@ -92,6 +93,9 @@ func (d *deviceCache) add(device contract.Device) error {
 	if _, ok := d.dMap[device.Name]; ok {
 		return fmt.Errorf("device %s has already existed in cache", device.Name)
 	}
+	if err := d.admit(device.Name); err != nil {
+		return err
+	}
 	d.dMap[device.Name] = &device
 	d.nameMap[device.Id] = device.Name
 	return nil
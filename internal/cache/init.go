@@ -31,27 +31,9 @@ func InitCache(
 	dc metadata.DeviceClient,
 	pwc metadata.ProvisionWatcherClient) {
 	initOnce.Do(func() {
-		ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
-
-		vds, err := vdc.ValueDescriptors(ctx)
-		if err != nil {
-			lc.Error(fmt.Sprintf("Value Descriptor cache initialization failed: %v", err))
-			vds = make([]contract.ValueDescriptor, 0)
-		}
+		vds, ds, pws := retrieve(serviceName, lc, vdc, dc, pwc)
 		newValueDescriptorCache(vds)
-
-		ds, err := dc.DevicesForServiceByName(ctx, serviceName)
-		if err != nil {
-			lc.Error(fmt.Sprintf("Device cache initialization failed: %v", err))
-			ds = make([]contract.Device, 0)
-		}
 		newDeviceCache(ds)
-
-		pws, err := pwc.ProvisionWatchersForServiceByName(ctx, serviceName)
-		if err != nil {
-			lc.Error(fmt.Sprintf("Provision Watcher cache initialization failed %v", err))
-			pws = make([]contract.ProvisionWatcher, 0)
-		}
 		newProvisionWatcherCache(pws)
 
 		dps := make([]contract.DeviceProfile, len(ds))
@@ -59,5 +41,80 @@ func InitCache(
 			dps[i] = d.Profile
 		}
 		newProfileCache(dps)
+
+		// DeviceTemplates are local to the Device Service and aren't stored in Core Metadata
+		newTemplateCache()
+	})
+}
+
+// InitEmptyCache creates empty caches, which SyncCache populates once Core Metadata
+// and Core Data are available.
+func InitEmptyCache() {
+	initOnce.Do(func() {
+		newValueDescriptorCache(make([]contract.ValueDescriptor, 0))
+		newDeviceCache(make([]contract.Device, 0))
+		newProvisionWatcherCache(make([]contract.ProvisionWatcher, 0))
+		newProfileCache(make([]contract.DeviceProfile, 0))
+		newTemplateCache()
 	})
 }
+
+// SyncCache adds the Value Descriptors, Devices, Device Profiles and Provision Watchers
+// of the Device Service to the caches created by InitEmptyCache. The entries already
+// cached are updated. It returns the Devices retrieved from Core Metadata.
+func SyncCache(
+	serviceName string,
+	lc logger.LoggingClient,
+	vdc coredata.ValueDescriptorClient,
+	dc metadata.DeviceClient,
+	pwc metadata.ProvisionWatcherClient) []contract.Device {
+	vds, ds, pws := retrieve(serviceName, lc, vdc, dc, pwc)
+	for _, vd := range vds {
+		if ValueDescriptors().Add(vd) != nil {
+			_ = ValueDescriptors().Update(vd)
+		}
+	}
+	for _, pw := range pws {
+		if ProvisionWatchers().Add(pw) != nil {
+			_ = ProvisionWatchers().Update(pw)
+		}
+	}
+	for _, d := range ds {
+		if Profiles().Add(d.Profile) != nil {
+			_ = Profiles().Update(d.Profile)
+		}
+		if Devices().Add(d) != nil {
+			_ = Devices().Update(d)
+		}
+	}
+	return ds
+}
+
+func retrieve(
+	serviceName string,
+	lc logger.LoggingClient,
+	vdc coredata.ValueDescriptorClient,
+	dc metadata.DeviceClient,
+	pwc metadata.ProvisionWatcherClient) ([]contract.ValueDescriptor, []contract.Device, []contract.ProvisionWatcher) {
+	ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
+
+	vds, err := vdc.ValueDescriptors(ctx)
+	if err != nil {
+		lc.Error(fmt.Sprintf("Value Descriptor cache initialization failed: %v", err))
+		vds = make([]contract.ValueDescriptor, 0)
+	}
+
+	ds, err := dc.DevicesForServiceByName(ctx, serviceName)
+	if err != nil {
+		lc.Error(fmt.Sprintf("Device cache initialization failed: %v", err))
+		ds = make([]contract.Device, 0)
+	}
+
+	pws, err := pwc.ProvisionWatchersForServiceByName(ctx, serviceName)
+	if err != nil {
+		lc.Error(fmt.Sprintf("Provision Watcher cache initialization failed %v", err))
+		pws = make([]contract.ProvisionWatcher, 0)
+	}
+
+	return vds, ds, pws
+}
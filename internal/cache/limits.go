@@ -0,0 +1,84 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+var (
+	limits      common.CacheLimitsInfo
+	limitsMutex sync.RWMutex
+)
+
+// SetLimits bounds the number of Devices and Device Profiles added to the caches
+// afterwards. 0 doesn't bound the cache.
+func SetLimits(info common.CacheLimitsInfo) {
+	limitsMutex.Lock()
+	defer limitsMutex.Unlock()
+	limits = info
+}
+
+func currentLimits() common.CacheLimitsInfo {
+	limitsMutex.RLock()
+	defer limitsMutex.RUnlock()
+	return limits
+}
+
+// admit returns an error if the Device cache is full. The Devices are never evicted as
+// they're all served by the Device Service.
+func (d *deviceCache) admit(name string) error {
+	max := currentLimits().MaxDevices
+	if max > 0 && len(d.dMap) >= max {
+		return fmt.Errorf("device cache is full with %d devices, device %s not added; raise Device.CacheLimits.MaxDevices", len(d.dMap), name)
+	}
+	return nil
+}
+
+// touch marks the Device Profile as the most recently used one.
+func (p *profileCache) touch(name string) {
+	if _, ok := p.dpMap[name]; ok {
+		p.useCount++
+		p.lastUsed[name] = p.useCount
+	}
+}
+
+// admit evicts the least recently used Device Profile no cached Device refers to if the
+// Device Profile cache is full, and returns an error if there is none. The evicted Device
+// Profiles are loaded again along with the Devices referring to them.
+func (p *profileCache) admit(name string) error {
+	max := currentLimits().MaxProfiles
+	if max <= 0 || len(p.dpMap) < max {
+		return nil
+	}
+
+	inUse := make(map[string]bool)
+	if dc != nil {
+		dc.mutex.Lock()
+		for _, d := range dc.dMap {
+			inUse[d.Profile.Name] = true
+		}
+		dc.mutex.Unlock()
+	}
+
+	var lru string
+	for n := range p.dpMap {
+		if inUse[n] {
+			continue
+		}
+		if lru == "" || p.lastUsed[n] < p.lastUsed[lru] {
+			lru = n
+		}
+	}
+	if lru == "" {
+		return fmt.Errorf("device profile cache is full with %d profiles in use, device profile %s not added; raise Device.CacheLimits.MaxProfiles", len(p.dpMap), name)
+	}
+	return p.removeByName(lru)
+}
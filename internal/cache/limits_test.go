@@ -0,0 +1,96 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+func setLimits(t *testing.T, info common.CacheLimitsInfo) {
+	SetLimits(info)
+	t.Cleanup(func() {
+		SetLimits(common.CacheLimitsInfo{})
+	})
+}
+
+func testProfile(name string) contract.DeviceProfile {
+	return contract.DeviceProfile{Id: name + "-id", Name: name}
+}
+
+func testDevice(name string, profileName string) contract.Device {
+	return contract.Device{Id: name + "-id", Name: name, Profile: contract.DeviceProfile{Name: profileName}}
+}
+
+func TestDeviceCacheLimit(t *testing.T) {
+	setLimits(t, common.CacheLimitsInfo{MaxDevices: 2})
+	dc := newDeviceCache(nil)
+
+	require.NoError(t, dc.Add(testDevice("d1", "p1")))
+	require.NoError(t, dc.Add(testDevice("d2", "p1")))
+	err := dc.Add(testDevice("d3", "p1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "device cache is full")
+
+	require.NoError(t, dc.RemoveByName("d1"))
+	assert.NoError(t, dc.Add(testDevice("d3", "p1")), "the Devices are admitted once there's room")
+}
+
+func TestProfileCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	setLimits(t, common.CacheLimitsInfo{MaxProfiles: 2})
+	newDeviceCache(nil)
+	pc := newProfileCache(nil)
+
+	require.NoError(t, pc.Add(testProfile("p1")))
+	require.NoError(t, pc.Add(testProfile("p2")))
+	_, ok := pc.ForName("p1")
+	require.True(t, ok)
+
+	require.NoError(t, pc.Add(testProfile("p3")))
+	_, ok = pc.ForName("p2")
+	assert.False(t, ok, "the least recently used Device Profile is evicted")
+	_, ok = pc.ForName("p1")
+	assert.True(t, ok)
+	_, ok = pc.ForId("p3-id")
+	assert.True(t, ok)
+	assert.Len(t, pc.All(), 2)
+}
+
+func TestProfileCacheKeepsProfilesInUse(t *testing.T) {
+	setLimits(t, common.CacheLimitsInfo{MaxProfiles: 2})
+	dc := newDeviceCache(nil)
+	pc := newProfileCache(nil)
+
+	require.NoError(t, pc.Add(testProfile("p1")))
+	require.NoError(t, pc.Add(testProfile("p2")))
+	require.NoError(t, dc.Add(testDevice("d1", "p1")))
+	_, ok := pc.ForName("p2")
+	require.True(t, ok)
+
+	require.NoError(t, pc.Add(testProfile("p3")))
+	_, ok = pc.ForName("p1")
+	assert.True(t, ok, "the Device Profile a Device refers to isn't evicted, though least recently used")
+	_, ok = pc.ForName("p2")
+	assert.False(t, ok)
+
+	require.NoError(t, dc.Add(testDevice("d3", "p3")))
+	err := pc.Add(testProfile("p4"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "device profile cache is full with 2 profiles in use")
+	_, ok = pc.ForName("p4")
+	assert.False(t, ok)
+
+	require.NoError(t, dc.RemoveByName("d1"))
+	require.NoError(t, pc.Add(testProfile("p4")), "the Device Profile no longer in use is evicted")
+	_, ok = pc.ForName("p1")
+	assert.False(t, ok)
+}
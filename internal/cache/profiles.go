@@ -41,6 +41,8 @@ type profileCache struct {
 	getRoMap map[string]map[string][]contract.ResourceOperation
 	setRoMap map[string]map[string][]contract.ResourceOperation
 	ccMap    map[string]map[string]contract.Command
+	lastUsed map[string]uint64 // key is DeviceProfile name, and value is useCount when last used
+	useCount uint64
 	mutex    sync.Mutex
 }
 
@@ -48,6 +50,7 @@ func (p *profileCache) ForName(name string) (contract.DeviceProfile, bool) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
+	p.touch(name)
 	dp, ok := p.dpMap[name]
 	return dp, ok
 }
@@ -89,11 +92,15 @@ func (p *profileCache) add(profile contract.DeviceProfile) error {
 	if _, ok := p.dpMap[profile.Name]; ok {
 		return fmt.Errorf("device profile %s has already existed in cache", profile.Name)
 	}
+	if err := p.admit(profile.Name); err != nil {
+		return err
+	}
 	p.dpMap[profile.Name] = profile
 	p.nameMap[profile.Id] = profile.Name
 	p.drMap[profile.Name] = deviceResourceSliceToMap(profile.DeviceResources)
 	p.getRoMap[profile.Name], p.setRoMap[profile.Name] = profileResourceSliceToMaps(profile.DeviceCommands)
 	p.ccMap[profile.Name] = commandSliceToMap(profile.CoreCommands)
+	p.touch(profile.Name)
 	return nil
 }
 
@@ -172,12 +179,14 @@ func (p *profileCache) removeByName(name string) error {
 	delete(p.getRoMap, name)
 	delete(p.setRoMap, name)
 	delete(p.ccMap, name)
+	delete(p.lastUsed, name)
 	return nil
 }
 
 func (p *profileCache) DeviceResource(profileName string, resourceName string) (contract.DeviceResource, bool) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
+	p.touch(profileName)
 
 	drs, ok := p.drMap[profileName]
 	if !ok {
@@ -193,6 +202,7 @@ func (p *profileCache) DeviceResource(profileName string, resourceName string) (
 func (p *profileCache) CommandExists(profileName string, cmd string, method string) (bool, error) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
+	p.touch(profileName)
 
 	_, profileExist := p.dpMap[profileName]
 	if !profileExist {
@@ -218,6 +228,7 @@ func (p *profileCache) CommandExists(profileName string, cmd string, method stri
 func (p *profileCache) ResourceOperations(profileName string, cmd string, method string) ([]contract.ResourceOperation, error) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
+	p.touch(profileName)
 
 	var resOps []contract.ResourceOperation
 	var rosMap map[string][]contract.ResourceOperation
@@ -242,6 +253,7 @@ func (p *profileCache) ResourceOperations(profileName string, cmd string, method
 func (p *profileCache) ResourceOperation(profileName string, deviceResource string, method string) (contract.ResourceOperation, error) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
+	p.touch(profileName)
 
 	var ro contract.ResourceOperation
 	var rosMap map[string][]contract.ResourceOperation
@@ -288,7 +300,7 @@ func newProfileCache(profiles []contract.DeviceProfile) ProfileCache {
 		getRoMap[dp.Name], setRoMap[dp.Name] = profileResourceSliceToMaps(dp.DeviceCommands)
 		cmdMap[dp.Name] = commandSliceToMap(dp.CoreCommands)
 	}
-	pc = &profileCache{dpMap: dpMap, nameMap: nameMap, drMap: drMap, getRoMap: getRoMap, setRoMap: setRoMap, ccMap: cmdMap}
+	pc = &profileCache{dpMap: dpMap, nameMap: nameMap, drMap: drMap, getRoMap: getRoMap, setRoMap: setRoMap, ccMap: cmdMap, lastUsed: make(map[string]uint64, defaultSize)}
 	return pc
 }
 
@@ -0,0 +1,103 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var (
+	tc *templateCache
+)
+
+type TemplateCache interface {
+	ForName(name string) (dsModels.DeviceTemplate, bool)
+	All() []dsModels.DeviceTemplate
+	Add(template dsModels.DeviceTemplate) error
+	Update(template dsModels.DeviceTemplate) error
+	RemoveByName(name string) error
+}
+
+type templateCache struct {
+	tMap  map[string]*dsModels.DeviceTemplate // key is DeviceTemplate name
+	mutex sync.Mutex
+}
+
+// ForName returns a DeviceTemplate with the given name.
+func (t *templateCache) ForName(name string) (dsModels.DeviceTemplate, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if template, ok := t.tMap[name]; ok {
+		return *template, ok
+	} else {
+		return dsModels.DeviceTemplate{}, ok
+	}
+}
+
+// All returns the current list of DeviceTemplates in the cache.
+func (t *templateCache) All() []dsModels.DeviceTemplate {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	templates := make([]dsModels.DeviceTemplate, len(t.tMap))
+	i := 0
+	for _, template := range t.tMap {
+		templates[i] = *template
+		i++
+	}
+	return templates
+}
+
+// Add adds a new DeviceTemplate to the cache. This method is used to populate the
+// template cache with templates defined by the Device Service or added via REST.
+func (t *templateCache) Add(template dsModels.DeviceTemplate) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.tMap[template.Name]; ok {
+		return fmt.Errorf("DeviceTemplate %s has already existed in cache", template.Name)
+	}
+	t.tMap[template.Name] = &template
+	return nil
+}
+
+// Update updates the DeviceTemplate in the cache.
+func (t *templateCache) Update(template dsModels.DeviceTemplate) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.tMap[template.Name]; !ok {
+		return fmt.Errorf("DeviceTemplate %s does not exist in cache", template.Name)
+	}
+	t.tMap[template.Name] = &template
+	return nil
+}
+
+// RemoveByName removes the specified DeviceTemplate by name from the cache.
+func (t *templateCache) RemoveByName(name string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.tMap[name]; !ok {
+		return fmt.Errorf("DeviceTemplate %s does not exist in cache", name)
+	}
+	delete(t.tMap, name)
+	return nil
+}
+
+func newTemplateCache() TemplateCache {
+	tc = &templateCache{tMap: make(map[string]*dsModels.DeviceTemplate)}
+	return tc
+}
+
+func Templates() TemplateCache {
+	return tc
+}
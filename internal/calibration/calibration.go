@@ -0,0 +1,187 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package calibration stores the versioned calibration coefficients of the Devices'
+// deviceResources applied to their readings, optionally persisted to a file so that
+// they survive restarts.
+package calibration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxVersions is the number of prior versions retained per deviceResource.
+const maxVersions = 10
+
+// Coefficients transform a reading x into p(x)*Gain + Offset, where p is the Polynomial
+// whose i-th coefficient is of degree i. The polynomial is skipped if it's empty, and a
+// zero Gain is a gain of 1.
+type Coefficients struct {
+	Offset     float64   `json:"offset"`
+	Gain       float64   `json:"gain"`
+	Polynomial []float64 `json:"polynomial,omitempty"`
+}
+
+// Apply returns the calibrated value.
+func (c Coefficients) Apply(x float64) float64 {
+	if len(c.Polynomial) > 0 {
+		y := 0.0
+		for i := len(c.Polynomial) - 1; i >= 0; i-- {
+			y = y*x + c.Polynomial[i]
+		}
+		x = y
+	}
+	if c.Gain != 0 {
+		x *= c.Gain
+	}
+	return x + c.Offset
+}
+
+// Calibration is a version of the calibration of a Device's deviceResource.
+type Calibration struct {
+	Device       string       `json:"device"`
+	Resource     string       `json:"resource"`
+	Coefficients Coefficients `json:"coefficients"`
+	Version      int          `json:"version"`
+	Created      int64        `json:"created"`
+}
+
+type entry struct {
+	Current Calibration   `json:"current"`
+	History []Calibration `json:"history,omitempty"`
+}
+
+var (
+	entries = make(map[string]map[string]*entry) // keys are device and deviceResource names
+	path    string
+	mutex   sync.RWMutex
+)
+
+// Load sets the file persisting the calibrations and loads it if it exists. The
+// calibrations are only kept in memory if file is empty.
+func Load(file string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+	path = file
+	entries = make(map[string]map[string]*entry)
+	if path == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read calibration file %s: %v", path, err)
+	}
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return fmt.Errorf("failed to decode calibration file %s: %v", path, err)
+	}
+	return nil
+}
+
+// Set stores a new version of the calibration of the Device's deviceResource.
+func Set(device string, resource string, coefficients Coefficients) (Calibration, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	resources, ok := entries[device]
+	if !ok {
+		resources = make(map[string]*entry)
+		entries[device] = resources
+	}
+	c := Calibration{
+		Device:       device,
+		Resource:     resource,
+		Coefficients: coefficients,
+		Version:      1,
+		Created:      time.Now().UnixNano(),
+	}
+	if e, ok := resources[resource]; ok {
+		c.Version = e.Current.Version + 1
+		e.History = append([]Calibration{e.Current}, e.History...)
+		if len(e.History) > maxVersions {
+			e.History = e.History[:maxVersions]
+		}
+		e.Current = c
+	} else {
+		resources[resource] = &entry{Current: c}
+	}
+	return c, save()
+}
+
+// Get returns the current calibration of the Device's deviceResource.
+func Get(device string, resource string) (Calibration, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	e, ok := entries[device][resource]
+	if !ok {
+		return Calibration{}, false
+	}
+	return e.Current, true
+}
+
+// History returns the prior versions of the calibration of the Device's deviceResource,
+// latest first.
+func History(device string, resource string) []Calibration {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	e, ok := entries[device][resource]
+	if !ok {
+		return nil
+	}
+	return append([]Calibration(nil), e.History...)
+}
+
+// ForDevice returns the current calibrations of the Device's deviceResources.
+func ForDevice(device string) []Calibration {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	calibrations := make([]Calibration, 0, len(entries[device]))
+	for _, e := range entries[device] {
+		calibrations = append(calibrations, e.Current)
+	}
+	return calibrations
+}
+
+// Remove removes the calibration of the Device's deviceResource, reporting whether it existed.
+func Remove(device string, resource string) (bool, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, ok := entries[device][resource]; !ok {
+		return false, nil
+	}
+	delete(entries[device], resource)
+	if len(entries[device]) == 0 {
+		delete(entries, device)
+	}
+	return true, save()
+}
+
+// save writes the calibrations to the file, if any. The caller holds the lock.
+func save() error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode calibrations: %v", err)
+	}
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write calibration file %s: %v", path, err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write calibration file %s: %v", path, err)
+	}
+	return nil
+}
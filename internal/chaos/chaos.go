@@ -0,0 +1,143 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chaos injects latency, drops and errors at defined points of the Device Service,
+// so that operators can validate the retries and the buffering before trusting them in
+// production. It's a debugging aid: the faults are only injected when
+// Service.FailureInjection is enabled.
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+// The points the faults are injected at.
+const (
+	// PointDriver is the ProtocolDriver read and write commands.
+	PointDriver = "driver"
+	// PointPublish is the publishing of the events to Core Data or the MessageBus.
+	PointPublish = "publish"
+	// PointCallback is the callbacks from Core Metadata.
+	PointCallback = "callback"
+)
+
+var points = map[string]bool{PointDriver: true, PointPublish: true, PointCallback: true}
+
+var (
+	// ErrInjected is the error injected at a point.
+	ErrInjected = errors.New("injected failure")
+	// ErrDropped is returned when the operation at a point is to be dropped silently.
+	ErrDropped = errors.New("injected drop")
+)
+
+// Fault is the fault injected at a point. The operation is delayed by Latency, then
+// dropped with the probability DropRate or failed with the probability ErrorRate.
+type Fault struct {
+	Latency   string  `json:"latency,omitempty"`
+	DropRate  float64 `json:"dropRate,omitempty"`
+	ErrorRate float64 `json:"errorRate,omitempty"`
+	latency   time.Duration
+}
+
+var (
+	enabled bool
+	faults  = make(map[string]Fault) // key is point
+	random  = rand.New(rand.NewSource(time.Now().UnixNano()))
+	mutex   sync.Mutex
+)
+
+// SetEnabled enables the failure injection, or disables it and clears the faults.
+func SetEnabled(enable bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	enabled = enable
+	if !enable {
+		faults = make(map[string]Fault)
+	}
+}
+
+// Enabled reports whether the failure injection is enabled.
+func Enabled() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return enabled
+}
+
+// SetFault sets the fault injected at the point.
+func SetFault(point string, f Fault) error {
+	if !points[point] {
+		return fmt.Errorf("unknown failure injection point %s", point)
+	}
+	if f.Latency != "" {
+		d, err := time.ParseDuration(f.Latency)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid latency %s", f.Latency)
+		}
+		f.latency = d
+	}
+	if f.DropRate < 0 || f.ErrorRate < 0 || f.DropRate+f.ErrorRate > 1 {
+		return errors.New("dropRate and errorRate must be positive and add up to 1 at most")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if !enabled {
+		return errors.New("failure injection disabled")
+	}
+	faults[point] = f
+	return nil
+}
+
+// ClearFaults removes the faults of all points.
+func ClearFaults() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	faults = make(map[string]Fault)
+}
+
+// Faults returns the faults keyed by point.
+func Faults() map[string]Fault {
+	mutex.Lock()
+	defer mutex.Unlock()
+	result := make(map[string]Fault, len(faults))
+	for point, f := range faults {
+		result[point] = f
+	}
+	return result
+}
+
+// Inject applies the fault of the point, if any: it waits for the latency, then returns
+// ErrDropped or ErrInjected according to the rates, or nil.
+func Inject(point string) error {
+	mutex.Lock()
+	f, ok := faults[point]
+	var roll float64
+	if ok {
+		roll = random.Float64()
+	}
+	mutex.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if f.latency > 0 {
+		<-clock.After(f.latency)
+	}
+	switch {
+	case roll < f.DropRate:
+		return ErrDropped
+	case roll < f.DropRate+f.ErrorRate:
+		return ErrInjected
+	default:
+		return nil
+	}
+}
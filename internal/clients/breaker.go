@@ -0,0 +1,151 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/breaker"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+// processTransport is the default transport of the process, left unchanged for the HTTP
+// requests other than those of the core service clients, such as the drivers' requests.
+var processTransport = http.DefaultTransport
+
+// router is installed once as the default transport, since the clients of the core
+// services create their http.Client for each request and can't be given a transport. It
+// sends the requests to the core services through the transport dedicated to them, and
+// the other requests through the default transport of the process.
+var (
+	router        = &routingTransport{}
+	installRouter sync.Once
+)
+
+type routingTransport struct {
+	current atomic.Value // holds the *breakerTransport of the core services
+}
+
+func (t *routingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if core, ok := t.current.Load().(*breakerTransport); ok {
+		return core.RoundTrip(req)
+	}
+	return processTransport.RoundTrip(req)
+}
+
+// newCoreTransport returns the transport dedicated to the core service clients, so that
+// their proxy and connection settings don't apply to the other HTTP requests.
+func newCoreTransport() *http.Transport {
+	if t, ok := processTransport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return &http.Transport{Proxy: http.ProxyFromEnvironment}
+}
+
+// breakerTransport sends the requests of the core service clients through their dedicated
+// transport, counting the failed requests and applying the circuit breaker of the core
+// service each request is sent to, if enabled. Transport errors and 5xx responses count as
+// failures. The requests to other hosts go through the default transport of the process.
+type breakerTransport struct {
+	next     http.RoundTripper
+	clients  map[string]string           // key is the host of the core service, value is the client name
+	breakers map[string]*breaker.Breaker // key is the host of the core service
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	client, ok := t.clients[req.URL.Host]
+	if !ok {
+		return processTransport.RoundTrip(req)
+	}
+	b := t.breakers[req.URL.Host]
+	if b != nil {
+		if err := b.Allow(); err != nil {
+			metrics.ClientError(client)
+			return nil, fmt.Errorf("%s %s: %v", req.Method, req.URL, err)
+		}
+	}
+	res, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		if b != nil {
+			b.Abort()
+		}
+	case err != nil || res.StatusCode >= http.StatusInternalServerError:
+		metrics.ClientError(client)
+		if b != nil {
+			b.Failure()
+		}
+	default:
+		if b != nil {
+			b.Success()
+		}
+	}
+	return res, err
+}
+
+// configureBreakers routes the clients of Core Data and Core Metadata through the core
+// transport, with the circuit breakers of Service.CircuitBreaker if enabled, and counts
+// their failed requests.
+func configureBreakers(configuration *common.ConfigurationStruct, core http.RoundTripper, lc logger.LoggingClient) error {
+	info := configuration.Service.CircuitBreaker
+	breaker.Reset()
+	installRouter.Do(func() {
+		http.DefaultTransport = router
+	})
+
+	transport := &breakerTransport{next: core, clients: make(map[string]string), breakers: make(map[string]*breaker.Breaker)}
+	for _, name := range []string{common.ClientData, common.ClientMetadata} {
+		u, err := url.Parse(configuration.Clients[name].Url())
+		if err != nil || u.Host == "" {
+			if info.Enabled {
+				return errors.New("invalid " + name + " client URL")
+			}
+			continue
+		}
+		transport.clients[u.Host] = name
+	}
+	if !info.Enabled {
+		router.current.Store(transport)
+		return nil
+	}
+
+	threshold := info.FailureThreshold
+	if threshold == 0 {
+		threshold = defaultFailureThreshold
+	} else if threshold < 0 {
+		return fmt.Errorf("invalid Service.CircuitBreaker.FailureThreshold %d", threshold)
+	}
+	openTimeout := defaultOpenTimeout
+	if info.OpenTimeout != "" {
+		d, err := time.ParseDuration(info.OpenTimeout)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid Service.CircuitBreaker.OpenTimeout %s", info.OpenTimeout)
+		}
+		openTimeout = d
+	}
+
+	for host, name := range transport.clients {
+		transport.breakers[host] = breaker.New(name, threshold, openTimeout)
+	}
+	router.current.Store(transport)
+	lc.Info(fmt.Sprintf("core service clients fail fast after %d consecutive failures for %s", threshold, openTimeout))
+	return nil
+}
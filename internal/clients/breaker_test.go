@@ -0,0 +1,62 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+func TestCoreTransportIsDedicated(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("direct"))
+	}))
+	defer other.Close()
+
+	lc := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return lc
+		},
+	})
+	configuration := &common.ConfigurationStruct{
+		Clients: map[string]bootstrapConfig.ClientInfo{
+			common.ClientData:     {Host: "core-data.test", Port: 48080, Protocol: "http"},
+			common.ClientMetadata: {Host: "core-metadata.test", Port: 48081, Protocol: "http"},
+		},
+	}
+	transport := newCoreTransport()
+	require.NoError(t, configureProxy(common.ProxyInfo{URL: proxy.URL}, transport, dic))
+	require.NoError(t, configureBreakers(configuration, transport, lc))
+	defer router.current.Store(&breakerTransport{next: processTransport})
+
+	get := func(url string) string {
+		res, err := http.Get(url)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	assert.Equal(t, "proxied", get("http://core-data.test:48080/api/v1/ping"), "the core services are reached through the proxy")
+	assert.Equal(t, "direct", get(other.URL), "the proxy doesn't apply to the other requests")
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/command"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/coredata"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/general"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
@@ -27,8 +28,12 @@ import (
 	"github.com/edgexfoundry/go-mod-registry/v2/registry"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/health"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/registrymonitor"
 )
 
+const defaultCoreDataCheckInterval = 10 * time.Second
+
 // Clients contains references to dependencies required by the Clients bootstrap implementation.
 type Clients struct {
 }
@@ -50,16 +55,29 @@ func (_ *Clients) BootstrapHandler(
 // through Metadata Client and Core Data Client.
 // Service Client Initializer also needs to check the service status of Metadata and Core Data Services,
 // because they are important dependencies of Device Service.
-// The initialization process should be pending until Metadata Service and Core Data Service are both available.
+// The initialization process should be pending until Metadata Service and Core Data Service are both available,
+// unless Service.DeferredStartup leaves the check to WaitForDependencies.
 func InitDependencyClients(ctx context.Context, startupTimer startup.Timer, dic *di.Container) bool {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	configuration := container.ConfigurationFrom(dic.Get)
 
-	if err := validateClientConfig(container.ConfigurationFrom(dic.Get)); err != nil {
+	if err := validateClientConfig(configuration); err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	transport := newCoreTransport()
+	if err := configureProxy(configuration.Service.Proxy, transport, dic); err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	if err := configureBreakers(configuration, transport, lc); err != nil {
 		lc.Error(err.Error())
 		return false
 	}
 
-	if checkDependencyServices(ctx, startupTimer, dic) == false {
+	if configuration.Service.DeferredStartup {
+		lc.Info("Deferred startup enabled, checking dependency services in the background")
+	} else if WaitForDependencies(ctx, startupTimer, dic) == false {
 		return false
 	}
 
@@ -69,6 +87,51 @@ func InitDependencyClients(ctx context.Context, startupTimer startup.Timer, dic
 	return true
 }
 
+// WaitForDependencies blocks until Core Data and Core Metadata are both available or the
+// startup timer elapses, and completes the dependenciesUp startup phase once they are.
+// Only Core Metadata is waited for if Service.CoreDataOptional is enabled, Core Data being
+// checked in the background until it responds.
+func WaitForDependencies(ctx context.Context, startupTimer startup.Timer, dic *di.Container) bool {
+	if checkDependencyServices(ctx, startupTimer, dic) == false {
+		return false
+	}
+	if container.ConfigurationFrom(dic.Get).Service.CoreDataOptional {
+		common.SetCoreDataReachable(false)
+		go waitForCoreData(ctx, dic)
+	}
+	health.CompletePhase(health.PhaseDependenciesUp, bootstrapContainer.LoggingClientFrom(dic.Get))
+	return true
+}
+
+// waitForCoreData checks Core Data every Service.CheckInterval until it's available, the
+// events being deferred until then.
+func waitForCoreData(ctx context.Context, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	configuration := container.ConfigurationFrom(dic.Get)
+	interval, err := time.ParseDuration(configuration.Service.CheckInterval)
+	if err != nil || interval <= 0 {
+		interval = defaultCoreDataCheckInterval
+	}
+
+	for {
+		if rc := bootstrapContainer.RegistryFrom(dic.Get); rc != nil && !registrymonitor.Degraded() {
+			err = checkServiceAvailableViaRegistry(common.ClientData, rc, lc)
+		} else {
+			err = checkServiceAvailableByPing(common.ClientData, configuration, lc)
+		}
+		if err == nil {
+			common.SetCoreDataReachable(true)
+			lc.Info("Core Data available, posting the events")
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
 func validateClientConfig(configuration *common.ConfigurationStruct) error {
 
 	if len(configuration.Clients[common.ClientMetadata].Host) == 0 {
@@ -94,6 +157,9 @@ func validateClientConfig(configuration *common.ConfigurationStruct) error {
 
 func checkDependencyServices(ctx context.Context, startupTimer startup.Timer, dic *di.Container) bool {
 	var dependencyList = []string{common.ClientData, common.ClientMetadata}
+	if container.ConfigurationFrom(dic.Get).Service.CoreDataOptional {
+		dependencyList = []string{common.ClientMetadata}
+	}
 	var waitGroup sync.WaitGroup
 	checkingErr := true
 
@@ -122,7 +188,8 @@ func checkServiceAvailable(ctx context.Context, serviceId string, startupTimer s
 		case <-ctx.Done():
 			return false
 		default:
-			if rc != nil {
+			// the configured endpoint is pinged while the registry is unavailable
+			if rc != nil && !registrymonitor.Degraded() {
 				if checkServiceAvailableViaRegistry(serviceId, rc, lc) == nil {
 					return true
 				}
@@ -220,3 +287,13 @@ func initializeClients(dic *di.Container) {
 		},
 	})
 }
+
+// NewCommandClient returns the Core Command client if Clients.Command is configured, or nil
+// otherwise.
+func NewCommandClient(configuration *common.ConfigurationStruct) command.CommandClient {
+	info, ok := configuration.Clients[common.ClientCommand]
+	if !ok || len(info.Host) == 0 {
+		return nil
+	}
+	return command.NewCommandClient(local.New(info.Url() + clients.ApiDeviceRoute))
+}
@@ -0,0 +1,151 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// The keys of the proxy credentials in the Secret Store.
+const (
+	ProxyUsernameKey = "username"
+	ProxyPasswordKey = "password"
+)
+
+// configureProxy makes the HTTP clients of the core services, which use the core
+// transport, reach them through the proxy of Service.Proxy. The HTTP_PROXY, HTTPS_PROXY
+// and NO_PROXY environment variables apply if no proxy URL is configured.
+func configureProxy(info common.ProxyInfo, transport *http.Transport, dic *di.Container) error {
+	if info.URL == "" && len(info.NoProxy) == 0 {
+		return nil
+	}
+
+	var proxyURL *url.URL
+	if info.URL != "" {
+		u, err := url.Parse(info.URL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid Service.Proxy.URL %s", info.URL)
+		}
+		proxyURL = u
+	}
+	if proxyURL != nil && info.SecretPath != "" {
+		provider := bootstrapContainer.SecretProviderFrom(dic.Get)
+		if provider == nil {
+			return errors.New("no Secret Provider for the proxy credentials")
+		}
+		secrets, err := provider.GetSecrets(info.SecretPath, ProxyUsernameKey, ProxyPasswordKey)
+		if err != nil {
+			return fmt.Errorf("failed to get the proxy credentials: %v", err)
+		}
+		proxyURL.User = url.UserPassword(secrets[ProxyUsernameKey], secrets[ProxyPasswordKey])
+	}
+
+	noProxy, err := parseNoProxy(info.NoProxy)
+	if err != nil {
+		return err
+	}
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if noProxy.matches(req.URL) {
+			return nil, nil
+		}
+		if proxyURL == nil {
+			return http.ProxyFromEnvironment(req)
+		}
+		return proxyURL, nil
+	}
+	bootstrapContainer.LoggingClientFrom(dic.Get).Info(fmt.Sprintf("core service clients reach the services through proxy %s", proxyDescription(proxyURL)))
+	return nil
+}
+
+func proxyDescription(proxyURL *url.URL) string {
+	if proxyURL == nil {
+		return "from the environment"
+	}
+	u := *proxyURL
+	u.User = nil
+	return u.String()
+}
+
+// noProxyRule excludes a host from the proxy, optionally only on a port.
+type noProxyRule struct {
+	domain  string // matches the host and its subdomains
+	network *net.IPNet
+	port    string
+}
+
+type noProxyRules []noProxyRule
+
+// parseNoProxy parses the hosts reached directly, given as in NO_PROXY: host names, domain
+// names starting with '.', IP addresses and CIDR ranges, optionally followed by a port.
+func parseNoProxy(hosts []string) (noProxyRules, error) {
+	rules := make(noProxyRules, 0, len(hosts))
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" {
+			continue
+		}
+		if host == "*" {
+			rules = append(rules, noProxyRule{domain: "*"})
+			continue
+		}
+		if _, network, err := net.ParseCIDR(host); err == nil {
+			rules = append(rules, noProxyRule{network: network})
+			continue
+		}
+
+		var rule noProxyRule
+		if h, port, err := net.SplitHostPort(host); err == nil {
+			host, rule.port = h, port
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			rule.network = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		} else if strings.Contains(host, "/") || strings.Contains(host, ":") {
+			return nil, fmt.Errorf("invalid Service.Proxy.NoProxy host %s", host)
+		} else {
+			rule.domain = strings.TrimPrefix(host, ".")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// matches reports whether the URL is reached directly.
+func (rules noProxyRules) matches(u *url.URL) bool {
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	ip := net.ParseIP(host)
+	for _, rule := range rules {
+		if rule.port != "" && rule.port != port {
+			continue
+		}
+		switch {
+		case rule.domain == "*":
+			return true
+		case rule.network != nil:
+			if ip != nil && rule.network.Contains(ip) {
+				return true
+			}
+		case host == rule.domain || strings.HasSuffix(host, "."+rule.domain):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package coalesce collapses the bursts of writes to the same deviceResource, e.g. from
+// a dashboard slider, into a single write of the latest value.
+package coalesce
+
+import (
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// WindowAttribute is the deviceResource attribute enabling the coalescing of its writes. It
+// represents as a duration string, e.g. '200ms', the delay of the first write of a burst
+// during which the subsequent writes replace its value.
+const WindowAttribute = common.SDKReservedPrefix + "coalesceWindow"
+
+// WriteFunc invokes the driver to write the values.
+type WriteFunc func(reqs []dsModels.CommandRequest, cvs []*dsModels.CommandValue) error
+
+type burst struct {
+	reqs    []dsModels.CommandRequest
+	cvs     []*dsModels.CommandValue
+	err     error
+	written chan struct{}
+}
+
+var (
+	bursts = make(map[string]*burst) // key is Device name and deviceResource name
+	mutex  sync.Mutex
+)
+
+// Write invokes write unless the request writes a single deviceResource with
+// WindowAttribute. The write of such a deviceResource is delayed by the window, and the
+// requests received meanwhile replace its values and wait for it. It returns the values
+// written by the driver, which are those of the latest request of the burst, and the
+// result of the write shared by all the requests of the burst.
+func Write(deviceName string, reqs []dsModels.CommandRequest, cvs []*dsModels.CommandValue, write WriteFunc) ([]*dsModels.CommandValue, error) {
+	if len(reqs) != 1 {
+		return cvs, write(reqs, cvs)
+	}
+	window, err := time.ParseDuration(reqs[0].Attributes[WindowAttribute])
+	if err != nil || window <= 0 {
+		return cvs, write(reqs, cvs)
+	}
+
+	key := deviceName + "/" + reqs[0].DeviceResourceName
+	mutex.Lock()
+	if b, ok := bursts[key]; ok {
+		b.reqs, b.cvs = reqs, cvs
+		mutex.Unlock()
+		<-b.written
+		return b.cvs, b.err
+	}
+	b := &burst{reqs: reqs, cvs: cvs, written: make(chan struct{})}
+	bursts[key] = b
+	mutex.Unlock()
+
+	<-clock.After(window)
+	mutex.Lock()
+	delete(bursts, key)
+	mutex.Unlock()
+
+	// the burst is no longer modified once removed
+	b.err = write(b.reqs, b.cvs)
+	close(b.written)
+	return b.cvs, b.err
+}
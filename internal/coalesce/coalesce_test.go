@@ -0,0 +1,77 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coalesce
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestWriteWithoutWindow(t *testing.T) {
+	reqs := []dsModels.CommandRequest{{DeviceResourceName: "Slider"}}
+	cvs := []*dsModels.CommandValue{dsModels.NewStringValue("Slider", 0, "1")}
+
+	calls := 0
+	written, err := Write("Device", reqs, cvs, func([]dsModels.CommandRequest, []*dsModels.CommandValue) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, cvs, written)
+}
+
+func TestWriteCoalescesBurst(t *testing.T) {
+	f := clock.NewFake(time.Now())
+	clock.Set(f)
+	defer clock.Set(nil)
+
+	reqs := []dsModels.CommandRequest{{DeviceResourceName: "Slider", Attributes: map[string]string{WindowAttribute: "200ms"}}}
+	var writesMutex sync.Mutex
+	var writes [][]*dsModels.CommandValue
+	write := func(reqs []dsModels.CommandRequest, cvs []*dsModels.CommandValue) error {
+		writesMutex.Lock()
+		defer writesMutex.Unlock()
+		writes = append(writes, cvs)
+		return nil
+	}
+
+	values := []string{"1", "2", "3"}
+	results := make([][]*dsModels.CommandValue, len(values))
+	var wg sync.WaitGroup
+	for i, v := range values {
+		cvs := []*dsModels.CommandValue{dsModels.NewStringValue("Slider", 0, v)}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = Write("Device", reqs, cvs, write)
+		}(i)
+		// wait for the write to join the burst before the next one
+		require.Eventually(t, func() bool {
+			mutex.Lock()
+			defer mutex.Unlock()
+			b, ok := bursts["Device/Slider"]
+			return ok && b.cvs[0] == cvs[0] && f.Waiters() == 1
+		}, time.Second, time.Millisecond)
+	}
+
+	f.Advance(200 * time.Millisecond)
+	wg.Wait()
+
+	require.Len(t, writes, 1)
+	assert.Equal(t, "3", writes[0][0].ValueToString())
+	for _, result := range results {
+		assert.Equal(t, writes[0], result)
+	}
+}
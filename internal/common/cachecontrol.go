@@ -0,0 +1,77 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+const (
+	// CacheMaxAgeAttribute is the deviceResource attribute, a duration string e.g. '30s',
+	// of how long its read values may be reused by the HTTP caches and the SDK.
+	CacheMaxAgeAttribute = SDKReservedPrefix + "cacheMaxAge"
+	// CacheNoStoreAttribute is the deviceResource attribute forbidding the reuse of its
+	// read values when set to true.
+	CacheNoStoreAttribute = SDKReservedPrefix + "cacheNoStore"
+	// CacheControlHeader is the header of the caching policy of the command GET responses.
+	CacheControlHeader = "Cache-Control"
+)
+
+// CacheControl is the caching policy of the values read from deviceResources.
+type CacheControl struct {
+	// MaxAge is negative if no deviceResource sets it.
+	MaxAge  time.Duration
+	NoStore bool
+}
+
+// ResourcesCacheControl returns the caching policy of the values read together from the
+// deviceResources: they aren't reused if any of them has CacheNoStoreAttribute, and they
+// are reused up to the shortest CacheMaxAgeAttribute otherwise. The invalid attributes
+// are ignored.
+func ResourcesCacheControl(resources []contract.DeviceResource) CacheControl {
+	cc := CacheControl{MaxAge: -1}
+	for _, dr := range resources {
+		if noStore, err := strconv.ParseBool(dr.Attributes[CacheNoStoreAttribute]); err == nil && noStore {
+			cc.NoStore = true
+		}
+		maxAge, err := time.ParseDuration(dr.Attributes[CacheMaxAgeAttribute])
+		if err != nil || maxAge < 0 {
+			continue
+		}
+		if cc.MaxAge < 0 || maxAge < cc.MaxAge {
+			cc.MaxAge = maxAge
+		}
+	}
+	return cc
+}
+
+// Header returns the value of the Cache-Control header, or "" if the policy is unset.
+func (c CacheControl) Header() string {
+	if c.NoStore {
+		return "no-store"
+	}
+	if c.MaxAge < 0 {
+		return ""
+	}
+	return fmt.Sprintf("max-age=%d", int64(math.Floor(c.MaxAge.Seconds())))
+}
+
+// Allows returns the longest age of a reused value allowed by the policy, at most limit.
+func (c CacheControl) Allows(limit time.Duration) time.Duration {
+	if c.NoStore {
+		return 0
+	}
+	if c.MaxAge >= 0 && c.MaxAge < limit {
+		return c.MaxAge
+	}
+	return limit
+}
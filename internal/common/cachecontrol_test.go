@@ -0,0 +1,41 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourcesCacheControl(t *testing.T) {
+	slow := contract.DeviceResource{Name: "slow", Attributes: map[string]string{CacheMaxAgeAttribute: "5m"}}
+	fast := contract.DeviceResource{Name: "fast", Attributes: map[string]string{CacheMaxAgeAttribute: "1.5s"}}
+	secret := contract.DeviceResource{Name: "secret", Attributes: map[string]string{CacheNoStoreAttribute: "true"}}
+	invalid := contract.DeviceResource{Name: "invalid", Attributes: map[string]string{CacheMaxAgeAttribute: "soon"}}
+
+	tests := []struct {
+		name           string
+		resources      []contract.DeviceResource
+		expectedHeader string
+		expectedAllows time.Duration
+	}{
+		{"unset", []contract.DeviceResource{invalid}, "", time.Minute},
+		{"max-age", []contract.DeviceResource{slow}, "max-age=300", time.Minute},
+		{"shortest max-age", []contract.DeviceResource{slow, fast}, "max-age=1", 1500 * time.Millisecond},
+		{"no-store", []contract.DeviceResource{slow, secret}, "no-store", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := ResourcesCacheControl(tt.resources)
+			assert.Equal(t, tt.expectedHeader, cc.Header())
+			assert.Equal(t, tt.expectedAllows, cc.Allows(time.Minute))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var driverCapabilities = dsModels.AllCapabilities
+
+// SetDriverCapabilities records the features supported by the ProtocolDriver.
+func SetDriverCapabilities(capabilities dsModels.Capabilities) {
+	driverCapabilities = capabilities
+}
+
+// DriverCapabilities returns the features supported by the ProtocolDriver.
+func DriverCapabilities() dsModels.Capabilities {
+	return driverCapabilities
+}
+
+// ValidateCapabilities returns an error if the configuration enables features the
+// ProtocolDriver doesn't support.
+func ValidateCapabilities(config *ConfigurationStruct) error {
+	var unsupported []string
+	if config.Device.Discovery.Enabled && !driverCapabilities.Discovery {
+		unsupported = append(unsupported, "Device.Discovery.Enabled")
+	}
+	if config.Service.EnableAsyncReadings && !driverCapabilities.AsyncReadings {
+		unsupported = append(unsupported, "Service.EnableAsyncReadings")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("the ProtocolDriver doesn't support the features enabled by %s", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"strconv"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/coredata"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var chunking ChunkingInfo
+
+// SetChunking sets the limits beyond which the events are split into correlated parts.
+func SetChunking(info ChunkingInfo) {
+	chunking = info
+}
+
+// chunkEvent splits the event into parts within the Device.Chunking limits, each with an
+// id of its own and tagged with the correlation shared by the parts, its index and the
+// number of parts. The event is returned as it is if it's within the limits.
+func chunkEvent(event *dsModels.Event, lc logger.LoggingClient, ec coredata.EventClient) []*dsModels.Event {
+	limits := chunking
+	readings := event.Readings
+	if len(readings) <= 1 || (limits.MaxReadings <= 0 && limits.MaxSize <= 0) {
+		return []*dsModels.Event{event}
+	}
+
+	correlation := uuid.New().String()
+	// the tags are sized for the largest index, so that the parts stay within MaxSize
+	// once they're tagged
+	placeholder := strconv.Itoa(len(readings))
+	part := func(readings []contract.Reading, id string, index string, count string) *dsModels.Event {
+		e := event.Event
+		e.ID = id
+		e.Readings = readings
+		e.Tags = make(map[string]string, len(event.Tags)+3)
+		for k, v := range event.Tags {
+			e.Tags[k] = v
+		}
+		e.Tags[dsModels.ChunkCorrelationTag] = correlation
+		e.Tags[dsModels.ChunkIndexTag] = index
+		e.Tags[dsModels.ChunkCountTag] = count
+		return &dsModels.Event{Event: e}
+	}
+	oversized := func(readings []contract.Reading) bool {
+		if limits.MaxReadings > 0 && len(readings) > limits.MaxReadings {
+			return true
+		}
+		if limits.MaxSize <= 0 {
+			return false
+		}
+		encoded, err := ec.MarshalEvent(part(readings, event.ID, placeholder, placeholder).Event)
+		if err != nil {
+			lc.Error("SendEvent: Error encoding event part", "device", event.Device, "error", err)
+			return false
+		}
+		return int64(len(encoded)) > limits.MaxSize*1024
+	}
+	if !oversized(readings) {
+		return []*dsModels.Event{event}
+	}
+
+	var groups [][]contract.Reading
+	var split func(readings []contract.Reading)
+	split = func(readings []contract.Reading) {
+		if len(readings) == 1 || !oversized(readings) {
+			if len(readings) == 1 && limits.MaxSize > 0 && oversized(readings) {
+				lc.Warn("SendEvent: reading exceeds Device.Chunking.MaxSize", "device", event.Device, "resource", readings[0].Name)
+			}
+			groups = append(groups, readings)
+			return
+		}
+		// fill the parts up to MaxReadings, halving them while they exceed MaxSize
+		half := len(readings) / 2
+		if limits.MaxReadings > 0 && len(readings) > limits.MaxReadings {
+			half = limits.MaxReadings
+		}
+		split(readings[:half])
+		split(readings[half:])
+	}
+	split(readings)
+
+	count := strconv.Itoa(len(groups))
+	parts := make([]*dsModels.Event, len(groups))
+	for i, group := range groups {
+		parts[i] = part(group, NewEventId(event.Device), strconv.Itoa(i+1), count)
+	}
+	lc.Debug("SendEvent: split event into parts", "device", event.Device, "parts", count, dsModels.ChunkCorrelationTag, correlation)
+	return parts
+}
@@ -0,0 +1,77 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestChunkEvent(t *testing.T) {
+	defer SetChunking(ChunkingInfo{})
+
+	readings := make([]contract.Reading, 10)
+	for i := range readings {
+		readings[i] = contract.Reading{Name: fmt.Sprintf("Channel%d", i)}
+	}
+
+	tests := []struct {
+		Name          string
+		MaxReadings   int
+		ExpectedSizes []int
+	}{
+		{"unlimited", 0, []int{10}},
+		{"within the limit", 10, []int{10}},
+		{"split evenly", 5, []int{5, 5}},
+		{"split with remainder", 4, []int{4, 4, 2}},
+		{"one reading per part", 1, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			SetChunking(ChunkingInfo{MaxReadings: test.MaxReadings})
+			event := &dsModels.Event{Event: contract.Event{Device: "Simple-Device01", Readings: readings, Tags: map[string]string{"site": "A"}}}
+
+			parts := chunkEvent(event, logger.NewMockClient(), nil)
+
+			require.Len(t, parts, len(test.ExpectedSizes))
+			if len(parts) == 1 {
+				assert.Same(t, event, parts[0])
+				return
+			}
+			correlation := parts[0].Tags[dsModels.ChunkCorrelationTag]
+			assert.NotEmpty(t, correlation)
+			var names []string
+			ids := make(map[string]bool)
+			for i, part := range parts {
+				assert.NotEmpty(t, part.ID)
+				assert.False(t, ids[part.ID], "each part has an id of its own")
+				ids[part.ID] = true
+				assert.Len(t, part.Readings, test.ExpectedSizes[i])
+				assert.Equal(t, "Simple-Device01", part.Device)
+				assert.Equal(t, "A", part.Tags["site"])
+				assert.Equal(t, correlation, part.Tags[dsModels.ChunkCorrelationTag])
+				assert.Equal(t, fmt.Sprint(i+1), part.Tags[dsModels.ChunkIndexTag])
+				assert.Equal(t, fmt.Sprint(len(parts)), part.Tags[dsModels.ChunkCountTag])
+				for _, r := range part.Readings {
+					names = append(names, r.Name)
+				}
+			}
+			assert.Len(t, names, len(readings))
+			assert.Equal(t, readings[0].Name, names[0])
+			assert.Equal(t, readings[9].Name, names[9])
+			assert.NotContains(t, event.Tags, dsModels.ChunkCorrelationTag)
+		})
+	}
+}
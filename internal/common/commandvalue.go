@@ -0,0 +1,254 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2020-2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// CreateCommandValueFromDeviceResource parses the string value according to the value
+// type of the deviceResource and returns the corresponding CommandValue.
+func CreateCommandValueFromDeviceResource(dr *contract.DeviceResource, v string) (*dsModels.CommandValue, error) {
+	var err error
+	var result *dsModels.CommandValue
+
+	origin := time.Now().UnixNano()
+	switch strings.ToLower(dr.Properties.Value.Type) {
+	case strings.ToLower(v2.ValueTypeString):
+		result = dsModels.NewStringValue(dr.Name, origin, v)
+	case strings.ToLower(v2.ValueTypeBool):
+		value, err := strconv.ParseBool(v)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewBoolValue(dr.Name, origin, value)
+	case strings.ToLower(v2.ValueTypeBoolArray):
+		var arr []bool
+		err = json.Unmarshal([]byte(v), &arr)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewBoolArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeUint8):
+		n, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewUint8Value(dr.Name, origin, uint8(n))
+	case strings.ToLower(v2.ValueTypeUint8Array):
+		var arr []uint8
+		strArr := strings.Split(strings.Trim(v, "[]"), ",")
+		for _, u := range strArr {
+			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 8)
+			if err != nil {
+				return result, err
+			}
+			arr = append(arr, uint8(n))
+		}
+		result, err = dsModels.NewUint8ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeUint16):
+		n, err := strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewUint16Value(dr.Name, origin, uint16(n))
+	case strings.ToLower(v2.ValueTypeUint16Array):
+		var arr []uint16
+		strArr := strings.Split(strings.Trim(v, "[]"), ",")
+		for _, u := range strArr {
+			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 16)
+			if err != nil {
+				return result, err
+			}
+			arr = append(arr, uint16(n))
+		}
+		result, err = dsModels.NewUint16ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeUint32):
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewUint32Value(dr.Name, origin, uint32(n))
+	case strings.ToLower(v2.ValueTypeUint32Array):
+		var arr []uint32
+		strArr := strings.Split(strings.Trim(v, "[]"), ",")
+		for _, u := range strArr {
+			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 32)
+			if err != nil {
+				return result, err
+			}
+			arr = append(arr, uint32(n))
+		}
+		result, err = dsModels.NewUint32ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeUint64):
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewUint64Value(dr.Name, origin, n)
+	case strings.ToLower(v2.ValueTypeUint64Array):
+		var arr []uint64
+		strArr := strings.Split(strings.Trim(v, "[]"), ",")
+		for _, u := range strArr {
+			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 64)
+			if err != nil {
+				return result, err
+			}
+			arr = append(arr, n)
+		}
+		result, err = dsModels.NewUint64ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeInt8):
+		n, err := strconv.ParseInt(v, 10, 8)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt8Value(dr.Name, origin, int8(n))
+	case strings.ToLower(v2.ValueTypeInt8Array):
+		var arr []int8
+		err = json.Unmarshal([]byte(v), &arr)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt8ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeInt16):
+		n, err := strconv.ParseInt(v, 10, 16)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt16Value(dr.Name, origin, int16(n))
+	case strings.ToLower(v2.ValueTypeInt16Array):
+		var arr []int16
+		err = json.Unmarshal([]byte(v), &arr)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt16ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeInt32):
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt32Value(dr.Name, origin, int32(n))
+	case strings.ToLower(v2.ValueTypeInt32Array):
+		var arr []int32
+		err = json.Unmarshal([]byte(v), &arr)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt32ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeInt64):
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt64Value(dr.Name, origin, n)
+	case strings.ToLower(v2.ValueTypeInt64Array):
+		var arr []int64
+		err = json.Unmarshal([]byte(v), &arr)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewInt64ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeFloat32):
+		n, e := strconv.ParseFloat(v, 32)
+		if e == nil {
+			result, err = dsModels.NewFloat32Value(dr.Name, origin, float32(n))
+			break
+		}
+		if numError, ok := e.(*strconv.NumError); ok {
+			if numError.Err == strconv.ErrRange {
+				err = e
+				break
+			}
+		}
+		var decodedToBytes []byte
+		decodedToBytes, err = base64.StdEncoding.DecodeString(v)
+		if err == nil {
+			var val float32
+			val, err = float32FromBytes(decodedToBytes)
+			if err != nil {
+				break
+			} else if math.IsNaN(float64(val)) {
+				err = fmt.Errorf("fail to parse %v to float32, unexpected result %v", v, val)
+			} else {
+				result, err = dsModels.NewFloat32Value(dr.Name, origin, val)
+			}
+		}
+	case strings.ToLower(v2.ValueTypeFloat32Array):
+		var arr []float32
+		err = json.Unmarshal([]byte(v), &arr)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewFloat32ArrayValue(dr.Name, origin, arr)
+	case strings.ToLower(v2.ValueTypeFloat64):
+		var val float64
+		val, err = strconv.ParseFloat(v, 64)
+		if err == nil {
+			result, err = dsModels.NewFloat64Value(dr.Name, origin, val)
+			break
+		}
+		if numError, ok := err.(*strconv.NumError); ok {
+			if numError.Err == strconv.ErrRange {
+				break
+			}
+		}
+		var decodedToBytes []byte
+		decodedToBytes, err = base64.StdEncoding.DecodeString(v)
+		if err == nil {
+			val, err = float64FromBytes(decodedToBytes)
+			if err != nil {
+				break
+			} else if math.IsNaN(val) {
+				err = fmt.Errorf("fail to parse %v to float64, unexpected result %v", v, val)
+			} else {
+				result, err = dsModels.NewFloat64Value(dr.Name, origin, val)
+			}
+		}
+	case strings.ToLower(v2.ValueTypeFloat64Array):
+		var arr []float64
+		err = json.Unmarshal([]byte(v), &arr)
+		if err != nil {
+			return result, err
+		}
+		result, err = dsModels.NewFloat64ArrayValue(dr.Name, origin, arr)
+	default:
+		err = errors.New("unsupported deviceResource value type")
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	return result, err
+}
+
+func float32FromBytes(numericValue []byte) (res float32, err error) {
+	reader := bytes.NewReader(numericValue)
+	err = binary.Read(reader, binary.BigEndian, &res)
+	return
+}
+
+func float64FromBytes(numericValue []byte) (res float64, err error) {
+	reader := bytes.NewReader(numericValue)
+	err = binary.Read(reader, binary.BigEndian, &res)
+	return
+}
@@ -7,9 +7,32 @@
 package common
 
 import (
+	"sync"
+
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
 )
 
+var (
+	writableFrozen      func() bool
+	writableFrozenMutex sync.RWMutex
+)
+
+// SetWritableFrozen registers the function telling whether the Writable section received
+// from the Configuration Provider is ignored, the last-known one being kept, e.g. while
+// the registry is unavailable.
+func SetWritableFrozen(frozen func() bool) {
+	writableFrozenMutex.Lock()
+	defer writableFrozenMutex.Unlock()
+	writableFrozen = frozen
+}
+
+func isWritableFrozen() bool {
+	writableFrozenMutex.RLock()
+	frozen := writableFrozen
+	writableFrozenMutex.RUnlock()
+	return frozen != nil && frozen()
+}
+
 // ConfigurationStruct contains the configuration properties for the device service.
 type ConfigurationStruct struct {
 	// WritableInfo contains configuration settings that can be changed in the Registry .
@@ -28,6 +51,8 @@ type ConfigurationStruct struct {
 	Driver map[string]string
 	// SecretStore contains information for connecting to the secure SecretStore (Vault) to retrieve or store secrets
 	SecretStore bootstrapConfig.SecretStoreInfo
+	// MessageQueue contains information for publishing the events onto the MessageBus instead of posting them to Core Data
+	MessageQueue MessageQueueInfo
 }
 
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
@@ -51,11 +76,20 @@ func (c *ConfigurationStruct) EmptyWritablePtr() interface{} {
 }
 
 // UpdateWritableFromRaw converts configuration received from the registry to a service-specific WritableInfo struct
-// which is then used to overwrite the service's existing configuration's WritableInfo struct.
+// which is then used to overwrite the service's existing configuration's WritableInfo struct. The
+// existing one is kept while the Writable section is frozen, still returning true as false would
+// stop the bootstrap from listening for the changes.
 func (c *ConfigurationStruct) UpdateWritableFromRaw(rawWritable interface{}) bool {
 	writable, ok := rawWritable.(*WritableInfo)
+	if ok && isWritableFrozen() {
+		return true
+	}
 	if ok {
+		changed := writableDriverChanged(c.Writable.Driver, writable.Driver)
 		c.Writable = *writable
+		if changed {
+			notifyWritableDriver(writable.Driver)
+		}
 	}
 	return ok
 }
@@ -16,6 +16,7 @@ import (
 const (
 	ClientData     = "Data"
 	ClientMetadata = "Metadata"
+	ClientCommand  = "Command"
 
 	EnvInstanceName = "EDGEX_INSTANCE_NAME"
 
@@ -38,7 +39,57 @@ const (
 	APIDiscoveryRoute       = clients.ApiBase + "/discovery"
 	APITransformRoute       = clients.ApiBase + "/debug/transformData/{transformData}"
 
-	APIV2SecretRoute = v2.ApiBase + "/secret"
+	APIV2SecretRoute       = v2.ApiBase + "/secret"
+	APIV2ReadyRoute        = v2.ApiBase + "/ready"
+	APIV2LiveRoute         = v2.ApiBase + "/live"
+	APIV2StatusRoute       = v2.ApiBase + "/status"
+	APIV2CapabilitiesRoute = v2.ApiBase + "/capabilities"
+	APIV2MaintenanceRoute  = v2.ApiBase + "/maintenance"
+	APIV2ChaosRoute        = v2.ApiBase + "/chaos"
+
+	APIV2InflightRoute     = v2.ApiBase + "/debug/inflight"
+	APIV2InflightByIdRoute = APIV2InflightRoute + "/" + v2.Id + "/{" + v2.Id + "}"
+
+	APIV2DiscoveryStopRoute   = v2.ApiDiscoveryRoute + "/stop"
+	APIV2DiscoveryStatusRoute = v2.ApiDiscoveryRoute + "/status"
+
+	APIV2DeviceTemplateRoute       = v2.ApiBase + "/devicetemplate"
+	APIV2AllDeviceTemplateRoute    = APIV2DeviceTemplateRoute + "/" + v2.All
+	APIV2DeviceTemplateByNameRoute = APIV2DeviceTemplateRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+	APIV2DeviceFromTemplateRoute   = APIV2DeviceTemplateByNameRoute + "/" + v2.Device
+
+	APIV2DeviceExportRoute = v2.ApiBase + "/device/export"
+	APIV2DeviceResyncRoute = v2.ApiDeviceByNameRoute + "/resync"
+
+	APIV2DeviceDecommissionRoute = v2.ApiDeviceByNameRoute + "/decommission"
+
+	// DeviceCommandsName is the last segment of the route listing the commands of a Device.
+	DeviceCommandsName       = "commands"
+	APIV2DeviceCommandsRoute = v2.ApiDeviceByNameRoute + "/" + DeviceCommandsName
+
+	APIV2BatchCommandRoute = v2.ApiAllDeviceRoute + "/{" + CommandVar + "}"
+
+	APIV2DeviceUpdateRoute       = v2.ApiBase + "/device/update"
+	APIV2DeviceUpdateByIdRoute   = APIV2DeviceUpdateRoute + "/" + v2.Id + "/{" + v2.Id + "}"
+	APIV2DeviceUpdateByNameRoute = APIV2DeviceUpdateRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+
+	APIV2CalibrationRoute           = v2.ApiBase + "/calibration"
+	APIV2CalibrationByNameRoute     = APIV2CalibrationRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+	APIV2CalibrationByResourceRoute = APIV2CalibrationByNameRoute + "/" + v2.ResourceName + "/{" + v2.ResourceName + "}"
+
+	APIV2ProfileStagingRoute      = v2.ApiBase + "/profile/staging"
+	APIV2StagedProfileByNameRoute = APIV2ProfileStagingRoute + "/" + v2.Name + "/{" + v2.Name + "}"
+	APIV2ApplyStagedProfileRoute  = APIV2StagedProfileByNameRoute + "/apply"
+	APIV2RollbackProfileRoute     = APIV2StagedProfileByNameRoute + "/rollback"
+
+	APIV2EventExportRoute     = v2.ApiBase + "/event/export"
+	APIV2EventExportByIdRoute = APIV2EventExportRoute + "/" + v2.Id + "/{" + v2.Id + "}"
+
+	APIV2TransactionRoute = v2.ApiBase + "/transaction"
+
+	APIV2CommandHistoryRoute = v2.ApiBase + "/command/history"
+
+	APIPrometheusMetricsRoute = "/metrics"
 
 	IdVar        string = "id"
 	NameVar      string = "name"
@@ -50,6 +101,8 @@ const (
 	DeviceResourceWriteOnly string = "W"
 
 	CorrelationHeader = clients.CorrelationHeader
+	RetryAfterHeader  = "Retry-After"
+	AcceptHeader      = "Accept"
 	URLRawQuery       = "urlRawQuery"
 	SDKReservedPrefix = "ds-"
 )
@@ -59,3 +112,9 @@ var SDKVersion string = "0.0.0"
 
 // ServiceVersion indicates the version of the device service itself, not the SDK - will be overwritten by build
 var ServiceVersion string = "0.0.0"
+
+// The backends of the Store of the store-and-forward queue.
+const (
+	StoreBackendFile   = "file"
+	StoreBackendMemory = "memory"
+)
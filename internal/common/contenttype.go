@@ -0,0 +1,32 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+)
+
+// PreferCBOR reports whether the event returned to a command is encoded in CBOR given
+// the Accept header of the request: it is if CBOR is accepted explicitly, or if the
+// event has Binary readings and JSON isn't accepted explicitly.
+func PreferCBOR(accept string, binary bool) bool {
+	json := false
+	for _, mediaRange := range strings.Split(accept, ",") {
+		if i := strings.Index(mediaRange, ";"); i >= 0 {
+			mediaRange = mediaRange[:i]
+		}
+		switch strings.ToLower(strings.TrimSpace(mediaRange)) {
+		case clients.ContentTypeCBOR:
+			return true
+		case clients.ContentTypeJSON:
+			json = true
+		}
+	}
+	return binary && !json
+}
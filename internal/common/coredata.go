@@ -0,0 +1,30 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import "sync"
+
+var (
+	coreDataReachable = true
+	coreDataMutex     sync.RWMutex
+)
+
+// SetCoreDataReachable records whether Core Data is known to be reachable. While it isn't,
+// i.e. when Service.CoreDataOptional let the Device Service start without it, SendEvent
+// queues the events in the store-and-forward queue, if enabled, instead of posting them.
+func SetCoreDataReachable(reachable bool) {
+	coreDataMutex.Lock()
+	defer coreDataMutex.Unlock()
+	coreDataReachable = reachable
+}
+
+// CoreDataReachable reports whether Core Data is known to be reachable.
+func CoreDataReachable() bool {
+	coreDataMutex.RLock()
+	defer coreDataMutex.RUnlock()
+	return coreDataReachable
+}
@@ -0,0 +1,49 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/coredata"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+type postCountingClient struct {
+	coredata.EventClient
+	posted int
+}
+
+func (c *postCountingClient) AddBytes(_ context.Context, _ []byte) (string, error) {
+	c.posted++
+	return "", nil
+}
+
+func TestSendEventDeferredUntilCoreDataReachable(t *testing.T) {
+	defer SetCoreDataReachable(true)
+	ec := &postCountingClient{}
+	lc := logger.NewMockClient()
+	newEvent := func() *dsModels.Event {
+		return &dsModels.Event{
+			Event:        contract.Event{Device: "Simple-Device01", Readings: []contract.Reading{{Name: "SwitchButton", Value: "true"}}},
+			EncodedEvent: []byte(`{"device":"Simple-Device01"}`),
+		}
+	}
+
+	SetCoreDataReachable(false)
+	SendEvent(newEvent(), lc, ec)
+	assert.Equal(t, 0, ec.posted, "event posted while core data isn't reachable")
+
+	SetCoreDataReachable(true)
+	SendEvent(newEvent(), lc, ec)
+	assert.Equal(t, 1, ec.posted)
+}
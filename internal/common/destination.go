@@ -0,0 +1,87 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DestinationProtocol is the protocol of the Device overriding the destination of its
+// events, so that the Devices of a Device Service can feed different pipelines:
+//
+//	[DeviceList.Protocols.ds-destination]
+//	  Target = "messagebus"
+//	  TopicPrefix = "edgex/events/line2"
+const DestinationProtocol = SDKReservedPrefix + "destination"
+
+const (
+	// DestinationTarget is the property of DestinationProtocol selecting where the events
+	// go, one of the Destination constants.
+	DestinationTarget = "Target"
+	// DestinationTopicPrefix is the property of DestinationProtocol replacing the
+	// MessageQueue.PublishTopicPrefix of the events of the Device.
+	DestinationTopicPrefix = "TopicPrefix"
+
+	// DestinationCoreData posts the events to Core Data even if a MessageBus is configured.
+	DestinationCoreData = "coredata"
+	// DestinationMessageBus publishes the events onto the MessageBus.
+	DestinationMessageBus = "messagebus"
+	// DestinationNone suppresses the events.
+	DestinationNone = "none"
+)
+
+// Destination is the destination of the events of a Device. The zero value is the
+// destination configured for the Device Service.
+type Destination struct {
+	Target      string
+	TopicPrefix string
+}
+
+var (
+	destinations      func(deviceName string) map[string]string
+	destinationsMutex sync.RWMutex
+)
+
+// SetDeviceDestinations sets the function returning the DestinationProtocol properties of
+// a Device, if any.
+func SetDeviceDestinations(properties func(deviceName string) map[string]string) {
+	destinationsMutex.Lock()
+	defer destinationsMutex.Unlock()
+	destinations = properties
+}
+
+// ParseDestination parses the DestinationProtocol properties of a Device.
+func ParseDestination(properties map[string]string) (Destination, error) {
+	d := Destination{
+		Target:      strings.ToLower(strings.TrimSpace(properties[DestinationTarget])),
+		TopicPrefix: strings.TrimSuffix(strings.TrimSpace(properties[DestinationTopicPrefix]), "/"),
+	}
+	switch d.Target {
+	case "", DestinationCoreData, DestinationMessageBus, DestinationNone:
+	default:
+		return Destination{}, fmt.Errorf("invalid %s %s, expecting %s, %s or %s",
+			DestinationTarget, properties[DestinationTarget], DestinationCoreData, DestinationMessageBus, DestinationNone)
+	}
+	return d, nil
+}
+
+// DeviceDestination returns the destination of the events of the Device.
+func DeviceDestination(deviceName string) (Destination, error) {
+	destinationsMutex.RLock()
+	properties := destinations
+	destinationsMutex.RUnlock()
+	if properties == nil {
+		return Destination{}, nil
+	}
+	p := properties(deviceName)
+	if len(p) == 0 {
+		return Destination{}, nil
+	}
+	return ParseDestination(p)
+}
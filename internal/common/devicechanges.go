@@ -0,0 +1,33 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// UpdateDriverDevice notifies the driver of the update of the Device from its previous
+// definition, with UpdateDeviceWithChanges if the driver is a DeviceChangeHandler and
+// with UpdateDevice otherwise, then with UpdateDeviceOperatingState if the driver is an
+// OperatingStateHandler and the OperatingState changed.
+func UpdateDriverDevice(driver dsModels.ProtocolDriver, previous contract.Device, device contract.Device) error {
+	var err error
+	if handler, ok := driver.(dsModels.DeviceChangeHandler); ok {
+		err = handler.UpdateDeviceWithChanges(previous, device, dsModels.CompareDevices(previous, device))
+	} else {
+		err = driver.UpdateDevice(device.Name, DriverProtocols(device.Protocols), device.AdminState)
+	}
+	if err != nil || previous.OperatingState == device.OperatingState {
+		return err
+	}
+	if handler, ok := driver.(dsModels.OperatingStateHandler); ok {
+		return handler.UpdateDeviceOperatingState(device.Name, device.OperatingState)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// updateDriver records the updates of the Devices, the other methods being unused.
+type updateDriver struct {
+	dsModels.ProtocolDriver
+	protocols map[string]contract.ProtocolProperties
+	updated   []string
+	err       error
+}
+
+func (d *updateDriver) UpdateDevice(deviceName string, protocols map[string]contract.ProtocolProperties, _ contract.AdminState) error {
+	d.updated = append(d.updated, deviceName)
+	d.protocols = protocols
+	return d.err
+}
+
+type changeHandlerDriver struct {
+	updateDriver
+	changes []dsModels.DeviceChanges
+	states  []contract.OperatingState
+}
+
+func (d *changeHandlerDriver) UpdateDeviceWithChanges(_ contract.Device, device contract.Device, changes dsModels.DeviceChanges) error {
+	d.updated = append(d.updated, device.Name)
+	d.changes = append(d.changes, changes)
+	return d.err
+}
+
+func (d *changeHandlerDriver) UpdateDeviceOperatingState(_ string, state contract.OperatingState) error {
+	d.states = append(d.states, state)
+	return nil
+}
+
+func TestUpdateDriverDevice(t *testing.T) {
+	previous := contract.Device{
+		Name:           "device",
+		OperatingState: contract.Enabled,
+		Protocols: map[string]contract.ProtocolProperties{
+			"modbus-tcp": {"Address": "localhost"},
+			"ds-delta":   {"Threshold": "1"},
+		},
+	}
+	renamed := previous
+	renamed.Name = "renamed"
+
+	driver := &updateDriver{}
+	require.NoError(t, UpdateDriverDevice(driver, previous, renamed))
+	assert.Equal(t, []string{"renamed"}, driver.updated)
+	assert.Equal(t, map[string]contract.ProtocolProperties{"modbus-tcp": {"Address": "localhost"}}, driver.protocols,
+		"the protocols reserved for the SDK aren't passed to the driver")
+
+	handler := &changeHandlerDriver{}
+	require.NoError(t, UpdateDriverDevice(handler, previous, renamed))
+	assert.Equal(t, []string{"renamed"}, handler.updated, "UpdateDeviceWithChanges replaces UpdateDevice")
+	assert.Equal(t, []dsModels.DeviceChanges{{}}, handler.changes, "a rename isn't a change of the definition")
+	assert.Empty(t, handler.states)
+
+	disabled := renamed
+	disabled.OperatingState = contract.Disabled
+	require.NoError(t, UpdateDriverDevice(handler, previous, disabled))
+	assert.Equal(t, []contract.OperatingState{contract.Disabled}, handler.states)
+
+	handler.err = errors.New("update failed")
+	assert.Error(t, UpdateDriverDevice(handler, previous, disabled))
+	assert.Len(t, handler.states, 1, "the OperatingState isn't updated once the update failed")
+}
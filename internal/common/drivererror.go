@@ -0,0 +1,91 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// defaultCommandRetryAfter is the delay suggested to the clients of the rejected commands
+// when Writable.CommandRetryAfter is not configured.
+const defaultCommandRetryAfter = time.Second
+
+// ErrDeviceQueueFull is the error of a command rejected because Writable.MaxDeviceQueuedCommands
+// are already waiting for the device.
+var ErrDeviceQueueFull = errors.New("too many commands queued for the device")
+
+// DriverErrorStatus returns the HTTP status of a command failed with the error returned by
+// the ProtocolDriver. Errors without a DriverErrorKind are reported as internal server errors,
+// except the commands rejected with ErrDeviceQueueFull which are reported as too many requests.
+func DriverErrorStatus(err error) int {
+	if errors.Is(err, ErrDeviceQueueFull) {
+		return http.StatusTooManyRequests
+	}
+	switch dsModels.DriverErrorKindOf(err) {
+	case dsModels.NotReachable:
+		return http.StatusBadGateway
+	case dsModels.Timeout:
+		return http.StatusGatewayTimeout
+	case dsModels.Unauthorized:
+		return http.StatusForbidden
+	case dsModels.BadRequest:
+		return http.StatusBadRequest
+	case dsModels.Busy:
+		return http.StatusServiceUnavailable
+	case dsModels.Unsupported:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Throttled reports whether a command failed with the error as the device was busy or its
+// queue was full, in which case the client is told when to retry. The other unavailable
+// services, e.g. in maintenance, aren't worth retrying that soon.
+func Throttled(err error) bool {
+	return errors.Is(err, ErrDeviceQueueFull) || dsModels.DriverErrorKindOf(err) == dsModels.Busy
+}
+
+// RetryAfter returns the value of the Retry-After header of a throttled command, i.e.
+// Writable.CommandRetryAfter in whole seconds.
+func RetryAfter(retryAfter string) string {
+	d, err := time.ParseDuration(retryAfter)
+	if err != nil || d <= 0 {
+		d = defaultCommandRetryAfter
+	}
+	return strconv.Itoa(int((d + time.Second - 1) / time.Second))
+}
+
+func NewDriverError(msg string, err error) AppError {
+	return appError{err: err, msg: msg, code: DriverErrorStatus(err)}
+}
+
+// driverEdgeX is an EdgeX error whose code is derived from the DriverErrorKind.
+type driverEdgeX struct {
+	edgexErr.CommonEdgeX
+	code int
+}
+
+func (e driverEdgeX) Code() int {
+	return e.code
+}
+
+// NewDriverEdgeX returns the EdgeX error of a command failed with the error returned by
+// the ProtocolDriver.
+func NewDriverEdgeX(msg string, err error) edgexErr.EdgeX {
+	return driverEdgeX{
+		CommonEdgeX: edgexErr.NewCommonEdgeX(edgexErr.KindServerError, msg, err),
+		code:        DriverErrorStatus(err),
+	}
+}
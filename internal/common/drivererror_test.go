@@ -0,0 +1,41 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/stretchr/testify/assert"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestThrottled(t *testing.T) {
+	busy := dsModels.NewDriverError(dsModels.Busy, errors.New("device busy"))
+	tests := []struct {
+		name      string
+		err       error
+		throttled bool
+	}{
+		{"busy", busy, true},
+		{"queue full", fmt.Errorf("read failed: %w", ErrDeviceQueueFull), true},
+		{"busy EdgeX", edgexErr.NewCommonEdgeXWrapper(NewDriverEdgeX("read failed", busy)), true},
+		{"maintenance", edgexErr.NewCommonEdgeX(edgexErr.KindServiceUnavailable, "in maintenance", nil), false},
+		{"not reachable", dsModels.NewDriverError(dsModels.NotReachable, errors.New("no route")), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.throttled, Throttled(tt.err))
+		})
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, DriverErrorStatus(busy), "busy devices are still unavailable")
+}
@@ -0,0 +1,56 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// defaultEventIdGenerator generates UUIDs and unique nanosecond timestamps.
+type defaultEventIdGenerator struct{}
+
+func (defaultEventIdGenerator) EventId(_ string) string {
+	return uuid.New().String()
+}
+
+func (defaultEventIdGenerator) Origin(_ string) int64 {
+	return GetUniqueOrigin()
+}
+
+var (
+	eventIdGenerator dsModels.EventIdGenerator = defaultEventIdGenerator{}
+	generatorMutex   sync.RWMutex
+)
+
+// SetEventIdGenerator replaces the generator of the event ids and origins, nil restores
+// the default one.
+func SetEventIdGenerator(generator dsModels.EventIdGenerator) {
+	generatorMutex.Lock()
+	defer generatorMutex.Unlock()
+	if generator == nil {
+		generator = defaultEventIdGenerator{}
+	}
+	eventIdGenerator = generator
+}
+
+// NewEventId returns the id of a new event of the Device.
+func NewEventId(deviceName string) string {
+	generatorMutex.RLock()
+	defer generatorMutex.RUnlock()
+	return eventIdGenerator.EventId(deviceName)
+}
+
+// NewEventOrigin returns the origin of a new event of the Device.
+func NewEventOrigin(deviceName string) int64 {
+	generatorMutex.RLock()
+	defer generatorMutex.RUnlock()
+	return eventIdGenerator.Origin(deviceName)
+}
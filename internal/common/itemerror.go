@@ -0,0 +1,45 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"net/http"
+
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// ItemError reports the failure of an item of a batch operation, so that the callers
+// can retry the failed items only.
+type ItemError struct {
+	// Item identifies the failed item, e.g. the Device name.
+	Item    string `json:"item"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewItemError returns the ItemError of the item failed with the EdgeX error.
+func NewItemError(item string, err edgexErr.EdgeX) ItemError {
+	return ItemError{Item: item, Code: err.Code(), Message: err.Error()}
+}
+
+// BatchStatusCode returns the status code of a batch operation: successCode if no item
+// failed, the code of the failed items if none succeeded and they share it, and
+// 207 Multi-Status otherwise.
+func BatchStatusCode(succeeded int, errs []ItemError, successCode int) int {
+	if len(errs) == 0 {
+		return successCode
+	}
+	if succeeded > 0 {
+		return http.StatusMultiStatus
+	}
+	for _, e := range errs[1:] {
+		if e.Code != errs[0].Code {
+			return http.StatusMultiStatus
+		}
+	}
+	return errs[0].Code
+}
@@ -0,0 +1,111 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"strconv"
+	"strings"
+
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var numericEncoding NumericEncodingInfo
+
+// SetNumericEncoding applies the numeric encoding settings to the readings created afterwards.
+func SetNumericEncoding(info NumericEncodingInfo) {
+	numericEncoding = info
+}
+
+// ReadingFloatEncoding returns the float encoding of the readings of a deviceResource,
+// falling back on the configured default when the deviceResource doesn't specify one.
+func ReadingFloatEncoding(encoding string) string {
+	if encoding != "" {
+		return encoding
+	}
+	if numericEncoding.FloatEncoding != "" {
+		return numericEncoding.FloatEncoding
+	}
+	return dsModels.DefaultFloatEncoding
+}
+
+// ReadingValue returns the string value of a non-binary reading. Int64 and Uint64 values
+// are always decimal strings; the elements of Int64Array and Uint64Array values are quoted
+// when Int64ArrayAsStrings is enabled, and floats encoded in DecimalNotation are rounded
+// to FloatSignificantDigits.
+func ReadingValue(cv *dsModels.CommandValue, encoding string) string {
+	switch cv.Type {
+	case v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+		if encoding == dsModels.DecimalNotation && numericEncoding.FloatSignificantDigits > 0 {
+			if v, bitSize, ok := floatValue(cv); ok {
+				return formatDecimal(v, bitSize)
+			}
+		}
+	case v2.ValueTypeFloat32Array:
+		if encoding == dsModels.DecimalNotation {
+			if values, err := cv.Float32ArrayValue(); err == nil {
+				elements := make([]string, len(values))
+				for i, v := range values {
+					elements[i] = formatDecimal(float64(v), 32)
+				}
+				return "[" + strings.Join(elements, ",") + "]"
+			}
+		}
+	case v2.ValueTypeFloat64Array:
+		if encoding == dsModels.DecimalNotation {
+			if values, err := cv.Float64ArrayValue(); err == nil {
+				elements := make([]string, len(values))
+				for i, v := range values {
+					elements[i] = formatDecimal(v, 64)
+				}
+				return "[" + strings.Join(elements, ",") + "]"
+			}
+		}
+	case v2.ValueTypeInt64Array:
+		if numericEncoding.Int64ArrayAsStrings {
+			if values, err := cv.Int64ArrayValue(); err == nil {
+				elements := make([]string, len(values))
+				for i, v := range values {
+					elements[i] = strconv.Quote(strconv.FormatInt(v, 10))
+				}
+				return "[" + strings.Join(elements, ",") + "]"
+			}
+		}
+	case v2.ValueTypeUint64Array:
+		if numericEncoding.Int64ArrayAsStrings {
+			if values, err := cv.Uint64ArrayValue(); err == nil {
+				elements := make([]string, len(values))
+				for i, v := range values {
+					elements[i] = strconv.Quote(strconv.FormatUint(v, 10))
+				}
+				return "[" + strings.Join(elements, ",") + "]"
+			}
+		}
+	}
+	return cv.ValueToString(encoding)
+}
+
+func floatValue(cv *dsModels.CommandValue) (float64, int, bool) {
+	if cv.Type == v2.ValueTypeFloat32 {
+		v, err := cv.Float32Value()
+		return float64(v), 32, err == nil
+	}
+	v, err := cv.Float64Value()
+	return v, 64, err == nil
+}
+
+// formatDecimal formats the float without exponent, rounded to FloatSignificantDigits
+// if configured.
+func formatDecimal(v float64, bitSize int) string {
+	if digits := numericEncoding.FloatSignificantDigits; digits > 0 {
+		if rounded, err := strconv.ParseFloat(strconv.FormatFloat(v, 'g', digits, bitSize), bitSize); err == nil {
+			v = rounded
+		}
+	}
+	return strconv.FormatFloat(v, 'f', -1, bitSize)
+}
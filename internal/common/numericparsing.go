@@ -0,0 +1,87 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+const (
+	// ScientificNotationAllow accepts the floats in scientific notation, e.g. '1.5e3'.
+	ScientificNotationAllow = "allow"
+	// ScientificNotationReject rejects the numbers in scientific notation.
+	ScientificNotationReject = "reject"
+)
+
+var numericParsing NumericParsingInfo
+
+// SetNumericParsing applies the numeric parsing settings to the numeric strings parsed
+// afterwards.
+func SetNumericParsing(info NumericParsingInfo) {
+	numericParsing = info
+}
+
+// CheckNumber returns an error explaining why the string isn't a plain number of the
+// numeric value type, independent from any locale: an optional sign, decimal digits, '.' as
+// the decimal separator of the floats, no thousands separator and, per the ScientificNotation
+// setting, an exponent for the floats. The hexadecimal, infinite and NaN floats are rejected.
+// The floats encoded in Base64 and the strings of the other value types aren't checked.
+func CheckNumber(s string, valueType string) error {
+	var float bool
+	switch strings.ToLower(valueType) {
+	case strings.ToLower(v2.ValueTypeFloat32), strings.ToLower(v2.ValueTypeFloat64):
+		float = true
+	case strings.ToLower(v2.ValueTypeUint8), strings.ToLower(v2.ValueTypeUint16),
+		strings.ToLower(v2.ValueTypeUint32), strings.ToLower(v2.ValueTypeUint64),
+		strings.ToLower(v2.ValueTypeInt8), strings.ToLower(v2.ValueTypeInt16),
+		strings.ToLower(v2.ValueTypeInt32), strings.ToLower(v2.ValueTypeInt64):
+	default:
+		return nil
+	}
+	if float {
+		if b, err := base64.StdEncoding.DecodeString(s); err == nil && (len(b) == 4 || len(b) == 8) {
+			return nil
+		}
+	}
+	digits, point, exponent := 0, false, false
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '+' || c == '-':
+			if i != 0 && !(exponent && (s[i-1] == 'e' || s[i-1] == 'E')) {
+				return fmt.Errorf("invalid %s value %s, misplaced sign", valueType, s)
+			}
+		case c == '.' && !float:
+			return fmt.Errorf("invalid %s value %s, expecting an integer without decimal or thousands separator", valueType, s)
+		case c == '.' && (point || exponent):
+			return fmt.Errorf("invalid %s value %s, thousands separators aren't allowed", valueType, s)
+		case c == '.':
+			point = true
+		case c == ',':
+			return fmt.Errorf("invalid %s value %s, ',' is allowed neither as decimal separator, which should be '.', nor as thousands separator", valueType, s)
+		case c == '\'' || c == '_' || c == ' ' || c == '\u00a0' || c == '\u2009' || c == '\u202f':
+			return fmt.Errorf("invalid %s value %s, thousands separators aren't allowed", valueType, s)
+		case (c == 'e' || c == 'E') && digits > 0 && !exponent:
+			if !float || strings.EqualFold(numericParsing.ScientificNotation, ScientificNotationReject) {
+				return fmt.Errorf("invalid %s value %s, scientific notation isn't allowed", valueType, s)
+			}
+			exponent = true
+			digits = 0
+		default:
+			return fmt.Errorf("invalid %s value %s, expecting a decimal number", valueType, s)
+		}
+	}
+	if digits == 0 {
+		return fmt.Errorf("invalid %s value %s, expecting a decimal number", valueType, s)
+	}
+	return nil
+}
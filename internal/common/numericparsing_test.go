@@ -0,0 +1,52 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNumber(t *testing.T) {
+	defer SetNumericParsing(NumericParsingInfo{})
+
+	tests := []struct {
+		value     string
+		valueType string
+		valid     bool
+	}{
+		{"-12", v2.ValueTypeInt16, true},
+		{"+12", v2.ValueTypeUint8, true},
+		{"1.5", v2.ValueTypeFloat32, true},
+		{".5", v2.ValueTypeFloat64, true},
+		{"-1.5e-3", v2.ValueTypeFloat64, true},
+		{"QUAAAA==", v2.ValueTypeFloat32, true},
+		{"1,5", v2.ValueTypeFloat32, false},
+		{"1,000", v2.ValueTypeInt32, false},
+		{"1.000", v2.ValueTypeInt32, false},
+		{"1.000.000", v2.ValueTypeFloat64, false},
+		{"1 000", v2.ValueTypeInt32, false},
+		{"1'000", v2.ValueTypeInt32, false},
+		{"1e3", v2.ValueTypeInt32, false},
+		{"0x1p3", v2.ValueTypeFloat64, false},
+		{"NaN", v2.ValueTypeFloat64, false},
+		{"Inf", v2.ValueTypeFloat64, false},
+		{"1-", v2.ValueTypeInt8, false},
+		{"", v2.ValueTypeInt8, false},
+		{"1,5", v2.ValueTypeString, true},
+	}
+	for _, tt := range tests {
+		err := CheckNumber(tt.value, tt.valueType)
+		assert.Equal(t, tt.valid, err == nil, "%s %s: %v", tt.valueType, tt.value, err)
+	}
+
+	SetNumericParsing(NumericParsingInfo{ScientificNotation: ScientificNotationReject})
+	assert.Error(t, CheckNumber("1e3", v2.ValueTypeFloat64))
+	assert.NoError(t, CheckNumber("1000", v2.ValueTypeFloat64))
+}
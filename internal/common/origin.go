@@ -0,0 +1,117 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The policies of the assignment of the reading origins.
+const (
+	// OriginPolicyDriver keeps the origins set by the ProtocolDriver.
+	OriginPolicyDriver = "driver"
+	// OriginPolicyGateway always uses the time of the Device Service.
+	OriginPolicyGateway = "gateway"
+	// OriginPolicySkew uses the time of the Device Service when the origin set by the
+	// ProtocolDriver is off by more than the maximum skew.
+	OriginPolicySkew = "skew"
+)
+
+// OriginSkew is the skew, in milliseconds, of the origins set by the ProtocolDriver for
+// the readings of a Device relative to the time of the Device Service.
+type OriginSkew struct {
+	Samples uint64 `json:"samples"`
+	Last    int64  `json:"last"`
+	MaxAbs  int64  `json:"maxAbs"`
+	// Replaced is the number of origins replaced by the time of the Device Service.
+	Replaced uint64 `json:"replaced"`
+}
+
+var (
+	originPolicy  = OriginPolicyDriver
+	originMaxSkew time.Duration
+	originSkews   = make(map[string]*OriginSkew) // key is Device name
+	skewMutex     sync.RWMutex
+)
+
+// SetOriginPolicy applies Device.Origin to the readings created afterwards.
+func SetOriginPolicy(info OriginInfo) error {
+	policy := info.Policy
+	var maxSkew time.Duration
+	switch policy {
+	case "":
+		policy = OriginPolicyDriver
+	case OriginPolicyDriver, OriginPolicyGateway:
+	case OriginPolicySkew:
+		d, err := time.ParseDuration(info.MaxSkew)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid Device.Origin.MaxSkew %s required by the %s policy", info.MaxSkew, policy)
+		}
+		maxSkew = d
+	default:
+		return fmt.Errorf("invalid Device.Origin.Policy %s", info.Policy)
+	}
+
+	skewMutex.Lock()
+	defer skewMutex.Unlock()
+	originPolicy = policy
+	originMaxSkew = maxSkew
+	return nil
+}
+
+// ReadingOrigin returns the origin of a reading of the Device according to the origin
+// policy, given the origin set by the ProtocolDriver, which is 0 if it didn't set any,
+// and records its skew.
+func ReadingOrigin(deviceName string, origin int64) int64 {
+	now := time.Now().UnixNano()
+	if origin <= 0 {
+		return now
+	}
+
+	skew := time.Duration(origin - now)
+	skewMutex.Lock()
+	defer skewMutex.Unlock()
+	s, ok := originSkews[deviceName]
+	if !ok {
+		s = &OriginSkew{}
+		originSkews[deviceName] = s
+	}
+	s.Samples++
+	s.Last = skew.Milliseconds()
+	abs := s.Last
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs > s.MaxAbs {
+		s.MaxAbs = abs
+	}
+
+	switch originPolicy {
+	case OriginPolicyGateway:
+		s.Replaced++
+		return now
+	case OriginPolicySkew:
+		if skew > originMaxSkew || skew < -originMaxSkew {
+			s.Replaced++
+			return now
+		}
+	}
+	return origin
+}
+
+// OriginSkews returns the skews of the origins set by the ProtocolDriver, keyed by Device name.
+func OriginSkews() map[string]OriginSkew {
+	skewMutex.RLock()
+	defer skewMutex.RUnlock()
+	skews := make(map[string]OriginSkew, len(originSkews))
+	for name, s := range originSkews {
+		skews[name] = *s
+	}
+	return skews
+}
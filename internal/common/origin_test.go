@@ -0,0 +1,75 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOriginPolicy(t *testing.T) {
+	defer func() { _ = SetOriginPolicy(OriginInfo{}) }()
+
+	assert.NoError(t, SetOriginPolicy(OriginInfo{}))
+	assert.NoError(t, SetOriginPolicy(OriginInfo{Policy: OriginPolicyGateway}))
+	assert.NoError(t, SetOriginPolicy(OriginInfo{Policy: OriginPolicySkew, MaxSkew: "1s"}))
+	assert.Error(t, SetOriginPolicy(OriginInfo{Policy: OriginPolicySkew}))
+	assert.Error(t, SetOriginPolicy(OriginInfo{Policy: OriginPolicySkew, MaxSkew: "-1s"}))
+	assert.Error(t, SetOriginPolicy(OriginInfo{Policy: "ntp"}))
+}
+
+func TestReadingOrigin(t *testing.T) {
+	defer func() { _ = SetOriginPolicy(OriginInfo{}) }()
+
+	tests := []struct {
+		Name           string
+		Info           OriginInfo
+		Skew           time.Duration
+		DriverExpected bool
+	}{
+		{"driver policy", OriginInfo{Policy: OriginPolicyDriver}, -time.Hour, true},
+		{"gateway policy", OriginInfo{Policy: OriginPolicyGateway}, 0, false},
+		{"skew policy within the maximum skew", OriginInfo{Policy: OriginPolicySkew, MaxSkew: "1m"}, -time.Second, true},
+		{"skew policy beyond the maximum skew", OriginInfo{Policy: OriginPolicySkew, MaxSkew: "1m"}, -time.Hour, false},
+		{"skew policy ahead of the maximum skew", OriginInfo{Policy: OriginPolicySkew, MaxSkew: "1m"}, time.Hour, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			require.NoError(t, SetOriginPolicy(test.Info))
+			deviceName := "origin-" + test.Name
+			origin := time.Now().Add(test.Skew).UnixNano()
+
+			before := time.Now().UnixNano()
+			result := ReadingOrigin(deviceName, origin)
+			if test.DriverExpected {
+				assert.Equal(t, origin, result)
+			} else {
+				assert.GreaterOrEqual(t, result, before)
+			}
+
+			skew := OriginSkews()[deviceName]
+			assert.Equal(t, uint64(1), skew.Samples)
+			assert.InDelta(t, test.Skew.Milliseconds(), skew.Last, 1000)
+			if test.DriverExpected {
+				assert.Equal(t, uint64(0), skew.Replaced)
+			} else {
+				assert.Equal(t, uint64(1), skew.Replaced)
+			}
+		})
+	}
+
+	t.Run("no driver origin", func(t *testing.T) {
+		before := time.Now().UnixNano()
+		assert.GreaterOrEqual(t, ReadingOrigin("origin-none", 0), before)
+		_, ok := OriginSkews()["origin-none"]
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,70 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// The optional fields of the published events which can be trimmed through ReadingFieldsInfo.
+const (
+	ReadingFieldFloatEncoding = "floatEncoding"
+	ReadingFieldMediaType     = "mediaType"
+	ReadingFieldTags          = "tags"
+)
+
+var readingFields ReadingFieldsInfo
+
+// SetReadingFields applies the reading field settings to the events created afterwards.
+func SetReadingFields(info ReadingFieldsInfo) {
+	readingFields = info
+}
+
+// FilterReadingFields clears the optional fields of the event and its readings which
+// aren't included for the Device Profile of the source Device.
+func FilterReadingFields(event *contract.Event, profileName string) {
+	included := includedReadingFields(profileName)
+	if included == nil {
+		return
+	}
+	if !included[ReadingFieldTags] {
+		event.Tags = nil
+	}
+	for i := range event.Readings {
+		if !included[ReadingFieldFloatEncoding] {
+			event.Readings[i].FloatEncoding = ""
+		}
+		if !included[ReadingFieldMediaType] {
+			event.Readings[i].MediaType = ""
+		}
+	}
+}
+
+// ReadingFieldIncluded returns true if the optional field is included in the events of
+// the Devices of the Device Profile.
+func ReadingFieldIncluded(profileName string, field string) bool {
+	included := includedReadingFields(profileName)
+	return included == nil || included[field]
+}
+
+// includedReadingFields returns the set of the optional fields included for the Device
+// Profile, or nil if they're all included.
+func includedReadingFields(profileName string) map[string]bool {
+	include, ok := readingFields.Profiles[profileName]
+	if !ok {
+		include = readingFields.Include
+	}
+	if include == nil {
+		return nil
+	}
+
+	included := make(map[string]bool, len(include))
+	for _, field := range include {
+		included[field] = true
+	}
+	return included
+}
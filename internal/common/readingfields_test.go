@@ -0,0 +1,65 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEvent() contract.Event {
+	return contract.Event{
+		Tags: map[string]string{"SourceName": "Temperature"},
+		Readings: []contract.Reading{
+			{Name: "Temperature", FloatEncoding: contract.Base64Encoding},
+			{Name: "Image", MediaType: "image/jpeg"},
+		},
+	}
+}
+
+func TestFilterReadingFields(t *testing.T) {
+	defer SetReadingFields(ReadingFieldsInfo{})
+	SetReadingFields(ReadingFieldsInfo{
+		Include:  []string{ReadingFieldMediaType},
+		Profiles: map[string][]string{"Thermostat": {ReadingFieldTags, ReadingFieldFloatEncoding}, "Camera": {}},
+	})
+
+	tests := []struct {
+		name          string
+		profileName   string
+		tags          bool
+		floatEncoding bool
+		mediaType     bool
+	}{
+		{"default", "Sensor", false, false, true},
+		{"profile", "Thermostat", true, true, false},
+		{"profile without optional fields", "Camera", false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := testEvent()
+			FilterReadingFields(&event, tt.profileName)
+			assert.Equal(t, tt.tags, event.Tags != nil)
+			assert.Equal(t, tt.floatEncoding, event.Readings[0].FloatEncoding != "")
+			assert.Equal(t, tt.mediaType, event.Readings[1].MediaType != "")
+
+			assert.Equal(t, tt.tags, ReadingFieldIncluded(tt.profileName, ReadingFieldTags))
+			assert.Equal(t, tt.floatEncoding, ReadingFieldIncluded(tt.profileName, ReadingFieldFloatEncoding))
+			assert.Equal(t, tt.mediaType, ReadingFieldIncluded(tt.profileName, ReadingFieldMediaType))
+		})
+	}
+}
+
+func TestFilterReadingFieldsNotConfigured(t *testing.T) {
+	SetReadingFields(ReadingFieldsInfo{})
+	event := testEvent()
+	FilterReadingFields(&event, "Sensor")
+	assert.Equal(t, testEvent(), event, "all the optional fields are included")
+	assert.True(t, ReadingFieldIncluded("Sensor", ReadingFieldTags))
+}
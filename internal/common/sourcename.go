@@ -0,0 +1,129 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/properties"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// The strategies naming the source of the events.
+const (
+	// SourceNameCommand names the source after the deviceCommand read, or the deviceResource
+	// if it's read directly.
+	SourceNameCommand = "command"
+	// SourceNameResource names the source after the deviceResource of the readings if they
+	// all come from the same one, and after the command otherwise.
+	SourceNameResource = "resource"
+	// SourceNameTemplate names the source with a template.
+	SourceNameTemplate = "template"
+)
+
+// sourceNameRule is a parsed SourceNameRule.
+type sourceNameRule struct {
+	strategy string
+	template *template.Template
+}
+
+// sourceNameData is the data of the source name templates, e.g.
+// '{{.ProfileName}}/{{.Command}}'. Resource is empty if the readings come from several
+// deviceResources.
+type sourceNameData struct {
+	properties.Data
+	Command  string
+	Resource string
+}
+
+var (
+	sourceNameDefault  sourceNameRule
+	sourceNameProfiles map[string]sourceNameRule // key is Device Profile name
+	sourceNameMutex    sync.RWMutex
+)
+
+// SetSourceNaming applies Device.SourceName to the events created afterwards.
+func SetSourceNaming(info SourceNameInfo) error {
+	def, err := parseSourceNameRule("Device.SourceName", SourceNameRule{Strategy: info.Strategy, Template: info.Template})
+	if err != nil {
+		return err
+	}
+	profiles := make(map[string]sourceNameRule, len(info.Profiles))
+	for name, rule := range info.Profiles {
+		r, err := parseSourceNameRule("Device.SourceName.Profiles."+name, rule)
+		if err != nil {
+			return err
+		}
+		profiles[name] = r
+	}
+
+	sourceNameMutex.Lock()
+	defer sourceNameMutex.Unlock()
+	sourceNameDefault = def
+	sourceNameProfiles = profiles
+	return nil
+}
+
+func parseSourceNameRule(key string, rule SourceNameRule) (sourceNameRule, error) {
+	switch rule.Strategy {
+	case "", SourceNameCommand, SourceNameResource:
+		return sourceNameRule{strategy: rule.Strategy}, nil
+	case SourceNameTemplate:
+		tmpl, err := properties.Parse(key, rule.Template)
+		if err != nil || rule.Template == "" {
+			return sourceNameRule{}, fmt.Errorf("invalid %s.Template %s: %v", key, rule.Template, err)
+		}
+		return sourceNameRule{strategy: rule.Strategy, template: tmpl}, nil
+	default:
+		return sourceNameRule{}, fmt.Errorf("invalid %s.Strategy %s", key, rule.Strategy)
+	}
+}
+
+// SourceName returns the source name of the event of the Device read with the command
+// cmd from the CommandValues, or an empty string if the source of the events of the
+// Device Profile isn't named.
+func SourceName(deviceName string, profileName string, cmd string, cvs []*dsModels.CommandValue) string {
+	sourceNameMutex.RLock()
+	rule, ok := sourceNameProfiles[profileName]
+	if !ok {
+		rule = sourceNameDefault
+	}
+	sourceNameMutex.RUnlock()
+
+	resource := ""
+	for i, cv := range cvs {
+		if i > 0 && cv.DeviceResourceName != resource {
+			resource = ""
+			break
+		}
+		resource = cv.DeviceResourceName
+	}
+
+	switch rule.strategy {
+	case SourceNameCommand:
+		return cmd
+	case SourceNameResource:
+		if resource != "" {
+			return resource
+		}
+		return cmd
+	case SourceNameTemplate:
+		data, ok := properties.ForDevice(deviceName)
+		if !ok {
+			data = properties.Data{DeviceName: deviceName, ProfileName: profileName}
+		}
+		var buf bytes.Buffer
+		if err := rule.template.Execute(&buf, sourceNameData{Data: data, Command: cmd, Resource: resource}); err != nil {
+			return cmd
+		}
+		return buf.String()
+	}
+	return ""
+}
@@ -18,6 +18,43 @@ type WritableInfo struct {
 	// Level is the logging level of writing log message
 	LogLevel        string
 	InsecureSecrets bootstrapConfig.InsecureSecrets
+	// MaxConcurrentCommands is the maximum number of read and write commands the
+	// ProtocolDriver handles simultaneously across all devices. 0 means no limit.
+	MaxConcurrentCommands int
+	// MaxDeviceConcurrentCommands is the maximum number of read and write commands
+	// the ProtocolDriver handles simultaneously for a single device. Default is 1.
+	MaxDeviceConcurrentCommands int
+	// MaxDeviceQueuedCommands is the maximum number of on-demand commands waiting for a
+	// device once MaxDeviceConcurrentCommands are running; further ones are rejected with
+	// 429 Too Many Requests. 0 means no limit.
+	MaxDeviceQueuedCommands int
+	// CommandRetryAfter is the delay suggested with the Retry-After header to the clients
+	// of the commands rejected as the device is busy or its queue is full. It represents
+	// as a duration string. Default is 1s.
+	CommandRetryAfter string
+	// CommandPriority is the order of the commands waiting for a device: 'interactive' runs
+	// the on-demand commands before the pending AutoEvent reads, 'none' in no particular
+	// order. Default is 'interactive'.
+	CommandPriority string
+	// ReadRetries is the number of times a read command is retried when the ProtocolDriver
+	// reports a NotReachable, Timeout or Busy error. 0 disables the retries.
+	ReadRetries int
+	// Maintenance is the maintenance mode of the whole Device Service, which can also be
+	// toggled through the /maintenance endpoint.
+	Maintenance MaintenanceModeInfo
+	// Driver is a string map of the settings of the protocol driver which can be changed in
+	// the Registry, passed to the driver implementing WritableConfigUpdater on each change.
+	Driver map[string]string
+}
+
+// MaintenanceModeInfo is a struct which contains configuration of the maintenance mode of the
+// Device Service. In maintenance mode the AutoEvents and the device discovery are paused, and
+// the write commands are rejected with 503 Service Unavailable.
+type MaintenanceModeInfo struct {
+	// Enabled puts the Device Service in maintenance mode.
+	Enabled bool
+	// RejectReads rejects the read commands as well in maintenance mode.
+	RejectReads bool
 }
 
 // ServiceInfo is a struct which contains service related configuration
@@ -53,6 +90,124 @@ type ServiceInfo struct {
 	EnableAsyncReadings bool
 	// AsyncBufferSize defines the size of asynchronous channel
 	AsyncBufferSize int
+	// AsyncQueueCapacity is the number of asynchronous readings pending processing,
+	// defaulting to AsyncBufferSize.
+	AsyncQueueCapacity int
+	// AsyncOverflowPolicy applies when the asynchronous readings queue is full: 'block'
+	// blocks the ProtocolDriver, 'drop-oldest' and 'drop-newest' discard readings. Default is 'block'.
+	// The 'drop-oldest' and 'drop-newest' policies require a positive AsyncQueueCapacity.
+	AsyncOverflowPolicy string
+	// AsyncRateLimit is the maximum number of asynchronous readings queued per second, 0
+	// for no limit. The readings in excess are held back with the 'block' AsyncOverflowPolicy,
+	// discarded with 'drop-newest', and with 'drop-oldest' the latest wait for their turn,
+	// discarding those waiting.
+	AsyncRateLimit float64
+	// AsyncRateBurst is the number of asynchronous readings queued at once over AsyncRateLimit,
+	// defaulting to AsyncRateLimit.
+	AsyncRateBurst int
+	// DeferredStartup starts the REST API and the ProtocolDriver without waiting for Core Data
+	// and Core Metadata. The caches and the pre-defined provisioning are loaded in the background
+	// once they respond, and the asynchronous readings are held in the asynchronous channel until then.
+	DeferredStartup bool
+	// CoreDataOptional lets the Device Service start with only Core Metadata available, for
+	// the read-only and command-only deployments. The events are queued in the
+	// store-and-forward queue, if enabled, or dropped until Core Data responds.
+	CoreDataOptional bool
+	// MaxRequestSize defines the maximum size of http request body in kilobytes
+	// accepted by the write endpoints. 0 means no limit.
+	MaxRequestSize int64
+	// StrictContentType rejects write requests whose body is not declared as
+	// application/json with 415 Unsupported Media Type.
+	StrictContentType bool
+	// ReadinessChecks lists the checks performed by the readiness probe, among
+	// 'dependencies', 'cache' and 'driver'. All of them are performed by default.
+	ReadinessChecks []string
+	// SocketPath is the path of the Unix domain socket the REST API is also served on, so
+	// that the sidecars reach it without a TCP port and with the access controlled by the
+	// file system permissions. Blank disables it. With client certificates required, the
+	// requests over the socket are only let through if TLS.SocketExempt is set.
+	SocketPath string
+	// SocketMode is the octal file mode of the socket of SocketPath, which the clients need
+	// write access to. Default is '0660', the owner and group only.
+	SocketMode string
+	// TLS specifies how the REST API is served over HTTPS.
+	TLS TLSInfo
+	// CallbackAuth specifies how the callbacks from Core Metadata are authenticated.
+	CallbackAuth CallbackAuthInfo
+	// Proxy is the outbound HTTP proxy the core services are reached through.
+	Proxy ProxyInfo
+	// CircuitBreaker configures the circuit breakers of the core service clients.
+	CircuitBreaker CircuitBreakerInfo
+	// FailureInjection enables the injection of latency, drops and errors through the
+	// /chaos endpoint, to validate the resilience of the Device Service. Debugging only.
+	FailureInjection bool
+}
+
+// CircuitBreakerInfo is a struct which contains configuration of the circuit breakers of the
+// Core Data and Core Metadata clients, failing the requests fast while the service is
+// unavailable.
+type CircuitBreakerInfo struct {
+	// Enabled wraps the clients with the circuit breakers.
+	Enabled bool
+	// FailureThreshold is the number of consecutive failures opening the breaker, 5 by default.
+	FailureThreshold int
+	// OpenTimeout is the duration the breaker stays open before a probe request is let
+	// through, 30s by default.
+	OpenTimeout string
+}
+
+// ProxyInfo is a struct which contains configuration of the outbound HTTP proxy of the
+// clients of the core services. The HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables apply when it's empty.
+type ProxyInfo struct {
+	// URL is the http(s) URL of the proxy, e.g. http://proxy.example.com:3128. The proxy is
+	// taken from the environment when it's empty.
+	URL string
+	// NoProxy lists the hosts reached directly, as in NO_PROXY: host names, domain names
+	// starting with '.', IP addresses and CIDR ranges, optionally followed by a port.
+	NoProxy []string
+	// SecretPath is the path in the Secret Store of the proxy credentials, stored with the
+	// keys 'username' and 'password'. The proxy isn't authenticated when it's empty.
+	SecretPath string
+}
+
+// CallbackAuthInfo is a struct which contains configuration of the authentication of the
+// callback requests, which add, update and remove Devices, Device Profiles and Provision Watchers.
+type CallbackAuthInfo struct {
+	// Method is 'none', 'secret' to require the shared secret in the Header, or 'jwt' to
+	// require a bearer JWT signed with HS256 using the shared secret. Default is 'none'.
+	Method string
+	// SecretPath is the path in the Secret Store of the shared secret, stored with the key
+	// 'callbackSecret'.
+	SecretPath string
+	// Header is the request header carrying the shared secret. Default is X-Callback-Secret.
+	Header string
+	// AllowedSources lists the IP addresses and CIDR ranges the callbacks are accepted from.
+	// The callbacks are accepted from any source when it's empty.
+	AllowedSources []string
+}
+
+// TLSInfo is a struct which contains configuration of the HTTPS REST API.
+type TLSInfo struct {
+	// Enabled serves the REST API over HTTPS rather than HTTP on the Port of the service.
+	// Protocol should then be 'https' for the Device Service to be called over HTTPS.
+	Enabled bool
+	// SecretPath is the path in the Secret Store of the PEM encoded server certificate chain
+	// and private key, stored with the keys 'cert' and 'key', and of the CA certificates
+	// verifying the client certificates, stored with the key 'clientCA'.
+	SecretPath string
+	// RequireClientCert requires a client certificate signed by the client CA on the
+	// command and callback routes and on those changing the state of the Devices or of the
+	// service (mTLS).
+	RequireClientCert bool
+	// SocketExempt exempts the requests received over the Unix domain socket of
+	// Service.SocketPath, whose access is controlled by the file system permissions, from
+	// RequireClientCert. They are rejected on the routes requiring a client certificate
+	// otherwise.
+	SocketExempt bool
+	// RefreshInterval is how often the certificates are reloaded from the Secret Store, so
+	// that the rotated ones are served without restart. Default is '1m'.
+	RefreshInterval string
 }
 
 // DeviceInfo is a struct which contains device specific configuration settings.
@@ -80,11 +235,194 @@ type DeviceInfo struct {
 	// ProfilesDir specifies a directory which contains device profiles
 	// files which should be imported on startup.
 	ProfilesDir string
+	// ProvisionConcurrency is the maximum number of the pre-defined Device Profiles, and
+	// then Devices, concurrently added to Core Metadata at startup. Defaults to 4.
+	ProvisionConcurrency int
+	// ProfileConflictStrategy resolves the conflicts between the pre-defined Device Profiles
+	// and their different version in Core Metadata at startup: 'skip' keeps the version in
+	// Core Metadata, 'overwrite' updates it, 'newversion' adds the pre-defined one under its
+	// name suffixed by the hash of its content and 'fail' aborts the startup. Defaults to 'skip'.
+	ProfileConflictStrategy string
+	// BatchConcurrency is the maximum number of devices a batch command is concurrently
+	// executed on. Defaults to 8.
+	BatchConcurrency int
+	// CommandHistorySize is the number of the recent command executions retained for the
+	// command history endpoint. 0 disables the history.
+	CommandHistorySize int
+	// NotReachableThreshold is the number of consecutive NotReachable driver errors after
+	// which a Device is disabled. 0 leaves the OperatingState to the ProtocolDriver.
+	NotReachableThreshold int
 	// UpdateLastConnected specifies whether to update device's LastConnected
 	// timestamp in metadata.
 	UpdateLastConnected bool
+	// WriteConfirmTimeout specifies how long a write command waits for the device to
+	// asynchronously confirm the value written to a deviceResource that requires
+	// confirmation. It represents as a duration string and defaults to 5s.
+	WriteConfirmTimeout string
+	// DedupWindow drops the events identical, in Device and reading values, to one
+	// published within the window. It represents as a duration string; blank disables it.
+	DedupWindow string
+	// ReadCacheTTL serves the read commands from the values read within it rather than from
+	// the devices, sparing the slow buses the polling storms. The deviceResources override
+	// it with the ds-cacheMaxAge attribute. It represents as a duration string; blank disables it.
+	ReadCacheTTL string
+	// RegistrationInterval is the minimum interval between the attempts of a driver to
+	// register the same unknown Device. It represents as a duration string and defaults to 30s.
+	RegistrationInterval string
+	// CalibrationFile is the file persisting the calibrations of the Devices' deviceResources.
+	// They're only kept in memory if it's empty.
+	CalibrationFile string
+	// Aliases maps the alternate names, resolvable in the command endpoints, to the
+	// names of the Devices. Aliases may also be declared as 'alias:<name>' Device labels.
+	Aliases map[string]string
+	// RedactWriteOnly hides the values written to the write-only deviceResources, such as
+	// passwords or sensitive setpoints, from the logs.
+	RedactWriteOnly bool
+	// EventTags are the tags added to the published events, keyed by tag name. The values
+	// are templates referring to the Device of the event, e.g. '{{.Properties.floor}}' for
+	// the value of its 'floor:<value>' label.
+	EventTags map[string]string
+
+	Discovery       DiscoveryInfo
+	Naming          NamingInfo
+	Export          ExportInfo
+	NumericEncoding NumericEncodingInfo
+	NumericParsing  NumericParsingInfo
+	ReadingFields   ReadingFieldsInfo
+	SourceName      SourceNameInfo
+	TimeSync        TimeSyncInfo
+	UnitsOfMeasure  UnitsOfMeasureInfo
+	Delta           DeltaInfo
+	Origin          OriginInfo
+	CacheLimits     CacheLimitsInfo
+	Chunking        ChunkingInfo
+	// AutoEventReadiness defers the AutoEvents of the Devices until they're reachable.
+	AutoEventReadiness AutoEventReadinessInfo
+	// AutoEventSchedule spreads the executions of the AutoEvents of the Devices.
+	AutoEventSchedule AutoEventScheduleInfo
+	// Store configures the persistence of the command history and of the AutoEvents state.
+	Store StoreInfo
+	// StoreForward configures the buffering of the events Core Data couldn't be reached for.
+	StoreForward StoreForwardInfo
+	// ProvisioningSources configures the synchronization of the Devices from the external
+	// device registries.
+	ProvisioningSources ProvisioningSourcesInfo
+	// MaintenanceWindows are the scheduled outages of the Devices.
+	MaintenanceWindows []MaintenanceWindowInfo
+}
+
+// CacheLimitsInfo is a struct which contains configuration of the bounds of the Device and
+// Device Profile caches, for memory-constrained gateways. 0 doesn't bound the cache.
+type CacheLimitsInfo struct {
+	// MaxDevices is the maximum number of cached Devices. The Devices added beyond it are
+	// rejected.
+	MaxDevices int
+	// MaxProfiles is the maximum number of cached Device Profiles. The least recently used
+	// Device Profile no Device refers to is evicted to add another one; the Device Profile
+	// is rejected if they're all in use.
+	MaxProfiles int
+}
+
+// ChunkingInfo is a struct which contains configuration of the limits beyond which an event
+// is split into parts sharing the ChunkCorrelation tag, for devices with hundreds of
+// channels. 0 doesn't limit the events.
+type ChunkingInfo struct {
+	// MaxReadings is the maximum number of readings of an event.
+	MaxReadings int
+	// MaxSize is the maximum size of an encoded event in kilobytes. A single reading
+	// exceeding it is sent on its own.
+	MaxSize int64
+}
+
+// AutoEventReadinessInfo is a struct which contains configuration of the gating of the
+// AutoEvents of a Device on a first successful read, avoiding a storm of timeouts when many
+// devices are offline at startup, e.g. after a site power cycle.
+type AutoEventReadinessInfo struct {
+	// Enabled defers the AutoEvents of a Device until a read of the resource of its first
+	// AutoEvent succeeds.
+	Enabled bool
+	// InitialBackoff is the delay before the failed read is retried, doubled after each
+	// failure. It represents as a duration string and defaults to 1s.
+	InitialBackoff string
+	// MaxBackoff is the maximum delay between the reads. It represents as a duration
+	// string and defaults to 1m.
+	MaxBackoff string
+}
+
+// AutoEventScheduleInfo is a struct which contains configuration of the spreading of the
+// AutoEvents executions, avoiding synchronized bursts of reads across many devices. The
+// ds-autoEventSchedule protocol of a Device sets the jitter and the offset of its AutoEvents.
+type AutoEventScheduleInfo struct {
+	// Jitter is the maximum random delay added to each interval of an AutoEvent. It
+	// represents as a duration string; the intervals are exact if empty.
+	Jitter string
+	// AlignedStart runs the first execution of an AutoEvent after its full interval.
+	// Otherwise it runs after a random fraction of the interval, unless an offset is set.
+	AlignedStart bool
+}
 
-	Discovery DiscoveryInfo
+// StoreInfo is a struct which contains configuration of the Store persisting the command
+// history and the last readings of the AutoEvents with OnChange across restarts.
+type StoreInfo struct {
+	// Backend is the Store: 'file' persists the state in Path, 'memory' keeps it until the
+	// Device Service stops. The state isn't stored if empty, unless a Store is set with
+	// store.Set.
+	Backend string
+	// Path is the directory the state is persisted in.
+	Path string
+}
+
+// StoreForwardInfo is a struct which contains configuration of the store-and-forward queue,
+// persisting the events which couldn't be posted to Core Data and forwarding them in order
+// once it's reachable again.
+type StoreForwardInfo struct {
+	// Enabled queues the events which couldn't be posted.
+	Enabled bool
+	// Backend is the Store of the queued events: 'file' persists them in Path, 'memory'
+	// keeps them until the Device Service stops. Default is 'file'. It doesn't apply if a
+	// Store is set with store.Set.
+	Backend string
+	// Path is the directory the queued events are persisted in.
+	Path string
+	// BatchSize is the maximum number of events forwarded every Interval. 0 forwards them all.
+	BatchSize int
+	// Interval is the period the queued events are forwarded at, e.g. 10s.
+	Interval string
+	// MaxDepth is the maximum number of queued events, the oldest ones being discarded
+	// beyond it. 0 doesn't bound the queue.
+	MaxDepth int
+}
+
+// MessageQueueInfo is a struct which contains configuration of the MessageBus the events are
+// published to. The events are posted to Core Data unless it's enabled.
+type MessageQueueInfo struct {
+	// Enabled publishes the events onto the MessageBus.
+	Enabled bool
+	// Type is the MessageBus implementation, either 'redis' for Redis Streams or 'mqtt'.
+	Type string
+	// Protocol is either 'tcp' or 'tls'. Default is 'tcp'.
+	Protocol string
+	Host     string
+	Port     int
+	// PublishTopicPrefix is the prefix of the topics, followed by the Device name.
+	// Default is 'edgex/events/device'.
+	PublishTopicPrefix string
+	// Optional holds the ClientId, Username and Password of the MessageBus.
+	Optional map[string]string
+	// Codec is the encoding of the published events: 'json', 'cbor' or 'protobuf' following
+	// the schema internal/messagebus/event.proto. By default the events are encoded as for
+	// Core Data, i.e. in JSON or, if they have binary readings, in CBOR.
+	Codec string
+	// StreamMaxLength is the approximate number of entries the Redis Streams are trimmed to.
+	// Default is 10000.
+	StreamMaxLength int
+	// SystemEvents publishes the SystemEvents of the Devices added, updated and removed by
+	// the Device Service, the discovery scans completed and the Device Profiles applied.
+	// The MessageQueue must be enabled.
+	SystemEvents bool
+	// SystemEventTopic is the topic of the SystemEvents. Default is
+	// 'edgex/system-events/<service name>'.
+	SystemEventTopic string
 }
 
 // DiscoveryInfo is a struct which contains configuration of device auto discovery.
@@ -94,22 +432,204 @@ type DiscoveryInfo struct {
 	// Interval indicates how often the discovery process will be triggered.
 	// It represents as a duration string.
 	Interval string
+	// Schedule is the cron expression the discovery is re-run at, e.g. "0 2 * * *" for
+	// every night at 2 AM, taking precedence over Interval.
+	Schedule string
+	// Providers configures the supplemental DiscoveryProviders, keyed by name. The
+	// DiscoveryProviders not configured are enabled.
+	Providers map[string]DiscoveryProviderInfo
+}
+
+// DiscoveryProviderInfo is a struct which contains configuration of a supplemental
+// DiscoveryProvider.
+type DiscoveryProviderInfo struct {
+	// Enabled runs the DiscoveryProvider in the scans.
+	Enabled bool
+}
+
+// NamingInfo is a struct which contains configuration of the names of discovered Devices.
+type NamingInfo struct {
+	// AllowedCharacters is the regular expression character class, e.g. 'A-Za-z0-9_-', of
+	// the characters allowed in the names. The others are replaced with '_'.
+	AllowedCharacters string
+	// MaxLength truncates the longer names. 0 doesn't limit the length.
+	MaxLength int
+	// Prefix is a template prepended to the names, e.g. '{{.ServiceName}}-'. The template
+	// data are ServiceName and ProfileName.
+	Prefix string
+	// Template renders the whole names in place of the names given by the ProtocolDriver,
+	// e.g. '{{.ProfileName}}-{{sanitize (index .Protocols "mac").Address}}-{{.Index}}'.
+	// The template data are ServiceName, ProfileName, Name, Protocols, Labels and Index,
+	// the lowest number from 1 making the name unique. The function sanitize replaces the
+	// characters not in AllowedCharacters, or not valid in a name if it's empty, with '_',
+	// and lower and upper change the case. Prefix doesn't apply.
+	Template string
+	// CollisionPolicy applies when the name is taken by another Device. It should be
+	// 'suffix', 'reject' or 'replace', and defaults to reject.
+	CollisionPolicy string
+}
+
+// ExportInfo is a struct which contains configuration of the events export bundle.
+type ExportInfo struct {
+	// BufferSize is the number of recent events retained for export. The oldest
+	// events are discarded once it's exceeded. 0 disables the export.
+	BufferSize int
+	// SecretPath is the path in the Secret Store of the key used to sign exported
+	// bundles. Bundles are only digested with SHA-256 when it's empty.
+	SecretPath string
+}
+
+// TimeSyncInfo is a struct which contains configuration of the clock synchronization of the Devices.
+type TimeSyncInfo struct {
+	// MaxSkew is the maximum clock offset of the Devices reported by the ProtocolDriver
+	// before a warning is logged. It represents as a duration string; blank disables it.
+	MaxSkew string
+	// AutoSet controls whether the ProtocolDriver sets the clock of the Devices whose
+	// offset exceeds MaxSkew, if it's able to.
+	AutoSet bool
+}
+
+// ProvisioningSourcesInfo is a struct which contains configuration of the synchronization of
+// the Devices from the ProvisioningSources.
+type ProvisioningSourcesInfo struct {
+	// SyncInterval is the interval between the synchronizations. It represents as a duration
+	// string and defaults to 5m.
+	SyncInterval string
+	// ConflictPolicy applies when a Device of a ProvisioningSource has the name of a Device
+	// not synchronized from it: 'metadata' keeps the existing Device and 'source' takes it
+	// over. Default is 'metadata'.
+	ConflictPolicy string
+	// RemoveMissing removes the Devices synchronized from a ProvisioningSource which it no
+	// longer provisions.
+	RemoveMissing bool
+}
+
+// OriginInfo is a struct which contains configuration of the assignment of the reading origins.
+type OriginInfo struct {
+	// Policy is 'driver' to keep the origins set by the ProtocolDriver, 'gateway' to always
+	// use the time of the Device Service, or 'skew' to use it when the origin set by the
+	// ProtocolDriver is off by more than MaxSkew, e.g. for buffered readings replayed by a
+	// device with a bad clock. The time of the Device Service is used for the readings
+	// without origin regardless of the policy. Default is 'driver'.
+	Policy string
+	// MaxSkew is the maximum skew of the origins under the 'skew' policy. It represents
+	// as a duration string.
+	MaxSkew string
+}
+
+// DeltaInfo is a struct which contains configuration of the delta event mode, in which the
+// events of the selected Devices only include the readings whose values changed since the
+// previous event, tagged with 'delta', and full snapshots are published periodically.
+type DeltaInfo struct {
+	// Devices are the names of the Devices in delta mode.
+	Devices []string
+	// Labels select the Devices having any of them.
+	Labels []string
+	// SnapshotInterval is the interval between the full snapshots. It represents as a
+	// duration string and defaults to 5m if SnapshotEvents isn't configured either.
+	SnapshotInterval string
+	// SnapshotEvents is the number of delta events after which a full snapshot is published.
+	// 0 disables it.
+	SnapshotEvents int
+}
+
+// UnitsOfMeasureInfo is a struct which contains configuration of the validation of the units
+// of the deviceResources against a central definition of the units of measure.
+type UnitsOfMeasureInfo struct {
+	// Source is the path or the http(s) URI of the YAML or JSON units of measure definition.
+	// The units aren't checked against a definition when it's empty.
+	Source string
+	// Validation applies to the pre-defined Device Profiles having units missing from the
+	// definition or invalid ds-ucum attributes: 'warn' logs them and 'reject' doesn't load
+	// the Device Profile. Default is 'warn'.
+	Validation string
+}
+
+// NumericParsingInfo is a struct which contains configuration of the parsing of the numeric
+// values written and of the numeric properties of the Device Profiles.
+type NumericParsingInfo struct {
+	// ScientificNotation accepts the floats in scientific notation, e.g. '1.5e3', if
+	// 'allow' and rejects them if 'reject'. Defaults to 'allow'. The integers are never
+	// accepted in scientific notation.
+	ScientificNotation string
+}
+
+// NumericEncodingInfo is a struct which contains configuration of the encoding of numeric readings.
+type NumericEncodingInfo struct {
+	// FloatEncoding is the float encoding of readings whose deviceResource doesn't specify
+	// one. It should be 'Base64', 'eNotation' or 'decimal', and defaults to Base64.
+	FloatEncoding string
+	// FloatSignificantDigits rounds the floats encoded in decimal notation to the given
+	// number of significant digits. 0 keeps the fewest digits representing the value exactly.
+	FloatSignificantDigits int
+	// Int64ArrayAsStrings encodes the elements of Int64Array and Uint64Array readings as
+	// strings, so that consumers parsing them as JSON numbers don't lose precision.
+	// Int64 and Uint64 readings are always encoded as decimal strings.
+	Int64ArrayAsStrings bool
+}
+
+// ReadingFieldsInfo is a struct which contains configuration of the optional fields included
+// in the published events, i.e. 'floatEncoding', 'mediaType' and 'tags'.
+type ReadingFieldsInfo struct {
+	// Include lists the optional fields included in the events of all the Devices.
+	// All of them are included when it's not configured.
+	Include []string
+	// Profiles overrides Include for the Devices of the given Device Profiles; the key is
+	// the Device Profile name.
+	Profiles map[string][]string
+}
+
+// SourceNameInfo is a struct which contains configuration of the naming of the source of the
+// published events, set as their SourceName tag.
+type SourceNameInfo struct {
+	// Strategy is 'command' to name the source after the deviceCommand read, 'resource' to
+	// name it after the deviceResource of the readings if they all come from the same one,
+	// or 'template' to expand Template. The events aren't tagged when it's not configured.
+	Strategy string
+	// Template is the template of the 'template' strategy, e.g. '{{.ProfileName}}/{{.Command}}'.
+	// It refers to the DeviceName, ProfileName, Labels and Properties of the Device, the
+	// Command read and the Resource of the readings, empty if they come from several ones.
+	Template string
+	// Profiles overrides the naming for the Devices of the given Device Profiles; the key is
+	// the Device Profile name.
+	Profiles map[string]SourceNameRule
+}
+
+// SourceNameRule is the naming of the source of the events of a Device Profile.
+type SourceNameRule struct {
+	Strategy string
+	Template string
+}
+
+// MaintenanceWindowInfo is a struct which contains configuration of a scheduled maintenance window.
+// During the window, the failures of the covered Devices don't disable them and are only logged
+// at debug level.
+type MaintenanceWindowInfo struct {
+	// Start and End bound the window, represented as RFC 3339 timestamps.
+	Start string
+	End   string
+	// Devices are the names of the Devices covered by the window.
+	Devices []string
+	// Labels cover the Devices having any of them.
+	Labels []string
+	// PauseAutoEvents skips the AutoEvents of the covered Devices during the window.
+	PauseAutoEvents bool
 }
 
 // DeviceConfig is the definition of Devices which will be auto created when the Device Service starts up
 type DeviceConfig struct {
 	// Name is the Device name
-	Name string
+	Name string `json:"name" yaml:"name"`
 	// Profile is the profile name of the Device
-	Profile string
+	Profile string `json:"profile" yaml:"profile"`
 	// Description describes the device
-	Description string
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// Other labels applied to the device to help with searching
-	Labels []string
+	Labels []string `json:"labels,omitempty" yaml:"labels,flow,omitempty"`
 	// Protocols for the device - stores protocol properties
-	Protocols map[string]dsModels.ProtocolProperties
+	Protocols map[string]dsModels.ProtocolProperties `json:"protocols,omitempty" yaml:"protocols,omitempty"`
 	// AutoEvent supports auto-generated events sourced from a device service
-	AutoEvents []dsModels.AutoEvent
+	AutoEvents []dsModels.AutoEvent `json:"autoEvents,omitempty" yaml:"autoEvents,omitempty"`
 }
 
 func (s ServiceInfo) GetBootstrapServiceInfo() bootstrapConfig.ServiceInfo {
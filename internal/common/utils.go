@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
@@ -21,10 +22,19 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/coredata"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/metadata"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/types"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
 	"github.com/google/uuid"
 
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/chaos"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/dedup"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/delta"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/export"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/messagebus"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/properties"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/storeforward"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 )
 
@@ -45,33 +55,61 @@ func BuildAddr(host string, port string) string {
 }
 
 func CommandValueToReading(cv *dsModels.CommandValue, devName string, mediaType string, encoding string) *contract.Reading {
-	if encoding == "" {
-		encoding = dsModels.DefaultFloatEncoding
-	}
+	encoding = ReadingFloatEncoding(encoding)
 
 	reading := &contract.Reading{Name: cv.DeviceResourceName, Device: devName, ValueType: cv.Type}
 	if cv.Type == v2.ValueTypeBool {
 		reading.BinaryValue = cv.BinValue
 		reading.MediaType = mediaType
 	} else if cv.Type == v2.ValueTypeFloat32 || cv.Type == v2.ValueTypeFloat64 {
-		reading.Value = cv.ValueToString(encoding)
+		reading.Value = ReadingValue(cv, encoding)
 		reading.FloatEncoding = encoding
 	} else {
-		reading.Value = cv.ValueToString(encoding)
+		reading.Value = ReadingValue(cv, encoding)
 	}
 
-	// if value has a non-zero Origin, use it
-	if cv.Origin > 0 {
-		reading.Origin = cv.Origin
-	} else {
-		reading.Origin = time.Now().UnixNano()
-	}
+	// the Origin set by the driver, if any, is used according to the origin policy
+	reading.Origin = ReadingOrigin(devName, cv.Origin)
 
 	return reading
 }
 
 func SendEvent(event *dsModels.Event, lc logger.LoggingClient, ec coredata.EventClient) {
-	correlation := uuid.New().String()
+	SendCorrelatedEvent(event, uuid.New().String(), lc, ec)
+}
+
+// SendCorrelatedEvent sends the event as SendEvent does, with the correlation id of the
+// command it results from.
+func SendCorrelatedEvent(event *dsModels.Event, correlation string, lc logger.LoggingClient, ec coredata.EventClient) {
+	destination, err := DeviceDestination(event.Device)
+	if err != nil {
+		lc.Warn("SendEvent: using the default destination", "device", event.Device, "error", err)
+	}
+	if destination.Target == DestinationNone {
+		lc.Debug("SendEvent: dropped event suppressed by the destination of the device", "device", event.Device)
+		return
+	}
+	if dedup.GetFilter().Duplicate(event.Event) {
+		lc.Debug("SendEvent: dropped duplicate event", "device", event.Device)
+		return
+	}
+	modified, publish := delta.GetFilter().Apply(&event.Event)
+	if !publish {
+		lc.Debug("SendEvent: dropped event without changed readings", "device", event.Device)
+		return
+	}
+	if properties.EnrichEvent(&event.Event) {
+		modified = true
+	}
+	if modified {
+		event.EncodedEvent = nil
+	}
+	for _, part := range chunkEvent(event, lc, ec) {
+		sendEvent(part, destination, correlation, lc, ec)
+	}
+}
+
+func sendEvent(event *dsModels.Event, destination Destination, correlation string, lc logger.LoggingClient, ec coredata.EventClient) {
 	ctx := context.WithValue(context.Background(), CorrelationHeader, correlation)
 	if event.HasBinaryValue() {
 		ctx = context.WithValue(ctx, clients.ContentType, clients.ContentTypeCBOR)
@@ -90,16 +128,105 @@ func SendEvent(event *dsModels.Event, lc logger.LoggingClient, ec coredata.Event
 	} else {
 		lc.Debug("SendEvent: EventClient.MarshalEvent passed through encoded event", clients.CorrelationHeader, correlation)
 	}
+	// retain the event for export to disconnected sites
+	export.GetBuffer().Record(event.Event)
+	// apply the fault injected for resilience testing, if any
+	injected := chaos.Inject(chaos.PointPublish)
+	if injected == chaos.ErrDropped {
+		lc.Debug("SendEvent: dropped event by failure injection", "device", event.Device, clients.CorrelationHeader, correlation)
+		return
+	}
+	// publish the event onto the MessageBus if enabled, instead of posting it to core data
+	// unless the destination of the device says otherwise
+	publisher := messagebus.GetPublisher()
+	if publisher == nil && destination.Target == DestinationMessageBus {
+		lc.Warn("SendEvent: no MessageBus to publish the event of the device onto, posting it to core data", "device", event.Device)
+	}
+	if publisher != nil && destination.Target != DestinationCoreData {
+		topic := publisher.Topic(event.Device)
+		if destination.TopicPrefix != "" {
+			topic = destination.TopicPrefix + "/" + event.Device
+		}
+		contentType := clients.FromContext(ctx, clients.ContentType)
+		payload := event.EncodedEvent
+		err = injected
+		if codec := publisher.Codec(); codec != nil && err == nil {
+			contentType = codec.ContentType()
+			payload, err = codec.Encode(event.Event)
+		}
+		if err == nil {
+			err = publisher.PublishTopic(topic, correlation, contentType, payload)
+		}
+		metrics.EventPublished(metrics.DestinationMessageBus, err)
+		if err != nil {
+			lc.Error("SendEvent Failed to publish event", "device", event.Device, "topic", topic, "error", err)
+		} else {
+			lc.Debug("SendEvent: Published event to the MessageBus", "topic", topic, clients.ContentType, contentType, clients.CorrelationHeader, correlation)
+		}
+		return
+	}
+	// queue the event behind the ones waiting for core data to keep them in order
+	queue := storeforward.GetQueue()
+	if queue != nil && queue.Depth() > 0 {
+		storeEvent(ctx, queue, event, lc)
+		return
+	}
+	// defer posting the events until core data is reachable if the service started without it
+	if !CoreDataReachable() {
+		if queue != nil {
+			storeEvent(ctx, queue, event, lc)
+		} else {
+			lc.Warn("SendEvent: dropped event, core data not reachable yet", "device", event.Device, clients.CorrelationHeader, correlation)
+		}
+		return
+	}
 	// Call AddBytes to post event to core data
-	responseBody, errPost := ec.AddBytes(ctx, event.EncodedEvent)
+	var responseBody string
+	errPost := injected
+	if errPost == nil {
+		responseBody, errPost = ec.AddBytes(ctx, event.EncodedEvent)
+	}
+	metrics.EventPublished(metrics.DestinationCoreData, errPost)
 	if errPost != nil {
 		lc.Error("SendEvent Failed to push event", "device", event.Device, "response", responseBody, "error", errPost)
+		// the events core data rejected aren't retried
+		if errsc, ok := errPost.(types.ErrServiceClient); queue != nil && !(ok && eventRejected(errsc.StatusCode)) {
+			storeEvent(ctx, queue, event, lc)
+		}
 	} else {
 		lc.Debug("SendEvent: Pushed event to core data", clients.ContentType, clients.FromContext(ctx, clients.ContentType), clients.CorrelationHeader, correlation)
 		lc.Trace("SendEvent: Pushed this event to core data", clients.ContentType, clients.FromContext(ctx, clients.ContentType), clients.CorrelationHeader, correlation, "event", event)
 	}
 }
 
+func storeEvent(ctx context.Context, queue *storeforward.Queue, event *dsModels.Event, lc logger.LoggingClient) {
+	correlation := clients.FromContext(ctx, CorrelationHeader)
+	if err := queue.Enqueue(clients.FromContext(ctx, clients.ContentType), event.EncodedEvent); err != nil {
+		lc.Error("SendEvent Failed to queue event", "device", event.Device, clients.CorrelationHeader, correlation, "error", err)
+	} else {
+		lc.Debug("SendEvent: Queued event to forward to core data", "depth", queue.Depth(), clients.CorrelationHeader, correlation)
+	}
+}
+
+// ForwardEvent posts the encoded event of the content type queued by SendEvent to core data.
+// The events core data rejects with a 4xx status, other than a timeout or throttling, are
+// returned as a storeforward.RejectedError.
+func ForwardEvent(ec coredata.EventClient, contentType string, data []byte) error {
+	ctx := context.WithValue(context.Background(), CorrelationHeader, uuid.New().String())
+	ctx = context.WithValue(ctx, clients.ContentType, contentType)
+	_, err := ec.AddBytes(ctx, data)
+	metrics.EventPublished(metrics.DestinationCoreData, err)
+	if errsc, ok := err.(types.ErrServiceClient); ok && eventRejected(errsc.StatusCode) {
+		return storeforward.RejectedError{Err: err}
+	}
+	return err
+}
+
+func eventRejected(statusCode int) bool {
+	return statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError &&
+		statusCode != http.StatusRequestTimeout && statusCode != http.StatusTooManyRequests
+}
+
 func CompareCoreCommands(a []contract.Command, b []contract.Command) bool {
 	if len(a) != len(b) {
 		return false
@@ -291,6 +418,28 @@ func FilterQueryParams(queryParams string, lc logger.LoggingClient) url.Values {
 	return m
 }
 
+// DriverProtocols returns the protocols of a Device without those reserved by the SDK, whose
+// name starts with SDKReservedPrefix, as the ProtocolDriver doesn't know them.
+func DriverProtocols(protocols map[string]contract.ProtocolProperties) map[string]contract.ProtocolProperties {
+	reserved := false
+	for name := range protocols {
+		if strings.HasPrefix(name, SDKReservedPrefix) {
+			reserved = true
+			break
+		}
+	}
+	if !reserved {
+		return protocols
+	}
+	filtered := make(map[string]contract.ProtocolProperties, len(protocols))
+	for name, properties := range protocols {
+		if !strings.HasPrefix(name, SDKReservedPrefix) {
+			filtered[name] = properties
+		}
+	}
+	return filtered
+}
+
 func UpdateLastConnected(name string, configuration *ConfigurationStruct, lc logger.LoggingClient, dc metadata.DeviceClient) {
 	if !configuration.Device.UpdateLastConnected {
 		lc.Debug("Update of last connected times is disabled for: " + name)
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
 
 func TestBuildAddr(t *testing.T) {
@@ -115,3 +116,18 @@ func TestFilterQueryParams(t *testing.T) {
 		}
 	}
 }
+
+func TestDriverProtocols(t *testing.T) {
+	protocols := map[string]contract.ProtocolProperties{
+		"modbus-tcp":                          {"Address": "host"},
+		SDKReservedPrefix + "resourceSets":    {"all": "a,b"},
+		"in-" + SDKReservedPrefix + "name-in": {"key": "value"},
+	}
+	actual := DriverProtocols(protocols)
+	if len(actual) != 2 || actual["modbus-tcp"]["Address"] != "host" || actual["in-"+SDKReservedPrefix+"name-in"] == nil {
+		t.Errorf("Protocols with ds- prefix should be filtered out, got %v", actual)
+	}
+	if len(protocols) != 3 {
+		t.Errorf("The protocols of the Device should be left unchanged.")
+	}
+}
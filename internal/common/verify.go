@@ -0,0 +1,114 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// VerifyWriteAttribute is the deviceResource attribute which enables reading the
+	// resource back after a successful write to confirm the device applied the value.
+	VerifyWriteAttribute = SDKReservedPrefix + "verifyWrite"
+	// VerifyToleranceAttribute is the deviceResource attribute which specifies the
+	// absolute difference allowed between the written and read back numeric value.
+	VerifyToleranceAttribute = SDKReservedPrefix + "verifyTolerance"
+)
+
+// VerifyWrite reads back every resource of the write request that has VerifyWriteAttribute
+// enabled and returns an error if the device reports a different value than was written.
+// The reqs and cvs slices are expected to be index aligned, as passed to HandleWriteCommands.
+func VerifyWrite(driver dsModels.ProtocolDriver, device *contract.Device, reqs []dsModels.CommandRequest, cvs []*dsModels.CommandValue) error {
+	var readReqs []dsModels.CommandRequest
+	expected := make(map[string]*dsModels.CommandValue)
+	tolerances := make(map[string]float64)
+	for i, req := range reqs {
+		enabled, err := strconv.ParseBool(req.Attributes[VerifyWriteAttribute])
+		if err != nil || !enabled {
+			continue
+		}
+		tolerance := 0.0
+		if t, ok := req.Attributes[VerifyToleranceAttribute]; ok {
+			tolerance, err = strconv.ParseFloat(t, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s attribute of deviceResource %s: %v", VerifyToleranceAttribute, req.DeviceResourceName, err)
+			}
+		}
+		readReqs = append(readReqs, req)
+		expected[req.DeviceResourceName] = cvs[i]
+		tolerances[req.DeviceResourceName] = tolerance
+	}
+	if len(readReqs) == 0 {
+		return nil
+	}
+
+	results, err := driver.HandleReadCommands(device.Name, device.Protocols, readReqs)
+	if err != nil {
+		return fmt.Errorf("failed to read back written values for %s: %v", device.Name, err)
+	}
+
+	for _, actual := range results {
+		if actual == nil {
+			continue
+		}
+		written, ok := expected[actual.DeviceResourceName]
+		if !ok {
+			continue
+		}
+		if !CompareCommandValues(written, actual, tolerances[actual.DeviceResourceName]) {
+			return fmt.Errorf("write verification failed for deviceResource %s of %s: wrote %s, read back %s",
+				actual.DeviceResourceName, device.Name, written.ValueToString(models.ENotation), actual.ValueToString(models.ENotation))
+		}
+		delete(expected, actual.DeviceResourceName)
+	}
+	for name := range expected {
+		return fmt.Errorf("write verification failed for deviceResource %s of %s: no value read back", name, device.Name)
+	}
+
+	return nil
+}
+
+// CompareCommandValues reports whether two CommandValues hold the same value. Numeric
+// values are considered equal when they differ by no more than tolerance.
+func CompareCommandValues(expected *dsModels.CommandValue, actual *dsModels.CommandValue, tolerance float64) bool {
+	if expected.Type != actual.Type {
+		return false
+	}
+
+	switch expected.Type {
+	case v2.ValueTypeBinary:
+		return bytes.Equal(expected.BinValue, actual.BinValue)
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64,
+		v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64,
+		v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+		e := expected.ValueToString(models.ENotation)
+		a := actual.ValueToString(models.ENotation)
+		if e == a {
+			return true
+		}
+		ef, err := strconv.ParseFloat(e, 64)
+		if err != nil {
+			return false
+		}
+		af, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return false
+		}
+		return math.Abs(ef-af) <= tolerance
+	default:
+		return expected.ValueToString() == actual.ValueToString()
+	}
+}
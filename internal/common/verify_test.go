@@ -0,0 +1,52 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestCompareCommandValues(t *testing.T) {
+	value := func(cv *dsModels.CommandValue, err error) *dsModels.CommandValue {
+		require.NoError(t, err)
+		return cv
+	}
+	tests := []struct {
+		name      string
+		expected  *dsModels.CommandValue
+		actual    *dsModels.CommandValue
+		tolerance float64
+		equal     bool
+	}{
+		{"same integer", value(dsModels.NewInt32Value("r", 0, 42)), value(dsModels.NewInt32Value("r", 0, 42)), 0, true},
+		{"different integer", value(dsModels.NewInt32Value("r", 0, 42)), value(dsModels.NewInt32Value("r", 0, 43)), 0, false},
+		{"integer within tolerance", value(dsModels.NewUint16Value("r", 0, 42)), value(dsModels.NewUint16Value("r", 0, 43)), 1, true},
+		{"large integers", value(dsModels.NewUint64Value("r", 0, math.MaxUint64)), value(dsModels.NewUint64Value("r", 0, math.MaxUint64)), 0, true},
+		{"same float", value(dsModels.NewFloat64Value("r", 0, 21.5)), value(dsModels.NewFloat64Value("r", 0, 21.5)), 0, true},
+		{"float within tolerance", value(dsModels.NewFloat32Value("r", 0, 21.5)), value(dsModels.NewFloat32Value("r", 0, 21.55)), 0.1, true},
+		{"float beyond tolerance", value(dsModels.NewFloat64Value("r", 0, 21.5)), value(dsModels.NewFloat64Value("r", 0, 21.7)), 0.1, false},
+		{"different types", value(dsModels.NewInt32Value("r", 0, 42)), value(dsModels.NewInt64Value("r", 0, 42)), 0, false},
+		{"same bool", value(dsModels.NewBoolValue("r", 0, true)), value(dsModels.NewBoolValue("r", 0, true)), 0, true},
+		{"different bool", value(dsModels.NewBoolValue("r", 0, true)), value(dsModels.NewBoolValue("r", 0, false)), 1, false},
+		{"same string", dsModels.NewStringValue("r", 0, "on"), dsModels.NewStringValue("r", 0, "on"), 0, true},
+		{"different string", dsModels.NewStringValue("r", 0, "on"), dsModels.NewStringValue("r", 0, "off"), 1, false},
+		{"same binary", value(dsModels.NewBinaryValue("r", 0, []byte{1, 2})), value(dsModels.NewBinaryValue("r", 0, []byte{1, 2})), 0, true},
+		{"different binary", value(dsModels.NewBinaryValue("r", 0, []byte{1, 2})), value(dsModels.NewBinaryValue("r", 0, []byte{1, 3})), 0, false},
+		{"origin ignored", value(dsModels.NewInt8Value("r", 1, 42)), value(dsModels.NewInt8Value("r", 2, 42)), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, CompareCommandValues(tt.expected, tt.actual, tt.tolerance))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	writableDriverHandler      func(raw map[string]string)
+	writableDriverHandlerMutex sync.RWMutex
+)
+
+// SetWritableDriverHandler registers the function called with the Writable.Driver section
+// when it changes in the Configuration Provider.
+func SetWritableDriverHandler(handler func(raw map[string]string)) {
+	writableDriverHandlerMutex.Lock()
+	defer writableDriverHandlerMutex.Unlock()
+	writableDriverHandler = handler
+}
+
+// notifyWritableDriver calls the registered function, if any, with a copy of the
+// Writable.Driver section, which the configuration keeps for itself.
+func notifyWritableDriver(raw map[string]string) {
+	writableDriverHandlerMutex.RLock()
+	handler := writableDriverHandler
+	writableDriverHandlerMutex.RUnlock()
+	if handler == nil {
+		return
+	}
+	section := make(map[string]string, len(raw))
+	for key, value := range raw {
+		section[key] = value
+	}
+	handler(section)
+}
+
+// writableDriverChanged reports whether the Writable.Driver section changed, an empty
+// section being the same as a missing one.
+func writableDriverChanged(previous map[string]string, current map[string]string) bool {
+	if len(previous) == 0 && len(current) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(previous, current)
+}
@@ -0,0 +1,50 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateWritableFromRawNotifiesDriverChanges(t *testing.T) {
+	var notified []map[string]string
+	SetWritableDriverHandler(func(raw map[string]string) {
+		notified = append(notified, raw)
+	})
+	defer SetWritableDriverHandler(nil)
+
+	config := &ConfigurationStruct{}
+	require.True(t, config.UpdateWritableFromRaw(&WritableInfo{LogLevel: "DEBUG", Driver: map[string]string{}}))
+	assert.Empty(t, notified, "an empty section is the same as a missing one")
+
+	require.True(t, config.UpdateWritableFromRaw(&WritableInfo{Driver: map[string]string{"PollRate": "1s"}}))
+	require.True(t, config.UpdateWritableFromRaw(&WritableInfo{LogLevel: "INFO", Driver: map[string]string{"PollRate": "1s"}}))
+	require.True(t, config.UpdateWritableFromRaw(&WritableInfo{Driver: map[string]string{"PollRate": "5s"}}))
+
+	assert.Equal(t, []map[string]string{{"PollRate": "1s"}, {"PollRate": "5s"}}, notified)
+	assert.False(t, config.UpdateWritableFromRaw(&ServiceInfo{}))
+
+	notified[1]["PollRate"] = "changed by the driver"
+	assert.Equal(t, "5s", config.Writable.Driver["PollRate"], "the driver gets a copy of the section")
+}
+
+func TestUpdateWritableFromRawFrozen(t *testing.T) {
+	frozen := true
+	SetWritableFrozen(func() bool { return frozen })
+	defer SetWritableFrozen(nil)
+
+	config := &ConfigurationStruct{Writable: WritableInfo{LogLevel: "INFO"}}
+	require.True(t, config.UpdateWritableFromRaw(&WritableInfo{}), "the bootstrap keeps listening")
+	assert.Equal(t, "INFO", config.Writable.LogLevel, "the last-known section is kept")
+
+	frozen = false
+	require.True(t, config.UpdateWritableFromRaw(&WritableInfo{LogLevel: "DEBUG"}))
+	assert.Equal(t, "DEBUG", config.Writable.LogLevel)
+}
@@ -0,0 +1,41 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// RedactedValue replaces the values written to the write-only deviceResources in the logs
+// when Device.RedactWriteOnly is enabled.
+const RedactedValue = "<redacted>"
+
+var redactWriteOnly bool
+
+// SetRedactWriteOnly applies Device.RedactWriteOnly to the write requests handled afterwards.
+func SetRedactWriteOnly(enabled bool) {
+	redactWriteOnly = enabled
+}
+
+// WrittenValue returns the value written to the deviceResource as it is to be logged.
+func WrittenValue(dr contract.DeviceResource, value string) string {
+	if redactWriteOnly && dr.Properties.Value.ReadWrite == DeviceResourceWriteOnly {
+		return RedactedValue
+	}
+	return value
+}
+
+// WriteParams returns the body of a write request as it is to be logged. The whole body is
+// redacted if any of the deviceResources it may write is write-only.
+func WriteParams(params string, drs ...contract.DeviceResource) string {
+	for _, dr := range drs {
+		if WrittenValue(dr, params) == RedactedValue {
+			return RedactedValue
+		}
+	}
+	return params
+}
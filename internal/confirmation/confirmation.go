@@ -0,0 +1,179 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package confirmation tracks write commands whose outcome is acknowledged
+// asynchronously by the device, either by a value pushed on the async values
+// channel or by an explicit confirmation from the ProtocolDriver.
+package confirmation
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// ConfirmWriteAttribute is the deviceResource attribute which makes a write command
+	// wait for the device to confirm the written value asynchronously.
+	ConfirmWriteAttribute = common.SDKReservedPrefix + "confirmWrite"
+	// DefaultTimeout is used when Device.WriteConfirmTimeout is not configured.
+	DefaultTimeout = 5 * time.Second
+)
+
+var (
+	pendingWrites = make(map[string][]*Pending) // key is Device name
+	mutex         sync.Mutex
+)
+
+// Pending represents a write command awaiting confirmation from the device.
+type Pending struct {
+	deviceName string
+	expected   map[string]*dsModels.CommandValue // key is DeviceResource name
+	tolerances map[string]float64
+	done       chan error
+}
+
+// Expect registers the resources of the write request that have ConfirmWriteAttribute
+// enabled and returns the Pending confirmation, or nil if no resource requires one.
+// It should be called before the values are handed to the driver so that a
+// confirmation arriving during HandleWriteCommands is not missed. No confirmation is
+// awaited if the ProtocolDriver doesn't support write confirmation.
+func Expect(deviceName string, reqs []dsModels.CommandRequest, cvs []*dsModels.CommandValue) *Pending {
+	if !common.DriverCapabilities().WriteConfirmation {
+		return nil
+	}
+
+	p := &Pending{
+		deviceName: deviceName,
+		expected:   make(map[string]*dsModels.CommandValue),
+		tolerances: make(map[string]float64),
+		done:       make(chan error, 1),
+	}
+	for i, req := range reqs {
+		enabled, err := strconv.ParseBool(req.Attributes[ConfirmWriteAttribute])
+		if err != nil || !enabled {
+			continue
+		}
+		tolerance, _ := strconv.ParseFloat(req.Attributes[common.VerifyToleranceAttribute], 64)
+		p.expected[req.DeviceResourceName] = cvs[i]
+		p.tolerances[req.DeviceResourceName] = tolerance
+	}
+	if len(p.expected) == 0 {
+		return nil
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	pendingWrites[deviceName] = append(pendingWrites[deviceName], p)
+	return p
+}
+
+// Wait blocks until every expected resource is confirmed, the driver reports a failure
+// or the timeout expires.
+func (p *Pending) Wait(timeout time.Duration) error {
+	select {
+	case err := <-p.done:
+		return err
+	case <-time.After(timeout):
+		mutex.Lock()
+		defer mutex.Unlock()
+		p.remove()
+		// the confirmation may have completed while acquiring the lock
+		select {
+		case err := <-p.done:
+			return err
+		default:
+		}
+		return fmt.Errorf("write to %s not confirmed within %v", p.deviceName, timeout)
+	}
+}
+
+// Cancel discards the Pending confirmation, e.g. when the write itself failed.
+func (p *Pending) Cancel() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	p.remove()
+}
+
+// Match resolves pending writes of the device whose expected value equals one of
+// the CommandValues pushed by the driver.
+func Match(deviceName string, cvs []*dsModels.CommandValue) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		for _, p := range pendingWrites[deviceName] {
+			expected, ok := p.expected[cv.DeviceResourceName]
+			if !ok || !common.CompareCommandValues(expected, cv, p.tolerances[cv.DeviceResourceName]) {
+				continue
+			}
+			delete(p.expected, cv.DeviceResourceName)
+			if len(p.expected) == 0 {
+				p.complete(nil)
+			}
+			break
+		}
+	}
+}
+
+// Confirm resolves the oldest pending write of the given device resource. A non-nil
+// err fails the whole write command. It returns false if no write is awaiting
+// confirmation for the resource.
+func Confirm(deviceName string, resourceName string, err error) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, p := range pendingWrites[deviceName] {
+		if _, ok := p.expected[resourceName]; !ok {
+			continue
+		}
+		if err != nil {
+			p.complete(fmt.Errorf("write to deviceResource %s of %s failed: %v", resourceName, deviceName, err))
+			return true
+		}
+		delete(p.expected, resourceName)
+		if len(p.expected) == 0 {
+			p.complete(nil)
+		}
+		return true
+	}
+	return false
+}
+
+// ParseTimeout returns the configured confirmation timeout, falling back to DefaultTimeout.
+func ParseTimeout(timeout string) time.Duration {
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		return DefaultTimeout
+	}
+	return d
+}
+
+// complete and remove must be called with the mutex held.
+func (p *Pending) complete(err error) {
+	p.remove()
+	p.done <- err
+}
+
+func (p *Pending) remove() {
+	pending := pendingWrites[p.deviceName]
+	for i, e := range pending {
+		if e == p {
+			pendingWrites[p.deviceName] = append(pending[:i], pending[i+1:]...)
+			break
+		}
+	}
+	if len(pendingWrites[p.deviceName]) == 0 {
+		delete(pendingWrites, p.deviceName)
+	}
+}
@@ -0,0 +1,92 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package confirmation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	testDevice   = "testDevice"
+	testResource = "testResource"
+)
+
+func newTestWrite(t *testing.T, confirm string) ([]dsModels.CommandRequest, []*dsModels.CommandValue) {
+	cv, err := dsModels.NewInt32Value(testResource, 0, 10)
+	require.NoError(t, err)
+	reqs := []dsModels.CommandRequest{{
+		DeviceResourceName: testResource,
+		Attributes:         map[string]string{ConfirmWriteAttribute: confirm},
+		Type:               cv.Type,
+	}}
+	return reqs, []*dsModels.CommandValue{cv}
+}
+
+func TestExpectNotRequired(t *testing.T) {
+	reqs, cvs := newTestWrite(t, "false")
+	assert.Nil(t, Expect(testDevice, reqs, cvs))
+}
+
+func TestMatch(t *testing.T) {
+	reqs, cvs := newTestWrite(t, "true")
+	pending := Expect(testDevice, reqs, cvs)
+	require.NotNil(t, pending)
+
+	other, err := dsModels.NewInt32Value(testResource, 0, 11)
+	require.NoError(t, err)
+	same, err := dsModels.NewInt32Value(testResource, 0, 10)
+	require.NoError(t, err)
+
+	Match(testDevice, []*dsModels.CommandValue{other})
+	select {
+	case <-pending.done:
+		t.Fatal("write should not be confirmed by a different value")
+	default:
+	}
+
+	Match(testDevice, []*dsModels.CommandValue{same})
+	assert.NoError(t, pending.Wait(time.Second))
+	assert.Empty(t, pendingWrites)
+}
+
+func TestConfirm(t *testing.T) {
+	reqs, cvs := newTestWrite(t, "true")
+
+	pending := Expect(testDevice, reqs, cvs)
+	require.NotNil(t, pending)
+	assert.True(t, Confirm(testDevice, testResource, nil))
+	assert.NoError(t, pending.Wait(time.Second))
+
+	pending = Expect(testDevice, reqs, cvs)
+	require.NotNil(t, pending)
+	assert.True(t, Confirm(testDevice, testResource, errors.New("rejected")))
+	assert.Error(t, pending.Wait(time.Second))
+
+	assert.False(t, Confirm(testDevice, testResource, nil), "no write should be pending")
+}
+
+func TestWaitTimeout(t *testing.T) {
+	reqs, cvs := newTestWrite(t, "true")
+	pending := Expect(testDevice, reqs, cvs)
+	require.NotNil(t, pending)
+
+	assert.Error(t, pending.Wait(10*time.Millisecond))
+	assert.Empty(t, pendingWrites)
+}
+
+func TestParseTimeout(t *testing.T) {
+	assert.Equal(t, DefaultTimeout, ParseTimeout(""))
+	assert.Equal(t, DefaultTimeout, ParseTimeout("invalid"))
+	assert.Equal(t, 2*time.Second, ParseTimeout("2s"))
+}
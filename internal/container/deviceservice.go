@@ -21,6 +21,10 @@ var ProtocolDiscoveryName = di.TypeInstanceToName((*models.ProtocolDiscovery)(ni
 // ProtocolDriverName contains the name of protocol driver implementation in the DIC.
 var ProtocolDriverName = di.TypeInstanceToName((*models.ProtocolDriver)(nil))
 
+// RawProtocolDriverName contains the name of the protocol driver implementation as passed
+// to the Device Service, without the wrappers executing the commands, in the DIC.
+var RawProtocolDriverName = "RawProtocolDriver"
+
 // DeviceServiceFrom helper function queries the DIC and returns device service struct.
 func DeviceServiceFrom(get di.Get) *contract.DeviceService {
 	return get(DeviceServiceName).(*contract.DeviceService)
@@ -39,3 +43,14 @@ func ProtocolDiscoveryFrom(get di.Get) models.ProtocolDiscovery {
 func ProtocolDriverFrom(get di.Get) models.ProtocolDriver {
 	return get(ProtocolDriverName).(models.ProtocolDriver)
 }
+
+// RawProtocolDriverFrom helper function queries the DIC and returns the protocol driver
+// implementation as passed to the Device Service, on which its optional interfaces are
+// checked and its non-command methods called. It falls back to the driver returned by
+// ProtocolDriverFrom if none is registered.
+func RawProtocolDriverFrom(get di.Get) models.ProtocolDriver {
+	if driver, ok := get(RawProtocolDriverName).(models.ProtocolDriver); ok {
+		return driver
+	}
+	return ProtocolDriverFrom(get)
+}
@@ -0,0 +1,25 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
+)
+
+// StoreName contains the name of the Store persisting the state of the Device Service in the DIC.
+var StoreName = di.TypeInstanceToName((*store.Store)(nil))
+
+// StoreFrom helper function queries the DIC and returns the Store persisting the state of
+// the Device Service, or nil if the state is only kept in memory.
+func StoreFrom(get di.Get) store.Store {
+	if s, ok := get(StoreName).(store.Store); ok {
+		return s
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/gorilla/mux"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/chaos"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+const (
+	// CallbackAuthNone accepts the callbacks without credentials.
+	CallbackAuthNone = "none"
+	// CallbackAuthSecret requires the shared secret in the configured header.
+	CallbackAuthSecret = "secret"
+	// CallbackAuthJWT requires a bearer JWT signed with HS256 using the shared secret.
+	CallbackAuthJWT = "jwt"
+
+	// CallbackSecretKey is the key of the shared secret in the secret path.
+	CallbackSecretKey = "callbackSecret"
+
+	defaultCallbackHeader = "X-Callback-Secret"
+	bearerPrefix          = "Bearer "
+)
+
+// ValidateCallbackAuth returns an error if Service.CallbackAuth is invalid.
+func ValidateCallbackAuth(info sdkCommon.CallbackAuthInfo) error {
+	switch info.Method {
+	case "", CallbackAuthNone:
+	case CallbackAuthSecret, CallbackAuthJWT:
+		if info.SecretPath == "" {
+			return fmt.Errorf("Service.CallbackAuth.SecretPath is required by the %s method", info.Method)
+		}
+	default:
+		return fmt.Errorf("invalid Service.CallbackAuth.Method %s", info.Method)
+	}
+	_, err := parseSources(info.AllowedSources)
+	return err
+}
+
+// addCallbackRoute registers the reserved route of a callback from Core Metadata, which
+// is served only once the request is authenticated according to Service.CallbackAuth.
+func (c *RestController) addCallbackRoute(route string, handler func(http.ResponseWriter, *http.Request)) *mux.Route {
+	return c.addReservedRoute(route, func(w http.ResponseWriter, r *http.Request) {
+		if !c.clientVerified(r) {
+			c.sendBodyError(w, r, "callback rejected: no verified client certificate", http.StatusUnauthorized)
+			return
+		}
+		if err := c.authenticateCallback(r); err != nil {
+			c.sendBodyError(w, r, fmt.Sprintf("callback rejected: %v", err), http.StatusUnauthorized)
+			return
+		}
+		// apply the fault injected for resilience testing, if any
+		switch err := chaos.Inject(chaos.PointCallback); err {
+		case nil:
+		case chaos.ErrDropped:
+			c.LoggingClient.Debug(fmt.Sprintf("callback %s %s dropped by failure injection", r.Method, r.URL.Path))
+			w.WriteHeader(http.StatusOK)
+			return
+		default:
+			c.sendBodyError(w, r, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+func (c *RestController) authenticateCallback(r *http.Request) error {
+	info := container.ConfigurationFrom(c.dic.Get).Service.CallbackAuth
+
+	if len(info.AllowedSources) > 0 {
+		sources, err := parseSources(info.AllowedSources)
+		if err != nil {
+			return err
+		}
+		if !sourceAllowed(r.RemoteAddr, sources) {
+			return fmt.Errorf("source %s not allowed", r.RemoteAddr)
+		}
+	}
+
+	switch info.Method {
+	case "", CallbackAuthNone:
+		return nil
+	case CallbackAuthSecret:
+		secret, err := c.callbackSecret(info.SecretPath)
+		if err != nil {
+			return err
+		}
+		header := info.Header
+		if header == "" {
+			header = defaultCallbackHeader
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(header)), secret) != 1 {
+			return errors.New("invalid shared secret")
+		}
+		return nil
+	case CallbackAuthJWT:
+		secret, err := c.callbackSecret(info.SecretPath)
+		if err != nil {
+			return err
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, bearerPrefix) {
+			return errors.New("missing bearer token")
+		}
+		return verifyJWT(strings.TrimPrefix(auth, bearerPrefix), secret, time.Now())
+	default:
+		return fmt.Errorf("invalid Service.CallbackAuth.Method %s", info.Method)
+	}
+}
+
+func (c *RestController) callbackSecret(path string) ([]byte, error) {
+	provider := bootstrapContainer.SecretProviderFrom(c.dic.Get)
+	if provider == nil {
+		return nil, errors.New("no Secret Provider")
+	}
+	secrets, err := provider.GetSecrets(path, CallbackSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the shared secret: %v", err)
+	}
+	secret := secrets[CallbackSecretKey]
+	if secret == "" {
+		return nil, errors.New("empty shared secret")
+	}
+	return []byte(secret), nil
+}
+
+func parseSources(sources []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(sources))
+	for _, source := range sources {
+		if !strings.Contains(source, "/") {
+			ip := net.ParseIP(source)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid Service.CallbackAuth.AllowedSources address %s", source)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Service.CallbackAuth.AllowedSources range %s: %v", source, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// sourceAllowed reports whether the remote address of the request is within the networks.
+// Forwarding headers are ignored as they are set by the client.
+func sourceAllowed(remoteAddr string, networks []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyJWT verifies the HS256 signature of the token and, if present, its exp and nbf claims.
+func verifyJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return fmt.Errorf("unsupported token algorithm %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errors.New("malformed token signature")
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errors.New("invalid token signature")
+	}
+
+	var claims struct {
+		Exp *int64 `json:"exp"`
+		Nbf *int64 `json:"nbf"`
+	}
+	if err = decodeJWTPart(parts[1], &claims); err != nil {
+		return err
+	}
+	if claims.Exp != nil && now.Unix() >= *claims.Exp {
+		return errors.New("token expired")
+	}
+	if claims.Nbf != nil && now.Unix() < *claims.Nbf {
+		return errors.New("token not yet valid")
+	}
+	return nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.New("malformed token")
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return errors.New("malformed token")
+	}
+	return nil
+}
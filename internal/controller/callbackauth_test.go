@@ -0,0 +1,128 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+const testCallbackSecret = "s3cret"
+
+func signJWT(claims string, secret string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidateCallbackAuth(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Info          common.CallbackAuthInfo
+		ErrorExpected bool
+	}{
+		{"Valid - default", common.CallbackAuthInfo{}, false},
+		{"Valid - secret", common.CallbackAuthInfo{Method: CallbackAuthSecret, SecretPath: "callback"}, false},
+		{"Valid - sources", common.CallbackAuthInfo{AllowedSources: []string{"10.0.0.1", "192.168.0.0/16", "::1"}}, false},
+		{"Invalid - unknown method", common.CallbackAuthInfo{Method: "basic"}, true},
+		{"Invalid - jwt without secret path", common.CallbackAuthInfo{Method: CallbackAuthJWT}, true},
+		{"Invalid - source address", common.CallbackAuthInfo{AllowedSources: []string{"10.0.0"}}, true},
+		{"Invalid - source range", common.CallbackAuthInfo{AllowedSources: []string{"10.0.0.0/33"}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := ValidateCallbackAuth(test.Info)
+			if test.ErrorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCallbackRouteAuthentication(t *testing.T) {
+	validToken := signJWT(fmt.Sprintf(`{"sub":"core-metadata","exp":%d}`, time.Now().Add(time.Minute).Unix()), testCallbackSecret)
+	expiredToken := signJWT(fmt.Sprintf(`{"sub":"core-metadata","exp":%d}`, time.Now().Add(-time.Minute).Unix()), testCallbackSecret)
+	wrongToken := signJWT(`{"sub":"core-metadata"}`, "other")
+
+	tests := []struct {
+		Name               string
+		Info               common.CallbackAuthInfo
+		RemoteAddr         string
+		Header             string
+		Value              string
+		ExpectedStatusCode int
+	}{
+		{"Valid - no authentication", common.CallbackAuthInfo{}, "10.0.0.1:1234", "", "", http.StatusOK},
+		{"Valid - allowed source", common.CallbackAuthInfo{AllowedSources: []string{"10.0.0.0/24"}}, "10.0.0.1:1234", "", "", http.StatusOK},
+		{"Invalid - source not allowed", common.CallbackAuthInfo{AllowedSources: []string{"10.0.0.0/24"}}, "10.0.1.1:1234", "", "", http.StatusUnauthorized},
+		{"Valid - shared secret", common.CallbackAuthInfo{Method: CallbackAuthSecret, SecretPath: "callback"}, "10.0.0.1:1234", defaultCallbackHeader, testCallbackSecret, http.StatusOK},
+		{"Valid - shared secret in custom header", common.CallbackAuthInfo{Method: CallbackAuthSecret, SecretPath: "callback", Header: "X-Token"}, "10.0.0.1:1234", "X-Token", testCallbackSecret, http.StatusOK},
+		{"Invalid - wrong shared secret", common.CallbackAuthInfo{Method: CallbackAuthSecret, SecretPath: "callback"}, "10.0.0.1:1234", defaultCallbackHeader, "wrong", http.StatusUnauthorized},
+		{"Invalid - missing shared secret", common.CallbackAuthInfo{Method: CallbackAuthSecret, SecretPath: "callback"}, "10.0.0.1:1234", "", "", http.StatusUnauthorized},
+		{"Valid - jwt", common.CallbackAuthInfo{Method: CallbackAuthJWT, SecretPath: "callback"}, "10.0.0.1:1234", "Authorization", bearerPrefix + validToken, http.StatusOK},
+		{"Invalid - expired jwt", common.CallbackAuthInfo{Method: CallbackAuthJWT, SecretPath: "callback"}, "10.0.0.1:1234", "Authorization", bearerPrefix + expiredToken, http.StatusUnauthorized},
+		{"Invalid - jwt signed with another secret", common.CallbackAuthInfo{Method: CallbackAuthJWT, SecretPath: "callback"}, "10.0.0.1:1234", "Authorization", bearerPrefix + wrongToken, http.StatusUnauthorized},
+		{"Invalid - malformed jwt", common.CallbackAuthInfo{Method: CallbackAuthJWT, SecretPath: "callback"}, "10.0.0.1:1234", "Authorization", bearerPrefix + "abc", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			config := &common.ConfigurationStruct{}
+			config.Service.CallbackAuth = test.Info
+			mockProvider := &mocks.SecretProvider{}
+			mockProvider.On("GetSecrets", "callback", CallbackSecretKey).Return(map[string]string{CallbackSecretKey: testCallbackSecret}, nil)
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return config
+				},
+				bootstrapContainer.SecretProviderName: func(get di.Get) interface{} {
+					return mockProvider
+				},
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return logger.NewMockClient()
+				},
+			})
+
+			controller := NewRestController(mux.NewRouter(), dic)
+			controller.addCallbackRoute(contractsV2.ApiDeviceCallbackNameRoute, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v2/callback/device/name/Simple-Device01", nil)
+			req.RemoteAddr = test.RemoteAddr
+			if test.Header != "" {
+				req.Header.Set(test.Header, test.Value)
+			}
+			recorder := httptest.NewRecorder()
+			controller.Router().ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.ExpectedStatusCode, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+// validateRequestBody enforces Service.MaxRequestSize and Service.StrictContentType on the
+// requests carrying a body, so that oversized or non-JSON payloads are rejected before
+// they reach the handler.
+func (c *RestController) validateRequestBody(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut && r.Method != http.MethodPatch {
+		return true
+	}
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return true
+	}
+
+	config := container.ConfigurationFrom(c.dic.Get)
+
+	if config.Service.StrictContentType {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get(clients.ContentType))
+		if err != nil || mediaType != clients.ContentTypeJSON {
+			msg := fmt.Sprintf("unsupported Content-Type '%s', expected '%s'", r.Header.Get(clients.ContentType), clients.ContentTypeJSON)
+			c.sendBodyError(w, r, msg, http.StatusUnsupportedMediaType)
+			return false
+		}
+	}
+
+	maxBytes := config.Service.MaxRequestSize * 1024
+	if maxBytes <= 0 {
+		return true
+	}
+	if r.ContentLength > maxBytes {
+		msg := fmt.Sprintf("request body size %d exceeds the limit of %d bytes", r.ContentLength, maxBytes)
+		c.sendBodyError(w, r, msg, http.StatusRequestEntityTooLarge)
+		return false
+	}
+
+	// ContentLength may be unknown (chunked encoding) or understated, so the body is
+	// read up to one byte past the limit to detect an overflow before handing it on.
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	_ = r.Body.Close()
+	if err != nil {
+		c.sendBodyError(w, r, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return false
+	}
+	if int64(len(body)) > maxBytes {
+		msg := fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytes)
+		c.sendBodyError(w, r, msg, http.StatusRequestEntityTooLarge)
+		return false
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return true
+}
+
+func (c *RestController) sendBodyError(w http.ResponseWriter, r *http.Request, msg string, statusCode int) {
+	correlationID := r.Header.Get(sdkCommon.CorrelationHeader)
+	c.LoggingClient.Error(msg, sdkCommon.CorrelationHeader, correlationID, "path", r.URL.Path)
+
+	if !strings.HasPrefix(r.URL.Path, contractsV2.ApiBase) {
+		http.Error(w, msg, statusCode)
+		return
+	}
+
+	w.Header().Set(sdkCommon.CorrelationHeader, correlationID)
+	w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(common.NewBaseResponse("", msg, statusCode))
+}
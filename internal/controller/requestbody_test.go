@@ -0,0 +1,93 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+func TestValidateRequestBody(t *testing.T) {
+	largeBody := strings.Repeat("a", 2048)
+
+	tests := []struct {
+		name           string
+		method         string
+		contentType    string
+		body           string
+		maxSize        int64
+		strict         bool
+		expectedStatus int
+	}{
+		{"valid - GET is not checked", http.MethodGet, "", "", 1, true, http.StatusOK},
+		{"valid - body within limit", http.MethodPut, clients.ContentTypeJSON, `{"a":1}`, 1, true, http.StatusOK},
+		{"valid - no limit", http.MethodPut, clients.ContentTypeJSON, largeBody, 0, false, http.StatusOK},
+		{"valid - content type with charset", http.MethodPost, "application/json; charset=utf-8", `{}`, 1, true, http.StatusOK},
+		{"valid - content type not enforced", http.MethodPost, "text/plain", `{}`, 1, false, http.StatusOK},
+		{"valid - empty body", http.MethodPost, "", "", 1, true, http.StatusOK},
+		{"invalid - body too large", http.MethodPut, clients.ContentTypeJSON, largeBody, 1, false, http.StatusRequestEntityTooLarge},
+		{"invalid - unsupported content type", http.MethodPost, "text/plain", `{}`, 1, true, http.StatusUnsupportedMediaType},
+		{"invalid - missing content type", http.MethodPost, "", `{}`, 1, true, http.StatusUnsupportedMediaType},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := &common.ConfigurationStruct{
+				Service: common.ServiceInfo{
+					MaxRequestSize:    testCase.maxSize,
+					StrictContentType: testCase.strict,
+				},
+			}
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return logger.NewMockClient()
+				},
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return config
+				},
+			})
+
+			var received string
+			controller := NewRestController(mux.NewRouter(), dic)
+			controller.addReservedRoute(contractsV2.ApiDeviceCallbackRoute, func(w http.ResponseWriter, r *http.Request) {
+				data, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				received = string(data)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(testCase.method, contractsV2.ApiDeviceCallbackRoute, strings.NewReader(testCase.body))
+			if testCase.contentType != "" {
+				req.Header.Set(clients.ContentType, testCase.contentType)
+			}
+			recorder := httptest.NewRecorder()
+			controller.router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+			if testCase.expectedStatus == http.StatusOK {
+				assert.Equal(t, testCase.body, received, "handler should receive the original body")
+			} else {
+				assert.Equal(t, clients.ContentTypeJSON, recorder.Header().Get(clients.ContentType))
+			}
+		})
+	}
+}
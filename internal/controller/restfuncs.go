@@ -13,12 +13,17 @@ import (
 	"io/ioutil"
 	"net/http"
 	"runtime"
+	"strings"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/autodiscovery"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/handler"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/handler/callback"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
@@ -31,6 +36,7 @@ const (
 	statusNotImplemented string = "Discovery not implemented"
 	statusUnavailable    string = "Discovery disabled by configuration"
 	statusLocked         string = "OperatingState disabled"
+	statusMaintenance    string = "Service in maintenance mode"
 )
 
 type ConfigRespMap struct {
@@ -67,9 +73,13 @@ func (c *RestController) discoveryFunc(w http.ResponseWriter, req *http.Request)
 		http.Error(w, statusUnavailable, http.StatusServiceUnavailable) // status=503
 		return
 	}
+	if maintenance.ServiceMode() {
+		http.Error(w, statusMaintenance, http.StatusServiceUnavailable) // status=503
+		return
+	}
 
 	discovery := container.ProtocolDiscoveryFrom(c.dic.Get)
-	if discovery == nil {
+	if discovery == nil && !autodiscovery.HasProviders() {
 		http.Error(w, statusNotImplemented, http.StatusNotImplemented) // status=501
 		return
 	}
@@ -113,7 +123,7 @@ func (c *RestController) callbackFunc(w http.ResponseWriter, req *http.Request)
 }
 
 func (c *RestController) commandFunc(w http.ResponseWriter, req *http.Request) {
-	if c.checkServiceLocked(w, req, container.DeviceServiceFrom(c.dic.Get).AdminState) {
+	if c.checkServiceLocked(w, req, container.DeviceServiceFrom(c.dic.Get).AdminState) || c.checkMaintenance(w, req) {
 		return
 	}
 	vars := mux.Vars(req)
@@ -123,14 +133,25 @@ func (c *RestController) commandFunc(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	event, appErr := handler.CommandHandler(vars, body, req.Method, req.URL.RawQuery, c.dic)
+	event, appErr := handler.CorrelatedCommandHandler(vars, body, req.Method, req.URL.RawQuery, req.Header.Get(common.CorrelationHeader), c.dic)
+	// the values read with query parameters depend on them
+	if appErr == nil && event != nil && strings.ToLower(req.Method) == common.GetCmdMethod && req.URL.RawQuery == "" {
+		lastvalue.Record(event.Readings, lastvalue.SourceCommand)
+	}
 
 	if appErr != nil {
+		c.setRetryAfter(w, appErr.Error())
 		http.Error(w, fmt.Sprintf("%s %s", appErr.Message(), req.URL.Path), appErr.Code())
 	} else if event != nil {
+		if strings.ToLower(req.Method) == common.GetCmdMethod {
+			setCacheControl(w, event)
+		}
 		ec := container.CoredataEventClientFrom(c.dic.Get)
-		if event.HasBinaryValue() {
-			// TODO: Add conditional toggle in case caller of command does not require this response.
+		if !event.HasBinaryValue() && common.PreferCBOR(req.Header.Get(common.AcceptHeader), false) {
+			// the EncodedEvent of the events without binary readings is in JSON
+			w.Header().Set(clients.ContentType, clients.ContentTypeCBOR)
+			w.Write(event.Event.CBOR())
+		} else if common.PreferCBOR(req.Header.Get(common.AcceptHeader), event.HasBinaryValue()) {
 			// Encode response as application/CBOR.
 			if len(event.EncodedEvent) <= 0 {
 				var err error
@@ -150,13 +171,32 @@ func (c *RestController) commandFunc(w http.ResponseWriter, req *http.Request) {
 			w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
 			json.NewEncoder(w).Encode(event)
 		}
-		// push to Core Data
+		// push to Core Data once the response is written, as SendEvent filters and
+		// enriches the event in place
 		go common.SendEvent(event, c.LoggingClient, container.CoredataEventClientFrom(c.dic.Get))
 	}
 }
 
+// setCacheControl sets the Cache-Control header of the event read from the caching
+// policy of the deviceResources of its readings.
+func setCacheControl(w http.ResponseWriter, event *dsModels.Event) {
+	device, ok := cache.Devices().ForName(event.Device)
+	if !ok {
+		return
+	}
+	resources := make([]contract.DeviceResource, 0, len(event.Readings))
+	for _, r := range event.Readings {
+		if dr, ok := cache.Profiles().DeviceResource(device.Profile.Name, r.Name); ok {
+			resources = append(resources, dr)
+		}
+	}
+	if cacheControl := common.ResourcesCacheControl(resources).Header(); cacheControl != "" {
+		w.Header().Set(common.CacheControlHeader, cacheControl)
+	}
+}
+
 func (c *RestController) commandAllFunc(w http.ResponseWriter, req *http.Request) {
-	if c.checkServiceLocked(w, req, container.DeviceServiceFrom(c.dic.Get).AdminState) {
+	if c.checkServiceLocked(w, req, container.DeviceServiceFrom(c.dic.Get).AdminState) || c.checkMaintenance(w, req) {
 		return
 	}
 
@@ -170,16 +210,19 @@ func (c *RestController) commandAllFunc(w http.ResponseWriter, req *http.Request
 
 	events, appErr := handler.CommandAllHandler(vars[common.CommandVar], body, req.Method, req.URL.RawQuery, c.dic)
 	if appErr != nil {
+		c.setRetryAfter(w, appErr.Error())
 		http.Error(w, appErr.Message(), appErr.Code())
 	} else if len(events) > 0 {
+		// the events are encoded before they are pushed to Core Data, as SendEvent
+		// filters and enriches them in place
+		w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
+		json.NewEncoder(w).Encode(events)
 		// push to Core Data
 		for _, event := range events {
 			if event != nil {
 				go common.SendEvent(event, c.LoggingClient, container.CoredataEventClientFrom(c.dic.Get))
 			}
 		}
-		w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
-		json.NewEncoder(w).Encode(events)
 	}
 }
 
@@ -193,6 +236,25 @@ func (c *RestController) checkServiceLocked(w http.ResponseWriter, req *http.Req
 	return false
 }
 
+// checkMaintenance rejects the command if the Device Service is in maintenance mode.
+func (c *RestController) checkMaintenance(w http.ResponseWriter, req *http.Request) bool {
+	if maintenance.CommandRejected(req.Method) {
+		msg := fmt.Sprintf("%s; %s %s", statusMaintenance, req.Method, req.URL)
+		c.LoggingClient.Debug(msg)
+		http.Error(w, msg, http.StatusServiceUnavailable) // status=503
+		return true
+	}
+	return false
+}
+
+// setRetryAfter sets the Retry-After header of a command failed with the error as the
+// device was busy or its queue was full.
+func (c *RestController) setRetryAfter(w http.ResponseWriter, err error) {
+	if common.Throttled(err) {
+		w.Header().Set(common.RetryAfterHeader, common.RetryAfter(container.ConfigurationFrom(c.dic.Get).Writable.CommandRetryAfter))
+	}
+}
+
 func (c *RestController) readBodyAsString(w http.ResponseWriter, req *http.Request) (string, bool) {
 	defer req.Body.Close()
 	body, err := ioutil.ReadAll(req.Body)
@@ -60,6 +60,9 @@ func TestCallback(t *testing.T) {
 		container.DeviceServiceName: func(get di.Get) interface{} {
 			return ds
 		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &common.ConfigurationStruct{}
+		},
 	})
 
 	r := mux.NewRouter()
@@ -96,6 +99,9 @@ func TestCommandServiceLocked(t *testing.T) {
 		container.DeviceServiceName: func(get di.Get) interface{} {
 			return ds
 		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &common.ConfigurationStruct{}
+		},
 	})
 	r := mux.NewRouter()
 	controller := NewRestController(r, dic)
@@ -143,6 +149,9 @@ func TestCommandNoDevice(t *testing.T) {
 		container.MetadataProvisionWatcherClientName: func(get di.Get) interface{} {
 			return pwc
 		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &common.ConfigurationStruct{}
+		},
 	})
 	cache.InitCache("device-sdk-test", lc, vdc, dc, pwc)
 
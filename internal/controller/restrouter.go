@@ -15,7 +15,9 @@ import (
 	"net/http"
 
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/controller/correlation"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/tlsconfig"
 	v2 "github.com/edgexfoundry/device-sdk-go/v2/internal/v2/controller/http"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -52,17 +54,20 @@ func (c *RestController) InitRestRoutes() {
 	// Version
 	c.addReservedRoute(sdkCommon.APIVersionRoute, c.versionFunc).Methods(http.MethodGet)
 	// Command
-	c.addReservedRoute(sdkCommon.APIAllCommandRoute, c.commandAllFunc).Methods(http.MethodGet, http.MethodPut)
-	c.addReservedRoute(sdkCommon.APIIdCommandRoute, c.commandFunc).Methods(http.MethodGet, http.MethodPut)
-	c.addReservedRoute(sdkCommon.APINameCommandRoute, c.commandFunc).Methods(http.MethodGet, http.MethodPut)
+	c.addCommandRoute(sdkCommon.APIAllCommandRoute, c.commandAllFunc).Methods(http.MethodGet, http.MethodPut)
+	c.addCommandRoute(sdkCommon.APIIdCommandRoute, c.commandFunc).Methods(http.MethodGet, http.MethodPut)
+	c.addCommandRoute(sdkCommon.APINameCommandRoute, c.commandFunc).Methods(http.MethodGet, http.MethodPut)
 	// Callback
-	c.addReservedRoute(sdkCommon.APICallbackRoute, c.callbackFunc)
+	c.addCallbackRoute(sdkCommon.APICallbackRoute, c.callbackFunc)
 	// Discovery and Transform
-	c.addReservedRoute(sdkCommon.APIDiscoveryRoute, c.discoveryFunc).Methods(http.MethodPost)
+	if sdkCommon.DriverCapabilities().Discovery {
+		c.addAdminRoute(sdkCommon.APIDiscoveryRoute, c.discoveryFunc).Methods(http.MethodPost)
+	}
 	c.addReservedRoute(sdkCommon.APITransformRoute, c.transformFunc).Methods(http.MethodGet)
 	// Metric and Config
 	c.addReservedRoute(sdkCommon.APIMetricsRoute, c.metricsFunc).Methods(http.MethodGet)
 	c.addReservedRoute(sdkCommon.APIConfigRoute, c.configFunc).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIPrometheusMetricsRoute, c.v2HttpController.PrometheusMetrics).Methods(http.MethodGet)
 
 	c.InitV2RestRoutes()
 
@@ -71,6 +76,14 @@ func (c *RestController) InitRestRoutes() {
 	c.router.Use(correlation.OnRequestBegin)
 }
 
+// InitProbeRoutes registers the readiness, liveness and startup status routes, which are
+// served from the beginning of the startup, before the dependencies are available.
+func (c *RestController) InitProbeRoutes() {
+	c.addReservedRoute(sdkCommon.APIV2ReadyRoute, c.v2HttpController.Ready).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIV2LiveRoute, c.v2HttpController.Live).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIV2StatusRoute, c.v2HttpController.Status).Methods(http.MethodGet)
+}
+
 func (c *RestController) InitV2RestRoutes() {
 	c.LoggingClient.Info("Registering v2 routes...")
 
@@ -79,20 +92,65 @@ func (c *RestController) InitV2RestRoutes() {
 	c.addReservedRoute(contractsV2.ApiConfigRoute, c.v2HttpController.Config).Methods(http.MethodGet)
 	c.addReservedRoute(contractsV2.ApiMetricsRoute, c.v2HttpController.Metrics).Methods(http.MethodGet)
 
-	c.addReservedRoute(sdkCommon.APIV2SecretRoute, c.v2HttpController.Secret).Methods(http.MethodPost)
+	c.addAdminRoute(sdkCommon.APIV2SecretRoute, c.v2HttpController.Secret).Methods(http.MethodPost)
 
-	c.addReservedRoute(contractsV2.ApiDiscoveryRoute, c.v2HttpController.Discovery).Methods(http.MethodPost)
-
-	c.addReservedRoute(contractsV2.ApiDeviceNameCommandNameRoute, c.v2HttpController.Command).Methods(http.MethodPut, http.MethodGet)
-
-	c.addReservedRoute(contractsV2.ApiDeviceCallbackRoute, c.v2HttpController.AddDevice).Methods(http.MethodPost)
-	c.addReservedRoute(contractsV2.ApiDeviceCallbackRoute, c.v2HttpController.UpdateDevice).Methods(http.MethodPut)
-	c.addReservedRoute(contractsV2.ApiDeviceCallbackNameRoute, c.v2HttpController.DeleteDevice).Methods(http.MethodDelete)
-	c.addReservedRoute(contractsV2.ApiProfileCallbackRoute, c.v2HttpController.UpdateProfile).Methods(http.MethodPut)
-	c.addReservedRoute(contractsV2.ApiProvisionWatcherRoute, c.v2HttpController.AddProvisionWatcher).Methods(http.MethodPost)
-	c.addReservedRoute(contractsV2.ApiProvisionWatcherRoute, c.v2HttpController.UpdateProvisionWatcher).Methods(http.MethodPut)
-	c.addReservedRoute(contractsV2.ApiProvisionWatcherByNameRoute, c.v2HttpController.DeleteProvisionWatcher).Methods(http.MethodDelete)
-	c.addReservedRoute(contractsV2.ApiServiceCallbackRoute, c.v2HttpController.UpdateDeviceService).Methods(http.MethodPut)
+	if sdkCommon.DriverCapabilities().Discovery {
+		c.addAdminRoute(contractsV2.ApiDiscoveryRoute, c.v2HttpController.Discovery).Methods(http.MethodPost)
+		c.addAdminRoute(sdkCommon.APIV2DiscoveryStopRoute, c.v2HttpController.StopDiscovery).Methods(http.MethodPost)
+		c.addReservedRoute(sdkCommon.APIV2DiscoveryStatusRoute, c.v2HttpController.DiscoveryStatus).Methods(http.MethodGet)
+	}
+	c.addReservedRoute(sdkCommon.APIV2CapabilitiesRoute, c.v2HttpController.Capabilities).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIV2MaintenanceRoute, c.v2HttpController.Maintenance).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2MaintenanceRoute, c.v2HttpController.SetMaintenance).Methods(http.MethodPut)
+	c.addAdminRoute(sdkCommon.APIV2ChaosRoute, c.v2HttpController.Faults).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2ChaosRoute, c.v2HttpController.SetFault).Methods(http.MethodPut)
+	c.addAdminRoute(sdkCommon.APIV2ChaosRoute, c.v2HttpController.ClearFaults).Methods(http.MethodDelete)
+	c.addReservedRoute(sdkCommon.APIV2InflightRoute, c.v2HttpController.InflightOperations).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2InflightByIdRoute, c.v2HttpController.CancelInflightOperation).Methods(http.MethodDelete)
+
+	// registered before the command route, which it would otherwise be matched by
+	c.addReservedRoute(sdkCommon.APIV2DeviceCommandsRoute, c.v2HttpController.DeviceCommands).Methods(http.MethodGet)
+	c.addCommandRoute(sdkCommon.APIV2BatchCommandRoute, c.v2HttpController.BatchCommand).Methods(http.MethodPost)
+	c.addCommandRoute(contractsV2.ApiDeviceNameCommandNameRoute, c.v2HttpController.Command).Methods(http.MethodPut, http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIV2CommandHistoryRoute, c.v2HttpController.CommandHistory).Methods(http.MethodGet)
+
+	c.addCallbackRoute(contractsV2.ApiDeviceCallbackRoute, c.v2HttpController.AddDevice).Methods(http.MethodPost)
+	c.addCallbackRoute(contractsV2.ApiDeviceCallbackRoute, c.v2HttpController.UpdateDevice).Methods(http.MethodPut)
+	c.addCallbackRoute(contractsV2.ApiDeviceCallbackNameRoute, c.v2HttpController.DeleteDevice).Methods(http.MethodDelete)
+	c.addCallbackRoute(contractsV2.ApiProfileCallbackRoute, c.v2HttpController.UpdateProfile).Methods(http.MethodPut)
+	c.addCallbackRoute(contractsV2.ApiWatcherCallbackRoute, c.v2HttpController.AddProvisionWatcher).Methods(http.MethodPost)
+	c.addCallbackRoute(contractsV2.ApiWatcherCallbackRoute, c.v2HttpController.UpdateProvisionWatcher).Methods(http.MethodPut)
+	c.addCallbackRoute(contractsV2.ApiWatcherCallbackNameRoute, c.v2HttpController.DeleteProvisionWatcher).Methods(http.MethodDelete)
+	c.addCallbackRoute(contractsV2.ApiServiceCallbackRoute, c.v2HttpController.UpdateDeviceService).Methods(http.MethodPut)
+
+	c.addAdminRoute(sdkCommon.APIV2DeviceTemplateRoute, c.v2HttpController.AddDeviceTemplate).Methods(http.MethodPost)
+	c.addAdminRoute(sdkCommon.APIV2DeviceTemplateRoute, c.v2HttpController.UpdateDeviceTemplate).Methods(http.MethodPut)
+	c.addReservedRoute(sdkCommon.APIV2AllDeviceTemplateRoute, c.v2HttpController.AllDeviceTemplates).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIV2DeviceTemplateByNameRoute, c.v2HttpController.DeviceTemplateByName).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2DeviceTemplateByNameRoute, c.v2HttpController.DeleteDeviceTemplate).Methods(http.MethodDelete)
+	c.addAdminRoute(sdkCommon.APIV2DeviceFromTemplateRoute, c.v2HttpController.AddDeviceFromTemplate).Methods(http.MethodPost)
+
+	c.addReservedRoute(sdkCommon.APIV2DeviceExportRoute, c.v2HttpController.ExportDevices).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2DeviceResyncRoute, c.v2HttpController.ResyncDevice).Methods(http.MethodPost)
+	c.addAdminRoute(sdkCommon.APIV2DeviceDecommissionRoute, c.v2HttpController.DecommissionDevice).Methods(http.MethodPost)
+	c.addAdminRoute(sdkCommon.APIV2DeviceUpdateRoute, c.v2HttpController.StartDeviceUpdate).Methods(http.MethodPost)
+	c.addReservedRoute(sdkCommon.APIV2DeviceUpdateByIdRoute, c.v2HttpController.DeviceUpdateById).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIV2DeviceUpdateByNameRoute, c.v2HttpController.DeviceUpdatesByName).Methods(http.MethodGet)
+	c.addCommandRoute(sdkCommon.APIV2TransactionRoute, c.v2HttpController.ExecuteTransaction).Methods(http.MethodPost)
+
+	c.addReservedRoute(sdkCommon.APIV2CalibrationByNameRoute, c.v2HttpController.CalibrationsByName).Methods(http.MethodGet)
+	c.addReservedRoute(sdkCommon.APIV2CalibrationByResourceRoute, c.v2HttpController.CalibrationByResource).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2CalibrationByResourceRoute, c.v2HttpController.SetCalibration).Methods(http.MethodPut)
+	c.addAdminRoute(sdkCommon.APIV2CalibrationByResourceRoute, c.v2HttpController.RemoveCalibration).Methods(http.MethodDelete)
+
+	c.addAdminRoute(sdkCommon.APIV2ProfileStagingRoute, c.v2HttpController.StageProfile).Methods(http.MethodPost)
+	c.addReservedRoute(sdkCommon.APIV2StagedProfileByNameRoute, c.v2HttpController.StagedProfileByName).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2StagedProfileByNameRoute, c.v2HttpController.DiscardStagedProfile).Methods(http.MethodDelete)
+	c.addAdminRoute(sdkCommon.APIV2ApplyStagedProfileRoute, c.v2HttpController.ApplyStagedProfile).Methods(http.MethodPost)
+	c.addAdminRoute(sdkCommon.APIV2RollbackProfileRoute, c.v2HttpController.RollbackProfile).Methods(http.MethodPost)
+
+	c.addReservedRoute(sdkCommon.APIV2EventExportRoute, c.v2HttpController.ExportEvents).Methods(http.MethodGet)
+	c.addAdminRoute(sdkCommon.APIV2EventExportByIdRoute, c.v2HttpController.MarkEventsExported).Methods(http.MethodPut)
 }
 
 func (c *RestController) addReservedRoute(route string, handler func(http.ResponseWriter, *http.Request)) *mux.Route {
@@ -100,6 +158,9 @@ func (c *RestController) addReservedRoute(route string, handler func(http.Respon
 	return c.router.HandleFunc(
 		route,
 		func(w http.ResponseWriter, r *http.Request) {
+			if !c.validateRequestBody(w, r) {
+				return
+			}
 			ctx := context.WithValue(r.Context(), bootstrapContainer.LoggingClientInterfaceName, c.LoggingClient)
 			handler(
 				w,
@@ -107,6 +168,34 @@ func (c *RestController) addReservedRoute(route string, handler func(http.Respon
 		})
 }
 
+// addCommandRoute registers the reserved route of a command, which requires a verified
+// client certificate if Service.TLS.RequireClientCert is enabled.
+func (c *RestController) addCommandRoute(route string, handler func(http.ResponseWriter, *http.Request)) *mux.Route {
+	return c.addReservedRoute(route, func(w http.ResponseWriter, r *http.Request) {
+		if !c.clientVerified(r) {
+			c.sendBodyError(w, r, "command rejected: no verified client certificate", http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// addAdminRoute registers the reserved route changing the state of the Devices or of the
+// service, which requires a verified client certificate as the command routes do.
+func (c *RestController) addAdminRoute(route string, handler func(http.ResponseWriter, *http.Request)) *mux.Route {
+	return c.addReservedRoute(route, func(w http.ResponseWriter, r *http.Request) {
+		if !c.clientVerified(r) {
+			c.sendBodyError(w, r, "request rejected: no verified client certificate", http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+func (c *RestController) clientVerified(r *http.Request) bool {
+	return tlsconfig.ClientVerified(container.ConfigurationFrom(c.dic.Get).Service.TLS, r)
+}
+
 func (c *RestController) AddRoute(route string, handler func(http.ResponseWriter, *http.Request), methods ...string) error {
 	if c.reservedRoutes[route] {
 		return errors.New("route is reserved")
@@ -18,8 +18,10 @@
 package controller
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -28,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 )
 
@@ -114,3 +117,50 @@ func TestInitRestRoutes(t *testing.T) {
 
 	assert.NoError(t, err, "Unexpected error examining route")
 }
+
+func TestRoutesRequiringClientCert(t *testing.T) {
+	config := &common.ConfigurationStruct{}
+	config.Service.TLS = common.TLSInfo{Enabled: true, SecretPath: "tls", RequireClientCert: true}
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return config
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+	controller := NewRestController(mux.NewRouter(), dic)
+	controller.InitRestRoutes()
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, common.APIV2TransactionRoute},
+		{http.MethodGet, common.APIV2ChaosRoute},
+		{http.MethodPut, common.APIV2ChaosRoute},
+		{http.MethodDelete, common.APIV2ChaosRoute},
+		{http.MethodDelete, common.APIV2InflightRoute + "/id/1"},
+		{http.MethodPut, common.APIV2MaintenanceRoute},
+		{http.MethodPost, "/api/v2/device/name/Simple-Device01/decommission"},
+		{http.MethodPost, "/api/v2/device/name/Simple-Device01/resync"},
+		{http.MethodPut, "/api/v2/calibration/name/Simple-Device01/resourceName/Temperature"},
+		{http.MethodPost, common.APIV2ProfileStagingRoute},
+		{http.MethodPost, common.APIV2DeviceTemplateRoute},
+		{http.MethodPost, common.APIV2SecretRoute},
+	}
+	for _, test := range tests {
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, nil)
+			req.TLS = &tls.ConnectionState{}
+			recorder := httptest.NewRecorder()
+			controller.Router().ServeHTTP(recorder, req)
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code, "no verified client certificate")
+
+			req = httptest.NewRequest(test.method, test.path, nil)
+			recorder = httptest.NewRecorder()
+			controller.Router().ServeHTTP(recorder, req)
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code, "the Unix domain socket isn't exempted")
+		})
+	}
+}
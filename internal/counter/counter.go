@@ -0,0 +1,194 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package counter derives delta and rate readings from deviceResources declared
+// as monotonically increasing counters.
+package counter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// CounterAttribute declares the deviceResource as a monotonically increasing counter.
+	CounterAttribute = common.SDKReservedPrefix + "counter"
+	// CounterMaxAttribute is the value after which the counter rolls over to zero.
+	// Without it, a decreasing value is treated as a counter reset.
+	CounterMaxAttribute = common.SDKReservedPrefix + "counterMax"
+	// CounterOutputAttribute is a comma separated list of the readings emitted for
+	// the counter, among OutputRaw, OutputDelta and OutputRate. Default is all of them.
+	CounterOutputAttribute = common.SDKReservedPrefix + "counterOutput"
+
+	OutputRaw   = "raw"
+	OutputDelta = "delta"
+	OutputRate  = "rate"
+
+	// DeltaSuffix and RateSuffix are appended to the counter deviceResource name
+	// to name the derived readings.
+	DeltaSuffix = "_delta"
+	RateSuffix  = "_rate"
+)
+
+type sample struct {
+	value  float64
+	origin int64
+}
+
+var (
+	samples = make(map[string]sample) // key is Device name and deviceResource name
+	mutex   sync.Mutex
+)
+
+// IsCounter reports whether the deviceResource attributes declare a counter.
+func IsCounter(attributes map[string]string) bool {
+	enabled, err := strconv.ParseBool(attributes[CounterAttribute])
+	return err == nil && enabled
+}
+
+// Process records the counter value and returns whether the raw value should be
+// emitted along with the derived delta and rate CommandValues. No derived values are
+// returned for the first sample of a counter.
+func Process(deviceName string, cv *dsModels.CommandValue, attributes map[string]string) (bool, []*dsModels.CommandValue, error) {
+	outputs := parseOutputs(attributes[CounterOutputAttribute])
+
+	current, isInteger, err := numericValue(cv)
+	if err != nil {
+		return true, nil, err
+	}
+
+	var max float64
+	if m, ok := attributes[CounterMaxAttribute]; ok {
+		max, err = strconv.ParseFloat(m, 64)
+		if err != nil {
+			return outputs[OutputRaw], nil, fmt.Errorf("invalid %s attribute: %v", CounterMaxAttribute, err)
+		}
+	}
+
+	origin := cv.Origin
+	if origin <= 0 {
+		origin = time.Now().UnixNano()
+	}
+
+	key := deviceName + "/" + cv.DeviceResourceName
+	mutex.Lock()
+	previous, exists := samples[key]
+	samples[key] = sample{value: current, origin: origin}
+	mutex.Unlock()
+
+	if !exists {
+		return outputs[OutputRaw], nil, nil
+	}
+
+	delta := current - previous.value
+	if delta < 0 {
+		if max > 0 {
+			// rollover: count up to max, then from zero to the current value
+			delta = max - previous.value + current
+			if isInteger {
+				delta++
+			}
+		} else {
+			// counter reset
+			delta = current
+		}
+	}
+
+	var derived []*dsModels.CommandValue
+	if outputs[OutputDelta] {
+		var deltaCV *dsModels.CommandValue
+		if isInteger {
+			deltaCV, err = dsModels.NewUint64Value(cv.DeviceResourceName+DeltaSuffix, origin, uint64(math.Round(delta)))
+		} else {
+			deltaCV, err = dsModels.NewFloat64Value(cv.DeviceResourceName+DeltaSuffix, origin, delta)
+		}
+		if err != nil {
+			return outputs[OutputRaw], nil, err
+		}
+		derived = append(derived, deltaCV)
+	}
+	if outputs[OutputRate] {
+		elapsed := time.Duration(origin - previous.origin).Seconds()
+		if elapsed > 0 {
+			rateCV, err := dsModels.NewFloat64Value(cv.DeviceResourceName+RateSuffix, origin, delta/elapsed)
+			if err != nil {
+				return outputs[OutputRaw], nil, err
+			}
+			derived = append(derived, rateCV)
+		}
+	}
+
+	return outputs[OutputRaw], derived, nil
+}
+
+// Readings returns the CommandValues of the readings of cv: the delta and rate ones derived
+// if the deviceResource is a counter, followed by cv itself unless the counter excludes its
+// raw reading. The failure to derive the readings is logged along with args, e.g. the
+// correlation id.
+func Readings(deviceName string, cv *dsModels.CommandValue, attributes map[string]string, lc logger.LoggingClient, args ...interface{}) []*dsModels.CommandValue {
+	if !IsCounter(attributes) {
+		return []*dsModels.CommandValue{cv}
+	}
+	emitRaw, derived, err := Process(deviceName, cv, attributes)
+	if err != nil {
+		lc.Warn(fmt.Sprintf("failed to derive counter readings of %s: %v", cv.DeviceResourceName, err), args...)
+	}
+	if emitRaw {
+		derived = append(derived, cv)
+	}
+	return derived
+}
+
+// Reset discards the recorded samples of the Device, e.g. when it's removed.
+func Reset(deviceName string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for key := range samples {
+		if strings.HasPrefix(key, deviceName+"/") {
+			delete(samples, key)
+		}
+	}
+}
+
+func parseOutputs(attribute string) map[string]bool {
+	if strings.TrimSpace(attribute) == "" {
+		return map[string]bool{OutputRaw: true, OutputDelta: true, OutputRate: true}
+	}
+	outputs := make(map[string]bool)
+	for _, o := range strings.Split(attribute, ",") {
+		outputs[strings.ToLower(strings.TrimSpace(o))] = true
+	}
+	return outputs
+}
+
+func numericValue(cv *dsModels.CommandValue) (float64, bool, error) {
+	var isInteger bool
+	switch cv.Type {
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64,
+		v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64:
+		isInteger = true
+	case v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+	default:
+		return 0, false, fmt.Errorf("counter %s has non-numeric value type %s", cv.DeviceResourceName, cv.Type)
+	}
+
+	value, err := strconv.ParseFloat(cv.ValueToString(models.ENotation), 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return value, isInteger, nil
+}
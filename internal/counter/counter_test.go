@@ -0,0 +1,142 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package counter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const second = int64(time.Second)
+
+func uint32Value(t *testing.T, value uint32, origin int64) *dsModels.CommandValue {
+	cv, err := dsModels.NewUint32Value("Packets", origin, value)
+	require.NoError(t, err)
+	return cv
+}
+
+func float64Value(t *testing.T, value float64, origin int64) *dsModels.CommandValue {
+	cv, err := dsModels.NewFloat64Value("Energy", origin, value)
+	require.NoError(t, err)
+	return cv
+}
+
+// derivedValues returns the derived values keyed by the name of their deviceResource.
+func derivedValues(t *testing.T, derived []*dsModels.CommandValue) map[string]float64 {
+	values := make(map[string]float64, len(derived))
+	for _, cv := range derived {
+		value, _, err := numericValue(cv)
+		require.NoError(t, err)
+		values[cv.DeviceResourceName] = value
+	}
+	return values
+}
+
+func TestIsCounter(t *testing.T) {
+	assert.True(t, IsCounter(map[string]string{CounterAttribute: "true"}))
+	assert.False(t, IsCounter(map[string]string{CounterAttribute: "false"}))
+	assert.False(t, IsCounter(map[string]string{CounterAttribute: "yes"}))
+	assert.False(t, IsCounter(nil))
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name     string
+		attrs    map[string]string
+		previous *dsModels.CommandValue
+		current  *dsModels.CommandValue
+		expected map[string]float64
+	}{
+		{"increase", nil, uint32Value(t, 100, second), uint32Value(t, 150, 3*second),
+			map[string]float64{"Packets_delta": 50, "Packets_rate": 25}},
+		{"reset", nil, uint32Value(t, 100, second), uint32Value(t, 20, 2*second),
+			map[string]float64{"Packets_delta": 20, "Packets_rate": 20}},
+		{"integer rollover", map[string]string{CounterMaxAttribute: "255"}, uint32Value(t, 250, second), uint32Value(t, 4, 2*second),
+			map[string]float64{"Packets_delta": 10, "Packets_rate": 10}},
+		{"float rollover", map[string]string{CounterMaxAttribute: "1000"}, float64Value(t, 990, second), float64Value(t, 10, 5*second),
+			map[string]float64{"Energy_delta": 20, "Energy_rate": 5}},
+		{"delta only", map[string]string{CounterOutputAttribute: "Delta"}, uint32Value(t, 1, second), uint32Value(t, 2, 2*second),
+			map[string]float64{"Packets_delta": 1}},
+		{"same origin", nil, uint32Value(t, 1, second), uint32Value(t, 2, second),
+			map[string]float64{"Packets_delta": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer Reset("Device")
+			_, derived, err := Process("Device", tt.previous, tt.attrs)
+			require.NoError(t, err)
+			assert.Empty(t, derived, "no derived values for the first sample")
+
+			_, derived, err = Process("Device", tt.current, tt.attrs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, derivedValues(t, derived))
+		})
+	}
+}
+
+func TestProcessRaw(t *testing.T) {
+	defer Reset("Device")
+	emitRaw, _, err := Process("Device", uint32Value(t, 1, second), map[string]string{CounterOutputAttribute: "delta, rate"})
+	require.NoError(t, err)
+	assert.False(t, emitRaw)
+	emitRaw, _, err = Process("Device", uint32Value(t, 2, 2*second), map[string]string{CounterOutputAttribute: "raw,delta"})
+	require.NoError(t, err)
+	assert.True(t, emitRaw)
+}
+
+func TestProcessInvalid(t *testing.T) {
+	defer Reset("Device")
+	_, _, err := Process("Device", dsModels.NewStringValue("Packets", second, "1"), nil)
+	assert.Error(t, err, "non-numeric counter")
+
+	_, _, err = Process("Device", uint32Value(t, 1, second), map[string]string{CounterMaxAttribute: "many"})
+	assert.Error(t, err, "invalid counter max")
+}
+
+func TestReadings(t *testing.T) {
+	defer Reset("Device")
+	lc := logger.NewMockClient()
+	attrs := map[string]string{CounterAttribute: "true", CounterOutputAttribute: "raw,delta"}
+
+	cv := uint32Value(t, 1, second)
+	assert.Equal(t, []*dsModels.CommandValue{cv}, Readings("Device", cv, nil, lc), "not a counter")
+	assert.Equal(t, []*dsModels.CommandValue{cv}, Readings("Device", cv, attrs, lc), "the first sample")
+
+	cv = uint32Value(t, 3, 2*second)
+	readings := Readings("Device", cv, attrs, lc)
+	require.Len(t, readings, 2)
+	assert.Equal(t, "Packets_delta", readings[0].DeviceResourceName)
+	assert.Equal(t, cv, readings[1], "the raw reading follows the derived ones")
+
+	attrs[CounterOutputAttribute] = "rate"
+	readings = Readings("Device", uint32Value(t, 5, 3*second), attrs, lc)
+	require.Len(t, readings, 1)
+	assert.Equal(t, "Packets_rate", readings[0].DeviceResourceName)
+}
+
+func TestReset(t *testing.T) {
+	_, _, err := Process("Device", uint32Value(t, 1, second), nil)
+	require.NoError(t, err)
+	_, _, err = Process("Device2", uint32Value(t, 1, second), nil)
+	require.NoError(t, err)
+
+	Reset("Device")
+	_, derived, err := Process("Device", uint32Value(t, 2, 2*second), nil)
+	require.NoError(t, err)
+	assert.Empty(t, derived, "the samples of the Device are discarded")
+	_, derived, err = Process("Device2", uint32Value(t, 2, 2*second), nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, derived, "the samples of the other Devices are kept")
+	Reset("Device")
+	Reset("Device2")
+}
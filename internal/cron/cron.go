@@ -0,0 +1,182 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cron parses the cron expressions scheduling the recurring tasks of the Device
+// Service. An expression has 5 fields, minute hour day-of-month month day-of-week, or 6
+// fields starting with the second; each field is *, a value, a range a-b, a step */n or
+// a-b/n, or a comma-separated list of them. The descriptors @yearly, @monthly, @weekly,
+// @daily and @hourly are accepted as well.
+//
+// The times are matched in the wall clock of the location of the time passed to Next: the
+// times in the hour skipped when the clocks go forward never match, and those in the hour
+// repeated when they go back match twice.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var fieldBounds = []bounds{
+	{"second", 0, 59},
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	second, minute, hour, dom, month, dow uint64 // bit sets of the allowed values
+	// a restricted day of month or day of week matches either of them, as in crontab
+	domRestricted, dowRestricted bool
+}
+
+// Parse parses the cron expression.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := descriptors[expr]; ok {
+		expr = d
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 or 6 fields", expr)
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseField(field, fieldBounds[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+		}
+		sets[i] = set
+	}
+	// Sunday may be written 7
+	if sets[5]&(1<<7) != 0 {
+		sets[5] |= 1
+	}
+	// as in crontab, a field starting with * isn't restricted, even with a step
+	return &Schedule{
+		second:        sets[0],
+		minute:        sets[1],
+		hour:          sets[2],
+		dom:           sets[3],
+		month:         sets[4],
+		dow:           sets[5],
+		domRestricted: !strings.HasPrefix(fields[3], "*"),
+		dowRestricted: !strings.HasPrefix(fields[5], "*"),
+	}, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	// Sunday may be written 7 as a value or the end of a range, but the steps stop at 6
+	// so that e.g. 5/2 is Friday only
+	max := b.max
+	if b.name == "day of week" {
+		max = 7
+	}
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step, stepped := part, 1, false
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid %s step %s", b.name, part)
+			}
+			rng, step, stepped = part[:i], s, true
+		}
+		lo, hi := b.min, b.max
+		if rng != "*" {
+			var err error
+			if i := strings.Index(rng, "-"); i >= 0 {
+				if lo, err = strconv.Atoi(rng[:i]); err == nil {
+					hi, err = strconv.Atoi(rng[i+1:])
+				}
+			} else if lo, err = strconv.Atoi(rng); err == nil && !stepped {
+				hi = lo
+			}
+			if err != nil || lo < b.min || hi > max || lo > hi {
+				return 0, fmt.Errorf("invalid %s %s", b.name, part)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first time after t the schedule matches, or the zero time if it never
+// does, e.g. on February 30th.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = later(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = later(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location()))
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// later returns next, the start of the following day or hour of t, unless its normalization
+// across a daylight saving time transition doesn't put it after t, in which case it returns
+// the start of the following hour of the absolute time so that Next keeps going forward.
+func later(t time.Time, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Truncate(time.Hour).Add(time.Hour)
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,167 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bits(values ...int) uint64 {
+	var set uint64
+	for _, v := range values {
+		set |= 1 << uint(v)
+	}
+	return set
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		expr          string
+		field         func(s *Schedule) uint64
+		expected      uint64
+		domRestricted bool
+		dowRestricted bool
+	}{
+		{"value", "5 * * * *", func(s *Schedule) uint64 { return s.minute }, bits(5), false, false},
+		{"seconds", "30 5 * * * *", func(s *Schedule) uint64 { return s.second }, bits(30), false, false},
+		{"default second", "5 * * * *", func(s *Schedule) uint64 { return s.second }, bits(0), false, false},
+		{"range", "* 9-11 * * *", func(s *Schedule) uint64 { return s.hour }, bits(9, 10, 11), false, false},
+		{"list", "* * * 1,6,12 *", func(s *Schedule) uint64 { return s.month }, bits(1, 6, 12), false, false},
+		{"star step", "*/20 * * * *", func(s *Schedule) uint64 { return s.minute }, bits(0, 20, 40), false, false},
+		{"range step", "10-30/10 * * * *", func(s *Schedule) uint64 { return s.minute }, bits(10, 20, 30), false, false},
+		{"value step", "* 18/2 * * *", func(s *Schedule) uint64 { return s.hour }, bits(18, 20, 22), false, false},
+		{"day of month", "* * 1,15 * *", func(s *Schedule) uint64 { return s.dom }, bits(1, 15), true, false},
+		{"day of month star step", "* * */10 * *", func(s *Schedule) uint64 { return s.dom }, bits(1, 11, 21, 31), false, false},
+		{"day of week", "* * * * 1-5", func(s *Schedule) uint64 { return s.dow }, bits(1, 2, 3, 4, 5), false, true},
+		{"day of week step", "* * * * 5/2", func(s *Schedule) uint64 { return s.dow }, bits(5), false, true},
+		{"day of week star step", "* * * * */2", func(s *Schedule) uint64 { return s.dow }, bits(0, 2, 4, 6), false, false},
+		{"day of week step to Sunday", "* * * * 1/3", func(s *Schedule) uint64 { return s.dow }, bits(1, 4), false, true},
+		{"Sunday as 7", "* * * * 7", func(s *Schedule) uint64 { return s.dow &^ bits(7) }, bits(0), false, true},
+		{"range to Sunday as 7", "* * * * 5-7", func(s *Schedule) uint64 { return s.dow &^ bits(7) }, bits(0, 5, 6), false, true},
+		{"descriptor", "@weekly", func(s *Schedule) uint64 { return s.dow }, bits(0), false, true},
+		{"surrounding spaces", "  @hourly ", func(s *Schedule) uint64 { return s.minute }, bits(0), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.field(s))
+			assert.Equal(t, tt.domRestricted, s.domRestricted)
+			assert.Equal(t, tt.dowRestricted, s.dowRestricted)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"empty", ""},
+		{"too few fields", "* * * *"},
+		{"too many fields", "* * * * * * *"},
+		{"unknown descriptor", "@often"},
+		{"not a number", "a * * * *"},
+		{"minute out of bounds", "60 * * * *"},
+		{"hour out of bounds", "* 24 * * *"},
+		{"day of month zero", "* * 0 * *"},
+		{"month out of bounds", "* * * 13 *"},
+		{"day of week out of bounds", "* * * * 8"},
+		{"reversed range", "* 11-9 * * *"},
+		{"zero step", "*/0 * * * *"},
+		{"invalid step", "*/x * * * *"},
+		{"empty list item", "1,,2 * * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Friday, January 29th 2021
+	from := time.Date(2021, 1, 29, 10, 15, 30, 0, time.UTC)
+	tests := []struct {
+		name     string
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"every minute", "* * * * *", from, time.Date(2021, 1, 29, 10, 16, 0, 0, time.UTC)},
+		{"every second", "* * * * * *", from, time.Date(2021, 1, 29, 10, 15, 31, 0, time.UTC)},
+		{"strictly after", "30 15 10 * * *", from, time.Date(2021, 1, 30, 10, 15, 30, 0, time.UTC)},
+		{"later today", "0 18 * * *", from, time.Date(2021, 1, 29, 18, 0, 0, 0, time.UTC)},
+		{"tomorrow", "0 9 * * *", from, time.Date(2021, 1, 30, 9, 0, 0, 0, time.UTC)},
+		{"next year", "0 0 1 1 *", from, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"month end skips the shorter months", "0 0 31 * *", from, time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"month end from February", "0 0 31 * *", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"February 29th", "0 0 29 2 *", from, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"year end", "59 23 31 12 *", from, time.Date(2021, 12, 31, 23, 59, 0, 0, time.UTC)},
+		{"day of week", "0 9 * * 1", from, time.Date(2021, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"day of week step is Friday only", "0 9 * * 5/2", from, time.Date(2021, 2, 5, 9, 0, 0, 0, time.UTC)},
+		{"Sunday as 7", "0 9 * * 7", from, time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC)},
+		{"day of month or day of week", "0 9 15 * 1", from, time.Date(2021, 2, 1, 9, 0, 0, 0, time.UTC)},
+		// an odd day and a Monday, the odd Sunday 31st not matching
+		{"stepped day of month and day of week", "0 9 */2 * 1", from, time.Date(2021, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", from, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Next(tt.from))
+		})
+	}
+}
+
+func TestNextDaylightSavingTime(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	// the clocks go forward from 2:00 to 3:00 on March 14th 2021, and back from 2:00 to
+	// 1:00 on November 7th 2021
+	tests := []struct {
+		name     string
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"skipped hour never matches", "30 2 * * *", time.Date(2021, 3, 14, 0, 0, 0, 0, location), time.Date(2021, 3, 15, 2, 30, 0, 0, location)},
+		{"after the spring forward", "0 3 * * *", time.Date(2021, 3, 14, 0, 0, 0, 0, location), time.Date(2021, 3, 14, 3, 0, 0, 0, location)},
+		{"hourly across the spring forward", "0 * * * *", time.Date(2021, 3, 14, 1, 30, 0, 0, location), time.Date(2021, 3, 14, 3, 0, 0, 0, location)},
+		{"daily across the spring forward", "0 9 * * *", time.Date(2021, 3, 13, 9, 0, 0, 0, location), time.Date(2021, 3, 14, 9, 0, 0, 0, location)},
+		{"repeated hour matches first", "30 1 * * *", time.Date(2021, 11, 7, 0, 0, 0, 0, location), time.Date(2021, 11, 7, 1, 30, 0, 0, location)},
+		{"repeated hour matches twice", "30 1 * * *", time.Date(2021, 11, 7, 1, 30, 0, 0, location), time.Date(2021, 11, 7, 1, 30, 0, 0, location).Add(time.Hour)},
+		{"daily across the fall back", "0 9 * * *", time.Date(2021, 11, 6, 9, 0, 0, 0, location), time.Date(2021, 11, 7, 9, 0, 0, 0, location)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			next := s.Next(tt.from)
+			assert.True(t, tt.expected.Equal(next), "expected %v, got %v", tt.expected, next)
+		})
+	}
+
+	// hourly runs every hour of the absolute time across the fall back
+	s, err := Parse("0 * * * *")
+	require.NoError(t, err)
+	first := s.Next(time.Date(2021, 11, 7, 0, 30, 0, 0, location))
+	second := s.Next(first)
+	third := s.Next(second)
+	assert.Equal(t, time.Hour, second.Sub(first))
+	assert.Equal(t, time.Hour, third.Sub(second))
+	assert.Equal(t, 1, second.Hour(), "the repeated hour")
+}
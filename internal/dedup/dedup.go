@@ -0,0 +1,90 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dedup suppresses the events identical to one published recently, e.g.
+// the same asynchronous values emitted again by a driver when it reconnects.
+package dedup
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OneOfOne/xxhash"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+// Filter remembers the fingerprints of the events published within the window.
+type Filter struct {
+	window    time.Duration
+	published map[string]map[uint64]time.Time // keys are Device name and event fingerprint
+	mutex     sync.Mutex
+}
+
+var f *Filter
+
+// NewFilter initiates the duplicate event filter with the window. The filter is
+// disabled when the window isn't positive.
+func NewFilter(window time.Duration) {
+	if window <= 0 {
+		f = nil
+		return
+	}
+	f = &Filter{
+		window:    window,
+		published: make(map[string]map[uint64]time.Time),
+	}
+}
+
+// GetFilter returns the duplicate event filter, which may be nil if it's disabled.
+func GetFilter() *Filter {
+	return f
+}
+
+// Duplicate reports whether an event of the same Device with the same deviceResources
+// and values was published within the window. Otherwise the event is remembered as
+// published.
+func (f *Filter) Duplicate(event contract.Event) bool {
+	if f == nil {
+		return false
+	}
+
+	fingerprint := fingerprint(event.Readings)
+	now := clock.Now()
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	published, ok := f.published[event.Device]
+	if !ok {
+		published = make(map[uint64]time.Time)
+		f.published[event.Device] = published
+	}
+	for fp, t := range published {
+		if now.Sub(t) >= f.window {
+			delete(published, fp)
+		}
+	}
+
+	if _, ok := published[fingerprint]; ok {
+		return true
+	}
+	published[fingerprint] = now
+	return false
+}
+
+// fingerprint hashes the deviceResource names and values of the readings regardless
+// of their order, ignoring their timestamps.
+func fingerprint(readings []contract.Reading) uint64 {
+	values := make([]string, len(readings))
+	for i, r := range readings {
+		values[i] = r.Name + "\x00" + r.Value + "\x00" + string(r.BinaryValue)
+	}
+	sort.Strings(values)
+	return xxhash.ChecksumString64(strings.Join(values, "\x01"))
+}
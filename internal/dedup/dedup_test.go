@@ -0,0 +1,45 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEvent(device string, origin int64, values ...string) contract.Event {
+	event := contract.Event{Device: device, Origin: origin}
+	for i := 0; i+1 < len(values); i += 2 {
+		event.Readings = append(event.Readings, contract.Reading{Name: values[i], Value: values[i+1], Origin: origin})
+	}
+	return event
+}
+
+func TestDuplicate(t *testing.T) {
+	NewFilter(50 * time.Millisecond)
+	defer NewFilter(0)
+	filter := GetFilter()
+
+	assert.False(t, filter.Duplicate(testEvent("d1", 1, "temperature", "21", "humidity", "40")))
+	assert.True(t, filter.Duplicate(testEvent("d1", 2, "humidity", "40", "temperature", "21")), "same values in another order and origin")
+	assert.False(t, filter.Duplicate(testEvent("d2", 3, "temperature", "21", "humidity", "40")), "same values of another device")
+	assert.False(t, filter.Duplicate(testEvent("d1", 4, "temperature", "22", "humidity", "40")), "different value")
+
+	time.Sleep(60 * time.Millisecond)
+	assert.False(t, filter.Duplicate(testEvent("d1", 5, "temperature", "21", "humidity", "40")), "published out of the window")
+}
+
+func TestDisabledFilter(t *testing.T) {
+	NewFilter(0)
+	event := testEvent("d1", 1, "temperature", "21")
+	assert.Nil(t, GetFilter())
+	assert.False(t, GetFilter().Duplicate(event))
+	assert.False(t, GetFilter().Duplicate(event))
+}
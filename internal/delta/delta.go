@@ -0,0 +1,145 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package delta reduces the events of the selected Devices to the readings whose
+// values changed since the previous event, publishing full snapshots periodically.
+package delta
+
+import (
+	"sync"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+const (
+	// TagDelta is the event tag set on the events only including the changed readings.
+	TagDelta = "delta"
+
+	// DefaultSnapshotInterval applies when neither the snapshot interval nor the number
+	// of events between snapshots is configured.
+	DefaultSnapshotInterval = 5 * time.Minute
+)
+
+// Filter remembers the last published values of the Devices in delta mode.
+type Filter struct {
+	enabled          func(deviceName string) bool
+	snapshotInterval time.Duration
+	snapshotEvents   int
+	devices          map[string]*deviceState // key is Device name
+	mutex            sync.Mutex
+}
+
+type deviceState struct {
+	values   map[string]string // key is deviceResource name
+	snapshot time.Time
+	deltas   int
+}
+
+var f *Filter
+
+// NewFilter initiates the delta filter for the Devices for which enabled returns true.
+// A full snapshot is published once snapshotInterval elapsed, if positive, or after
+// snapshotEvents delta events, if positive. The filter is disabled when enabled is nil.
+func NewFilter(enabled func(deviceName string) bool, snapshotInterval time.Duration, snapshotEvents int) {
+	if enabled == nil {
+		f = nil
+		return
+	}
+	if snapshotInterval <= 0 && snapshotEvents <= 0 {
+		snapshotInterval = DefaultSnapshotInterval
+	}
+	f = &Filter{
+		enabled:          enabled,
+		snapshotInterval: snapshotInterval,
+		snapshotEvents:   snapshotEvents,
+		devices:          make(map[string]*deviceState),
+	}
+}
+
+// GetFilter returns the delta filter, which may be nil if it's disabled.
+func GetFilter() *Filter {
+	return f
+}
+
+// Apply removes from the event of a Device in delta mode the readings whose values are
+// the same as in the previously published events, and tags it with TagDelta, unless a
+// full snapshot is due. It reports whether the event is modified and whether it's left
+// with readings to publish.
+func (f *Filter) Apply(event *contract.Event) (modified bool, publish bool) {
+	if f == nil || !f.enabled(event.Device) {
+		return false, true
+	}
+
+	now := time.Now()
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	state, ok := f.devices[event.Device]
+	if !ok || f.snapshotDue(state, now) {
+		if !ok {
+			state = &deviceState{values: make(map[string]string)}
+			f.devices[event.Device] = state
+		}
+		for _, r := range event.Readings {
+			state.values[r.Name] = readingValue(r)
+		}
+		state.snapshot = now
+		state.deltas = 0
+		return false, true
+	}
+
+	changed := make([]contract.Reading, 0, len(event.Readings))
+	for _, r := range event.Readings {
+		value := readingValue(r)
+		if previous, ok := state.values[r.Name]; ok && previous == value {
+			continue
+		}
+		state.values[r.Name] = value
+		changed = append(changed, r)
+	}
+	if len(changed) == 0 {
+		return true, false
+	}
+	state.deltas++
+
+	if len(changed) == len(event.Readings) {
+		return false, true
+	}
+	event.Readings = changed
+	tags := make(map[string]string, len(event.Tags)+1)
+	for k, v := range event.Tags {
+		tags[k] = v
+	}
+	tags[TagDelta] = "true"
+	event.Tags = tags
+	return true, true
+}
+
+// Forget discards the last published values of the Device, so that its next event
+// is a full snapshot.
+func (f *Filter) Forget(deviceName string) {
+	if f == nil {
+		return
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.devices, deviceName)
+}
+
+func (f *Filter) snapshotDue(state *deviceState, now time.Time) bool {
+	if f.snapshotInterval > 0 && now.Sub(state.snapshot) >= f.snapshotInterval {
+		return true
+	}
+	return f.snapshotEvents > 0 && state.deltas >= f.snapshotEvents
+}
+
+func readingValue(r contract.Reading) string {
+	if len(r.BinaryValue) > 0 {
+		return r.ValueType + "\x00" + string(r.BinaryValue)
+	}
+	return r.ValueType + "\x00" + r.Value
+}
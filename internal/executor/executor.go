@@ -0,0 +1,261 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package executor runs the commands issued by the SDK on the ProtocolDriver within the
+// concurrency limits, retrying the failed reads, recording the metrics, injecting the faults,
+// registering the operations in flight, invalidating the last known values written and
+// hiding the protocols reserved by the SDK from the ProtocolDriver.
+package executor
+
+import (
+	"context"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/chaos"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/inflight"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/limiter"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// retryInterval is the delay before the first retry of a read command, growing
+// linearly with the subsequent retries.
+const retryInterval = 100 * time.Millisecond
+
+// The policies of Writable.CommandPriority.
+const (
+	// PriorityInteractive runs the on-demand commands of a device before its pending
+	// AutoEvent reads.
+	PriorityInteractive = "interactive"
+	// PriorityNone runs the commands of a device in no particular order.
+	PriorityNone = "none"
+)
+
+// limitedDriver is a ProtocolDriver which runs read and write commands within
+// the limits configured in Writable. The limits are read on each invocation so
+// that changes from the Registry apply without restarting the service.
+type limitedDriver struct {
+	dsModels.ProtocolDriver
+	limiter *limiter.Limiter
+	config  *common.ConfigurationStruct
+}
+
+// NewDriver wraps the driver so that HandleReadCommands and HandleWriteCommands honor
+// Writable.MaxConcurrentCommands and Writable.MaxDeviceConcurrentCommands. The returned
+// driver implements ContextCommandHandler whether or not the wrapped driver does, but none
+// of the other optional interfaces of the ProtocolDriver, which are checked on the driver
+// returned by container.RawProtocolDriverFrom. The phases of the transactions of a
+// TransactionalDriver are run within the limits through Transactional.
+func NewDriver(driver dsModels.ProtocolDriver, config *common.ConfigurationStruct) dsModels.ProtocolDriver {
+	return &limitedDriver{
+		ProtocolDriver: driver,
+		limiter:        limiter.NewLimiter(),
+		config:         config,
+	}
+}
+
+type backgroundKey struct{}
+
+// backgroundDriver runs the commands of the wrapped limitedDriver in the background.
+type backgroundDriver struct {
+	*limitedDriver
+}
+
+// Background returns the driver running the commands in the background, i.e. after the
+// on-demand commands of the device, if driver was returned by NewDriver. Otherwise
+// driver is returned as it is.
+func Background(driver dsModels.ProtocolDriver) dsModels.ProtocolDriver {
+	if d, ok := driver.(*limitedDriver); ok {
+		return backgroundDriver{d}
+	}
+	return driver
+}
+
+func (d backgroundDriver) HandleReadCommands(deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	return d.HandleReadCommandsContext(context.Background(), deviceName, protocols, reqs)
+}
+
+func (d backgroundDriver) HandleReadCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	return d.limitedDriver.HandleReadCommandsContext(context.WithValue(ctx, backgroundKey{}, true), deviceName, protocols, reqs)
+}
+
+func (d backgroundDriver) HandleWriteCommands(deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	return d.HandleWriteCommandsContext(context.Background(), deviceName, protocols, reqs, params)
+}
+
+func (d backgroundDriver) HandleWriteCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	return d.limitedDriver.HandleWriteCommandsContext(context.WithValue(ctx, backgroundKey{}, true), deviceName, protocols, reqs, params)
+}
+
+// HandleReadCommands retries the read commands failed with a retryable DriverErrorKind
+// up to Writable.ReadRetries times, releasing the limits in between.
+func (d *limitedDriver) HandleReadCommands(deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	return d.HandleReadCommandsContext(context.Background(), deviceName, protocols, reqs)
+}
+
+// HandleReadCommandsContext is HandleReadCommands giving up once the context is done or
+// the operation is cancelled through the in-flight registry.
+// The wrapped driver keeps running the command if it doesn't implement ContextCommandHandler.
+func (d *limitedDriver) HandleReadCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	ctx, op := inflight.Begin(ctx, deviceName, resourceNames(reqs), common.GetCmdMethod)
+	defer op.Done()
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		results, err := d.handleReadCommands(ctx, op, deviceName, common.DriverProtocols(protocols), reqs)
+		if err == nil || attempt > d.config.Writable.ReadRetries || !dsModels.DriverErrorKindOf(err).Retryable() || ctx.Err() != nil {
+			metrics.CommandExecuted(deviceName, resourceNames(reqs), common.GetCmdMethod, err, time.Since(start))
+			return results, err
+		}
+		select {
+		case <-ctx.Done():
+			err = contextError(ctx)
+			metrics.CommandExecuted(deviceName, resourceNames(reqs), common.GetCmdMethod, err, time.Since(start))
+			return nil, err
+		case <-clock.After(time.Duration(attempt) * retryInterval):
+		}
+	}
+}
+
+func (d *limitedDriver) handleReadCommands(ctx context.Context, op *inflight.Op, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	if err := injectFault(); err != nil {
+		return nil, err
+	}
+	if err := d.acquire(ctx, deviceName); err != nil {
+		return nil, acquireError(ctx, err)
+	}
+	op.Running()
+	if handler, ok := d.ProtocolDriver.(dsModels.ContextCommandHandler); ok {
+		defer d.limiter.Release(deviceName)
+		return handler.HandleReadCommandsContext(ctx, deviceName, protocols, reqs)
+	}
+
+	type result struct {
+		values []*dsModels.CommandValue
+		err    error
+	}
+	done := make(chan result, 1)
+	release := op.Hold()
+	go func() {
+		// the limits and the operation are held until the driver returns, even if the
+		// caller gave up
+		defer release()
+		defer d.limiter.Release(deviceName)
+		values, err := d.ProtocolDriver.HandleReadCommands(deviceName, protocols, reqs)
+		done <- result{values, err}
+	}()
+	select {
+	case r := <-done:
+		return r.values, r.err
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
+}
+
+func (d *limitedDriver) HandleWriteCommands(deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	return d.HandleWriteCommandsContext(context.Background(), deviceName, protocols, reqs, params)
+}
+
+// HandleWriteCommandsContext is HandleWriteCommands giving up once the context is done or
+// the operation is cancelled through the in-flight registry.
+// The wrapped driver keeps running the command if it doesn't implement ContextCommandHandler.
+func (d *limitedDriver) HandleWriteCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	ctx, op := inflight.Begin(ctx, deviceName, resourceNames(reqs), common.SetCmdMethod)
+	defer op.Done()
+	start := time.Now()
+	err := d.handleWriteCommands(ctx, op, deviceName, common.DriverProtocols(protocols), reqs, params)
+	metrics.CommandExecuted(deviceName, resourceNames(reqs), common.SetCmdMethod, err, time.Since(start))
+	if err == nil {
+		lastvalue.Invalidate(deviceName, resourceNames(reqs))
+	}
+	return err
+}
+
+func (d *limitedDriver) handleWriteCommands(ctx context.Context, op *inflight.Op, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	if err := injectFault(); err != nil {
+		return err
+	}
+	if err := d.acquire(ctx, deviceName); err != nil {
+		return acquireError(ctx, err)
+	}
+	op.Running()
+	if handler, ok := d.ProtocolDriver.(dsModels.ContextCommandHandler); ok {
+		defer d.limiter.Release(deviceName)
+		return handler.HandleWriteCommandsContext(ctx, deviceName, protocols, reqs, params)
+	}
+
+	done := make(chan error, 1)
+	release := op.Hold()
+	go func() {
+		defer release()
+		defer d.limiter.Release(deviceName)
+		done <- d.ProtocolDriver.HandleWriteCommands(deviceName, protocols, reqs, params)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return contextError(ctx)
+	}
+}
+
+// acquire acquires the limits for a command of the device, after the on-demand commands
+// waiting for it if the command is in the background and Writable.CommandPriority is
+// PriorityInteractive. The on-demand command is rejected with common.ErrDeviceQueueFull if
+// Writable.MaxDeviceQueuedCommands are already waiting for the device.
+func (d *limitedDriver) acquire(ctx context.Context, deviceName string) error {
+	maxGlobal, maxDevice := d.config.Writable.MaxConcurrentCommands, d.config.Writable.MaxDeviceConcurrentCommands
+	if ctx.Value(backgroundKey{}) != nil {
+		if d.config.Writable.CommandPriority != PriorityNone {
+			return d.limiter.AcquireBackground(ctx, deviceName, maxGlobal, maxDevice)
+		}
+		return d.limiter.AcquireContext(ctx, deviceName, maxGlobal, maxDevice)
+	}
+	return d.limiter.AcquireQueued(ctx, deviceName, maxGlobal, maxDevice, d.config.Writable.MaxDeviceQueuedCommands)
+}
+
+// injectFault applies the fault injected at the driver commands, a drop being reported
+// as a timeout.
+func injectFault() error {
+	switch err := chaos.Inject(chaos.PointDriver); err {
+	case nil:
+		return nil
+	case chaos.ErrDropped:
+		return dsModels.NewDriverError(dsModels.Timeout, err)
+	default:
+		return dsModels.NewDriverError(dsModels.NotReachable, err)
+	}
+}
+
+// resourceNames returns the names of the deviceResources of the requests.
+func resourceNames(reqs []dsModels.CommandRequest) []string {
+	names := make([]string, len(reqs))
+	for i, req := range reqs {
+		names[i] = req.DeviceResourceName
+	}
+	return names
+}
+
+// acquireError returns the error of a command whose limits couldn't be acquired.
+func acquireError(ctx context.Context, err error) error {
+	if err == common.ErrDeviceQueueFull {
+		return err
+	}
+	return contextError(ctx)
+}
+
+// contextError returns the error of the done context, a Timeout DriverError if its
+// deadline expired.
+func contextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return dsModels.NewDriverError(dsModels.Timeout, ctx.Err())
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,103 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/inflight"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// TransactionHandler runs the phases of the transactions of a TransactionalDriver within
+// the limits of the commands, registered in flight as writes of the deviceResources of
+// reqs, and gives up once the context is done or the phase is cancelled through the
+// in-flight registry. The wrapped driver keeps running the phase it was given up on.
+type TransactionHandler interface {
+	PrepareWriteContext(ctx context.Context, transactionId string, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error
+	CommitWriteContext(ctx context.Context, transactionId string, deviceName string, reqs []dsModels.CommandRequest) error
+	AbortWriteContext(ctx context.Context, transactionId string, deviceName string, reqs []dsModels.CommandRequest) error
+}
+
+// Transactional returns the TransactionHandler of driver if it was returned by NewDriver
+// and the driver it wraps is a TransactionalDriver.
+func Transactional(driver dsModels.ProtocolDriver) (TransactionHandler, bool) {
+	d, ok := driver.(*limitedDriver)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := d.ProtocolDriver.(dsModels.TransactionalDriver); !ok {
+		return nil, false
+	}
+	return d, true
+}
+
+// PrepareWriteContext prepares the write with the faults injected at the driver commands,
+// a failed preparation being recorded as a failed write.
+func (d *limitedDriver) PrepareWriteContext(ctx context.Context, transactionId string, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	start := time.Now()
+	err := injectFault()
+	if err == nil {
+		err = d.runPhase(ctx, deviceName, reqs, func(driver dsModels.TransactionalDriver) error {
+			return driver.PrepareWrite(transactionId, deviceName, common.DriverProtocols(protocols), reqs, params)
+		})
+	}
+	if err != nil {
+		metrics.CommandExecuted(deviceName, resourceNames(reqs), common.SetCmdMethod, err, time.Since(start))
+	}
+	return err
+}
+
+// CommitWriteContext commits the prepared write, recorded as the write executed.
+func (d *limitedDriver) CommitWriteContext(ctx context.Context, transactionId string, deviceName string, reqs []dsModels.CommandRequest) error {
+	start := time.Now()
+	err := d.runPhase(ctx, deviceName, reqs, func(driver dsModels.TransactionalDriver) error {
+		return driver.CommitWrite(transactionId, deviceName)
+	})
+	metrics.CommandExecuted(deviceName, resourceNames(reqs), common.SetCmdMethod, err, time.Since(start))
+	if err == nil {
+		lastvalue.Invalidate(deviceName, resourceNames(reqs))
+	}
+	return err
+}
+
+func (d *limitedDriver) AbortWriteContext(ctx context.Context, transactionId string, deviceName string, reqs []dsModels.CommandRequest) error {
+	return d.runPhase(ctx, deviceName, reqs, func(driver dsModels.TransactionalDriver) error {
+		return driver.AbortWrite(transactionId, deviceName)
+	})
+}
+
+func (d *limitedDriver) runPhase(ctx context.Context, deviceName string, reqs []dsModels.CommandRequest, phase func(dsModels.TransactionalDriver) error) error {
+	ctx, op := inflight.Begin(ctx, deviceName, resourceNames(reqs), common.SetCmdMethod)
+	defer op.Done()
+	if err := d.acquire(ctx, deviceName); err != nil {
+		return acquireError(ctx, err)
+	}
+	op.Running()
+
+	result := make(chan error, 1)
+	release := op.Hold()
+	go func() {
+		// the limits and the operation are held until the driver returns, even if the
+		// caller gave up
+		defer release()
+		defer d.limiter.Release(deviceName)
+		result <- phase(d.ProtocolDriver.(dsModels.TransactionalDriver))
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return contextError(ctx)
+	}
+}
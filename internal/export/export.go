@@ -0,0 +1,170 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package export retains the recent events of the Device Service so that they can
+// be exported as a compressed and signed bundle, e.g. to carry the data of a
+// disconnected deployment to a connected site.
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+)
+
+const (
+	// SigningKeySecret is the key of the bundle signing key in the secret path
+	// configured by Device.Export.SecretPath.
+	SigningKeySecret = "signingKey"
+
+	hmacSignaturePrefix   = "hmac-sha256="
+	digestSignaturePrefix = "sha256="
+)
+
+// Bundle is the set of events exported at once.
+type Bundle struct {
+	Id      string           `json:"id"`
+	Service string           `json:"service"`
+	Created int64            `json:"created"`
+	Events  []contract.Event `json:"events"`
+}
+
+type entry struct {
+	seq   uint64
+	event contract.Event
+}
+
+// Buffer retains the most recent events until they are marked as exported.
+type Buffer struct {
+	capacity int
+	entries  []entry
+	nextSeq  uint64
+	bundles  map[string]uint64 // key is Bundle id, value is the last seq exported in it
+	mutex    sync.Mutex
+}
+
+var b *Buffer
+
+// NewBuffer initiates the export buffer which retains up to capacity events.
+// The export is disabled when capacity isn't positive.
+func NewBuffer(capacity int) {
+	if capacity <= 0 {
+		b = nil
+		return
+	}
+	b = &Buffer{
+		capacity: capacity,
+		entries:  make([]entry, 0, capacity),
+		bundles:  make(map[string]uint64),
+	}
+}
+
+// GetBuffer returns the export buffer, which may be nil if the export is disabled.
+func GetBuffer() *Buffer {
+	return b
+}
+
+// Record retains the event for export, discarding the oldest event once the buffer is full.
+func (b *Buffer) Record(event contract.Event) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.entries) == b.capacity {
+		b.entries = b.entries[1:]
+	}
+	b.nextSeq++
+	b.entries = append(b.entries, entry{seq: b.nextSeq, event: event})
+}
+
+// Export returns a Bundle of all the retained events which are not yet marked as
+// exported. The events remain in the buffer until MarkExported is called with the
+// Bundle id, so that a lost bundle can be exported again.
+func (b *Buffer) Export(serviceName string) (Bundle, error) {
+	if b == nil {
+		return Bundle{}, fmt.Errorf("events export is disabled")
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.entries) == 0 {
+		return Bundle{}, fmt.Errorf("no events to export")
+	}
+
+	bundle := Bundle{
+		Id:      uuid.New().String(),
+		Service: serviceName,
+		Created: time.Now().UnixNano(),
+		Events:  make([]contract.Event, len(b.entries)),
+	}
+	for i, e := range b.entries {
+		bundle.Events[i] = e.event
+	}
+	b.bundles[bundle.Id] = b.entries[len(b.entries)-1].seq
+	return bundle, nil
+}
+
+// MarkExported discards the events of the Bundle from the buffer once the bundle
+// has been delivered.
+func (b *Buffer) MarkExported(bundleId string) error {
+	if b == nil {
+		return fmt.Errorf("events export is disabled")
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	lastSeq, ok := b.bundles[bundleId]
+	if !ok {
+		return fmt.Errorf("bundle %s not found", bundleId)
+	}
+
+	i := 0
+	for i < len(b.entries) && b.entries[i].seq <= lastSeq {
+		i++
+	}
+	b.entries = b.entries[i:]
+	// earlier bundles only contain events which are now discarded
+	for id, seq := range b.bundles {
+		if seq <= lastSeq {
+			delete(b.bundles, id)
+		}
+	}
+	return nil
+}
+
+// Encode compresses the Bundle and signs the compressed data with HMAC-SHA256 using
+// the key. The data is only digested with SHA-256 when no key is given.
+func Encode(bundle Bundle, key []byte) (data []byte, signature string, err error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Name = bundle.Id + ".json"
+	if err = json.NewEncoder(zw).Encode(bundle); err != nil {
+		return nil, "", err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, "", err
+	}
+	data = buf.Bytes()
+
+	if len(key) == 0 {
+		digest := sha256.Sum256(data)
+		return data, digestSignaturePrefix + hex.EncodeToString(digest[:]), nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return data, hmacSignaturePrefix + hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,89 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer_ExportAndMarkExported(t *testing.T) {
+	NewBuffer(2)
+	buffer := GetBuffer()
+	require.NotNil(t, buffer)
+
+	_, err := buffer.Export("test-service")
+	assert.Error(t, err, "exporting an empty buffer should fail")
+
+	buffer.Record(contract.Event{Device: "d1"})
+	buffer.Record(contract.Event{Device: "d2"})
+	buffer.Record(contract.Event{Device: "d3"})
+
+	first, err := buffer.Export("test-service")
+	require.NoError(t, err)
+	require.Len(t, first.Events, 2, "oldest event should be discarded once the buffer is full")
+	assert.Equal(t, "d2", first.Events[0].Device)
+	assert.Equal(t, "d3", first.Events[1].Device)
+
+	buffer.Record(contract.Event{Device: "d4"})
+	second, err := buffer.Export("test-service")
+	require.NoError(t, err)
+	assert.Len(t, second.Events, 2, "events of an unacknowledged bundle should be exported again")
+
+	require.NoError(t, buffer.MarkExported(first.Id))
+	third, err := buffer.Export("test-service")
+	require.NoError(t, err)
+	require.Len(t, third.Events, 1)
+	assert.Equal(t, "d4", third.Events[0].Device)
+
+	assert.Error(t, buffer.MarkExported("unknown"))
+}
+
+func TestBuffer_Disabled(t *testing.T) {
+	NewBuffer(0)
+	buffer := GetBuffer()
+	assert.Nil(t, buffer)
+
+	buffer.Record(contract.Event{Device: "d1"})
+	_, err := buffer.Export("test-service")
+	assert.Error(t, err)
+}
+
+func TestEncode(t *testing.T) {
+	bundle := Bundle{Id: "b1", Service: "test-service", Events: []contract.Event{{Device: "d1"}}}
+
+	tests := []struct {
+		name   string
+		key    []byte
+		prefix string
+	}{
+		{"unsigned", nil, digestSignaturePrefix},
+		{"signed", []byte("secret"), hmacSignaturePrefix},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, signature, err := Encode(bundle, testCase.key)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(signature, testCase.prefix))
+
+			zr, err := gzip.NewReader(bytes.NewReader(data))
+			require.NoError(t, err)
+			var decoded Bundle
+			require.NoError(t, json.NewDecoder(zr).Decode(&decoded))
+			assert.Equal(t, bundle.Id, decoded.Id)
+			require.Len(t, decoded.Events, 1)
+			assert.Equal(t, "d1", decoded.Events[0].Device)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package federation lets the deviceResources of a composite Device be backed by the
+// commands of Devices owned by other Device Services, invoked through Core Command.
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/command"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// DeviceAttribute names the remote Device backing the deviceResource.
+	DeviceAttribute = common.SDKReservedPrefix + "federatedDevice"
+	// CommandAttribute names the command of the remote Device, the remote resource by default.
+	CommandAttribute = common.SDKReservedPrefix + "federatedCommand"
+	// ResourceAttribute names the reading of the remote command, the deviceResource by default.
+	ResourceAttribute = common.SDKReservedPrefix + "federatedResource"
+)
+
+// target is a command of a remote Device.
+type target struct {
+	device  string
+	command string
+}
+
+// remote returns the remote command and resource backing the request of the Device, if
+// any, refusing a Device backed by itself as the command would invoke itself endlessly.
+func remote(deviceName string, req dsModels.CommandRequest) (target, string, bool, error) {
+	device := req.Attributes[DeviceAttribute]
+	if device == "" {
+		return target{}, "", false, nil
+	}
+	if device == deviceName {
+		return target{}, "", false, dsModels.NewDriverError(dsModels.BadRequest,
+			fmt.Errorf("deviceResource %s of Device %s is federated to the Device itself", req.DeviceResourceName, deviceName))
+	}
+	resource := req.Attributes[ResourceAttribute]
+	if resource == "" {
+		resource = req.DeviceResourceName
+	}
+	cmd := req.Attributes[CommandAttribute]
+	if cmd == "" {
+		cmd = resource
+	}
+	return target{device: device, command: cmd}, resource, true, nil
+}
+
+// driver is a ProtocolDriver executing the federated requests through Core Command and
+// passing the other requests to the wrapped driver.
+type driver struct {
+	dsModels.ProtocolDriver
+	client  command.CommandClient
+	timeout time.Duration
+	lc      logger.LoggingClient
+}
+
+// contextDriver is a driver wrapping a ContextCommandHandler.
+type contextDriver struct {
+	*driver
+}
+
+// NewDriver wraps the ProtocolDriver so that the requests for deviceResources having the
+// DeviceAttribute are executed through the client instead, each remote command being
+// invoked once per request batch. The returned driver implements ContextCommandHandler
+// if the wrapped driver does.
+//
+// A write is applied to the remote Devices first and to the wrapped driver last. If one
+// fails, the remote commands already invoked are rolled back on a best-effort basis with
+// the values read beforehand.
+func NewDriver(wrapped dsModels.ProtocolDriver, client command.CommandClient, timeout time.Duration, lc logger.LoggingClient) dsModels.ProtocolDriver {
+	d := &driver{ProtocolDriver: wrapped, client: client, timeout: timeout, lc: lc}
+	if _, ok := wrapped.(dsModels.ContextCommandHandler); ok {
+		return contextDriver{d}
+	}
+	return d
+}
+
+func (d *driver) HandleReadCommands(deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	return d.handleReadCommands(context.Background(), deviceName, protocols, reqs, func(local []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+		return d.ProtocolDriver.HandleReadCommands(deviceName, protocols, local)
+	})
+}
+
+func (d *driver) HandleWriteCommands(deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	return d.handleWriteCommands(context.Background(), deviceName, reqs, params, func(local []dsModels.CommandRequest, localParams []*dsModels.CommandValue) error {
+		return d.ProtocolDriver.HandleWriteCommands(deviceName, protocols, local, localParams)
+	})
+}
+
+func (d contextDriver) HandleReadCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	return d.handleReadCommands(ctx, deviceName, protocols, reqs, func(local []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+		return d.ProtocolDriver.(dsModels.ContextCommandHandler).HandleReadCommandsContext(ctx, deviceName, protocols, local)
+	})
+}
+
+func (d contextDriver) HandleWriteCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	return d.handleWriteCommands(ctx, deviceName, reqs, params, func(local []dsModels.CommandRequest, localParams []*dsModels.CommandValue) error {
+		return d.ProtocolDriver.(dsModels.ContextCommandHandler).HandleWriteCommandsContext(ctx, deviceName, protocols, local, localParams)
+	})
+}
+
+func (d *driver) handleReadCommands(
+	ctx context.Context,
+	deviceName string,
+	protocols map[string]contract.ProtocolProperties,
+	reqs []dsModels.CommandRequest,
+	readLocal func([]dsModels.CommandRequest) ([]*dsModels.CommandValue, error)) ([]*dsModels.CommandValue, error) {
+
+	var local []dsModels.CommandRequest
+	var localIndexes []int
+	results := make([]*dsModels.CommandValue, len(reqs))
+	readings := make(map[target]map[string]contract.Reading)
+	for i, req := range reqs {
+		t, resource, ok, err := remote(deviceName, req)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			local = append(local, req)
+			localIndexes = append(localIndexes, i)
+			continue
+		}
+		if _, ok := readings[t]; !ok {
+			r, err := d.get(ctx, t)
+			if err != nil {
+				return nil, err
+			}
+			readings[t] = r
+		}
+		reading, ok := readings[t][resource]
+		if !ok {
+			return nil, dsModels.NewDriverError(dsModels.BadRequest,
+				fmt.Errorf("command %s of Device %s has no reading %s", t.command, t.device, resource))
+		}
+		cv, err := commandValue(req, reading)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = cv
+	}
+
+	if len(local) > 0 {
+		values, err := readLocal(local)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != len(local) {
+			return nil, fmt.Errorf("driver returned %d values for %d read commands of Device %s", len(values), len(local), deviceName)
+		}
+		for i, v := range values {
+			results[localIndexes[i]] = v
+		}
+	}
+	return results, nil
+}
+
+func (d *driver) handleWriteCommands(
+	ctx context.Context,
+	deviceName string,
+	reqs []dsModels.CommandRequest,
+	params []*dsModels.CommandValue,
+	writeLocal func([]dsModels.CommandRequest, []*dsModels.CommandValue) error) error {
+
+	var local []dsModels.CommandRequest
+	var localParams []*dsModels.CommandValue
+	var targets []target
+	bodies := make(map[target]map[string]string)
+	for i, req := range reqs {
+		t, resource, ok, err := remote(deviceName, req)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			local = append(local, req)
+			localParams = append(localParams, params[i])
+			continue
+		}
+		// Core Command takes the values of a write as strings
+		if params[i].Type == v2.ValueTypeBinary {
+			return dsModels.NewDriverError(dsModels.BadRequest,
+				fmt.Errorf("binary value of the federated deviceResource %s of Device %s can't be written", req.DeviceResourceName, deviceName))
+		}
+		if _, ok := bodies[t]; !ok {
+			bodies[t] = make(map[string]string)
+			targets = append(targets, t)
+		}
+		bodies[t][resource] = params[i].ValueToString()
+	}
+
+	var written []target
+	previous := make(map[target]map[string]string)
+	for i, t := range targets {
+		// the current values are needed only if a later write fails
+		if i < len(targets)-1 || len(local) > 0 {
+			previous[t] = d.current(ctx, t, bodies[t])
+		}
+		if err := d.put(ctx, t, bodies[t]); err != nil {
+			d.rollback(written, previous)
+			return err
+		}
+		written = append(written, t)
+	}
+	if len(local) > 0 {
+		if err := writeLocal(local, localParams); err != nil {
+			d.rollback(written, previous)
+			return err
+		}
+	}
+	return nil
+}
+
+// current reads the current values of the resources of the remote command, nil if they
+// can't all be read.
+func (d *driver) current(ctx context.Context, t target, values map[string]string) map[string]string {
+	readings, err := d.get(ctx, t)
+	if err != nil {
+		d.lc.Debug(fmt.Sprintf("Federation: failed to read command %s of Device %s before the write, it can't be rolled back: %v", t.command, t.device, err))
+		return nil
+	}
+	result := make(map[string]string, len(values))
+	for resource := range values {
+		reading, ok := readings[resource]
+		if !ok || reading.ValueType == v2.ValueTypeBinary {
+			return nil
+		}
+		result[resource] = reading.Value
+	}
+	return result
+}
+
+// rollback restores the values of the remote commands written, on a best-effort basis.
+func (d *driver) rollback(written []target, previous map[target]map[string]string) {
+	for _, t := range written {
+		if previous[t] == nil {
+			d.lc.Warn(fmt.Sprintf("Federation: command %s of Device %s written but not rolled back, its values weren't read", t.command, t.device))
+			continue
+		}
+		if err := d.put(context.Background(), t, previous[t]); err != nil {
+			d.lc.Warn(fmt.Sprintf("Federation: failed to roll back command %s of Device %s: %v", t.command, t.device, err))
+		}
+	}
+}
+
+// get invokes the remote command and returns its readings by name.
+func (d *driver) get(ctx context.Context, t target) (map[string]contract.Reading, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	body, err := d.client.GetDeviceCommandByNames(ctx, t.device, t.command)
+	if err != nil {
+		return nil, remoteError(ctx, t, err)
+	}
+	var event contract.Event
+	if err := json.Unmarshal([]byte(body), &event); err != nil {
+		return nil, fmt.Errorf("failed to decode the event of command %s of Device %s: %v", t.command, t.device, err)
+	}
+	readings := make(map[string]contract.Reading, len(event.Readings))
+	for _, r := range event.Readings {
+		readings[r.Name] = r
+	}
+	d.lc.Debug(fmt.Sprintf("Federation: read %d readings from command %s of Device %s", len(readings), t.command, t.device))
+	return readings, nil
+}
+
+// put invokes the remote command with the values of its resources.
+func (d *driver) put(ctx context.Context, t target, values map[string]string) error {
+	body, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	if _, err := d.client.PutDeviceCommandByNames(ctx, t.device, t.command, string(body)); err != nil {
+		return remoteError(ctx, t, err)
+	}
+	d.lc.Debug(fmt.Sprintf("Federation: wrote %d values to command %s of Device %s", len(values), t.command, t.device))
+	return nil
+}
+
+// commandValue converts the remote reading to the value type of the request.
+func commandValue(req dsModels.CommandRequest, reading contract.Reading) (*dsModels.CommandValue, error) {
+	var cv *dsModels.CommandValue
+	var err error
+	if req.Type == v2.ValueTypeBinary {
+		cv, err = dsModels.NewBinaryValue(req.DeviceResourceName, 0, reading.BinaryValue)
+	} else {
+		dr := &contract.DeviceResource{
+			Name:       req.DeviceResourceName,
+			Properties: contract.ProfileProperty{Value: contract.PropertyValue{Type: req.Type}},
+		}
+		cv, err = common.CreateCommandValueFromDeviceResource(dr, reading.Value)
+	}
+	if err != nil {
+		return nil, dsModels.NewDriverError(dsModels.BadRequest,
+			fmt.Errorf("failed to convert reading %s of Device %s to %s: %v", reading.Name, reading.Device, req.Type, err))
+	}
+	if reading.Origin != 0 {
+		cv.Origin = reading.Origin
+	}
+	return cv, nil
+}
+
+func remoteError(ctx context.Context, t target, err error) error {
+	err = fmt.Errorf("command %s of Device %s failed: %v", t.command, t.device, err)
+	if ctx.Err() == context.DeadlineExceeded {
+		return dsModels.NewDriverError(dsModels.Timeout, err)
+	}
+	// the composite Device stays enabled when a Device it's built from is unreachable
+	return err
+}
@@ -0,0 +1,233 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+type commandClientStub struct {
+	events  map[string]contract.Event
+	gets    []string
+	puts    map[string]string
+	putLog  []string
+	putErrs map[string]error
+}
+
+func (c *commandClientStub) Get(_ context.Context, _ string, _ string) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (c *commandClientStub) Put(_ context.Context, _ string, _ string, _ string) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (c *commandClientStub) GetDeviceCommandByNames(_ context.Context, deviceName string, commandName string) (string, error) {
+	c.gets = append(c.gets, deviceName+"/"+commandName)
+	event, ok := c.events[deviceName+"/"+commandName]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	body, err := json.Marshal(event)
+	return string(body), err
+}
+
+func (c *commandClientStub) PutDeviceCommandByNames(_ context.Context, deviceName string, commandName string, body string) (string, error) {
+	c.putLog = append(c.putLog, deviceName+"/"+commandName+" "+body)
+	if err := c.putErrs[deviceName+"/"+commandName]; err != nil {
+		return "", err
+	}
+	c.puts[deviceName+"/"+commandName] = body
+	return "", nil
+}
+
+type driverStub struct {
+	dsModels.ProtocolDriver
+	reads    []string
+	writes   []string
+	writeErr error
+}
+
+func (d *driverStub) HandleReadCommands(_ string, _ map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	var values []*dsModels.CommandValue
+	for _, req := range reqs {
+		d.reads = append(d.reads, req.DeviceResourceName)
+		values = append(values, dsModels.NewStringValue(req.DeviceResourceName, 0, "local"))
+	}
+	return values, nil
+}
+
+func (d *driverStub) HandleWriteCommands(_ string, _ map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest, _ []*dsModels.CommandValue) error {
+	if d.writeErr != nil {
+		return d.writeErr
+	}
+	for _, req := range reqs {
+		d.writes = append(d.writes, req.DeviceResourceName)
+	}
+	return nil
+}
+
+func TestDriver(t *testing.T) {
+	client := &commandClientStub{
+		events: map[string]contract.Event{
+			"press/status": {Device: "press", Readings: []contract.Reading{
+				{Device: "press", Name: "temperature", Value: "21.5"},
+				{Device: "press", Name: "running", Value: "true"},
+			}},
+		},
+		puts: make(map[string]string),
+	}
+	wrapped := &driverStub{}
+	driver := NewDriver(wrapped, client, time.Second, logger.NewMockClient())
+
+	reqs := []dsModels.CommandRequest{
+		{DeviceResourceName: "temperature", Type: v2.ValueTypeFloat64,
+			Attributes: map[string]string{DeviceAttribute: "press", CommandAttribute: "status"}},
+		{DeviceResourceName: "label", Type: v2.ValueTypeString},
+		{DeviceResourceName: "pressRunning", Type: v2.ValueTypeBool,
+			Attributes: map[string]string{DeviceAttribute: "press", CommandAttribute: "status", ResourceAttribute: "running"}},
+	}
+	values, err := driver.HandleReadCommands("machine", nil, reqs)
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+
+	temperature, err := values[0].Float64Value()
+	require.NoError(t, err)
+	assert.Equal(t, 21.5, temperature)
+	label, err := values[1].StringValue()
+	require.NoError(t, err)
+	assert.Equal(t, "local", label)
+	running, err := values[2].BoolValue()
+	require.NoError(t, err)
+	assert.True(t, running)
+	assert.Equal(t, "pressRunning", values[2].DeviceResourceName)
+	assert.Equal(t, []string{"press/status"}, client.gets, "the remote command is invoked once")
+	assert.Equal(t, []string{"label"}, wrapped.reads)
+
+	_, err = driver.HandleReadCommands("machine", nil, []dsModels.CommandRequest{
+		{DeviceResourceName: "speed", Type: v2.ValueTypeFloat64, Attributes: map[string]string{DeviceAttribute: "press", CommandAttribute: "status"}},
+	})
+	assert.Equal(t, dsModels.BadRequest, dsModels.DriverErrorKindOf(err))
+
+	setpoint, err := dsModels.NewInt32Value("setpoint", 0, 40)
+	require.NoError(t, err)
+	err = driver.HandleWriteCommands("machine", nil,
+		[]dsModels.CommandRequest{
+			{DeviceResourceName: "setpoint", Type: v2.ValueTypeInt32, Attributes: map[string]string{DeviceAttribute: "press"}},
+			{DeviceResourceName: "label", Type: v2.ValueTypeString},
+		},
+		[]*dsModels.CommandValue{setpoint, dsModels.NewStringValue("label", 0, "line 1")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"press/setpoint": `{"setpoint":"40"}`}, client.puts)
+	assert.Equal(t, []string{"label"}, wrapped.writes)
+}
+
+func TestDriverBinary(t *testing.T) {
+	client := &commandClientStub{
+		events: map[string]contract.Event{
+			"camera/image": {Device: "camera", Readings: []contract.Reading{
+				{Device: "camera", Name: "image", ValueType: v2.ValueTypeBinary, BinaryValue: []byte{1, 2, 3}, MediaType: "image/png"},
+			}},
+		},
+		puts: make(map[string]string),
+	}
+	driver := NewDriver(&driverStub{}, client, time.Second, logger.NewMockClient())
+	attributes := map[string]string{DeviceAttribute: "camera"}
+
+	values, err := driver.HandleReadCommands("machine", nil, []dsModels.CommandRequest{
+		{DeviceResourceName: "image", Type: v2.ValueTypeBinary, Attributes: attributes},
+	})
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	image, err := values[0].BinaryValue()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, image)
+
+	param, err := dsModels.NewBinaryValue("image", 0, []byte{4})
+	require.NoError(t, err)
+	err = driver.HandleWriteCommands("machine", nil, []dsModels.CommandRequest{
+		{DeviceResourceName: "image", Type: v2.ValueTypeBinary, Attributes: attributes},
+	}, []*dsModels.CommandValue{param})
+	assert.Equal(t, dsModels.BadRequest, dsModels.DriverErrorKindOf(err))
+	assert.Empty(t, client.putLog)
+}
+
+func TestDriverFederatedToItself(t *testing.T) {
+	client := &commandClientStub{puts: make(map[string]string)}
+	wrapped := &driverStub{}
+	driver := NewDriver(wrapped, client, time.Second, logger.NewMockClient())
+	reqs := []dsModels.CommandRequest{
+		{DeviceResourceName: "label", Type: v2.ValueTypeString},
+		{DeviceResourceName: "mirror", Type: v2.ValueTypeString, Attributes: map[string]string{DeviceAttribute: "machine"}},
+	}
+
+	_, err := driver.HandleReadCommands("machine", nil, reqs)
+	assert.Equal(t, dsModels.BadRequest, dsModels.DriverErrorKindOf(err))
+	err = driver.HandleWriteCommands("machine", nil, reqs,
+		[]*dsModels.CommandValue{dsModels.NewStringValue("label", 0, "a"), dsModels.NewStringValue("mirror", 0, "b")})
+	assert.Equal(t, dsModels.BadRequest, dsModels.DriverErrorKindOf(err))
+	assert.Empty(t, client.gets)
+	assert.Empty(t, client.putLog)
+	assert.Empty(t, wrapped.reads)
+	assert.Empty(t, wrapped.writes)
+}
+
+func TestDriverWriteRollback(t *testing.T) {
+	reqs := []dsModels.CommandRequest{
+		{DeviceResourceName: "label", Type: v2.ValueTypeString},
+		{DeviceResourceName: "setpoint", Type: v2.ValueTypeString, Attributes: map[string]string{DeviceAttribute: "press"}},
+		{DeviceResourceName: "speed", Type: v2.ValueTypeString, Attributes: map[string]string{DeviceAttribute: "pump"}},
+	}
+	params := []*dsModels.CommandValue{
+		dsModels.NewStringValue("label", 0, "line 1"),
+		dsModels.NewStringValue("setpoint", 0, "40"),
+		dsModels.NewStringValue("speed", 0, "5"),
+	}
+	newClient := func() *commandClientStub {
+		return &commandClientStub{
+			events: map[string]contract.Event{
+				"press/setpoint": {Device: "press", Readings: []contract.Reading{{Device: "press", Name: "setpoint", Value: "20"}}},
+				"pump/speed":     {Device: "pump", Readings: []contract.Reading{{Device: "pump", Name: "speed", Value: "1"}}},
+			},
+			puts:    make(map[string]string),
+			putErrs: make(map[string]error),
+		}
+	}
+
+	t.Run("remote failure", func(t *testing.T) {
+		client := newClient()
+		client.putErrs["pump/speed"] = errors.New("unreachable")
+		wrapped := &driverStub{}
+		driver := NewDriver(wrapped, client, time.Second, logger.NewMockClient())
+
+		assert.Error(t, driver.HandleWriteCommands("machine", nil, reqs, params))
+		assert.Equal(t, []string{`press/setpoint {"setpoint":"40"}`, `pump/speed {"speed":"5"}`, `press/setpoint {"setpoint":"20"}`}, client.putLog,
+			"the remote writes applied are rolled back")
+		assert.Empty(t, wrapped.writes, "the local write is applied last")
+	})
+
+	t.Run("local failure", func(t *testing.T) {
+		client := newClient()
+		wrapped := &driverStub{writeErr: errors.New("write failed")}
+		driver := NewDriver(wrapped, client, time.Second, logger.NewMockClient())
+
+		assert.Error(t, driver.HandleWriteCommands("machine", nil, reqs, params))
+		assert.Equal(t, map[string]string{"press/setpoint": `{"setpoint":"20"}`, "pump/speed": `{"speed":"1"}`}, client.puts)
+	})
+}
@@ -16,7 +16,14 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/counter"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/delta"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/naming"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/playback"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/provision"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/resolver"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/smoothing"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/coredata"
@@ -67,8 +74,15 @@ func handleAddDevice(ctx context.Context, id string, dic *di.Container) common.A
 		return appErr
 	}
 
+	// the Device taking the name is replaced according to the collision policy
+	if existing, ok := cache.Devices().ForName(device.Name); ok && existing.Id != device.Id && naming.Policy() == naming.CollisionReplace {
+		lc.Info(fmt.Sprintf("Replacing device %s with id %s", device.Name, device.Id))
+		_ = cache.Devices().RemoveByName(device.Name)
+	}
+
 	err = cache.Devices().Add(device)
 	if err == nil {
+		resolver.Put(resolver.Device, device.Id, device.Name)
 		lc.Info(fmt.Sprintf("Added device: %s", device.Name))
 	} else {
 		appErr := common.NewServerError(err.Error(), err)
@@ -76,8 +90,8 @@ func handleAddDevice(ctx context.Context, id string, dic *di.Container) common.A
 		return appErr
 	}
 
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err = driver.AddDevice(device.Name, device.Protocols, device.AdminState)
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	err = driver.AddDevice(device.Name, common.DriverProtocols(device.Protocols), device.AdminState)
 	if err == nil {
 		lc.Debug(fmt.Sprintf("Invoked driver.AddDevice callback for %s", device.Name))
 	} else {
@@ -88,6 +102,7 @@ func handleAddDevice(ctx context.Context, id string, dic *di.Container) common.A
 
 	lc.Debug(fmt.Sprintf("Handler - starting AutoEvents for device %s", device.Name))
 	autoevent.GetManager().RestartForDevice(device.Name, dic)
+	playback.GetManager().RestartForDevice(device.Name)
 
 	return nil
 }
@@ -114,8 +129,12 @@ func handleUpdateDevice(ctx context.Context, id string, dic *di.Container) commo
 		return appErr
 	}
 
+	// the Device may have been renamed, the cache still has its previous definition by id
+	previous, _ := cache.Devices().ForId(device.Id)
+	previousName := previous.Name
 	err = cache.Devices().Update(device)
 	if err == nil {
+		resolver.Put(resolver.Device, device.Id, device.Name)
 		lc.Info(fmt.Sprintf("Updated device: %s", device.Name))
 	} else {
 		appErr := common.NewServerError(err.Error(), err)
@@ -123,8 +142,8 @@ func handleUpdateDevice(ctx context.Context, id string, dic *di.Container) commo
 		return appErr
 	}
 
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err = driver.UpdateDevice(device.Name, device.Protocols, device.AdminState)
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	err = common.UpdateDriverDevice(driver, previous, device)
 	if err == nil {
 		lc.Debug(fmt.Sprintf("Invoked driver.UpdateDevice callback for %s", device.Name))
 	} else {
@@ -133,8 +152,13 @@ func handleUpdateDevice(ctx context.Context, id string, dic *di.Container) commo
 		return appErr
 	}
 
+	if previousName != device.Name {
+		autoevent.GetManager().StopForDevice(previousName)
+		playback.GetManager().StopForDevice(previousName)
+	}
 	lc.Debug(fmt.Sprintf("Handler - restarting AutoEvents for updated device %s", device.Name))
 	autoevent.GetManager().RestartForDevice(device.Name, dic)
+	playback.GetManager().RestartForDevice(device.Name)
 
 	return nil
 }
@@ -143,9 +167,19 @@ func handleDeleteDevice(id string, dic *di.Container) common.AppError {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
 	device, ok := cache.Devices().ForId(id)
+	if !ok {
+		// the Device is only known by the resolver if it was removed from the cache meanwhile
+		device.Name, _ = resolver.Name(context.Background(), resolver.Device, id)
+	}
+	defer resolver.Invalidate(resolver.Device, id)
 	if ok {
 		lc.Debug(fmt.Sprintf("Handler - stopping AutoEvents for updated device %s", device.Name))
 		autoevent.GetManager().StopForDevice(device.Name)
+		playback.GetManager().StopForDevice(device.Name)
+		counter.Reset(device.Name)
+		smoothing.Reset(device.Name)
+		lastvalue.Remove(device.Name)
+		delta.GetFilter().Forget(device.Name)
 	}
 
 	err := cache.Devices().Remove(id)
@@ -157,8 +191,8 @@ func handleDeleteDevice(id string, dic *di.Container) common.AppError {
 		return appErr
 	}
 
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err = driver.RemoveDevice(device.Name, device.Protocols)
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	err = driver.RemoveDevice(device.Name, common.DriverProtocols(device.Protocols))
 	if err == nil {
 		lc.Debug(fmt.Sprintf("Invoked driver.RemoveDevice callback for %s", device.Name))
 	} else {
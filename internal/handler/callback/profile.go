@@ -16,6 +16,7 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/provision"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/resolver"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/google/uuid"
@@ -35,6 +36,7 @@ func handleProfile(method string, id string, dic *di.Container) common.AppError
 
 		err = cache.Profiles().Update(profile)
 		if err == nil {
+			resolver.Put(resolver.Profile, profile.Id, profile.Name)
 			provision.CreateDescriptorsFromProfile(
 				&profile,
 				lc,
@@ -42,12 +44,13 @@ func handleProfile(method string, id string, dic *di.Container) common.AppError
 				container.CoredataValueDescriptorClientFrom(dic.Get))
 			lc.Info(fmt.Sprintf("Updated device profile %s", id))
 			devices := cache.Devices().All()
-			driver := container.ProtocolDriverFrom(dic.Get)
+			driver := container.RawProtocolDriverFrom(dic.Get)
 			for _, d := range devices {
 				if d.Profile.Name == profile.Name {
+					previous := d
 					d.Profile = profile
 					_ = cache.Devices().Update(d)
-					err := driver.UpdateDevice(d.Name, d.Protocols, d.AdminState)
+					err := common.UpdateDriverDevice(driver, previous, d)
 					if err != nil {
 						lc.Error(fmt.Sprintf("Failed to update device in protocoldriver: %s", err))
 					}
@@ -14,6 +14,7 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/resolver"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 	"github.com/google/uuid"
@@ -24,18 +25,16 @@ func handleProvisionWatcher(method string, id string, dic *di.Container) common.
 	ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
 	switch method {
 	case http.MethodPost:
-		handleAddProvisionWatcher(ctx, id, dic)
+		return handleAddProvisionWatcher(ctx, id, dic)
 	case http.MethodPut:
-		handleUpdateProvisionWatcher(ctx, id, dic)
+		return handleUpdateProvisionWatcher(ctx, id, dic)
 	case http.MethodDelete:
-		handleDeleteProvisionWatcher(id, dic)
+		return handleDeleteProvisionWatcher(id, dic)
 	default:
 		lc.Error(fmt.Sprintf("Invalid provisionwatcher method type: %s", method))
 		appErr := common.NewBadRequestError("Invalid provisionwatcher method", nil)
 		return appErr
 	}
-
-	return nil
 }
 
 func handleAddProvisionWatcher(ctx context.Context, id string, dic *di.Container) common.AppError {
@@ -49,9 +48,15 @@ func handleAddProvisionWatcher(ctx context.Context, id string, dic *di.Container
 		return appErr
 	}
 
-	err = cache.ProvisionWatchers().Add(pw)
+	// the provision watcher may already be cached if it was added by the Device Service
+	if _, ok := cache.ProvisionWatchers().ForId(pw.Id); ok {
+		err = cache.ProvisionWatchers().Update(pw)
+	} else {
+		err = cache.ProvisionWatchers().Add(pw)
+	}
 	if err == nil {
-		lc.Info(fmt.Sprintf("Added provisionwatcher %s", id))
+		resolver.Put(resolver.ProvisionWatcher, pw.Id, pw.Name)
+		lc.Info(fmt.Sprintf("Added provisionwatcher %s", pw.Name))
 	} else {
 		appErr := common.NewServerError(err.Error(), err)
 		lc.Error(fmt.Sprintf("Cannot add provisionwatcher %s: %v", id, err.Error()))
@@ -72,9 +77,14 @@ func handleUpdateProvisionWatcher(ctx context.Context, id string, dic *di.Contai
 		return appErr
 	}
 
-	err = cache.ProvisionWatchers().Update(pw)
+	if _, ok := cache.ProvisionWatchers().ForId(pw.Id); ok {
+		err = cache.ProvisionWatchers().Update(pw)
+	} else {
+		err = cache.ProvisionWatchers().Add(pw)
+	}
 	if err == nil {
-		lc.Info(fmt.Sprintf("Updated provisionwatcher %s", id))
+		resolver.Put(resolver.ProvisionWatcher, pw.Id, pw.Name)
+		lc.Info(fmt.Sprintf("Updated provisionwatcher %s", pw.Name))
 	} else {
 		appErr := common.NewServerError(err.Error(), err)
 		lc.Error(fmt.Sprintf("Cannot update provisionwatcher %s: %v", id, err.Error()))
@@ -86,9 +96,11 @@ func handleUpdateProvisionWatcher(ctx context.Context, id string, dic *di.Contai
 
 func handleDeleteProvisionWatcher(id string, dic *di.Container) common.AppError {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	name, _ := resolver.Name(context.Background(), resolver.ProvisionWatcher, id)
+	defer resolver.Invalidate(resolver.ProvisionWatcher, id)
 	err := cache.ProvisionWatchers().Remove(id)
 	if err == nil {
-		lc.Info(fmt.Sprintf("Removed provisionwatcher %s", id))
+		lc.Info(fmt.Sprintf("Removed provisionwatcher %s", name))
 	} else {
 		appErr := common.NewServerError(err.Error(), err)
 		lc.Error(fmt.Sprintf("Cannot remove provisionwatcher %s: %v", id, err.Error()))
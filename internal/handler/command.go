@@ -15,14 +15,21 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/coalesce"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/confirmation"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/counter"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/executor"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/history"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/smoothing"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -36,6 +43,45 @@ import (
 // Note, every HTTP request to ServeHTTP is made in a separate goroutine, which
 // means care needs to be taken with respect to shared data accessed through *Server.
 func CommandHandler(vars map[string]string, body string, method string, queryParams string, dic *di.Container) (*dsModels.Event, common.AppError) {
+	return commandHandler(vars, body, method, queryParams, "", false, dic)
+}
+
+// CorrelatedCommandHandler executes the command as CommandHandler does, recording the
+// correlation id of the request with its execution.
+func CorrelatedCommandHandler(vars map[string]string, body string, method string, queryParams string, correlationID string, dic *di.Container) (*dsModels.Event, common.AppError) {
+	return commandHandler(vars, body, method, queryParams, correlationID, false, dic)
+}
+
+// AutoEventHandler reads the resource of an AutoEvent as CommandHandler does, but after
+// the on-demand commands waiting for the Device according to Writable.CommandPriority.
+func AutoEventHandler(vars map[string]string, dic *di.Container) (*dsModels.Event, common.AppError) {
+	return commandHandler(vars, "", common.GetCmdMethod, "", "", true, dic)
+}
+
+// commandHandler executes the command and records its execution in the history.
+func commandHandler(vars map[string]string, body string, method string, queryParams string, correlationID string, background bool, dic *di.Container) (*dsModels.Event, common.AppError) {
+	start := time.Now()
+	evt, appErr := executeCommand(vars, body, method, queryParams, background, dic)
+
+	deviceName := vars[common.NameVar]
+	if d, ok := cache.Devices().ForId(vars[common.IdVar]); ok {
+		deviceName = d.Name
+	}
+	source := history.SourceAPI
+	if background {
+		source = history.SourceAutoEvent
+	}
+	code, message := http.StatusOK, ""
+	if appErr != nil {
+		code, message = appErr.Code(), appErr.Message()
+	}
+	execution := history.NewExecution(start, deviceName, vars[common.CommandVar], strings.ToLower(method), source, code, message)
+	execution.CorrelationID = correlationID
+	history.GetBuffer().Record(execution)
+	return evt, appErr
+}
+
+func executeCommand(vars map[string]string, body string, method string, queryParams string, background bool, dic *di.Container) (*dsModels.Event, common.AppError) {
 	dKey := vars[common.IdVar]
 	cmd := vars[common.CommandVar]
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
@@ -46,7 +92,7 @@ func CommandHandler(vars map[string]string, body string, method string, queryPar
 		d, ok = cache.Devices().ForId(dKey)
 	} else {
 		dKey = vars[common.NameVar]
-		d, ok = cache.Devices().ForName(dKey)
+		d, ok = cache.Devices().ForNameOrAlias(dKey)
 	}
 	if !ok {
 		msg := fmt.Sprintf("Device: %s not found; %s", dKey, method)
@@ -77,6 +123,10 @@ func CommandHandler(vars map[string]string, body string, method string, queryPar
 		return nil, common.NewServerError(msg, err)
 	}
 
+	driver := container.ProtocolDriverFrom(dic.Get)
+	if background {
+		driver = executor.Background(driver)
+	}
 	var evt *dsModels.Event = nil
 	var appErr common.AppError
 	if !cmdExists {
@@ -90,30 +140,32 @@ func CommandHandler(vars map[string]string, body string, method string, queryPar
 		if strings.ToLower(method) == common.GetCmdMethod {
 			evt, appErr = execReadDeviceResource(
 				&d, &dr, queryParams,
-				container.ProtocolDriverFrom(dic.Get),
+				driver,
 				lc,
 				container.MetadataDeviceClientFrom(dic.Get),
 				container.ConfigurationFrom(dic.Get))
 		} else {
 			appErr = execWriteDeviceResource(
 				&d, &dr, body,
-				container.ProtocolDriverFrom(dic.Get),
+				driver,
 				lc,
+				container.MetadataDeviceClientFrom(dic.Get),
 				container.ConfigurationFrom(dic.Get))
 		}
 	} else {
 		if strings.ToLower(method) == common.GetCmdMethod {
 			evt, appErr = execReadCmd(
 				&d, cmd, queryParams,
-				container.ProtocolDriverFrom(dic.Get),
+				driver,
 				lc,
 				container.MetadataDeviceClientFrom(dic.Get),
 				container.ConfigurationFrom(dic.Get))
 		} else {
 			appErr = execWriteCmd(
 				&d, cmd, body,
-				container.ProtocolDriverFrom(dic.Get),
+				driver,
 				lc,
+				container.MetadataDeviceClientFrom(dic.Get),
 				container.ConfigurationFrom(dic.Get))
 		}
 	}
@@ -138,6 +190,12 @@ func execReadDeviceResource(
 	var req dsModels.CommandRequest
 	lc.Debug(fmt.Sprintf("Handler - execReadCmd: deviceResource: %s", dr.Name))
 
+	if dr.Properties.Value.ReadWrite == common.DeviceResourceWriteOnly {
+		msg := fmt.Sprintf("Handler - execReadCmd: deviceResource: %s for dev: %s is write-only", dr.Name, device.Name)
+		lc.Error(msg)
+		return nil, common.NewBadRequestError(msg, nil)
+	}
+
 	req.DeviceResourceName = dr.Name
 	req.Attributes = dr.Attributes
 	if queryParams != "" {
@@ -151,9 +209,10 @@ func execReadDeviceResource(
 	reqs = append(reqs, req)
 
 	results, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	transformer.CheckDriverError(err, device, lc, dc)
 	if err != nil {
 		msg := fmt.Sprintf("Handler - execReadCmd: error for Device: %s DeviceResource: %s, %v", device.Name, dr.Name, err)
-		return nil, common.NewServerError(msg, err)
+		return nil, common.NewDriverError(msg, err)
 	}
 
 	return cvsToEvent(device, results, dr.Name, lc, dc, configuration)
@@ -181,6 +240,12 @@ func cvsToEvent(
 
 		if configuration.Device.DataTransform {
 			err = transformer.TransformReadResult(cv, dr.Properties.Value, lc)
+			if err == nil {
+				err = transformer.CalibrateReadResult(cv, device.Name, lc)
+			}
+			if err == nil {
+				cv, err = transformer.ApplyReadTransforms(device.Name, dr, cv)
+			}
 			if err != nil {
 				lc.Error(fmt.Sprintf("Handler - execReadCmd: CommandValue (%s) transformed failed: %v", cv.String(), err))
 				if errors.As(err, &transformer.OverflowError{}) {
@@ -193,6 +258,15 @@ func cvsToEvent(
 			}
 		}
 
+		if smoothing.IsSmoothed(dr.Attributes) {
+			smoothed, err := smoothing.Process(device.Name, cv, dr.Attributes)
+			if err != nil {
+				lc.Warn(fmt.Sprintf("Handler - execReadCmd: failed to smooth %s: %v", cv.DeviceResourceName, err))
+			} else {
+				cv = smoothed
+			}
+		}
+
 		err = transformer.CheckAssertion(cv, dr.Properties.Value.Assertion, device, lc, dc)
 		if err != nil {
 			lc.Error(fmt.Sprintf("Handler - execReadCmd: Assertion failed for device resource: %s, with value: %v", cv.String(), err))
@@ -219,13 +293,15 @@ func cvsToEvent(
 		// been implemened in gxds. TBD at the devices f2f whether this
 		// be killed completely.
 
-		reading := common.CommandValueToReading(cv, device.Name, dr.Properties.Value.MediaType, dr.Properties.Value.FloatEncoding)
-		readings = append(readings, *reading)
+		for _, cv := range counter.Readings(device.Name, cv, dr.Attributes, lc) {
+			reading := common.CommandValueToReading(cv, device.Name, dr.Properties.Value.MediaType, dr.Properties.Value.FloatEncoding)
+			readings = append(readings, *reading)
 
-		if cv.Type == v2.ValueTypeBinary {
-			lc.Debug(fmt.Sprintf("Handler - execReadCmd: device: %s DeviceResource: %v reading: binary value", device.Name, cv.DeviceResourceName))
-		} else {
-			lc.Debug(fmt.Sprintf("Handler - execReadCmd: device: %s DeviceResource: %v reading: %v", device.Name, cv.DeviceResourceName, reading))
+			if cv.Type == v2.ValueTypeBinary {
+				lc.Debug(fmt.Sprintf("Handler - execReadCmd: device: %s DeviceResource: %v reading: binary value", device.Name, cv.DeviceResourceName))
+			} else {
+				lc.Debug(fmt.Sprintf("Handler - execReadCmd: device: %s DeviceResource: %v reading: %v", device.Name, cv.DeviceResourceName, reading))
+			}
 		}
 	}
 
@@ -238,8 +314,13 @@ func cvsToEvent(
 
 	// push to Core Data
 	cevent := contract.Event{Device: device.Name, Readings: readings}
+	if name := common.SourceName(device.Name, device.Profile.Name, cmd, cvs); name != "" {
+		cevent.Tags = map[string]string{dsModels.SourceNameTag: name}
+	}
+	common.FilterReadingFields(&cevent, device.Profile.Name)
 	event := &dsModels.Event{Event: cevent}
-	event.Origin = common.GetUniqueOrigin()
+	event.ID = common.NewEventId(device.Name)
+	event.Origin = common.NewEventOrigin(device.Name)
 
 	// TODO: enforce config.MaxCmdValueLen; need to include overhead for
 	// the rest of the reading JSON + Event JSON length?  Should there be
@@ -270,9 +351,9 @@ func execReadCmd(
 		return nil, common.NewServerError(msg, nil)
 	}
 
-	reqs := make([]dsModels.CommandRequest, len(ros))
+	reqs := make([]dsModels.CommandRequest, 0, len(ros))
 
-	for i, op := range ros {
+	for _, op := range ros {
 		drName := op.DeviceResource
 		lc.Debug(fmt.Sprintf("Handler - execReadCmd: deviceResource: %s", drName))
 
@@ -288,22 +369,37 @@ func execReadCmd(
 			return nil, common.NewServerError(msg, nil)
 		}
 
-		reqs[i].DeviceResourceName = dr.Name
-		reqs[i].Attributes = dr.Attributes
+		// the write-only deviceResources of the command aren't read back
+		if dr.Properties.Value.ReadWrite == common.DeviceResourceWriteOnly {
+			lc.Debug(fmt.Sprintf("Handler - execReadCmd: skipping write-only deviceResource: %s", drName))
+			continue
+		}
+
+		req := dsModels.CommandRequest{
+			DeviceResourceName: dr.Name,
+			Attributes:         dr.Attributes,
+			Type:               dr.Properties.Value.Type,
+		}
 		if queryParams != "" {
-			if len(reqs[i].Attributes) <= 0 {
-				reqs[i].Attributes = make(map[string]string)
+			if len(req.Attributes) <= 0 {
+				req.Attributes = make(map[string]string)
 			}
 			m := common.FilterQueryParams(queryParams, lc)
-			reqs[i].Attributes[common.URLRawQuery] = m.Encode()
+			req.Attributes[common.URLRawQuery] = m.Encode()
 		}
-		reqs[i].Type = dr.Properties.Value.Type
+		reqs = append(reqs, req)
+	}
+	if len(reqs) == 0 {
+		msg := fmt.Sprintf("Handler - execReadCmd: all deviceResources of cmd: %s for dev: %s are write-only", cmd, device.Name)
+		lc.Error(msg)
+		return nil, common.NewBadRequestError(msg, nil)
 	}
 
 	results, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	transformer.CheckDriverError(err, device, lc, dc)
 	if err != nil {
 		msg := fmt.Sprintf("Handler - execReadCmd: error for Device: %s cmd: %s, %v", device.Name, cmd, err)
-		return nil, common.NewServerError(msg, err)
+		return nil, common.NewDriverError(msg, err)
 	}
 
 	return cvsToEvent(device, results, cmd, lc, dc, configuration)
@@ -315,10 +411,11 @@ func execWriteDeviceResource(
 	params string,
 	driver dsModels.ProtocolDriver,
 	lc logger.LoggingClient,
+	dc metadata.DeviceClient,
 	configuration *common.ConfigurationStruct) common.AppError {
 	paramMap, err := parseParams(params, lc)
 	if err != nil {
-		msg := fmt.Sprintf("Handler - execWriteDeviceResource: Put parameters parsing failed: %s", params)
+		msg := fmt.Sprintf("Handler - execWriteDeviceResource: Put parameters parsing failed: %s", common.WriteParams(params, *dr))
 		lc.Error(msg)
 		return common.NewBadRequestError(msg, err)
 	}
@@ -332,9 +429,18 @@ func execWriteDeviceResource(
 		return common.NewBadRequestError(msg, fmt.Errorf(msg))
 	}
 
+	if unmapped, ok := transformer.UnmapWriteValue(device.Profile.Name, dr.Name, v); ok {
+		v = unmapped
+	}
+
 	cv, err := createCommandValueFromDR(dr, v, lc)
 	if err != nil {
-		msg := fmt.Sprintf("Handler - execWriteDeviceResource: Put parameters parsing failed: %s", params)
+		msg := fmt.Sprintf("Handler - execWriteDeviceResource: Put parameters parsing failed: %s", common.WriteParams(params, *dr))
+		lc.Error(msg)
+		return common.NewBadRequestError(msg, err)
+	}
+	if err = transformer.CheckWriteRange(cv, *dr); err != nil {
+		msg := fmt.Sprintf("Handler - execWriteDeviceResource: %v", err)
 		lc.Error(msg)
 		return common.NewBadRequestError(msg, err)
 	}
@@ -346,20 +452,54 @@ func execWriteDeviceResource(
 	reqs[0].Type = cv.Type
 
 	if configuration.Device.DataTransform {
-		err = transformer.TransformWriteParameter(cv, dr.Properties.Value, lc)
+		cv, err = transformer.ApplyWriteTransforms(device.Name, *dr, cv)
+		if err == nil {
+			err = transformer.TransformWriteParameter(cv, dr.Properties.Value, lc)
+		}
 		if err != nil {
-			msg := fmt.Sprintf("Handler - execWriteDeviceResource: CommandValue (%s) transformed failed: %v", cv.String(), err)
+			msg := fmt.Sprintf("Handler - execWriteDeviceResource: CommandValue (%s) transformed failed: %v", common.WrittenValue(*dr, cv.String()), err)
 			lc.Error(msg)
 			return common.NewServerError(msg, err)
 		}
 	}
 
-	err = driver.HandleWriteCommands(device.Name, device.Protocols, reqs, []*dsModels.CommandValue{cv})
+	pending := confirmation.Expect(device.Name, reqs, []*dsModels.CommandValue{cv})
+	written, err := coalesce.Write(device.Name, reqs, []*dsModels.CommandValue{cv}, func(reqs []dsModels.CommandRequest, cvs []*dsModels.CommandValue) error {
+		return driver.HandleWriteCommands(device.Name, device.Protocols, reqs, cvs)
+	})
+	transformer.CheckDriverError(err, device, lc, dc)
 	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
 		msg := fmt.Sprintf("Handler - execWriteDeviceResource: error for Device: %s Device Resource: %s, %v", device.Name, dr.Name, err)
+		return common.NewDriverError(msg, err)
+	}
+	if pending != nil && written[0] != cv {
+		// superseded by a later write coalesced with this one
+		pending.Cancel()
+		pending = nil
+	}
+
+	err = common.VerifyWrite(driver, device, reqs, written)
+	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
+		msg := fmt.Sprintf("Handler - execWriteDeviceResource: %v", err)
+		lc.Error(msg)
 		return common.NewServerError(msg, err)
 	}
 
+	if pending != nil {
+		err = pending.Wait(confirmation.ParseTimeout(configuration.Device.WriteConfirmTimeout))
+		if err != nil {
+			msg := fmt.Sprintf("Handler - execWriteDeviceResource: %v", err)
+			lc.Error(msg)
+			return common.NewServerError(msg, err)
+		}
+	}
+
 	return nil
 }
 
@@ -369,6 +509,7 @@ func execWriteCmd(
 	params string,
 	driver dsModels.ProtocolDriver,
 	lc logger.LoggingClient,
+	dc metadata.DeviceClient,
 	configuration *common.ConfigurationStruct) common.AppError {
 	ros, err := cache.Profiles().ResourceOperations(device.Profile.Name, cmd, common.SetCmdMethod)
 	if err != nil {
@@ -386,7 +527,7 @@ func execWriteCmd(
 
 	cvs, err := parseWriteParams(device.Profile.Name, ros, params, lc)
 	if err != nil {
-		msg := fmt.Sprintf("Handler - execWriteCmd: Put parameters parsing failed: %s", params)
+		msg := fmt.Sprintf("Handler - execWriteCmd: Put parameters parsing failed: %s", common.WriteParams(params, writeResources(device.Profile.Name, ros)...))
 		lc.Error(msg)
 		return common.NewBadRequestError(msg, err)
 	}
@@ -412,25 +553,76 @@ func execWriteCmd(
 		reqs[i].Attributes = dr.Attributes
 		reqs[i].Type = cv.Type
 
+		if err = transformer.CheckWriteRange(cv, dr); err != nil {
+			msg := fmt.Sprintf("Handler - execWriteCmd: %v", err)
+			lc.Error(msg)
+			return common.NewBadRequestError(msg, err)
+		}
+
 		if configuration.Device.DataTransform {
-			err = transformer.TransformWriteParameter(cv, dr.Properties.Value, lc)
+			cvs[i], err = transformer.ApplyWriteTransforms(device.Name, dr, cv)
+			if err == nil {
+				err = transformer.TransformWriteParameter(cvs[i], dr.Properties.Value, lc)
+			}
 			if err != nil {
-				msg := fmt.Sprintf("Handler - execWriteCmd: CommandValue (%s) transformed failed: %v", cv.String(), err)
+				msg := fmt.Sprintf("Handler - execWriteCmd: CommandValue (%s) transformed failed: %v", common.WrittenValue(dr, cv.String()), err)
 				lc.Error(msg)
 				return common.NewServerError(msg, err)
 			}
 		}
 	}
 
-	err = driver.HandleWriteCommands(device.Name, device.Protocols, reqs, cvs)
+	pending := confirmation.Expect(device.Name, reqs, cvs)
+	written, err := coalesce.Write(device.Name, reqs, cvs, func(reqs []dsModels.CommandRequest, cvs []*dsModels.CommandValue) error {
+		return driver.HandleWriteCommands(device.Name, device.Protocols, reqs, cvs)
+	})
+	transformer.CheckDriverError(err, device, lc, dc)
 	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
 		msg := fmt.Sprintf("Handler - execWriteCmd: error for Device: %s cmd: %s, %v", device.Name, cmd, err)
+		return common.NewDriverError(msg, err)
+	}
+	if pending != nil && len(cvs) > 0 && written[0] != cvs[0] {
+		// superseded by a later write coalesced with this one
+		pending.Cancel()
+		pending = nil
+	}
+
+	err = common.VerifyWrite(driver, device, reqs, written)
+	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
+		msg := fmt.Sprintf("Handler - execWriteCmd: %v", err)
+		lc.Error(msg)
 		return common.NewServerError(msg, err)
 	}
 
+	if pending != nil {
+		err = pending.Wait(confirmation.ParseTimeout(configuration.Device.WriteConfirmTimeout))
+		if err != nil {
+			msg := fmt.Sprintf("Handler - execWriteCmd: %v", err)
+			lc.Error(msg)
+			return common.NewServerError(msg, err)
+		}
+	}
+
 	return nil
 }
 
+// writeResources returns the deviceResources written by the ResourceOperations.
+func writeResources(profileName string, ros []contract.ResourceOperation) []contract.DeviceResource {
+	drs := make([]contract.DeviceResource, 0, len(ros))
+	for _, ro := range ros {
+		if dr, ok := cache.Profiles().DeviceResource(profileName, ro.DeviceResource); ok {
+			drs = append(drs, dr)
+		}
+	}
+	return drs
+}
+
 func parseWriteParams(profileName string, ros []contract.ResourceOperation, params string, lc logger.LoggingClient) ([]*dsModels.CommandValue, error) {
 	paramMap, err := parseParams(params, lc)
 	if err != nil {
@@ -460,15 +652,15 @@ func parseWriteParams(profileName string, ros []contract.ResourceOperation, para
 			}
 		}
 
-		if len(ro.Mappings) > 0 {
-			newP, ok := ro.Mappings[p]
-			if ok {
-				p = newP
-			} else {
-				msg := fmt.Sprintf("parseWriteParams: Resource (%s) mapping value (%s) failed with the mapping table: %v", ro.DeviceResource, p, ro.Mappings)
-				lc.Warn(msg)
-				//return result, fmt.Errorf(msg) // issue #89 will discuss how to handle there is no mapping matched
-			}
+		if newP, ok := ro.Mappings[p]; ok {
+			p = newP
+		} else if unmapped, ok := transformer.UnmapWriteValue(profileName, ro.DeviceResource, p); ok {
+			p = unmapped
+		} else if len(ro.Mappings) > 0 {
+			dr, _ := cache.Profiles().DeviceResource(profileName, ro.DeviceResource)
+			msg := fmt.Sprintf("parseWriteParams: Resource (%s) mapping value (%s) failed with the mapping table: %v", ro.DeviceResource, common.WrittenValue(dr, p), ro.Mappings)
+			lc.Warn(msg)
+			//return result, fmt.Errorf(msg) // issue #89 will discuss how to handle there is no mapping matched
 		}
 
 		cv, err := createCommandValueFromRO(profileName, &ro, p, lc)
@@ -485,7 +677,7 @@ func parseWriteParams(profileName string, ros []contract.ResourceOperation, para
 func parseParams(params string, lc logger.LoggingClient) (paramMap map[string]string, err error) {
 	err = json.Unmarshal([]byte(params), &paramMap)
 	if err != nil {
-		lc.Error(fmt.Sprintf("parsing Write parameters failed %s, %v", params, err))
+		lc.Error(fmt.Sprintf("parsing Write parameters failed, %v", err))
 		return
 	}
 
@@ -512,6 +704,10 @@ func createCommandValueFromDR(dr *contract.DeviceResource, v string, lc logger.L
 	var err error
 	origin := time.Now().UnixNano()
 
+	if err = common.CheckNumber(v, dr.Properties.Value.Type); err != nil {
+		return result, err
+	}
+
 	switch strings.ToLower(dr.Properties.Value.Type) {
 	case "bool":
 		value, err := strconv.ParseBool(v)
@@ -714,7 +910,7 @@ func createCommandValueFromDR(dr *contract.DeviceResource, v string, lc logger.L
 	}
 
 	if err != nil {
-		lc.Error(fmt.Sprintf("Handler - Command: Parsing parameter value (%s) to %s failed: %v", v, dr.Properties.Value.Type, err))
+		lc.Error(fmt.Sprintf("Handler - Command: Parsing parameter value (%s) to %s failed: %v", common.WrittenValue(*dr, v), dr.Properties.Value.Type, err))
 		return result, err
 	}
 
@@ -765,6 +961,7 @@ func CommandAllHandler(cmd string, body string, method string, queryParams strin
 					device, cmd, body,
 					container.ProtocolDriverFrom(dic.Get),
 					lc,
+					container.MetadataDeviceClientFrom(dic.Get),
 					container.ConfigurationFrom(dic.Get))
 			}
 			cmdResults <- struct {
@@ -0,0 +1,144 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/metadata"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/executor"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+)
+
+// ResourceSetsProtocol is the Device protocol defining the named sets of deviceResources
+// the AutoEvents of the Device can read together. Each property is a set, whose value is
+// the comma-separated names of its deviceResources:
+//
+//	[DeviceList.Protocols.ds-resourceSets]
+//	  fast = "Temperature,Humidity"
+const ResourceSetsProtocol = common.SDKReservedPrefix + "resourceSets"
+
+// ResourceSet returns the deviceResources of the named set of the Device.
+func ResourceSet(device contract.Device, name string) ([]string, bool) {
+	value, ok := device.Protocols[ResourceSetsProtocol][name]
+	if !ok {
+		return nil, false
+	}
+	var resources []string
+	for _, r := range strings.Split(value, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			resources = append(resources, r)
+		}
+	}
+	return resources, true
+}
+
+// ResourceSetHandler reads the deviceResources of the named set of the Device in a single
+// request to the ProtocolDriver, returning them as one Event. The sets are read by the
+// AutoEvents, so the read runs after the on-demand commands waiting for the Device
+// according to Writable.CommandPriority.
+func ResourceSetHandler(deviceName string, setName string, dic *di.Container) (*dsModels.Event, common.AppError) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	d, ok := cache.Devices().ForName(deviceName)
+	if !ok {
+		msg := fmt.Sprintf("Device: %s not found; %s", deviceName, common.GetCmdMethod)
+		lc.Error(msg)
+		return nil, common.NewNotFoundError(msg, nil)
+	}
+	if d.AdminState == contract.Locked {
+		msg := fmt.Sprintf("%s is locked; %s", d.Name, common.GetCmdMethod)
+		lc.Error(msg)
+		return nil, common.NewLockedError(msg, nil)
+	}
+	if d.OperatingState == contract.Disabled {
+		msg := fmt.Sprintf("%s is disabled; %s", d.Name, common.GetCmdMethod)
+		lc.Error(msg)
+		return nil, common.NewLockedError(msg, nil)
+	}
+
+	resources, ok := ResourceSet(d, setName)
+	if !ok {
+		msg := fmt.Sprintf("resource set %s for Device: %s not found", setName, d.Name)
+		lc.Error(msg)
+		return nil, common.NewNotFoundError(msg, nil)
+	}
+
+	evt, appErr := execReadResourceSet(
+		&d, setName, resources,
+		executor.Background(container.ProtocolDriverFrom(dic.Get)),
+		lc,
+		container.MetadataDeviceClientFrom(dic.Get),
+		container.ConfigurationFrom(dic.Get))
+
+	go common.UpdateLastConnected(
+		d.Name,
+		container.ConfigurationFrom(dic.Get),
+		lc,
+		container.MetadataDeviceClientFrom(dic.Get))
+	return evt, appErr
+}
+
+func execReadResourceSet(
+	device *contract.Device,
+	setName string,
+	resources []string,
+	driver dsModels.ProtocolDriver,
+	lc logger.LoggingClient,
+	dc metadata.DeviceClient,
+	configuration *common.ConfigurationStruct) (*dsModels.Event, common.AppError) {
+	if len(resources) > configuration.Device.MaxCmdOps {
+		msg := fmt.Sprintf("Handler - execReadResourceSet: MaxCmdOps (%d) execeeded for dev: %s resource set: %s",
+			configuration.Device.MaxCmdOps, device.Name, setName)
+		lc.Error(msg)
+		return nil, common.NewServerError(msg, nil)
+	}
+
+	reqs := make([]dsModels.CommandRequest, 0, len(resources))
+	for _, drName := range resources {
+		dr, ok := cache.Profiles().DeviceResource(device.Profile.Name, drName)
+		if !ok {
+			msg := fmt.Sprintf("Handler - execReadResourceSet: no deviceResource: %s for dev: %s resource set: %s", drName, device.Name, setName)
+			lc.Error(msg)
+			return nil, common.NewNotFoundError(msg, nil)
+		}
+		if dr.Properties.Value.ReadWrite == common.DeviceResourceWriteOnly {
+			lc.Debug(fmt.Sprintf("Handler - execReadResourceSet: skipping write-only deviceResource: %s", drName))
+			continue
+		}
+		reqs = append(reqs, dsModels.CommandRequest{
+			DeviceResourceName: dr.Name,
+			Attributes:         dr.Attributes,
+			Type:               dr.Properties.Value.Type,
+		})
+	}
+	if len(reqs) == 0 {
+		msg := fmt.Sprintf("Handler - execReadResourceSet: no readable deviceResource in resource set: %s for dev: %s", setName, device.Name)
+		lc.Error(msg)
+		return nil, common.NewBadRequestError(msg, nil)
+	}
+
+	results, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	transformer.CheckDriverError(err, device, lc, dc)
+	if err != nil {
+		msg := fmt.Sprintf("Handler - execReadResourceSet: error for Device: %s resource set: %s, %v", device.Name, setName, err)
+		return nil, common.NewDriverError(msg, err)
+	}
+
+	return cvsToEvent(device, results, setName, lc, dc, configuration)
+}
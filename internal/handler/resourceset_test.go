@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler
+
+import (
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+)
+
+func TestResourceSet(t *testing.T) {
+	device := contract.Device{Protocols: map[string]contract.ProtocolProperties{
+		ResourceSetsProtocol: {"fast": " RandomValue_Int8, RandomValue_Int16,", "empty": ""},
+	}}
+
+	resources, ok := ResourceSet(device, "fast")
+	require.True(t, ok)
+	assert.Equal(t, []string{mock.ResourceObjectInt8, mock.ResourceObjectInt16}, resources)
+
+	resources, ok = ResourceSet(device, "empty")
+	assert.True(t, ok)
+	assert.Empty(t, resources)
+
+	_, ok = ResourceSet(device, "slow")
+	assert.False(t, ok)
+	_, ok = ResourceSet(contract.Device{}, "fast")
+	assert.False(t, ok)
+}
+
+func TestExecReadResourceSet(t *testing.T) {
+	tests := []struct {
+		testName  string
+		resources []string
+		expectErr bool
+	}{
+		{"ReadPass", []string{mock.ResourceObjectInt8, mock.ResourceObjectInt16}, false},
+		{"ResourceNotFound", []string{mock.ResourceObjectInt8, "InexistentResource"}, true},
+		{"NoResource", nil, true},
+		{"MaxCmdOpsExceeded", []string{mock.ResourceObjectInt8, mock.ResourceObjectInt16}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if tt.testName == "MaxCmdOpsExceeded" {
+				configuration.Device.MaxCmdOps = 1
+				defer func() {
+					configuration.Device.MaxCmdOps = 128
+				}()
+			}
+			event, err := execReadResourceSet(&deviceIntegerGenerator, "fast", tt.resources, driver, lc, dc, configuration)
+			if tt.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Len(t, event.Readings, len(tt.resources))
+			for i, r := range tt.resources {
+				assert.Equal(t, r, event.Readings[i].Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package health tracks the state of the Device Service used to answer the
+// readiness and liveness probes.
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+const (
+	// CheckDependencies verifies that Core Data and Core Metadata respond to ping.
+	CheckDependencies = "dependencies"
+	// CheckCache verifies that the Device, Device Profile and Provision Watcher caches are loaded.
+	CheckCache = "cache"
+	// CheckDriver verifies that the ProtocolDriver is initialized.
+	CheckDriver = "driver"
+
+	// StatusOK is the result of a passed check.
+	StatusOK = "ok"
+
+	defaultDependencyTimeout = 2 * time.Second
+)
+
+// DefaultReadinessChecks are performed when Service.ReadinessChecks isn't configured.
+var DefaultReadinessChecks = []string{CheckDependencies, CheckCache, CheckDriver}
+
+var (
+	stopping bool
+	mutex    sync.RWMutex
+)
+
+// SetStopping records that the Device Service is shutting down, failing the liveness probe.
+func SetStopping() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	stopping = true
+}
+
+// Live reports whether the process is healthy.
+func Live() bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return !stopping
+}
+
+// Ready performs the configured readiness checks and returns the result of each of
+// them, along with whether all of them passed.
+func Ready(config *common.ConfigurationStruct) (map[string]string, bool) {
+	checks := config.Service.ReadinessChecks
+	if len(checks) == 0 {
+		checks = DefaultReadinessChecks
+	}
+
+	results := make(map[string]string, len(checks))
+	ready := true
+	for _, check := range checks {
+		err := runCheck(check, config)
+		if err != nil {
+			results[check] = err.Error()
+			ready = false
+		} else {
+			results[check] = StatusOK
+		}
+	}
+	return results, ready
+}
+
+func runCheck(check string, config *common.ConfigurationStruct) error {
+	switch check {
+	case CheckCache:
+		if !PhaseCompleted(PhaseCacheLoaded) {
+			return fmt.Errorf("caches not loaded")
+		}
+	case CheckDriver:
+		if !PhaseCompleted(PhaseDriverInitialized) {
+			return fmt.Errorf("ProtocolDriver not initialized")
+		}
+	case CheckDependencies:
+		for _, clientName := range []string{common.ClientData, common.ClientMetadata} {
+			if clientName == common.ClientData && config.Service.CoreDataOptional {
+				continue
+			}
+			if err := ping(config, clientName); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown readiness check %s", check)
+	}
+	return nil
+}
+
+func ping(config *common.ConfigurationStruct, clientName string) error {
+	client, ok := config.Clients[clientName]
+	if !ok {
+		return fmt.Errorf("%s client not configured", clientName)
+	}
+
+	timeout := defaultDependencyTimeout
+	if config.Service.Timeout > 0 && time.Duration(config.Service.Timeout)*time.Millisecond < timeout {
+		timeout = time.Duration(config.Service.Timeout) * time.Millisecond
+	}
+	httpClient := http.Client{Timeout: timeout}
+	res, err := httpClient.Get(client.Url() + clients.ApiPingRoute)
+	if err != nil {
+		return fmt.Errorf("%s unreachable: %v", clientName, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s ping returned status %d", clientName, res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// The startup phases of the Device Service, in the order they complete.
+const (
+	PhaseConfigLoaded      = "configLoaded"
+	PhaseSecretsReady      = "secretsReady"
+	PhaseDependenciesUp    = "dependenciesUp"
+	PhaseCacheLoaded       = "cacheLoaded"
+	PhaseDriverInitialized = "driverInitialized"
+	PhaseDevicesConnected  = "devicesConnected"
+)
+
+var phases = []string{
+	PhaseConfigLoaded,
+	PhaseSecretsReady,
+	PhaseDependenciesUp,
+	PhaseCacheLoaded,
+	PhaseDriverInitialized,
+	PhaseDevicesConnected,
+}
+
+// Phase is the progress of a startup phase.
+type Phase struct {
+	Name        string `json:"name"`
+	Completed   bool   `json:"completed"`
+	CompletedAt int64  `json:"completedAt,omitempty"`
+	// Elapsed is the duration from the startup to the completion of the phase.
+	Elapsed string `json:"elapsed,omitempty"`
+}
+
+// StartupStatus is the progress of the Device Service startup.
+type StartupStatus struct {
+	StartedAt int64   `json:"startedAt"`
+	Elapsed   string  `json:"elapsed"`
+	Completed bool    `json:"completed"`
+	Phases    []Phase `json:"phases"`
+}
+
+var (
+	startedAt   = time.Now()
+	completedAt = make(map[string]time.Time) // key is phase name
+	phaseMutex  sync.RWMutex
+)
+
+// StartupBegan resets the startup progress.
+func StartupBegan() {
+	phaseMutex.Lock()
+	defer phaseMutex.Unlock()
+	startedAt = time.Now()
+	completedAt = make(map[string]time.Time)
+}
+
+// CompletePhase records and logs the completion of the startup phase.
+func CompletePhase(name string, lc logger.LoggingClient) {
+	phaseMutex.Lock()
+	now := time.Now()
+	completedAt[name] = now
+	elapsed := now.Sub(startedAt)
+	done := len(completedAt)
+	phaseMutex.Unlock()
+
+	lc.Info(fmt.Sprintf("startup phase %s completed (%d/%d) after %s", name, done, len(phases), elapsed.Round(time.Millisecond)),
+		"startupPhase", name, "elapsed", elapsed.String())
+}
+
+// PhaseCompleted reports whether the startup phase is completed.
+func PhaseCompleted(name string) bool {
+	phaseMutex.RLock()
+	defer phaseMutex.RUnlock()
+	_, ok := completedAt[name]
+	return ok
+}
+
+// Status returns the progress of the startup phases.
+func Status() StartupStatus {
+	phaseMutex.RLock()
+	defer phaseMutex.RUnlock()
+
+	status := StartupStatus{
+		StartedAt: startedAt.UnixNano(),
+		Completed: true,
+		Phases:    make([]Phase, len(phases)),
+	}
+	var last time.Time
+	for i, name := range phases {
+		status.Phases[i].Name = name
+		t, ok := completedAt[name]
+		if !ok {
+			status.Completed = false
+			continue
+		}
+		status.Phases[i].Completed = true
+		status.Phases[i].CompletedAt = t.UnixNano()
+		status.Phases[i].Elapsed = t.Sub(startedAt).String()
+		if t.After(last) {
+			last = t
+		}
+	}
+	if status.Completed {
+		status.Elapsed = last.Sub(startedAt).String()
+	} else {
+		status.Elapsed = time.Since(startedAt).String()
+	}
+	return status
+}
+
+// PhaseBootstrapHandler returns a BootstrapHandler which completes the startup phase once
+// the preceding bootstrap handlers succeeded.
+func PhaseBootstrapHandler(name string) interfaces.BootstrapHandler {
+	return func(_ context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+		CompletePhase(name, bootstrapContainer.LoggingClientFrom(dic.Get))
+		return true
+	}
+}
@@ -0,0 +1,279 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package history keeps the recent command executions of the Device Service, so that
+// the recent failures can be looked into without scraping the logs.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
+)
+
+// bucket is the bucket of the Store holding the executions.
+const bucket = "history"
+
+// FlushInterval is the interval the recorded executions are persisted in the Store at.
+const FlushInterval = time.Second
+
+// The statuses of the command executions.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// The sources of the command executions.
+const (
+	// SourceAPI is a command requested through the REST API.
+	SourceAPI = "api"
+	// SourceAutoEvent is a read of an AutoEvent.
+	SourceAutoEvent = "autoevent"
+)
+
+// Execution is the execution of a command or deviceResource of a Device.
+type Execution struct {
+	// Timestamp is the time the execution started, in nanoseconds.
+	Timestamp  int64  `json:"timestamp"`
+	DeviceName string `json:"deviceName"`
+	Command    string `json:"command"`
+	Method     string `json:"method"`
+	Source     string `json:"source"`
+	Status     string `json:"status"`
+	// Code is the HTTP status code of the execution.
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+	// LatencyMs is the duration of the execution in milliseconds.
+	LatencyMs     float64 `json:"latencyMs"`
+	CorrelationID string  `json:"correlationId,omitempty"`
+}
+
+// NewExecution returns the Execution of the command started at the time, completed with
+// the HTTP status code and, if it failed, the error message.
+func NewExecution(start time.Time, deviceName string, cmd string, method string, source string, code int, message string) Execution {
+	status := StatusSuccess
+	if code >= http.StatusBadRequest {
+		status = StatusError
+	}
+	return Execution{
+		Timestamp:  start.UnixNano(),
+		DeviceName: deviceName,
+		Command:    cmd,
+		Method:     method,
+		Source:     source,
+		Status:     status,
+		Code:       code,
+		Message:    message,
+		LatencyMs:  float64(time.Since(start)) / float64(time.Millisecond),
+	}
+}
+
+// Filter selects the executions. The empty fields select all the executions.
+type Filter struct {
+	DeviceName    string
+	Command       string
+	Method        string
+	Status        string
+	CorrelationID string
+	// Since selects the executions started at or after the timestamp, in nanoseconds.
+	Since int64
+	// Limit is the maximum number of executions returned, the most recent ones.
+	Limit int
+}
+
+func (f Filter) matches(e Execution) bool {
+	return (f.DeviceName == "" || e.DeviceName == f.DeviceName) &&
+		(f.Command == "" || e.Command == f.Command) &&
+		(f.Method == "" || e.Method == f.Method) &&
+		(f.Status == "" || e.Status == f.Status) &&
+		(f.CorrelationID == "" || e.CorrelationID == f.CorrelationID) &&
+		e.Timestamp >= f.Since
+}
+
+// Buffer retains the most recent command executions, persisting them in the Store if any
+// so that they're kept across restarts. The executions are persisted in batches by Flush,
+// off the path of the commands.
+type Buffer struct {
+	capacity   int
+	executions []Execution // ring buffer, next is the index of the oldest once full
+	next       int
+	store      store.Store
+	seqs       []uint64 // sequence numbers the executions are stored under, as ordered in executions
+	nextSeq    uint64
+	puts       map[uint64]Execution // executions recorded since the last flush
+	deletes    []uint64             // executions discarded since the last flush, already stored
+	lc         logger.LoggingClient
+	mutex      sync.Mutex
+	flushMutex sync.Mutex
+}
+
+var b *Buffer
+
+// NewBuffer initiates the history buffer which retains up to capacity executions, loading
+// the most recent ones stored by a previous run if the Store isn't nil. The history is
+// disabled when capacity isn't positive.
+func NewBuffer(capacity int, s store.Store, lc logger.LoggingClient) error {
+	if capacity <= 0 {
+		b = nil
+		return nil
+	}
+	buffer := &Buffer{capacity: capacity, executions: make([]Execution, 0, capacity), store: s, nextSeq: 1, puts: make(map[uint64]Execution), lc: lc}
+	if s != nil {
+		if err := buffer.load(); err != nil {
+			return err
+		}
+	}
+	b = buffer
+	return nil
+}
+
+// GetBuffer returns the history buffer, which may be nil if the history is disabled.
+func GetBuffer() *Buffer {
+	return b
+}
+
+func key(seq uint64) string {
+	return fmt.Sprintf("%020d", seq)
+}
+
+// load loads the most recent executions stored, deleting the ones beyond the capacity.
+func (b *Buffer) load() error {
+	keys, err := b.store.Keys(bucket)
+	if err != nil {
+		return fmt.Errorf("failed to load the command history: %v", err)
+	}
+	for i, k := range keys {
+		seq, err := strconv.ParseUint(k, 10, 64)
+		if err != nil {
+			continue
+		}
+		if seq >= b.nextSeq {
+			b.nextSeq = seq + 1
+		}
+		if i < len(keys)-b.capacity {
+			_ = b.store.Delete(bucket, k)
+			continue
+		}
+		data, err := b.store.Get(bucket, k)
+		if err != nil {
+			continue
+		}
+		var e Execution
+		if err = json.Unmarshal(data, &e); err != nil {
+			_ = b.store.Delete(bucket, k)
+			continue
+		}
+		b.executions = append(b.executions, e)
+		b.seqs = append(b.seqs, seq)
+	}
+	return nil
+}
+
+// Record retains the execution, discarding the oldest one once the buffer is full.
+func (b *Buffer) Record(e Execution) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	seq := b.nextSeq
+	b.nextSeq++
+	if b.store != nil {
+		b.puts[seq] = e
+	}
+
+	if len(b.executions) < b.capacity {
+		b.executions = append(b.executions, e)
+		b.seqs = append(b.seqs, seq)
+		return
+	}
+	if b.store != nil {
+		discarded := b.seqs[b.next]
+		if _, ok := b.puts[discarded]; ok {
+			delete(b.puts, discarded)
+		} else {
+			b.deletes = append(b.deletes, discarded)
+		}
+	}
+	b.executions[b.next] = e
+	b.seqs[b.next] = seq
+	b.next = (b.next + 1) % b.capacity
+}
+
+// Flush persists the executions recorded and deletes the ones discarded since the last flush.
+func (b *Buffer) Flush() {
+	if b == nil || b.store == nil {
+		return
+	}
+	b.flushMutex.Lock()
+	defer b.flushMutex.Unlock()
+
+	b.mutex.Lock()
+	puts, deletes := b.puts, b.deletes
+	b.puts, b.deletes = make(map[uint64]Execution), nil
+	b.mutex.Unlock()
+
+	for seq, e := range puts {
+		if data, err := json.Marshal(e); err != nil || b.store.Put(bucket, key(seq), data) != nil {
+			b.lc.Warn(fmt.Sprintf("failed to store the execution of %s of device %s, only kept in memory", e.Command, e.DeviceName))
+		}
+	}
+	for _, seq := range deletes {
+		_ = b.store.Delete(bucket, key(seq))
+	}
+}
+
+// Run flushes the executions every interval until the context is done, flushing them a
+// last time then.
+func (b *Buffer) Run(ctx context.Context, wg *sync.WaitGroup, interval time.Duration) {
+	if b == nil || b.store == nil {
+		return
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				b.Flush()
+				return
+			case <-ticker.C():
+				b.Flush()
+			}
+		}
+	}()
+}
+
+// Query returns the retained executions selected by the filter, the most recent first.
+func (b *Buffer) Query(f Filter) []Execution {
+	if b == nil {
+		return nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var result []Execution
+	n := len(b.executions)
+	for i := 0; i < n && (f.Limit <= 0 || len(result) < f.Limit); i++ {
+		e := b.executions[(b.next+n-1-i)%n]
+		if f.matches(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
+)
+
+func commands(executions []Execution) []string {
+	var result []string
+	for _, e := range executions {
+		result = append(result, e.Command)
+	}
+	return result
+}
+
+func TestBuffer(t *testing.T) {
+	require.NoError(t, NewBuffer(2, nil, logger.NewMockClient()))
+	b := GetBuffer()
+	b.Record(Execution{Timestamp: 1, DeviceName: "d1", Command: "c1", Status: StatusSuccess})
+	b.Record(Execution{Timestamp: 2, DeviceName: "d2", Command: "c2", Status: StatusError})
+	b.Record(Execution{Timestamp: 3, DeviceName: "d1", Command: "c3", Status: StatusError})
+
+	assert.Equal(t, []string{"c3", "c2"}, commands(b.Query(Filter{})), "the oldest execution is discarded")
+	assert.Equal(t, []string{"c3"}, commands(b.Query(Filter{DeviceName: "d1"})))
+	assert.Equal(t, []string{"c3"}, commands(b.Query(Filter{Status: StatusError, Limit: 1})))
+	assert.Equal(t, []string{"c3"}, commands(b.Query(Filter{Since: 3})))
+
+	require.NoError(t, NewBuffer(0, nil, logger.NewMockClient()))
+	assert.Nil(t, GetBuffer())
+	GetBuffer().Record(Execution{Command: "c4"})
+	assert.Empty(t, GetBuffer().Query(Filter{}))
+}
+
+func TestBufferStore(t *testing.T) {
+	lc := logger.NewMockClient()
+	s := store.NewMemory()
+	require.NoError(t, NewBuffer(2, s, lc))
+	for _, cmd := range []string{"c1", "c2"} {
+		GetBuffer().Record(Execution{Command: cmd})
+	}
+	keys, err := s.Keys(bucket)
+	require.NoError(t, err)
+	assert.Empty(t, keys, "the executions are stored once flushed")
+	GetBuffer().Flush()
+	GetBuffer().Record(Execution{Command: "c3"})
+	GetBuffer().Flush()
+	keys, err = s.Keys(bucket)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2, "the discarded execution is deleted from the Store")
+
+	// the executions are loaded after a restart, and the next ones recorded after them
+	require.NoError(t, NewBuffer(2, s, lc))
+	assert.Equal(t, []string{"c3", "c2"}, commands(GetBuffer().Query(Filter{})))
+	GetBuffer().Record(Execution{Command: "c4"})
+	assert.Equal(t, []string{"c4", "c3"}, commands(GetBuffer().Query(Filter{})))
+	GetBuffer().Flush()
+
+	// a smaller capacity only loads the most recent executions
+	require.NoError(t, NewBuffer(1, s, lc))
+	assert.Equal(t, []string{"c4"}, commands(GetBuffer().Query(Filter{})))
+	keys, err = s.Keys(bucket)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestBufferRun(t *testing.T) {
+	s := store.NewMemory()
+	require.NoError(t, NewBuffer(2, s, logger.NewMockClient()))
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	GetBuffer().Run(ctx, &wg, time.Hour)
+
+	// the executions discarded before they're flushed are never stored
+	for _, cmd := range []string{"c1", "c2", "c3"} {
+		GetBuffer().Record(Execution{Command: cmd})
+	}
+	cancel()
+	wg.Wait()
+	keys, err := s.Keys(bucket)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2, "the executions are flushed once the context is done")
+}
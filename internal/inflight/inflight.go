@@ -0,0 +1,144 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package inflight registers the driver operations being executed, so that the operators
+// can see what a wedged Device Service is doing and cancel its operations.
+package inflight
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// Operation is a driver operation being executed.
+type Operation struct {
+	Id            string    `json:"id"`
+	DeviceName    string    `json:"deviceName"`
+	Resources     []string  `json:"resources"`
+	Method        string    `json:"method"`
+	Started       time.Time `json:"started"`
+	CorrelationId string    `json:"correlationId,omitempty"`
+	// Queued is set while the operation waits for the concurrency limits of the Device.
+	Queued bool `json:"queued,omitempty"`
+	// Cancelled is set once the operation is cancelled, or given up on, while the driver is
+	// still running it.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+type entry struct {
+	Operation
+	cancel context.CancelFunc
+	holds  int // the operation is registered until no one holds it
+}
+
+// Op is the handle of a registered operation.
+type Op struct {
+	e *entry
+}
+
+var (
+	operations = make(map[string]*entry)
+	mutex      sync.Mutex
+)
+
+// Begin registers the operation of the method on the deviceResources of the Device,
+// queued until Running is called. It returns the context, cancelled by Cancel, to run the
+// operation with, and its handle whose Done is to be called once the caller is done with it.
+func Begin(ctx context.Context, deviceName string, resources []string, method string) (context.Context, *Op) {
+	ctx, cancel := context.WithCancel(ctx)
+	correlationID, _ := ctx.Value(common.CorrelationHeader).(string)
+	e := &entry{
+		Operation: Operation{
+			Id:            uuid.New().String(),
+			DeviceName:    deviceName,
+			Resources:     resources,
+			Method:        method,
+			Started:       time.Now(),
+			CorrelationId: correlationID,
+			Queued:        true,
+		},
+		cancel: cancel,
+		holds:  1,
+	}
+
+	mutex.Lock()
+	operations[e.Id] = e
+	mutex.Unlock()
+
+	return ctx, &Op{e: e}
+}
+
+// Running marks the operation as run by the driver, once the limits are acquired.
+func (op *Op) Running() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	op.e.Queued = false
+}
+
+// Hold keeps the operation registered until the returned function is called, e.g. by the
+// goroutine running the driver, which may outlive the caller giving up on it.
+func (op *Op) Hold() func() {
+	mutex.Lock()
+	op.e.holds++
+	mutex.Unlock()
+	return func() {
+		op.release(false)
+	}
+}
+
+// Done releases the operation for the caller, cancelling its context. The operation stays
+// registered, marked cancelled, while it's held by the driver still running it.
+func (op *Op) Done() {
+	op.release(true)
+	op.e.cancel()
+}
+
+func (op *Op) release(givenUp bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	op.e.holds--
+	if op.e.holds > 0 {
+		op.e.Cancelled = op.e.Cancelled || givenUp
+		return
+	}
+	delete(operations, op.e.Id)
+}
+
+// Operations returns the operations being executed, oldest first.
+func Operations() []Operation {
+	mutex.Lock()
+	result := make([]Operation, 0, len(operations))
+	for _, e := range operations {
+		result = append(result, e.Operation)
+	}
+	mutex.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Started.Before(result[j].Started)
+	})
+	return result
+}
+
+// Cancel cancels the context of the operation, or returns false if no such operation is
+// being executed. The drivers not implementing ContextCommandHandler keep running it, but
+// the Device Service stops waiting for them.
+func Cancel(id string) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	e, ok := operations[id]
+	if !ok {
+		return false
+	}
+	e.Cancelled = true
+	e.cancel()
+	return true
+}
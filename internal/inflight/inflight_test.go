@@ -0,0 +1,60 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+func TestCancel(t *testing.T) {
+	parent := context.WithValue(context.Background(), common.CorrelationHeader, "correlation")
+	ctx, op := Begin(parent, "device", []string{"resource"}, common.GetCmdMethod)
+
+	operations := Operations()
+	require.Len(t, operations, 1)
+	assert.Equal(t, "device", operations[0].DeviceName)
+	assert.Equal(t, "correlation", operations[0].CorrelationId)
+
+	assert.False(t, Cancel("unknown"))
+	assert.True(t, Cancel(operations[0].Id))
+	assert.Error(t, ctx.Err())
+	assert.True(t, Operations()[0].Cancelled)
+
+	op.Done()
+	assert.Empty(t, Operations())
+	assert.False(t, Cancel(operations[0].Id))
+}
+
+func TestOpStates(t *testing.T) {
+	_, op := Begin(context.Background(), "device", []string{"resource"}, common.SetCmdMethod)
+	require.Len(t, Operations(), 1)
+	assert.True(t, Operations()[0].Queued, "the operation is queued until it runs")
+	op.Running()
+	assert.False(t, Operations()[0].Queued)
+
+	// the caller gives up on the operation while the driver still runs it
+	release := op.Hold()
+	op.Done()
+	operations := Operations()
+	require.Len(t, operations, 1, "the operation is registered until the driver returns")
+	assert.True(t, operations[0].Cancelled)
+	release()
+	assert.Empty(t, Operations())
+
+	// the driver returning first doesn't mark the operation cancelled
+	_, op = Begin(context.Background(), "device", []string{"resource"}, common.SetCmdMethod)
+	op.Hold()()
+	assert.False(t, Operations()[0].Cancelled)
+	op.Done()
+	assert.Empty(t, Operations())
+}
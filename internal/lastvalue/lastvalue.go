@@ -0,0 +1,95 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lastvalue retains the last known value of each deviceResource, so that
+// read commands can be served from it while the device is busy or down.
+package lastvalue
+
+import (
+	"sync"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+// The sources of the readings recorded as last known values.
+const (
+	SourceAutoEvent = "autoevent"
+	SourceCommand   = "command"
+	SourceAsync     = "async"
+)
+
+// Value is the last known value of a deviceResource.
+type Value struct {
+	Reading contract.Reading
+	// Timestamp is when the reading was recorded, in nanoseconds.
+	Timestamp int64
+	Source    string
+}
+
+var (
+	values = make(map[string]map[string]Value) // keys are Device name and deviceResource name
+	mutex  sync.RWMutex
+)
+
+// Record retains the readings as the last known values of their deviceResources.
+func Record(readings []contract.Reading, source string) {
+	now := clock.Now().UnixNano()
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, r := range readings {
+		device, ok := values[r.Device]
+		if !ok {
+			device = make(map[string]Value)
+			values[r.Device] = device
+		}
+		device[r.Name] = Value{Reading: r, Timestamp: now, Source: source}
+	}
+}
+
+// Get returns the last known value of the deviceResource.
+func Get(deviceName string, resourceName string) (Value, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	v, ok := values[deviceName][resourceName]
+	return v, ok
+}
+
+// Fresh returns the last known values of the deviceResources of the Device if all of
+// them were recorded within maxAge.
+func Fresh(deviceName string, resourceNames []string, maxAge time.Duration) ([]Value, bool) {
+	oldest := clock.Now().Add(-maxAge).UnixNano()
+	mutex.RLock()
+	defer mutex.RUnlock()
+	result := make([]Value, 0, len(resourceNames))
+	for _, name := range resourceNames {
+		v, ok := values[deviceName][name]
+		if !ok || v.Timestamp < oldest {
+			return nil, false
+		}
+		result = append(result, v)
+	}
+	return result, len(result) > 0
+}
+
+// Invalidate discards the last known values of the deviceResources of the Device, once
+// they're written.
+func Invalidate(deviceName string, resourceNames []string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, name := range resourceNames {
+		delete(values[deviceName], name)
+	}
+}
+
+// Remove discards the last known values of the Device.
+func Remove(deviceName string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(values, deviceName)
+}
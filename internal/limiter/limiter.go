@@ -0,0 +1,123 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package limiter bounds the number of simultaneous ProtocolDriver command
+// invocations, both across the whole service and per device.
+package limiter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// DefaultDeviceLimit is the number of simultaneous commands allowed per device
+// when Writable.MaxDeviceConcurrentCommands is not configured.
+const DefaultDeviceLimit = 1
+
+// Limiter tracks in-flight commands and blocks callers exceeding the limits.
+type Limiter struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	total   int
+	devices map[string]int // key is Device name
+	waiting map[string]int // foreground callers waiting, key is Device name
+}
+
+func NewLimiter() *Limiter {
+	l := &Limiter{devices: make(map[string]int), waiting: make(map[string]int)}
+	l.cond = sync.NewCond(&l.mutex)
+	return l
+}
+
+// Acquire blocks until a command for the device can run within maxGlobal and
+// maxDevice. A maxGlobal of zero or less means no global limit, and a maxDevice
+// of zero or less falls back to DefaultDeviceLimit.
+func (l *Limiter) Acquire(deviceName string, maxGlobal int, maxDevice int) {
+	_ = l.acquire(context.Background(), deviceName, maxGlobal, maxDevice, 0, false)
+}
+
+// Release marks a command for the device as finished and wakes up waiting callers.
+func (l *Limiter) Release(deviceName string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.total--
+	l.devices[deviceName]--
+	if l.devices[deviceName] <= 0 {
+		delete(l.devices, deviceName)
+	}
+	// limits differ per device, so every waiter has to re-check its condition
+	l.cond.Broadcast()
+}
+
+// AcquireContext is Acquire giving up once the context is done, in which case the
+// context error is returned.
+func (l *Limiter) AcquireContext(ctx context.Context, deviceName string, maxGlobal int, maxDevice int) error {
+	return l.acquire(ctx, deviceName, maxGlobal, maxDevice, 0, false)
+}
+
+// AcquireQueued is AcquireContext failing with common.ErrDeviceQueueFull, rather than
+// waiting, if maxQueued foreground callers are already waiting for the device. A maxQueued
+// of zero or less means no limit.
+func (l *Limiter) AcquireQueued(ctx context.Context, deviceName string, maxGlobal int, maxDevice int, maxQueued int) error {
+	return l.acquire(ctx, deviceName, maxGlobal, maxDevice, maxQueued, false)
+}
+
+// AcquireBackground is AcquireContext for a background command, which also waits for the
+// foreground commands waiting for the device.
+func (l *Limiter) AcquireBackground(ctx context.Context, deviceName string, maxGlobal int, maxDevice int) error {
+	return l.acquire(ctx, deviceName, maxGlobal, maxDevice, 0, true)
+}
+
+func (l *Limiter) acquire(ctx context.Context, deviceName string, maxGlobal int, maxDevice int, maxQueued int, background bool) error {
+	if ctx.Done() != nil {
+		// wake up the waiters once the context is done so that they re-check it
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				l.mutex.Lock()
+				l.cond.Broadcast()
+				l.mutex.Unlock()
+			case <-stop:
+			}
+		}()
+	}
+
+	if maxDevice <= 0 {
+		maxDevice = DefaultDeviceLimit
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	blocked := func() bool {
+		return (maxGlobal > 0 && l.total >= maxGlobal) || l.devices[deviceName] >= maxDevice || (background && l.waiting[deviceName] > 0)
+	}
+	if maxQueued > 0 && l.waiting[deviceName] >= maxQueued && blocked() {
+		return common.ErrDeviceQueueFull
+	}
+	if !background {
+		l.waiting[deviceName]++
+		defer func() {
+			l.waiting[deviceName]--
+			if l.waiting[deviceName] <= 0 {
+				delete(l.waiting, deviceName)
+			}
+			// the background commands may wait for this one
+			l.cond.Broadcast()
+		}()
+	}
+	for blocked() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		l.cond.Wait()
+	}
+	l.total++
+	l.devices[deviceName]++
+	return nil
+}
@@ -0,0 +1,109 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package limiter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+func TestLimiter(t *testing.T) {
+	tests := []struct {
+		name          string
+		devices       []string
+		maxGlobal     int
+		maxDevice     int
+		expectedLimit int32
+	}{
+		{"per device default", []string{"d1", "d1", "d1"}, 0, 0, 1},
+		{"per device", []string{"d1", "d1", "d1", "d1"}, 0, 2, 2},
+		{"global", []string{"d1", "d2", "d3", "d4"}, 2, 1, 2},
+		{"unlimited global", []string{"d1", "d2", "d3"}, 0, 1, 3},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			l := NewLimiter()
+			var running, peak int32
+			var wg sync.WaitGroup
+			for _, device := range testCase.devices {
+				wg.Add(1)
+				go func(device string) {
+					defer wg.Done()
+					l.Acquire(device, testCase.maxGlobal, testCase.maxDevice)
+					defer l.Release(device)
+					n := atomic.AddInt32(&running, 1)
+					for {
+						p := atomic.LoadInt32(&peak)
+						if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+				}(device)
+			}
+			wg.Wait()
+
+			assert.Equal(t, testCase.expectedLimit, peak)
+			assert.Empty(t, l.devices)
+		})
+	}
+}
+
+func TestLimiterBackgroundAfterForeground(t *testing.T) {
+	l := NewLimiter()
+	l.Acquire("d1", 0, 1)
+
+	var order []string
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	run := func(name string, acquire func() error) {
+		defer wg.Done()
+		assert.NoError(t, acquire())
+		mutex.Lock()
+		order = append(order, name)
+		mutex.Unlock()
+		l.Release("d1")
+	}
+
+	wg.Add(2)
+	go run("background", func() error { return l.AcquireBackground(context.Background(), "d1", 0, 1) })
+	// let the background command wait first
+	time.Sleep(20 * time.Millisecond)
+	go run("foreground", func() error { return l.AcquireContext(context.Background(), "d1", 0, 1) })
+	time.Sleep(20 * time.Millisecond)
+	l.Release("d1")
+	wg.Wait()
+
+	assert.Equal(t, []string{"foreground", "background"}, order)
+}
+
+func TestLimiterQueueFull(t *testing.T) {
+	l := NewLimiter()
+	// nothing is queued while the device is free
+	assert.NoError(t, l.AcquireQueued(context.Background(), "d1", 0, 1, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	waiting := make(chan error, 1)
+	go func() { waiting <- l.AcquireQueued(ctx, "d1", 0, 1, 1) }()
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Equal(t, common.ErrDeviceQueueFull, l.AcquireQueued(context.Background(), "d1", 0, 1, 1))
+	assert.NoError(t, l.AcquireQueued(context.Background(), "d2", 0, 1, 1), "the queues are per device")
+
+	l.Release("d1")
+	assert.NoError(t, <-waiting)
+}
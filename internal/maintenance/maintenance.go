@@ -0,0 +1,114 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package maintenance tracks the scheduled maintenance windows during which the
+// failures of the Devices are expected and don't change their OperatingState.
+package maintenance
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+type window struct {
+	start           time.Time
+	end             time.Time
+	devices         map[string]bool
+	labels          map[string]bool
+	pauseAutoEvents bool
+}
+
+func (w window) covers(device contract.Device, now time.Time) bool {
+	if now.Before(w.start) || !now.Before(w.end) {
+		return false
+	}
+	if w.devices[device.Name] {
+		return true
+	}
+	for _, label := range device.Labels {
+		if w.labels[label] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	windows []window
+	mutex   sync.RWMutex
+)
+
+// SetWindows replaces the maintenance windows. Windows with invalid times are logged
+// and ignored.
+func SetWindows(infos []common.MaintenanceWindowInfo, lc logger.LoggingClient) {
+	parsed := make([]window, 0, len(infos))
+	for _, info := range infos {
+		start, err := time.Parse(time.RFC3339, info.Start)
+		if err != nil {
+			lc.Error(fmt.Sprintf("invalid maintenance window start %s: %v", info.Start, err))
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, info.End)
+		if err != nil {
+			lc.Error(fmt.Sprintf("invalid maintenance window end %s: %v", info.End, err))
+			continue
+		}
+		if !end.After(start) {
+			lc.Error(fmt.Sprintf("maintenance window end %s isn't after its start %s", info.End, info.Start))
+			continue
+		}
+
+		w := window{
+			start:           start,
+			end:             end,
+			devices:         make(map[string]bool, len(info.Devices)),
+			labels:          make(map[string]bool, len(info.Labels)),
+			pauseAutoEvents: info.PauseAutoEvents,
+		}
+		for _, name := range info.Devices {
+			w.devices[name] = true
+		}
+		for _, label := range info.Labels {
+			w.labels[label] = true
+		}
+		parsed = append(parsed, w)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	windows = parsed
+}
+
+// Active reports whether the Device is in a maintenance window.
+func Active(device contract.Device) bool {
+	active, _ := status(device)
+	return active
+}
+
+// AutoEventsPaused reports whether the Device is in a maintenance window pausing its AutoEvents.
+func AutoEventsPaused(device contract.Device) bool {
+	_, paused := status(device)
+	return paused
+}
+
+func status(device contract.Device) (active bool, paused bool) {
+	now := time.Now()
+	mutex.RLock()
+	defer mutex.RUnlock()
+	for _, w := range windows {
+		if w.covers(device, now) {
+			active = true
+			paused = paused || w.pauseAutoEvents
+		}
+	}
+	return active, paused
+}
@@ -0,0 +1,90 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// ModeSource returns the current Writable.Maintenance configuration.
+type ModeSource func() common.MaintenanceModeInfo
+
+// serviceMode is the maintenance mode toggled through the /maintenance endpoint, which
+// holds until Writable.Maintenance.Enabled changes.
+type serviceMode struct {
+	enabled  bool
+	writable bool // Writable.Maintenance.Enabled when toggled
+}
+
+var (
+	source       ModeSource
+	toggled      *serviceMode
+	serviceMutex sync.Mutex
+)
+
+// SetModeSource sets where the Writable.Maintenance configuration is read from, as it may
+// be changed through the Registry.
+func SetModeSource(s ModeSource) {
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	source = s
+}
+
+func modeInfo() common.MaintenanceModeInfo {
+	if source == nil {
+		return common.MaintenanceModeInfo{}
+	}
+	return source()
+}
+
+// SetServiceMode puts the Device Service in maintenance mode or out of it, overriding
+// Writable.Maintenance.Enabled until it's changed.
+func SetServiceMode(enabled bool) {
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	toggled = &serviceMode{enabled: enabled, writable: modeInfo().Enabled}
+}
+
+// ServiceMode reports whether the Device Service is in maintenance mode.
+func ServiceMode() bool {
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	return serviceModeLocked(modeInfo())
+}
+
+func serviceModeLocked(info common.MaintenanceModeInfo) bool {
+	if toggled != nil {
+		if toggled.writable == info.Enabled {
+			return toggled.enabled
+		}
+		toggled = nil
+	}
+	return info.Enabled
+}
+
+// ReadsRejected reports whether the read commands are rejected in maintenance mode.
+func ReadsRejected() bool {
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	return modeInfo().RejectReads
+}
+
+// CommandRejected reports whether a command of the HTTP method is rejected because the
+// Device Service is in maintenance mode.
+func CommandRejected(method string) bool {
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	info := modeInfo()
+	if !serviceModeLocked(info) {
+		return false
+	}
+	return !strings.EqualFold(method, http.MethodGet) || info.RejectReads
+}
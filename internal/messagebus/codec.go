@@ -0,0 +1,159 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messagebus
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// The codecs of the events published onto the MessageBus.
+const (
+	CodecJSON     = "json"
+	CodecCBOR     = "cbor"
+	CodecProtobuf = "protobuf"
+
+	// ContentTypeProtobuf is the content type of the events encoded as the Event message
+	// of event.proto.
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Codec encodes the events published onto the MessageBus.
+type Codec interface {
+	// ContentType is the content type of the encoded events, set in the MessageEnvelope.
+	ContentType() string
+	Encode(event contract.Event) ([]byte, error)
+}
+
+// NewCodec returns the codec of the given name. An empty name returns a nil Codec, in
+// which case the events are published as encoded for Core Data.
+func NewCodec(name string) (Codec, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case CodecJSON:
+		return jsonCodec{}, nil
+	case CodecCBOR:
+		return cborCodec{}, nil
+	case CodecProtobuf:
+		return protobufCodec{}, nil
+	default:
+		return nil, fmt.Errorf("invalid MessageQueue.Codec %s", name)
+	}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return clients.ContentTypeJSON
+}
+
+func (jsonCodec) Encode(event contract.Event) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+type cborCodec struct{}
+
+func (cborCodec) ContentType() string {
+	return clients.ContentTypeCBOR
+}
+
+func (cborCodec) Encode(event contract.Event) ([]byte, error) {
+	return cbor.Marshal(event)
+}
+
+// protobufCodec encodes the events in the protobuf wire format following event.proto.
+// The fields with default values are omitted as in proto3, and the tags are sorted so
+// that the encoding is deterministic.
+type protobufCodec struct{}
+
+// The wire types of the protobuf fields.
+const (
+	protoVarint = 0
+	protoBytes  = 2
+)
+
+func (protobufCodec) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (protobufCodec) Encode(event contract.Event) ([]byte, error) {
+	var buf []byte
+	buf = appendProtoString(buf, 1, event.ID)
+	buf = appendProtoString(buf, 2, event.Device)
+	buf = appendProtoInt(buf, 3, event.Origin)
+	buf = appendProtoInt(buf, 4, event.Created)
+	for _, r := range event.Readings {
+		buf = appendProtoBytes(buf, 5, encodeProtoReading(r))
+	}
+	names := make([]string, 0, len(event.Tags))
+	for name := range event.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var entry []byte
+		entry = appendProtoString(entry, 1, name)
+		entry = appendProtoString(entry, 2, event.Tags[name])
+		buf = appendProtoBytes(buf, 6, entry)
+	}
+	return buf, nil
+}
+
+func encodeProtoReading(r contract.Reading) []byte {
+	var buf []byte
+	buf = appendProtoString(buf, 1, r.Id)
+	buf = appendProtoString(buf, 2, r.Device)
+	buf = appendProtoString(buf, 3, r.Name)
+	buf = appendProtoInt(buf, 4, r.Origin)
+	buf = appendProtoString(buf, 5, r.Value)
+	buf = appendProtoString(buf, 6, r.ValueType)
+	buf = appendProtoString(buf, 7, r.FloatEncoding)
+	if len(r.BinaryValue) > 0 {
+		buf = appendProtoBytes(buf, 8, r.BinaryValue)
+	}
+	buf = appendProtoString(buf, 9, r.MediaType)
+	buf = appendProtoInt(buf, 10, r.Created)
+	return buf
+}
+
+func appendProtoKey(buf []byte, field int, wireType int) []byte {
+	return appendUvarint(buf, uint64(field<<3|wireType))
+}
+
+func appendProtoInt(buf []byte, field int, v int64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendProtoKey(buf, field, protoVarint)
+	return appendUvarint(buf, uint64(v))
+}
+
+func appendProtoString(buf []byte, field int, s string) []byte {
+	if s == "" {
+		return buf
+	}
+	return appendProtoBytes(buf, field, []byte(s))
+}
+
+func appendProtoBytes(buf []byte, field int, b []byte) []byte {
+	buf = appendProtoKey(buf, field, protoBytes)
+	buf = appendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	return append(buf, b[:n]...)
+}
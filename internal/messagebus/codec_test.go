@@ -0,0 +1,60 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messagebus
+
+import (
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCodec(t *testing.T) {
+	codec, err := NewCodec("")
+	require.NoError(t, err)
+	assert.Nil(t, codec)
+
+	codec, err = NewCodec(CodecProtobuf)
+	require.NoError(t, err)
+	assert.Equal(t, ContentTypeProtobuf, codec.ContentType())
+
+	_, err = NewCodec("xml")
+	assert.Error(t, err)
+}
+
+func TestProtobufCodec(t *testing.T) {
+	event := contract.Event{
+		Device:   "d1",
+		Origin:   300,
+		Readings: []contract.Reading{{Name: "r1", Value: "1"}},
+		Tags:     map[string]string{"b": "2", "a": "1"},
+	}
+	payload, err := protobufCodec{}.Encode(event)
+	require.NoError(t, err)
+
+	expected := []byte{
+		0x12, 2, 'd', '1', // device
+		0x18, 0xac, 0x02, // origin
+		0x2a, 7, 0x1a, 2, 'r', '1', 0x2a, 1, '1', // readings
+		0x32, 6, 0x0a, 1, 'a', 0x12, 1, '1', // tags, sorted
+		0x32, 6, 0x0a, 1, 'b', 0x12, 1, '2',
+	}
+	assert.Equal(t, expected, payload)
+}
+
+func TestCBORCodec(t *testing.T) {
+	event := contract.Event{Device: "d1", Readings: []contract.Reading{{Name: "r1", BinaryValue: []byte{1, 2}}}}
+	payload, err := cborCodec{}.Encode(event)
+	require.NoError(t, err)
+
+	var decoded contract.Event
+	require.NoError(t, cbor.Unmarshal(payload, &decoded))
+	assert.Equal(t, event.Device, decoded.Device)
+	assert.Equal(t, event.Readings[0].BinaryValue, decoded.Readings[0].BinaryValue)
+}
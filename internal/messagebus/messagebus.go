@@ -0,0 +1,271 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package messagebus publishes the events onto a MessageBus, either Redis Streams or MQTT,
+// instead of posting them to Core Data.
+package messagebus
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// TypeRedis publishes the events onto Redis Streams, one stream per topic.
+	TypeRedis = "redis"
+	// TypeMQTT publishes the events onto an MQTT broker at QoS 1.
+	TypeMQTT = "mqtt"
+
+	// ProtocolTCP connects to the MessageBus in plain TCP.
+	ProtocolTCP = "tcp"
+	// ProtocolTLS connects to the MessageBus over TLS.
+	ProtocolTLS = "tls"
+
+	// The Optional settings of the MessageBus.
+	OptionalClientId = "ClientId"
+	OptionalUsername = "Username"
+	OptionalPassword = "Password"
+
+	defaultTopicPrefix     = "edgex/events/device"
+	defaultStreamMaxLength = 10000
+	dialTimeout            = 10 * time.Second
+	writeTimeout           = 10 * time.Second
+)
+
+// Config is the configuration of the MessageBus.
+type Config struct {
+	Type               string
+	Protocol           string
+	Host               string
+	Port               int
+	PublishTopicPrefix string
+	Optional           map[string]string
+	// Codec is the name of the Codec of the events, see NewCodec.
+	Codec string
+	// StreamMaxLength is the approximate number of entries the Redis Streams are trimmed
+	// to. Default is 10000.
+	StreamMaxLength int
+}
+
+// MessageEnvelope is the message published onto the MessageBus, wrapping the encoded event.
+type MessageEnvelope struct {
+	CorrelationID string `json:"correlationID"`
+	Payload       []byte `json:"payload"`
+	ContentType   string `json:"contentType"`
+}
+
+// client is a connection to the MessageBus.
+type client interface {
+	publish(topic string, message []byte) error
+	io.Closer
+}
+
+// Publisher publishes the events onto the MessageBus. The connections are opened as needed
+// by the concurrent publishes, kept open for the next ones once idle, and dropped after a
+// failure, so that a slow publish doesn't hold up the others. A publish failing on an idle
+// connection, which the MessageBus may have closed meanwhile, is retried once on a new one.
+type Publisher struct {
+	config Config
+	codec  Codec
+	idle   []client
+	closed bool
+	mutex  sync.Mutex
+}
+
+// maxIdleClients is the number of idle connections kept open for the next publishes.
+const maxIdleClients = 4
+
+var (
+	p              *Publisher
+	publisherMutex sync.RWMutex
+)
+
+// NewPublisher initiates the Publisher with the configuration of the MessageBus, closing
+// the one initiated before if any.
+func NewPublisher(config Config) error {
+	switch config.Type {
+	case TypeRedis, TypeMQTT:
+	default:
+		return fmt.Errorf("invalid MessageQueue.Type %s", config.Type)
+	}
+	switch config.Protocol {
+	case "", ProtocolTCP, ProtocolTLS:
+	default:
+		return fmt.Errorf("invalid MessageQueue.Protocol %s", config.Protocol)
+	}
+	if config.Host == "" || config.Port <= 0 {
+		return fmt.Errorf("invalid MessageQueue address %s:%d", config.Host, config.Port)
+	}
+	codec, err := NewCodec(config.Codec)
+	if err != nil {
+		return err
+	}
+	if config.PublishTopicPrefix == "" {
+		config.PublishTopicPrefix = defaultTopicPrefix
+	}
+	if config.StreamMaxLength < 0 {
+		return fmt.Errorf("invalid MessageQueue.StreamMaxLength %d", config.StreamMaxLength)
+	} else if config.StreamMaxLength == 0 {
+		config.StreamMaxLength = defaultStreamMaxLength
+	}
+
+	publisherMutex.Lock()
+	previous := p
+	p = &Publisher{config: config, codec: codec}
+	publisherMutex.Unlock()
+	return previous.Close()
+}
+
+// GetPublisher returns the Publisher, which is nil if the events are posted to Core Data.
+func GetPublisher() *Publisher {
+	publisherMutex.RLock()
+	defer publisherMutex.RUnlock()
+	return p
+}
+
+// ClosePublisher closes the Publisher, the events being posted to Core Data from then on.
+func ClosePublisher() error {
+	publisherMutex.Lock()
+	previous := p
+	p = nil
+	publisherMutex.Unlock()
+	return previous.Close()
+}
+
+// Topic returns the topic the events of the Device are published to.
+func (p *Publisher) Topic(deviceName string) string {
+	return p.config.PublishTopicPrefix + "/" + deviceName
+}
+
+// Codec returns the Codec of the events, which is nil if they're published as encoded for
+// Core Data.
+func (p *Publisher) Codec() Codec {
+	return p.codec
+}
+
+// Publish publishes the encoded event of the Device onto the MessageBus.
+func (p *Publisher) Publish(deviceName string, correlationID string, contentType string, payload []byte) error {
+	return p.PublishTopic(p.Topic(deviceName), correlationID, contentType, payload)
+}
+
+// PublishTopic publishes the payload onto the topic of the MessageBus.
+func (p *Publisher) PublishTopic(topic string, correlationID string, contentType string, payload []byte) error {
+	message, err := json.Marshal(MessageEnvelope{CorrelationID: correlationID, Payload: payload, ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("failed to encode the message envelope: %v", err)
+	}
+
+	c, idle, err := p.acquire()
+	if err != nil {
+		return err
+	}
+	if err = c.publish(topic, message); err != nil {
+		_ = c.Close()
+		if !idle {
+			return err
+		}
+		if c, err = p.connect(); err != nil {
+			return fmt.Errorf("failed to connect to the MessageBus: %v", err)
+		}
+		if err = c.publish(topic, message); err != nil {
+			_ = c.Close()
+			return err
+		}
+	}
+	p.release(c)
+	return nil
+}
+
+// Close closes the idle connections to the MessageBus, those in use being closed once
+// their publish completes.
+func (p *Publisher) Close() error {
+	if p == nil {
+		return nil
+	}
+	p.mutex.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mutex.Unlock()
+
+	var result error
+	for _, c := range idle {
+		if err := c.Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// acquire returns an idle connection, or a new one if none is, and whether it was idle.
+func (p *Publisher) acquire() (client, bool, error) {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil, false, errors.New("the MessageBus publisher is closed")
+	}
+	if n := len(p.idle); n > 0 {
+		c := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mutex.Unlock()
+		return c, true, nil
+	}
+	p.mutex.Unlock()
+
+	c, err := p.connect()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to connect to the MessageBus: %v", err)
+	}
+	return c, false, nil
+}
+
+// release keeps the connection for the next publishes, or closes it if enough are idle or
+// the Publisher is closed.
+func (p *Publisher) release(c client) {
+	p.mutex.Lock()
+	if !p.closed && len(p.idle) < maxIdleClients {
+		p.idle = append(p.idle, c)
+		c = nil
+	}
+	p.mutex.Unlock()
+	if c != nil {
+		_ = c.Close()
+	}
+}
+
+func (p *Publisher) connect() (client, error) {
+	address := net.JoinHostPort(p.config.Host, strconv.Itoa(p.config.Port))
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if p.config.Protocol == ProtocolTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: p.config.Host})
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var c client
+	if p.config.Type == TypeMQTT {
+		c, err = newMQTTClient(conn, p.config.Optional)
+	} else {
+		c, err = newRedisClient(conn, p.config.Optional, p.config.StreamMaxLength)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
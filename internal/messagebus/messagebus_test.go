@@ -0,0 +1,376 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messagebus
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer accepts the connections of the Publisher and records the messages received.
+type fakeServer struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	conns    []net.Conn
+	messages chan fakeMessage
+}
+
+// fakeMessage is a command of the Redis client or a packet of the MQTT client.
+type fakeMessage struct {
+	command string
+	args    []string
+}
+
+func newFakeServer(t *testing.T, serve func(s *fakeServer, conn net.Conn)) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{listener: listener, messages: make(chan fakeMessage, 100)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mutex.Lock()
+			s.conns = append(s.conns, conn)
+			s.mutex.Unlock()
+			go serve(s, conn)
+		}
+	}()
+	t.Cleanup(s.close)
+	return s
+}
+
+func (s *fakeServer) config(busType string) Config {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return Config{Type: busType, Host: "127.0.0.1", Port: addr.Port}
+}
+
+// dropConnections closes the connections accepted so far.
+func (s *fakeServer) dropConnections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *fakeServer) connections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.conns)
+}
+
+func (s *fakeServer) close() {
+	_ = s.listener.Close()
+	s.dropConnections()
+}
+
+// serveRedis replies to the RESP commands, accepting the password "secret" only.
+func serveRedis(s *fakeServer, conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readRESP(reader)
+		if err != nil {
+			return
+		}
+		s.messages <- fakeMessage{command: args[0], args: args[1:]}
+		reply := "$3\r\n1-0\r\n"
+		if args[0] == "AUTH" && args[len(args)-1] != "secret" {
+			reply = "-WRONGPASS invalid password\r\n"
+		}
+		if _, err = conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func readRESP(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, length+2)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:length])
+	}
+	return args, nil
+}
+
+// serveMQTT acknowledges the CONNECT and PUBLISH packets, refusing the client id "refused",
+// and records the CONNECT and PUBLISH packets.
+func serveMQTT(s *fakeServer, conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		packetType, body, err := readMQTTPacket(reader)
+		if err != nil {
+			return
+		}
+		switch packetType & 0xf0 {
+		case mqttConnect:
+			// protocol name, level, flags and keep alive, then the client id and credentials
+			flags := body[7]
+			payload := readMQTTStrings(body[10:])
+			s.messages <- fakeMessage{command: "CONNECT", args: append([]string{fmt.Sprintf("%#x", flags)}, payload...)}
+			code := byte(0)
+			if payload[0] == "refused" {
+				code = 5
+			}
+			if _, err = conn.Write([]byte{mqttConnack, 2, 0, code}); err != nil {
+				return
+			}
+		case mqttPublish:
+			topicLength := int(body[0])<<8 | int(body[1])
+			payload := body[2+topicLength:]
+			if packetType&mqttQoS1 != 0 {
+				packetId := payload[:2]
+				payload = payload[2:]
+				if _, err = conn.Write([]byte{mqttPuback, 2, packetId[0], packetId[1]}); err != nil {
+					return
+				}
+			}
+			s.messages <- fakeMessage{command: "PUBLISH", args: []string{string(body[2 : 2+topicLength]), string(payload)}}
+		case mqttDisconnect:
+			s.messages <- fakeMessage{command: "DISCONNECT"}
+		}
+	}
+}
+
+func readMQTTPacket(reader *bufio.Reader) (byte, []byte, error) {
+	packetType, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(b&0x7f) * multiplier
+		multiplier *= 128
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	_, err = io.ReadFull(reader, body)
+	return packetType, body, err
+}
+
+func readMQTTStrings(data []byte) []string {
+	var result []string
+	for len(data) >= 2 {
+		n := int(data[0])<<8 | int(data[1])
+		result = append(result, string(data[2:2+n]))
+		data = data[2+n:]
+	}
+	return result
+}
+
+func decodeEnvelope(t *testing.T, message string) MessageEnvelope {
+	var envelope MessageEnvelope
+	require.NoError(t, json.Unmarshal([]byte(message), &envelope))
+	return envelope
+}
+
+func TestNewPublisher(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"invalid type", Config{Type: "zeromq", Host: "localhost", Port: 6379}},
+		{"invalid protocol", Config{Type: TypeRedis, Protocol: "udp", Host: "localhost", Port: 6379}},
+		{"no host", Config{Type: TypeRedis, Port: 6379}},
+		{"no port", Config{Type: TypeMQTT, Host: "localhost"}},
+		{"invalid codec", Config{Type: TypeMQTT, Host: "localhost", Port: 1883, Codec: "xml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, NewPublisher(tt.config))
+		})
+	}
+
+	require.NoError(t, NewPublisher(Config{Type: TypeRedis, Host: "localhost", Port: 6379}))
+	publisher := GetPublisher()
+	assert.Equal(t, defaultTopicPrefix+"/d1", publisher.Topic("d1"))
+	assert.NoError(t, publisher.Close())
+}
+
+func TestPublishRedis(t *testing.T) {
+	s := newFakeServer(t, serveRedis)
+	config := s.config(TypeRedis)
+	config.Optional = map[string]string{OptionalUsername: "user", OptionalPassword: "secret"}
+	require.NoError(t, NewPublisher(config))
+	publisher := GetPublisher()
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish("d1", "c1", "application/json", []byte(`{"device":"d1"}`)))
+
+	auth := <-s.messages
+	assert.Equal(t, fakeMessage{command: "AUTH", args: []string{"user", "secret"}}, auth)
+	xadd := <-s.messages
+	assert.Equal(t, "XADD", xadd.command)
+	require.Len(t, xadd.args, 7)
+	assert.Equal(t, []string{defaultTopicPrefix + "/d1", "MAXLEN", "~", "10000", "*", redisEnvelopeField}, xadd.args[:6])
+	envelope := decodeEnvelope(t, xadd.args[6])
+	assert.Equal(t, "c1", envelope.CorrelationID)
+	assert.Equal(t, "application/json", envelope.ContentType)
+	assert.Equal(t, `{"device":"d1"}`, string(envelope.Payload))
+}
+
+func TestPublishRedisAuthFailed(t *testing.T) {
+	s := newFakeServer(t, serveRedis)
+	config := s.config(TypeRedis)
+	config.Optional = map[string]string{OptionalPassword: "wrong"}
+	require.NoError(t, NewPublisher(config))
+	publisher := GetPublisher()
+	defer publisher.Close()
+
+	err := publisher.Publish("d1", "c1", "application/json", []byte("{}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WRONGPASS")
+}
+
+func TestPublishMQTT(t *testing.T) {
+	s := newFakeServer(t, serveMQTT)
+	config := s.config(TypeMQTT)
+	config.PublishTopicPrefix = "events"
+	config.Optional = map[string]string{OptionalClientId: "ds1", OptionalUsername: "user", OptionalPassword: "secret"}
+	require.NoError(t, NewPublisher(config))
+	publisher := GetPublisher()
+
+	require.NoError(t, publisher.Publish("d1", "c1", "application/cbor", []byte{0xa1, 0x01}))
+
+	connect := <-s.messages
+	assert.Equal(t, fakeMessage{command: "CONNECT", args: []string{"0xc2", "ds1", "user", "secret"}}, connect)
+	publish := <-s.messages
+	assert.Equal(t, "PUBLISH", publish.command)
+	assert.Equal(t, "events/d1", publish.args[0])
+	envelope := decodeEnvelope(t, publish.args[1])
+	assert.Equal(t, "application/cbor", envelope.ContentType)
+	assert.Equal(t, []byte{0xa1, 0x01}, envelope.Payload)
+
+	require.NoError(t, publisher.Close())
+	assert.Equal(t, "DISCONNECT", (<-s.messages).command)
+	assert.Error(t, publisher.Publish("d1", "c2", "application/cbor", []byte{0xa0}), "the Publisher is closed")
+}
+
+func TestPublishMQTTRefused(t *testing.T) {
+	s := newFakeServer(t, serveMQTT)
+	config := s.config(TypeMQTT)
+	config.Optional = map[string]string{OptionalClientId: "refused"}
+	require.NoError(t, NewPublisher(config))
+	publisher := GetPublisher()
+	defer publisher.Close()
+
+	err := publisher.Publish("d1", "c1", "application/json", []byte("{}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "return code 5")
+}
+
+func TestPublishReconnect(t *testing.T) {
+	s := newFakeServer(t, serveRedis)
+	require.NoError(t, NewPublisher(s.config(TypeRedis)))
+	publisher := GetPublisher()
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish("d1", "c1", "application/json", []byte("{}")))
+	<-s.messages
+	s.dropConnections()
+
+	// the publish failing on the closed idle connection is retried on a new one
+	require.NoError(t, publisher.Publish("d1", "c2", "application/json", []byte("{}")))
+	xadd := <-s.messages
+	assert.Equal(t, "c2", decodeEnvelope(t, xadd.args[6]).CorrelationID)
+}
+
+func TestPublishMQTTReconnect(t *testing.T) {
+	s := newFakeServer(t, serveMQTT)
+	require.NoError(t, NewPublisher(s.config(TypeMQTT)))
+	publisher := GetPublisher()
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish("d1", "c1", "application/json", []byte("{}")))
+	assert.Equal(t, "CONNECT", (<-s.messages).command)
+	assert.Equal(t, "PUBLISH", (<-s.messages).command)
+	s.dropConnections()
+
+	// the closed idle connection fails the publish awaiting its acknowledgement
+	require.NoError(t, publisher.Publish("d1", "c2", "application/json", []byte("{}")))
+	assert.Equal(t, "CONNECT", (<-s.messages).command)
+	publish := <-s.messages
+	assert.Equal(t, "c2", decodeEnvelope(t, publish.args[1]).CorrelationID)
+}
+
+func TestPublishStreamMaxLength(t *testing.T) {
+	s := newFakeServer(t, serveRedis)
+	config := s.config(TypeRedis)
+	config.StreamMaxLength = 500
+	require.NoError(t, NewPublisher(config))
+	publisher := GetPublisher()
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish("d1", "c1", "application/json", []byte("{}")))
+	assert.Equal(t, []string{"MAXLEN", "~", "500"}, (<-s.messages).args[1:4])
+
+	config.StreamMaxLength = -1
+	assert.Error(t, NewPublisher(config))
+}
+
+func TestPublishConcurrent(t *testing.T) {
+	s := newFakeServer(t, serveRedis)
+	require.NoError(t, NewPublisher(s.config(TypeRedis)))
+	publisher := GetPublisher()
+	defer publisher.Close()
+
+	const publishes = 50
+	var wg sync.WaitGroup
+	for i := 0; i < publishes; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, publisher.Publish("d1", strconv.Itoa(i), "application/json", []byte("{}")))
+		}(i)
+	}
+	wg.Wait()
+
+	received := make(map[string]bool)
+	for i := 0; i < publishes; i++ {
+		xadd := <-s.messages
+		received[decodeEnvelope(t, xadd.args[6]).CorrelationID] = true
+	}
+	assert.Len(t, received, publishes, "every message is received whole")
+	assert.LessOrEqual(t, len(publisher.idle), maxIdleClients)
+	assert.GreaterOrEqual(t, s.connections(), 1)
+}
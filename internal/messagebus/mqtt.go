@@ -0,0 +1,142 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messagebus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The MQTT 3.1.1 control packets used to publish the messages.
+const (
+	mqttConnect    = 0x10
+	mqttConnack    = 0x20
+	mqttPublish    = 0x30
+	mqttPuback     = 0x40
+	mqttDisconnect = 0xe0
+
+	mqttQoS1 = 0x02
+
+	mqttCleanSession = 0x02
+	mqttPasswordFlag = 0x40
+	mqttUsernameFlag = 0x80
+)
+
+// mqttClient publishes the messages at QoS 1, waiting for their acknowledgement, so that a
+// connection closed by the broker fails the publish rather than losing the message. The
+// keep alive is disabled as the client only sends.
+type mqttClient struct {
+	conn     net.Conn
+	packetId uint16
+}
+
+func newMQTTClient(conn net.Conn, optional map[string]string) (*mqttClient, error) {
+	clientId := optional[OptionalClientId]
+	if clientId == "" {
+		clientId = "device-sdk-" + uuid.New().String()
+	}
+
+	var header bytes.Buffer
+	writeMQTTString(&header, "MQTT")
+	header.WriteByte(4) // protocol level of MQTT 3.1.1
+	var payload bytes.Buffer
+	writeMQTTString(&payload, clientId)
+	flags := byte(mqttCleanSession)
+	if username := optional[OptionalUsername]; username != "" {
+		flags |= mqttUsernameFlag
+		writeMQTTString(&payload, username)
+		if password := optional[OptionalPassword]; password != "" {
+			flags |= mqttPasswordFlag
+			writeMQTTString(&payload, password)
+		}
+	}
+	header.WriteByte(flags)
+	header.Write([]byte{0, 0}) // keep alive
+	header.Write(payload.Bytes())
+
+	c := &mqttClient{conn: conn}
+	if err := c.write(mqttConnect, header.Bytes()); err != nil {
+		return nil, err
+	}
+	connack := make([]byte, 4)
+	if _, err := io.ReadFull(conn, connack); err != nil {
+		return nil, fmt.Errorf("failed to read the MQTT CONNACK: %v", err)
+	}
+	if connack[0] != mqttConnack {
+		return nil, fmt.Errorf("unexpected MQTT packet %#x instead of CONNACK", connack[0])
+	}
+	if connack[3] != 0 {
+		return nil, fmt.Errorf("MQTT connection refused with return code %d", connack[3])
+	}
+	return c, nil
+}
+
+func (c *mqttClient) publish(topic string, message []byte) error {
+	c.packetId++
+	if c.packetId == 0 {
+		c.packetId = 1 // 0 isn't a valid packet identifier
+	}
+	var packet bytes.Buffer
+	writeMQTTString(&packet, topic)
+	packet.Write([]byte{byte(c.packetId >> 8), byte(c.packetId)})
+	packet.Write(message)
+	if err := c.write(mqttPublish|mqttQoS1, packet.Bytes()); err != nil {
+		return err
+	}
+
+	if err := c.conn.SetReadDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	puback := make([]byte, 4)
+	if _, err := io.ReadFull(c.conn, puback); err != nil {
+		return fmt.Errorf("failed to read the MQTT PUBACK: %v", err)
+	}
+	if puback[0] != mqttPuback || puback[2] != byte(c.packetId>>8) || puback[3] != byte(c.packetId) {
+		return fmt.Errorf("unexpected MQTT packet %#x instead of the PUBACK of packet %d", puback[0], c.packetId)
+	}
+	return nil
+}
+
+func (c *mqttClient) Close() error {
+	_ = c.write(mqttDisconnect, nil)
+	return c.conn.Close()
+}
+
+func (c *mqttClient) write(packetType byte, body []byte) error {
+	var packet bytes.Buffer
+	packet.WriteByte(packetType)
+	// remaining length, encoded 7 bits per byte
+	length := len(body)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		packet.WriteByte(b)
+		if length == 0 {
+			break
+		}
+	}
+	packet.Write(body)
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(packet.Bytes())
+	return err
+}
+
+func writeMQTTString(buf *bytes.Buffer, s string) {
+	buf.Write([]byte{byte(len(s) >> 8), byte(len(s))})
+	buf.WriteString(s)
+}
@@ -0,0 +1,107 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messagebus
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisEnvelopeField is the field of the Redis Streams entries holding the message envelope.
+const redisEnvelopeField = "envelope"
+
+// redisClient appends the messages to the Redis Streams named after the topics, trimming
+// them to about maxLength entries.
+type redisClient struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	maxLength int
+}
+
+func newRedisClient(conn net.Conn, optional map[string]string, maxLength int) (*redisClient, error) {
+	c := &redisClient{conn: conn, reader: bufio.NewReader(conn), maxLength: maxLength}
+	if password := optional[OptionalPassword]; password != "" {
+		args := []string{"AUTH", password}
+		if username := optional[OptionalUsername]; username != "" {
+			args = []string{"AUTH", username, password}
+		}
+		if _, err := c.do(args...); err != nil {
+			return nil, fmt.Errorf("Redis authentication failed: %v", err)
+		}
+	}
+	return c, nil
+}
+
+func (c *redisClient) publish(topic string, message []byte) error {
+	_, err := c.do("XADD", topic, "MAXLEN", "~", strconv.Itoa(c.maxLength), "*", redisEnvelopeField, string(message))
+	return err
+}
+
+func (c *redisClient) Close() error {
+	return c.conn.Close()
+}
+
+// do sends the command in the RESP protocol and returns the reply, which is a simple
+// string or a bulk string.
+func (c *redisClient) do(args ...string) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := c.conn.SetDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return "", err
+	}
+	if _, err := c.conn.Write(buf.Bytes()); err != nil {
+		return "", err
+	}
+
+	line, err := c.readLine()
+	if err != nil {
+		return "", err
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return "", errors.New(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid Redis reply %q", line)
+		}
+		if n < 0 {
+			return "", nil
+		}
+		data := make([]byte, n+2)
+		if _, err = io.ReadFull(c.reader, data); err != nil {
+			return "", err
+		}
+		return string(data[:n]), nil
+	default:
+		return "", fmt.Errorf("unexpected Redis reply %q", line)
+	}
+}
+
+func (c *redisClient) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", errors.New("empty Redis reply")
+	}
+	return line, nil
+}
@@ -0,0 +1,90 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// DriverPrefix prefixes the names of the metrics of the ProtocolDriver.
+const DriverPrefix = "edgex_device_driver_"
+
+var metricName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// driverReporter registers the metrics of the ProtocolDriver.
+type driverReporter struct{}
+
+// driverMetric is a counter or a gauge of the ProtocolDriver.
+type driverMetric struct {
+	family *family
+}
+
+// NewDriverReporter returns the MetricsReporter given to the ProtocolDriver.
+func NewDriverReporter() dsModels.MetricsReporter {
+	return driverReporter{}
+}
+
+func (driverReporter) RegisterCounter(name string, help string, labels ...string) (dsModels.MetricCounter, error) {
+	return registerDriverMetric(name, help, kindCounter, labels)
+}
+
+func (driverReporter) RegisterGauge(name string, help string, labels ...string) (dsModels.MetricGauge, error) {
+	return registerDriverMetric(name, help, kindGauge, labels)
+}
+
+func registerDriverMetric(name string, help string, kind string, labels []string) (driverMetric, error) {
+	if !metricName.MatchString(name) {
+		return driverMetric{}, fmt.Errorf("invalid metric name %s", name)
+	}
+	for _, label := range labels {
+		if !metricName.MatchString(label) {
+			return driverMetric{}, fmt.Errorf("invalid label %s of metric %s", label, name)
+		}
+	}
+	name = DriverPrefix + name
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, fam := range families {
+		if fam.name != name {
+			continue
+		}
+		if fam.kind != kind || !reflect.DeepEqual(fam.labels, labels) {
+			return driverMetric{}, fmt.Errorf("metric %s already registered as a %s of labels %v", name, fam.kind, fam.labels)
+		}
+		return driverMetric{family: fam}, nil
+	}
+	fam := newFamily(name, help, kind, labels...)
+	families = append(families, fam)
+	return driverMetric{family: fam}, nil
+}
+
+func (m driverMetric) Inc(labelValues ...string) {
+	m.Add(1, labelValues...)
+}
+
+func (m driverMetric) Add(delta float64, labelValues ...string) {
+	if len(labelValues) != len(m.family.labels) {
+		return
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	m.family.get(labelValues...).value += delta
+}
+
+func (m driverMetric) Set(value float64, labelValues ...string) {
+	if len(labelValues) != len(m.family.labels) {
+		return
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	m.family.get(labelValues...).value = value
+}
@@ -0,0 +1,245 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics collects the metrics of the Device Service exposed in the Prometheus
+// text format on the /metrics endpoint.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType is the content type of the Prometheus text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// The statuses of the commands and the published events.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// The destinations of the published events.
+const (
+	DestinationCoreData   = "coredata"
+	DestinationMessageBus = "messagebus"
+)
+
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the buckets of the command latencies.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// family is a metric and its series, one per combination of label values.
+type family struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+	series map[string]*series // key is the label values joined
+	gauge  func() float64
+}
+
+// series is the value of a counter, or the observations of a histogram whose value is
+// their sum.
+type series struct {
+	values  []string
+	value   float64
+	count   uint64
+	buckets []uint64 // cumulative counts of the histogram
+}
+
+var (
+	commands = newFamily("edgex_device_commands_total", "Commands executed by the ProtocolDriver.",
+		kindCounter, "device", "resource", "method", "status")
+	commandDuration = newFamily("edgex_device_command_duration_seconds", "Latency of the commands executed by the ProtocolDriver.",
+		kindHistogram, "device", "resource", "method")
+	asyncReadings = newFamily("edgex_device_async_readings_total", "Readings pushed asynchronously by the ProtocolDriver.",
+		kindCounter, "device")
+	events = newFamily("edgex_device_events_published_total", "Events published to Core Data or onto the MessageBus.",
+		kindCounter, "destination", "status")
+	clientErrors = newFamily("edgex_device_client_errors_total", "Failed requests to the core services.",
+		kindCounter, "client")
+
+	families = []*family{commands, commandDuration, asyncReadings, events, clientErrors}
+	mutex    sync.Mutex
+)
+
+func newFamily(name string, help string, kind string, labels ...string) *family {
+	return &family{name: name, help: help, kind: kind, labels: labels, series: make(map[string]*series)}
+}
+
+// RegisterGauge adds the gauge whose value is returned by f when the metrics are
+// written, e.g. the size of a cache. A gauge of the same name is replaced.
+func RegisterGauge(name string, help string, f func() float64) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for i, fam := range families {
+		if fam.name == name {
+			families = append(families[:i], families[i+1:]...)
+			break
+		}
+	}
+	families = append(families, &family{name: name, help: help, kind: kindGauge, gauge: f})
+}
+
+// CommandExecuted records a read or write command of the deviceResources of the Device
+// executed by the ProtocolDriver.
+func CommandExecuted(deviceName string, resources []string, method string, err error, duration time.Duration) {
+	status := StatusSuccess
+	if err != nil {
+		status = StatusError
+	}
+	resource := strings.Join(resources, ",")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	commands.get(deviceName, resource, method, status).value++
+	commandDuration.get(deviceName, resource, method).observe(duration.Seconds())
+}
+
+// AsyncReadingsReceived records the readings of the Device pushed asynchronously.
+func AsyncReadingsReceived(deviceName string, count int) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	asyncReadings.get(deviceName).value += float64(count)
+}
+
+// EventPublished records an event published to the destination.
+func EventPublished(destination string, err error) {
+	status := StatusSuccess
+	if err != nil {
+		status = StatusError
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	events.get(destination, status).value++
+}
+
+// ClientError records a failed request to the core service of the client.
+func ClientError(client string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	clientErrors.get(client).value++
+}
+
+// Reset clears the recorded metrics and the gauges.
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	families = []*family{commands, commandDuration, asyncReadings, events, clientErrors}
+	for _, fam := range families {
+		fam.series = make(map[string]*series)
+	}
+}
+
+func (f *family) get(values ...string) *series {
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: values}
+		if f.kind == kindHistogram {
+			s.buckets = make([]uint64, len(durationBuckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+func (s *series) observe(v float64) {
+	for i, bound := range durationBuckets {
+		if v <= bound {
+			s.buckets[i]++
+		}
+	}
+	s.value += v
+	s.count++
+}
+
+// Write writes the metrics in the Prometheus text format.
+func Write(w io.Writer) error {
+	mutex.Lock()
+	fams := make([]*family, len(families))
+	copy(fams, families)
+	var b strings.Builder
+	for _, fam := range fams {
+		if fam.gauge == nil {
+			fam.write(&b)
+		}
+	}
+	mutex.Unlock()
+
+	// the gauges are evaluated outside the lock as they may take their own locks
+	for _, fam := range fams {
+		if fam.gauge != nil {
+			fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %s\n", fam.name, fam.help, fam.name, fam.kind, fam.name, formatFloat(fam.gauge()))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (f *family) write(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		labels := formatLabels(f.labels, s.values)
+		if f.kind != kindHistogram {
+			fmt.Fprintf(b, "%s%s %s\n", f.name, wrapLabels(labels), formatFloat(s.value))
+			continue
+		}
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, wrapLabels(joinLabels(labels, `le="`+formatFloat(bound)+`"`)), s.buckets[i])
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, wrapLabels(joinLabels(labels, `le="+Inf"`)), s.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", f.name, wrapLabels(labels), formatFloat(s.value))
+		fmt.Fprintf(b, "%s_count%s %d\n", f.name, wrapLabels(labels), s.count)
+	}
+}
+
+func formatLabels(names []string, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + escapeLabel(values[i]) + `"`
+	}
+	return strings.Join(pairs, ",")
+}
+
+func joinLabels(labels string, label string) string {
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,75 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	Reset()
+	defer Reset()
+	CommandExecuted("d1", []string{"r1"}, "get", nil, 20*time.Millisecond)
+	CommandExecuted("d1", []string{"r1"}, "get", errors.New("failed"), 2*time.Second)
+	EventPublished(DestinationCoreData, nil)
+	ClientError("Data")
+	RegisterGauge("edgex_device_cache_devices", "Devices in the cache.", func() float64 { return 3 })
+
+	var b strings.Builder
+	require.NoError(t, Write(&b))
+	out := b.String()
+
+	assert.Contains(t, out, "# TYPE edgex_device_commands_total counter\n")
+	assert.Contains(t, out, `edgex_device_commands_total{device="d1",resource="r1",method="get",status="success"} 1`+"\n")
+	assert.Contains(t, out, `edgex_device_commands_total{device="d1",resource="r1",method="get",status="error"} 1`+"\n")
+	assert.Contains(t, out, `edgex_device_command_duration_seconds_bucket{device="d1",resource="r1",method="get",le="0.025"} 1`+"\n")
+	assert.Contains(t, out, `edgex_device_command_duration_seconds_bucket{device="d1",resource="r1",method="get",le="+Inf"} 2`+"\n")
+	assert.Contains(t, out, `edgex_device_command_duration_seconds_count{device="d1",resource="r1",method="get"} 2`+"\n")
+	assert.Contains(t, out, `edgex_device_events_published_total{destination="coredata",status="success"} 1`+"\n")
+	assert.Contains(t, out, `edgex_device_client_errors_total{client="Data"} 1`+"\n")
+	assert.Contains(t, out, "# TYPE edgex_device_cache_devices gauge\nedgex_device_cache_devices 3\n")
+}
+
+func TestEscapeLabel(t *testing.T) {
+	assert.Equal(t, `a\"b\\c\n`, escapeLabel("a\"b\\c\n"))
+}
+
+func TestDriverReporter(t *testing.T) {
+	Reset()
+	defer Reset()
+	reporter := NewDriverReporter()
+	crcErrors, err := reporter.RegisterCounter("modbus_crc_errors_total", "CRC errors of the Modbus lines.", "line")
+	require.NoError(t, err)
+	crcErrors.Inc("ttyS0")
+	crcErrors.Add(2, "ttyS0")
+	crcErrors.Inc()
+	again, err := reporter.RegisterCounter("modbus_crc_errors_total", "CRC errors of the Modbus lines.", "line")
+	require.NoError(t, err)
+	again.Inc("ttyS1")
+	connections, err := reporter.RegisterGauge("connections", "Open connections.")
+	require.NoError(t, err)
+	connections.Set(4)
+
+	_, err = reporter.RegisterGauge("modbus_crc_errors_total", "", "line")
+	assert.Error(t, err)
+	_, err = reporter.RegisterCounter("crc-errors", "")
+	assert.Error(t, err)
+
+	var b strings.Builder
+	require.NoError(t, Write(&b))
+	out := b.String()
+	assert.Contains(t, out, "# TYPE edgex_device_driver_modbus_crc_errors_total counter\n")
+	assert.Contains(t, out, `edgex_device_driver_modbus_crc_errors_total{line="ttyS0"} 3`+"\n")
+	assert.Contains(t, out, `edgex_device_driver_modbus_crc_errors_total{line="ttyS1"} 1`+"\n")
+	assert.Contains(t, out, "# TYPE edgex_device_driver_connections gauge\nedgex_device_driver_connections 4\n")
+}
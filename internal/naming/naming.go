@@ -0,0 +1,261 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package naming sanitizes or renders the names of the discovered Devices and resolves
+// their collisions with the existing Devices.
+package naming
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// The policies applied when a Device name is already taken by another Device.
+const (
+	// CollisionSuffix appends the lowest free numeric suffix, e.g. "-2", to the name.
+	CollisionSuffix = "suffix"
+	// CollisionReject rejects the Device.
+	CollisionReject = "reject"
+	// CollisionReplace replaces the existing Device.
+	CollisionReplace = "replace"
+)
+
+// maxIndex bounds the Index tried to render a free name.
+const maxIndex = 100000
+
+// defaultAllowed are the characters kept by the sanitize template function if
+// AllowedCharacters isn't configured.
+var defaultAllowed = regexp.MustCompile(`[^A-Za-z0-9_.~-]`)
+
+// Sanitizer transforms a discovered Device name before the configured rules apply.
+type Sanitizer func(name string) string
+
+type templateData struct {
+	ServiceName string
+	ProfileName string
+}
+
+// nameData is the data of the name template.
+type nameData struct {
+	ServiceName string
+	ProfileName string
+	// Name is the name given by the ProtocolDriver.
+	Name      string
+	Protocols map[string]contract.ProtocolProperties
+	Labels    []string
+	// Index is the lowest number from 1 making the name unique.
+	Index int
+}
+
+type rules struct {
+	disallowed  *regexp.Regexp
+	maxLength   int
+	prefix      *template.Template
+	name        *template.Template
+	policy      string
+	serviceName string
+	sanitizer   Sanitizer
+}
+
+var (
+	current = rules{policy: CollisionReject}
+	mutex   sync.RWMutex
+)
+
+// SetRules replaces the sanitization rules and collision policy, returning an error if
+// any of them is invalid.
+func SetRules(info common.NamingInfo, serviceName string) error {
+	r := rules{
+		maxLength:   info.MaxLength,
+		policy:      info.CollisionPolicy,
+		serviceName: serviceName,
+	}
+	if info.AllowedCharacters != "" {
+		disallowed, err := regexp.Compile("[^" + info.AllowedCharacters + "]")
+		if err != nil {
+			return fmt.Errorf("invalid Device.Naming.AllowedCharacters %s: %v", info.AllowedCharacters, err)
+		}
+		r.disallowed = disallowed
+	}
+	if info.Prefix != "" {
+		prefix, err := template.New("prefix").Option("missingkey=error").Parse(info.Prefix)
+		if err != nil {
+			return fmt.Errorf("invalid Device.Naming.Prefix %s: %v", info.Prefix, err)
+		}
+		r.prefix = prefix
+	}
+	if info.Template != "" {
+		allowed := r.disallowed
+		if allowed == nil {
+			allowed = defaultAllowed
+		}
+		funcs := template.FuncMap{
+			"sanitize": func(v string) string { return allowed.ReplaceAllString(v, "_") },
+			"lower":    strings.ToLower,
+			"upper":    strings.ToUpper,
+		}
+		name, err := template.New("name").Funcs(funcs).Option("missingkey=error").Parse(info.Template)
+		if err != nil {
+			return fmt.Errorf("invalid Device.Naming.Template %s: %v", info.Template, err)
+		}
+		r.name = name
+	}
+	switch r.policy {
+	case "":
+		r.policy = CollisionReject
+	case CollisionSuffix, CollisionReject, CollisionReplace:
+	default:
+		return fmt.Errorf("invalid Device.Naming.CollisionPolicy %s, should be '%s', '%s' or '%s'",
+			info.CollisionPolicy, CollisionSuffix, CollisionReject, CollisionReplace)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	r.sanitizer = current.sanitizer
+	current = r
+	return nil
+}
+
+// SetSanitizer registers the driver-provided Sanitizer, nil removes it.
+func SetSanitizer(sanitizer Sanitizer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	current.sanitizer = sanitizer
+}
+
+// Sanitize returns the name of a discovered Device of the given profile: the disallowed
+// characters are replaced with '_', the prefix is prepended and the result is truncated
+// to the maximum length.
+func Sanitize(name string, profileName string) (string, error) {
+	mutex.RLock()
+	r := current
+	mutex.RUnlock()
+
+	if r.sanitizer != nil {
+		name = r.sanitizer(name)
+	}
+	if r.disallowed != nil {
+		name = r.disallowed.ReplaceAllString(name, "_")
+	}
+	if r.prefix != nil {
+		var buf bytes.Buffer
+		err := r.prefix.Execute(&buf, templateData{ServiceName: r.serviceName, ProfileName: profileName})
+		if err != nil {
+			return "", fmt.Errorf("failed to render the name prefix of Device %s: %v", name, err)
+		}
+		name = buf.String() + name
+	}
+	name = truncate(name, r.maxLength)
+	if name == "" {
+		return "", fmt.Errorf("sanitized Device name is empty")
+	}
+	return name, nil
+}
+
+// Name returns the name of the discovered Device of the given profile, rendered from
+// the name template if one is configured and sanitized from the name given by the
+// ProtocolDriver otherwise. If the template refers to the Index, the lowest Index for
+// which the name isn't taken is used.
+func Name(d dsModels.DiscoveredDevice, profileName string, taken func(name string) bool) (string, error) {
+	mutex.RLock()
+	r := current
+	mutex.RUnlock()
+	if r.name == nil {
+		return Sanitize(d.Name, profileName)
+	}
+
+	data := nameData{
+		ServiceName: r.serviceName,
+		ProfileName: profileName,
+		Name:        d.Name,
+		Protocols:   d.Protocols,
+		Labels:      d.Labels,
+	}
+	render := func(index int) (string, error) {
+		data.Index = index
+		var buf bytes.Buffer
+		if err := r.name.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to render the name of Device %s: %v", d.Name, err)
+		}
+		name := truncate(buf.String(), r.maxLength)
+		if name == "" {
+			return "", fmt.Errorf("rendered name of Device %s is empty", d.Name)
+		}
+		return name, nil
+	}
+
+	name, err := render(1)
+	if err != nil || !taken(name) {
+		return name, err
+	}
+	// the name doesn't depend on the Index, the collision policy applies
+	if second, err := render(2); err != nil || second == name {
+		return name, err
+	}
+	for i := 2; i <= maxIndex; i++ {
+		if name, err = render(i); err != nil || !taken(name) {
+			return name, err
+		}
+	}
+	return "", fmt.Errorf("no free name rendered for Device %s", d.Name)
+}
+
+// Resolve applies the collision policy to the name given whether names are taken by
+// other Devices. It returns the name to use and whether the Device taking it should
+// be replaced, or an error if the Device is rejected.
+func Resolve(name string, taken func(name string) bool) (string, bool, error) {
+	if !taken(name) {
+		return name, false, nil
+	}
+
+	mutex.RLock()
+	policy, maxLength := current.policy, current.maxLength
+	mutex.RUnlock()
+
+	switch policy {
+	case CollisionReplace:
+		return name, true, nil
+	case CollisionSuffix:
+		for i := 2; ; i++ {
+			suffix := "-" + strconv.Itoa(i)
+			candidate := truncate(name, maxLength-len(suffix)) + suffix
+			if !taken(candidate) {
+				return candidate, false, nil
+			}
+		}
+	default:
+		return "", false, fmt.Errorf("name conflicted, Device %s exists", name)
+	}
+}
+
+// Policy returns the collision policy.
+func Policy() string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current.policy
+}
+
+func truncate(name string, maxLength int) string {
+	if maxLength <= 0 || len(name) <= maxLength {
+		return name
+	}
+	// don't split a multi-byte character
+	for maxLength > 0 && !utf8.RuneStart(name[maxLength]) {
+		maxLength--
+	}
+	return name[:maxLength]
+}
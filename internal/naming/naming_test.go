@@ -0,0 +1,102 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"strings"
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestSanitize(t *testing.T) {
+	err := SetRules(common.NamingInfo{AllowedCharacters: "A-Za-z0-9_-", MaxLength: 16, Prefix: "{{.ProfileName}}-"}, "device-simple")
+	require.NoError(t, err)
+	defer func() { _ = SetRules(common.NamingInfo{}, "") }()
+
+	name, err := Sanitize("cam 1/2", "ip")
+	require.NoError(t, err)
+	assert.Equal(t, "ip-cam_1_2", name)
+
+	name, err = Sanitize("thermostat-living-room", "ip")
+	require.NoError(t, err)
+	assert.Equal(t, "ip-thermostat-li", name, "truncated to MaxLength")
+
+	SetSanitizer(strings.ToLower)
+	defer SetSanitizer(nil)
+	name, err = Sanitize("CAM", "ip")
+	require.NoError(t, err)
+	assert.Equal(t, "ip-cam", name)
+}
+
+func TestInvalidRules(t *testing.T) {
+	assert.Error(t, SetRules(common.NamingInfo{AllowedCharacters: "z-a"}, ""))
+	assert.Error(t, SetRules(common.NamingInfo{Prefix: "{{.ServiceName"}, ""))
+	assert.Error(t, SetRules(common.NamingInfo{CollisionPolicy: "merge"}, ""))
+}
+
+func TestResolve(t *testing.T) {
+	taken := map[string]bool{"cam": true, "cam-2": true}
+	isTaken := func(name string) bool { return taken[name] }
+	defer func() { _ = SetRules(common.NamingInfo{}, "") }()
+
+	tests := []struct {
+		policy       string
+		name         string
+		expectedName string
+		replace      bool
+		expectedErr  bool
+	}{
+		{CollisionReject, "cam", "", false, true},
+		{CollisionReject, "door", "door", false, false},
+		{CollisionReplace, "cam", "cam", true, false},
+		{CollisionSuffix, "cam", "cam-3", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy+"/"+tt.name, func(t *testing.T) {
+			require.NoError(t, SetRules(common.NamingInfo{CollisionPolicy: tt.policy}, ""))
+			name, replace, err := Resolve(tt.name, isTaken)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.replace, replace)
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	err := SetRules(common.NamingInfo{Template: `{{.ProfileName}}-{{sanitize (index .Protocols "mac").Address}}-{{.Index}}`}, "device-simple")
+	require.NoError(t, err)
+	defer func() { _ = SetRules(common.NamingInfo{}, "") }()
+
+	d := dsModels.DiscoveredDevice{
+		Name:      "camera at 10.0.0.1",
+		Protocols: map[string]contract.ProtocolProperties{"mac": {"Address": "00:1b:44:11:3a:b7"}},
+	}
+	taken := map[string]bool{}
+	isTaken := func(name string) bool { return taken[name] }
+
+	name, err := Name(d, "ip", isTaken)
+	require.NoError(t, err)
+	assert.Equal(t, "ip-00_1b_44_11_3a_b7-1", name)
+
+	taken[name] = true
+	name, err = Name(d, "ip", isTaken)
+	require.NoError(t, err)
+	assert.Equal(t, "ip-00_1b_44_11_3a_b7-2", name)
+
+	_, err = Name(dsModels.DiscoveredDevice{Name: "no mac"}, "ip", isTaken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,305 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package playback sources the readings of a Device from a recorded CSV or
+// JSON event file instead of the ProtocolDriver. The recorded values are pushed
+// on the async values channel, so they flow through the same transformation and
+// publishing pipeline as the readings of a real device.
+package playback
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// ProtocolName is the name of the Device protocol which enables playback.
+	ProtocolName = "playback"
+	// FileProperty is the path of the recorded CSV (.csv) or JSON event (.json) file.
+	FileProperty = "File"
+	// IntervalProperty is the duration between two played back records.
+	IntervalProperty = "Interval"
+	// LoopProperty restarts the playback from the first record once the file is exhausted.
+	LoopProperty = "Loop"
+
+	DefaultInterval = time.Second
+)
+
+// record maps deviceResource names to their recorded values.
+type record map[string]string
+
+type recordedEvent struct {
+	Readings []struct {
+		ResourceName string `json:"resourceName"`
+		Value        string `json:"value"`
+	} `json:"readings"`
+}
+
+type Manager struct {
+	ctx     context.Context
+	wg      *sync.WaitGroup
+	asyncCh chan<- *dsModels.AsyncValues
+	lc      logger.LoggingClient
+	players map[string]context.CancelFunc // key is Device name
+	mutex   sync.Mutex
+}
+
+var m *Manager
+
+// NewManager initiates the playback manager. The asyncCh is the channel the
+// played back values are pushed to; playback is unavailable when it's nil.
+func NewManager(ctx context.Context, wg *sync.WaitGroup, asyncCh chan<- *dsModels.AsyncValues, lc logger.LoggingClient) {
+	m = &Manager{
+		ctx:     ctx,
+		wg:      wg,
+		asyncCh: asyncCh,
+		lc:      lc,
+		players: make(map[string]context.CancelFunc),
+	}
+}
+
+// GetManager returns the playback manager, which may be nil if it isn't initiated.
+func GetManager() *Manager {
+	return m
+}
+
+// StartPlayback starts the playback of every Device in cache using the playback protocol.
+func (m *Manager) StartPlayback() {
+	if m == nil {
+		return
+	}
+	for _, d := range cache.Devices().All() {
+		m.RestartForDevice(d.Name)
+	}
+}
+
+// StopPlayback stops the playback of all Devices.
+func (m *Manager) StopPlayback() {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for name, cancel := range m.players {
+		cancel()
+		delete(m.players, name)
+	}
+}
+
+// RestartForDevice (re)starts the playback of the Device if it uses the playback protocol.
+func (m *Manager) RestartForDevice(deviceName string) {
+	if m == nil {
+		return
+	}
+	// the Device is stopped and started under the lock so that concurrent restarts don't
+	// leave several players running for it
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.stop(deviceName)
+
+	device, ok := cache.Devices().ForName(deviceName)
+	if !ok {
+		return
+	}
+	properties, ok := device.Protocols[ProtocolName]
+	if !ok || device.AdminState == contract.Locked {
+		return
+	}
+	if m.asyncCh == nil {
+		m.lc.Error(fmt.Sprintf("playback of Device %s requires Service.EnableAsyncReadings", deviceName))
+		return
+	}
+
+	records, err := loadRecords(properties[FileProperty])
+	if err != nil {
+		m.lc.Error(fmt.Sprintf("failed to load playback records of Device %s: %v", deviceName, err))
+		return
+	}
+	interval := DefaultInterval
+	if v, ok := properties[IntervalProperty]; ok {
+		interval, err = time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			m.lc.Error(fmt.Sprintf("invalid playback %s '%s' of Device %s", IntervalProperty, v, deviceName))
+			return
+		}
+	}
+	loop, _ := strconv.ParseBool(properties[LoopProperty])
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.players[deviceName] = cancel
+	m.wg.Add(1)
+	go m.play(ctx, deviceName, records, interval, loop)
+	m.lc.Info(fmt.Sprintf("playback of %d records started for Device %s", len(records), deviceName))
+}
+
+// StopForDevice stops the playback of the Device.
+func (m *Manager) StopForDevice(deviceName string) {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.stop(deviceName)
+}
+
+func (m *Manager) stop(deviceName string) {
+	if cancel, ok := m.players[deviceName]; ok {
+		cancel()
+		delete(m.players, deviceName)
+	}
+}
+
+func (m *Manager) play(ctx context.Context, deviceName string, records []record, interval time.Duration, loop bool) {
+	defer m.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		if i == len(records) {
+			if !loop {
+				m.lc.Info(fmt.Sprintf("playback finished for Device %s", deviceName))
+				return
+			}
+			i = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		device, ok := cache.Devices().ForName(deviceName)
+		if !ok {
+			return
+		}
+		cvs := m.commandValues(device, records[i])
+		if len(cvs) == 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case m.asyncCh <- &dsModels.AsyncValues{DeviceName: deviceName, CommandValues: cvs}:
+		}
+	}
+}
+
+func (m *Manager) commandValues(device contract.Device, r record) []*dsModels.CommandValue {
+	cvs := make([]*dsModels.CommandValue, 0, len(r))
+	for name, value := range r {
+		dr, ok := cache.Profiles().DeviceResource(device.Profile.Name, name)
+		if !ok {
+			m.lc.Warn(fmt.Sprintf("playback: deviceResource %s not found in Device Profile %s", name, device.Profile.Name))
+			continue
+		}
+		cv, err := common.CreateCommandValueFromDeviceResource(&dr, value)
+		if err != nil {
+			m.lc.Warn(fmt.Sprintf("playback: invalid value '%s' of deviceResource %s: %v", value, name, err))
+			continue
+		}
+		cvs = append(cvs, cv)
+	}
+	return cvs
+}
+
+// loadRecords reads the recorded values from a CSV file, whose header row holds the
+// deviceResource names, or from a JSON file containing an array of events.
+func loadRecords(path string) ([]record, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%s property not specified", FileProperty)
+	}
+
+	var records []record
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = loadCSV(path)
+	case ".json":
+		records, err = loadJSON(path)
+	default:
+		return nil, fmt.Errorf("unsupported playback file %s, expected .csv or .json", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no records found in %s", path)
+	}
+	return records, nil
+}
+
+func loadCSV(path string) ([]record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	// the trailing cells of a row may be left out
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 1 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	records := make([]record, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		r := make(record, len(header))
+		for i, name := range header {
+			// blank cells are skipped so that resources may be recorded at different rates
+			if i < len(row) && row[i] != "" {
+				r[strings.TrimSpace(name)] = row[i]
+			}
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func loadJSON(path string) ([]record, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []recordedEvent
+	if err = json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+
+	records := make([]record, 0, len(events))
+	for _, e := range events {
+		r := make(record, len(e.Readings))
+		for _, reading := range e.Readings {
+			r[reading.ResourceName] = reading.Value
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
@@ -0,0 +1,119 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package playback
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func writeFile(t *testing.T, name string, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+func TestLoadRecordsCSV(t *testing.T) {
+	path := writeFile(t, "recording.CSV", "Temperature, Humidity\n21.5,40\n,41\n22.0\n")
+	records, err := loadRecords(path)
+	require.NoError(t, err)
+	assert.Equal(t, []record{
+		{"Temperature": "21.5", "Humidity": "40"},
+		{"Humidity": "41"},
+		{"Temperature": "22.0"},
+	}, records, "the blank and missing cells are skipped")
+}
+
+func TestLoadRecordsJSON(t *testing.T) {
+	path := writeFile(t, "recording.json", `[
+		{"readings": [{"resourceName": "Temperature", "value": "21.5"}, {"resourceName": "Humidity", "value": "40"}]},
+		{"readings": [{"resourceName": "Humidity", "value": "41"}]}
+	]`)
+	records, err := loadRecords(path)
+	require.NoError(t, err)
+	assert.Equal(t, []record{
+		{"Temperature": "21.5", "Humidity": "40"},
+		{"Humidity": "41"},
+	}, records)
+}
+
+func TestLoadRecordsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		errMsg string
+	}{
+		{"no file", "", "not specified"},
+		{"unsupported", writeFile(t, "recording.txt", "21.5\n"), "unsupported playback file"},
+		{"missing", filepath.Join(t.TempDir(), "missing.csv"), "no such file"},
+		{"empty CSV", writeFile(t, "empty.csv", ""), "no records found"},
+		{"header only", writeFile(t, "header.csv", "Temperature\n"), "no records found"},
+		{"invalid CSV", writeFile(t, "invalid.csv", "Temperature\n\"21.5\n"), "extraneous or missing"},
+		{"empty JSON", writeFile(t, "empty.json", "[]"), "no records found"},
+		{"invalid JSON", writeFile(t, "invalid.json", `{"readings": []}`), "cannot unmarshal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadRecords(tt.path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestRestartForDeviceConcurrently(t *testing.T) {
+	lc := logger.NewMockClient()
+	cache.InitCache("device-sdk-test", lc, &mock.ValueDescriptorMock{}, &mock.DeviceClientMock{}, &mock.ProvisionWatcherClientMock{})
+	path := writeFile(t, "recording.csv", "RandomValue_Bool\ntrue\n")
+	device := contract.Device{
+		Id:         "playback-id",
+		Name:       "Playback-Device",
+		AdminState: contract.Unlocked,
+		Profile:    contract.DeviceProfile{Name: "Random-Boolean-Generator"},
+		Protocols:  map[string]contract.ProtocolProperties{ProtocolName: {FileProperty: path, IntervalProperty: "1h"}},
+	}
+	require.NoError(t, cache.Devices().Add(device))
+	defer cache.Devices().RemoveByName(device.Name)
+
+	wg := &sync.WaitGroup{}
+	NewManager(context.Background(), wg, make(chan *dsModels.AsyncValues, 1), lc)
+	restarts := &sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		restarts.Add(1)
+		go func() {
+			defer restarts.Done()
+			GetManager().RestartForDevice(device.Name)
+		}()
+	}
+	restarts.Wait()
+	assert.Len(t, GetManager().players, 1)
+
+	GetManager().StopPlayback()
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("a player left running by the concurrent restarts")
+	}
+}
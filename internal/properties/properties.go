@@ -0,0 +1,148 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package properties exposes the custom properties of the Devices, declared as
+// '<name>:<value>' labels, to the templates applied to the published events.
+package properties
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// Separator separates the name and the value of a property label, e.g. 'floor:3'.
+const Separator = ":"
+
+// Data is the data of the templates referring to the Device of an event, e.g.
+// '{{.DeviceName}}' or '{{.Properties.floor}}'. It's resolved from the cache when the
+// template is executed so that the updates of the Devices apply immediately.
+type Data struct {
+	DeviceName  string
+	ProfileName string
+	Labels      []string
+	Properties  map[string]string
+}
+
+// Lookup returns the Device of the given name.
+type Lookup func(deviceName string) (contract.Device, bool)
+
+var (
+	lookup    Lookup
+	eventTags map[string]*template.Template // key is tag name
+	mutex     sync.RWMutex
+)
+
+// FromLabels returns the properties declared by the labels of a Device. The labels
+// without separator aren't properties.
+func FromLabels(labels []string) map[string]string {
+	props := make(map[string]string)
+	for _, label := range labels {
+		i := strings.Index(label, Separator)
+		if i <= 0 {
+			continue
+		}
+		props[label[:i]] = label[i+len(Separator):]
+	}
+	return props
+}
+
+// SetLookup sets how the Devices are resolved when the templates are executed.
+func SetLookup(l Lookup) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	lookup = l
+}
+
+// ForDevice returns the template data of the Device.
+func ForDevice(deviceName string) (Data, bool) {
+	mutex.RLock()
+	l := lookup
+	mutex.RUnlock()
+	if l == nil {
+		return Data{}, false
+	}
+	device, ok := l(deviceName)
+	if !ok {
+		return Data{}, false
+	}
+	return Data{
+		DeviceName:  device.Name,
+		ProfileName: device.Profile.Name,
+		Labels:      device.Labels,
+		Properties:  FromLabels(device.Labels),
+	}, true
+}
+
+// Parse parses a template referring to Data. The missing properties are expanded to
+// empty strings.
+func Parse(name string, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=zero").Parse(text)
+}
+
+// Expand executes the template for the Device.
+func Expand(tmpl *template.Template, deviceName string) (string, error) {
+	data, ok := ForDevice(deviceName)
+	if !ok {
+		return "", fmt.Errorf("Device %s not found", deviceName)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// SetEventTags parses the templates of the tags added to the published events, keyed
+// by tag name, returning an error if any of them is invalid.
+func SetEventTags(tags map[string]string) error {
+	parsed := make(map[string]*template.Template, len(tags))
+	for name, text := range tags {
+		tmpl, err := Parse(name, text)
+		if err != nil {
+			return fmt.Errorf("invalid Device.EventTags template of tag %s: %v", name, err)
+		}
+		parsed[name] = tmpl
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	eventTags = parsed
+	return nil
+}
+
+// EnrichEvent adds the tags expanded for the Device of the event, and reports whether
+// the event is modified. The tags expanded to empty strings are omitted and the tags
+// already set on the event are kept.
+func EnrichEvent(event *contract.Event) bool {
+	mutex.RLock()
+	tags := eventTags
+	mutex.RUnlock()
+	if len(tags) == 0 {
+		return false
+	}
+
+	modified := false
+	for name, tmpl := range tags {
+		if _, ok := event.Tags[name]; ok {
+			continue
+		}
+		value, err := Expand(tmpl, event.Device)
+		if err != nil || value == "" {
+			continue
+		}
+		if event.Tags == nil {
+			event.Tags = make(map[string]string, len(tags))
+		}
+		event.Tags[name] = value
+		modified = true
+	}
+	return modified
+}
@@ -27,24 +27,25 @@ func LoadDevices(deviceList []common.DeviceConfig, dic *di.Container) error {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
 	lc.Debug("Loading pre-define Devices from configuration")
-	for _, d := range deviceList {
+	// the Devices are created concurrently, each with its own Core Metadata call
+	return forEach(len(deviceList), provisionConcurrency(dic), func(i int) error {
+		d := deviceList[i]
 		if _, ok := cache.Devices().ForName(d.Name); ok {
 			lc.Debug(fmt.Sprintf("Device %s exists, using the existing one", d.Name))
-			continue
-		} else {
-			lc.Debug(fmt.Sprintf("Device %s doesn't exist, creating a new one", d.Name))
-			err := createDevice(
-				d,
-				lc,
-				*container.DeviceServiceFrom(dic.Get),
-				container.MetadataDeviceClientFrom(dic.Get))
-			if err != nil {
-				lc.Error(fmt.Sprintf("creating Device %s from config failed", d.Name))
-				return err
-			}
+			return nil
 		}
-	}
-	return nil
+		lc.Debug(fmt.Sprintf("Device %s doesn't exist, creating a new one", d.Name))
+		err := createDevice(
+			d,
+			lc,
+			*container.DeviceServiceFrom(dic.Get),
+			container.MetadataDeviceClientFrom(dic.Get))
+		if err != nil {
+			lc.Error(fmt.Sprintf("creating Device %s from config failed", d.Name))
+			return err
+		}
+		return nil
+	})
 }
 
 func createDevice(
@@ -0,0 +1,53 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+const defaultProvisionConcurrency = 4
+
+// provisionConcurrency returns the maximum number of the pre-defined Device Profiles or
+// Devices concurrently added to Core Metadata.
+func provisionConcurrency(dic *di.Container) int {
+	if n := container.ConfigurationFrom(dic.Get).Device.ProvisionConcurrency; n > 0 {
+		return n
+	}
+	return defaultProvisionConcurrency
+}
+
+// forEach calls fn for the indexes below n, running at most concurrency calls at once,
+// and returns the error of the lowest index once all the calls are done.
+func forEach(n int, concurrency int, fn func(i int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// The strategies resolving the conflicts between the pre-defined Device Profiles and their
+// version in Core Metadata, set by Device.ProfileConflictStrategy.
+const (
+	// ConflictSkip keeps the version in Core Metadata.
+	ConflictSkip = "skip"
+	// ConflictOverwrite updates the version in Core Metadata with the pre-defined one.
+	ConflictOverwrite = "overwrite"
+	// ConflictNewVersion adds the pre-defined Device Profile to Core Metadata under its name
+	// suffixed by the hash of its content, leaving the existing Devices on the version in
+	// Core Metadata until they are moved to the new one.
+	ConflictNewVersion = "newversion"
+	// ConflictFail aborts the startup.
+	ConflictFail = "fail"
+)
+
+// conflictStrategy returns the Device.ProfileConflictStrategy, ConflictSkip if unset.
+func conflictStrategy(strategy string) (string, error) {
+	switch s := strings.ToLower(strings.TrimSpace(strategy)); s {
+	case "":
+		return ConflictSkip, nil
+	case ConflictSkip, ConflictOverwrite, ConflictNewVersion, ConflictFail:
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid Device.ProfileConflictStrategy %s, should be '%s', '%s', '%s' or '%s'",
+		strategy, ConflictSkip, ConflictOverwrite, ConflictNewVersion, ConflictFail)
+}
+
+// profileDiff returns the differences of the pre-defined Device Profile from its version
+// in Core Metadata, ignoring the ids and timestamps set by Core Metadata.
+func profileDiff(local contract.DeviceProfile, remote contract.DeviceProfile) []string {
+	var diff []string
+	field := func(name string, l interface{}, r interface{}) {
+		if !reflect.DeepEqual(l, r) {
+			diff = append(diff, fmt.Sprintf("%s changed from %v to %v", name, r, l))
+		}
+	}
+	field("description", local.Description, remote.Description)
+	field("manufacturer", local.Manufacturer, remote.Manufacturer)
+	field("model", local.Model, remote.Model)
+	field("labels", sortedCopy(local.Labels), sortedCopy(remote.Labels))
+
+	localResources := make(map[string]interface{})
+	for _, dr := range local.DeviceResources {
+		localResources[dr.Name] = dr
+	}
+	remoteResources := make(map[string]interface{})
+	for _, dr := range remote.DeviceResources {
+		remoteResources[dr.Name] = dr
+	}
+	diff = append(diff, namedDiff("deviceResource", localResources, remoteResources)...)
+
+	localCommands := make(map[string]interface{})
+	for _, pr := range local.DeviceCommands {
+		localCommands[pr.Name] = pr
+	}
+	remoteCommands := make(map[string]interface{})
+	for _, pr := range remote.DeviceCommands {
+		remoteCommands[pr.Name] = pr
+	}
+	diff = append(diff, namedDiff("deviceCommand", localCommands, remoteCommands)...)
+
+	localCoreCommands := make(map[string]interface{})
+	for _, c := range local.CoreCommands {
+		localCoreCommands[c.Name] = coreCommand(c)
+	}
+	remoteCoreCommands := make(map[string]interface{})
+	for _, c := range remote.CoreCommands {
+		remoteCoreCommands[c.Name] = coreCommand(c)
+	}
+	diff = append(diff, namedDiff("coreCommand", localCoreCommands, remoteCoreCommands)...)
+	return diff
+}
+
+// namedDiff returns the elements of the kind added, removed or changed, by name.
+func namedDiff(kind string, local map[string]interface{}, remote map[string]interface{}) []string {
+	var diff []string
+	for name, l := range local {
+		r, ok := remote[name]
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("%s %s added", kind, name))
+		case !equalJSON(l, r):
+			diff = append(diff, fmt.Sprintf("%s %s changed", kind, name))
+		}
+	}
+	for name := range remote {
+		if _, ok := local[name]; !ok {
+			diff = append(diff, fmt.Sprintf("%s %s removed", kind, name))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// coreCommand returns the Command without the id and timestamps set by Core Metadata.
+func coreCommand(c contract.Command) contract.Command {
+	return contract.Command{Name: c.Name, Get: c.Get, Put: c.Put}
+}
+
+// equalJSON compares the values as serialized, so that nil and empty collections, which
+// Core Metadata doesn't distinguish, are equal.
+func equalJSON(a interface{}, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	c := append([]string(nil), s...)
+	sort.Strings(c)
+	return c
+}
+
+// versionName returns the name of the new version of the Device Profile, suffixed by the
+// hash of its content so that restarting adds no further version.
+func versionName(profile contract.DeviceProfile, content []byte) string {
+	sum := sha256.Sum256(content)
+	return profile.Name + "-" + hex.EncodeToString(sum[:4])
+}
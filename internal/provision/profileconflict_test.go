@@ -0,0 +1,192 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/metadata"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+)
+
+const profileYaml = `name: %s
+manufacturer: IOTech
+model: %s
+deviceResources:
+  - name: temperature
+    properties:
+      value: { type: Float64, readWrite: R }
+deviceCommands:
+  - name: status
+    get:
+      - { deviceResource: temperature }
+`
+
+// profileClientStub records the Device Profiles added to and updated in Core Metadata.
+type profileClientStub struct {
+	metadata.DeviceProfileClient
+	added   []contract.DeviceProfile
+	updated []contract.DeviceProfile
+}
+
+func (c *profileClientStub) Add(_ context.Context, dp *contract.DeviceProfile) (string, error) {
+	c.added = append(c.added, *dp)
+	return "new-id", nil
+}
+
+func (c *profileClientStub) Update(_ context.Context, dp contract.DeviceProfile) error {
+	c.updated = append(c.updated, dp)
+	return nil
+}
+
+// generalClientStub tells that Core Metadata manages the Value Descriptors.
+type generalClientStub struct{}
+
+func (generalClientStub) FetchConfiguration(context.Context) (string, error) {
+	return `{"Writable": {"EnableValueDescriptorManagement": true}}`, nil
+}
+
+func (generalClientStub) FetchMetrics(context.Context) (string, error) {
+	return "", nil
+}
+
+func TestConflictStrategy(t *testing.T) {
+	for in, expected := range map[string]string{"": ConflictSkip, " Overwrite ": ConflictOverwrite, "newversion": ConflictNewVersion, "FAIL": ConflictFail} {
+		strategy, err := conflictStrategy(in)
+		require.NoError(t, err)
+		assert.Equal(t, expected, strategy)
+	}
+	_, err := conflictStrategy("merge")
+	assert.Error(t, err)
+}
+
+func TestProfileDiff(t *testing.T) {
+	remote := contract.DeviceProfile{
+		Id:           "id",
+		Name:         "profile",
+		Manufacturer: "IOTech",
+		Labels:       []string{"b", "a"},
+		DeviceResources: []contract.DeviceResource{
+			{Name: "temperature", Attributes: map[string]string{}},
+			{Name: "humidity"},
+		},
+		CoreCommands: []contract.Command{{Id: "command-id", Name: "status"}},
+	}
+	local := contract.DeviceProfile{
+		Name:            "profile",
+		Manufacturer:    "IOTech",
+		Labels:          []string{"a", "b"},
+		DeviceResources: []contract.DeviceResource{{Name: "temperature"}, {Name: "humidity"}},
+		CoreCommands:    []contract.Command{{Name: "status"}},
+	}
+	assert.Empty(t, profileDiff(local, remote), "the ids, the order of the labels and the empty collections don't differ")
+
+	local.Model = "2"
+	local.DeviceResources = []contract.DeviceResource{{Name: "temperature", Description: "changed"}, {Name: "pressure"}}
+	local.DeviceCommands = []contract.ProfileResource{{Name: "status"}}
+	assert.Equal(t, []string{
+		"model changed from  to 2",
+		"deviceResource humidity removed",
+		"deviceResource pressure added",
+		"deviceResource temperature changed",
+		"deviceCommand status added",
+	}, profileDiff(local, remote))
+}
+
+func TestVersionName(t *testing.T) {
+	profile := contract.DeviceProfile{Name: "profile"}
+	name := versionName(profile, []byte("content"))
+	assert.Regexp(t, "^profile-[0-9a-f]{8}$", name)
+	assert.Equal(t, name, versionName(profile, []byte("content")), "a restart adds no further version")
+	assert.NotEqual(t, name, versionName(profile, []byte("changed")))
+}
+
+func TestLoadProfile(t *testing.T) {
+	cache.InitEmptyCache()
+	dir := t.TempDir()
+
+	tests := []struct {
+		strategy string
+		// the model of the version in Core Metadata, the pre-defined one being "2"
+		remoteModel  string
+		expectedErr  bool
+		expectAdded  bool
+		expectUpdate bool
+		cachedModel  string
+	}{
+		{ConflictSkip, "2", false, false, false, "2"},
+		{ConflictSkip, "1", false, false, false, "1"},
+		{ConflictOverwrite, "1", false, false, true, "2"},
+		{ConflictNewVersion, "1", false, true, false, "1"},
+		{ConflictFail, "1", true, false, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy+" model "+tt.remoteModel, func(t *testing.T) {
+			name := "profile-" + tt.strategy + "-" + tt.remoteModel
+			content := []byte(profileYamlFor(name, "2"))
+			path := filepath.Join(dir, name+".yaml")
+			require.NoError(t, ioutil.WriteFile(path, content, 0644))
+			remote, err := decodeProfile(path, []byte(profileYamlFor(name, tt.remoteModel)))
+			require.NoError(t, err)
+			remote.Id = name + "-id"
+
+			client := &profileClientStub{}
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+				container.MetadataDeviceProfileClientName:     func(get di.Get) interface{} { return client },
+				container.GeneralClientName:                   func(get di.Get) interface{} { return generalClientStub{} },
+				container.CoredataValueDescriptorClientName:   func(get di.Get) interface{} { return &mock.ValueDescriptorMock{} },
+			})
+
+			err = loadProfile(path, map[string]contract.DeviceProfile{name: remote}, tt.strategy, dic)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			cached, ok := cache.Profiles().ForName(name)
+			require.True(t, ok)
+			assert.Equal(t, tt.cachedModel, cached.Model)
+			assert.Equal(t, remote.Id, cached.Id)
+
+			if tt.expectUpdate {
+				require.Len(t, client.updated, 1)
+				assert.Equal(t, remote.Id, client.updated[0].Id, "the version in Core Metadata is updated in place")
+			} else {
+				assert.Empty(t, client.updated)
+			}
+			if tt.expectAdded {
+				require.Len(t, client.added, 1)
+				version := versionName(remote, content)
+				assert.Equal(t, version, client.added[0].Name)
+				added, ok := cache.Profiles().ForName(version)
+				require.True(t, ok, "the new version is cached along with the existing one")
+				assert.Equal(t, "new-id", added.Id)
+			} else {
+				assert.Empty(t, client.added)
+			}
+		})
+	}
+}
+
+func profileYamlFor(name string, model string) string {
+	return fmt.Sprintf(profileYaml, name, model)
+}
@@ -25,7 +25,6 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/google/uuid"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -46,6 +45,12 @@ func LoadProfiles(path string, dic *di.Container) error {
 	}
 	lc.Debug(fmt.Sprintf("created absolute path for loading pre-defined Device Profiles: %s", absPath))
 
+	strategy, err := conflictStrategy(container.ConfigurationFrom(dic.Get).Device.ProfileConflictStrategy)
+	if err != nil {
+		lc.Error(err.Error())
+		return err
+	}
+
 	dpc := container.MetadataDeviceProfileClientFrom(dic.Get)
 	ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
 	profiles, err := dpc.DeviceProfiles(ctx)
@@ -61,54 +66,105 @@ func LoadProfiles(path string, dic *di.Container) error {
 		return err
 	}
 
+	var paths []string
 	for _, file := range fileInfo {
-		var profile contract.DeviceProfile
-
-		fName := file.Name()
-		lfName := strings.ToLower(fName)
+		lfName := strings.ToLower(file.Name())
 		if strings.HasSuffix(lfName, yamlExt) || strings.HasSuffix(lfName, ymlExt) {
-			fullPath := absPath + "/" + fName
-			yamlFile, err := ioutil.ReadFile(fullPath)
-			if err != nil {
-				lc.Error(fmt.Sprintf("profiles: couldn't read file: %s; %v", fullPath, err))
-				continue
-			}
+			paths = append(paths, absPath+"/"+file.Name())
+		}
+	}
 
-			err = yaml.Unmarshal(yamlFile, &profile)
-			if err != nil {
-				lc.Error(fmt.Sprintf("invalid Device Profile: %s; %v", fullPath, err))
-				continue
-			}
+	// the profile files are loaded concurrently, each with its own Core Metadata call
+	return forEach(len(paths), provisionConcurrency(dic), func(i int) error {
+		return loadProfile(paths[i], pMap, strategy, dic)
+	})
+}
 
-			// TODO: this section will be removed after the deprecated fields are truly removed
-			handleDeprecatedFields(&profile)
+// loadProfile adds the Device Profile of the file to Core Metadata, resolving the conflict
+// with a different version already there by the strategy, and to the cache. Only the errors
+// aborting the startup are returned; the invalid profiles are logged and skipped.
+func loadProfile(fullPath string, pMap map[string]contract.DeviceProfile, strategy string, dic *di.Container) error {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
-			// if profile already exists in metadata, skip it
-			if p, ok := pMap[profile.Name]; ok {
-				_ = cache.Profiles().Add(p)
-				continue
-			}
+	yamlFile, err := ioutil.ReadFile(fullPath)
+	if err != nil {
+		lc.Error(fmt.Sprintf("profiles: couldn't read file: %s; %v", fullPath, err))
+		return nil
+	}
+
+	profile, err := decodeProfile(fullPath, yamlFile)
+	if err != nil {
+		lc.Error(err.Error())
+		return nil
+	}
+
+	// TODO: this section will be removed after the deprecated fields are truly removed
+	handleDeprecatedFields(&profile)
+
+	if err = validateProfile(fullPath, profile); err != nil {
+		lc.Error(err.Error())
+		return nil
+	}
+	if err = validateUnits(fullPath, profile, lc); err != nil {
+		lc.Error(err.Error())
+		return nil
+	}
 
-			// add profile to metadata
+	if p, ok := pMap[profile.Name]; ok {
+		diff := profileDiff(profile, p)
+		if len(diff) == 0 {
+			_ = cache.Profiles().Add(p)
+			return nil
+		}
+		lc.Warn(fmt.Sprintf("Device Profile %s differs from its version in Core Metadata, resolved by the %s strategy: %s",
+			fullPath, strategy, strings.Join(diff, "; ")))
+
+		switch strategy {
+		case ConflictFail:
+			return fmt.Errorf("Device Profile %s differs from its version in Core Metadata", fullPath)
+		case ConflictOverwrite:
+			profile.Id = p.Id
 			ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
-			id, err := dpc.Add(ctx, &profile)
-			if err != nil {
-				lc.Error(fmt.Sprintf("Add Device Profile %s to Core Metadata failed: %v", fullPath, err))
-				continue
+			if err = container.MetadataDeviceProfileClientFrom(dic.Get).Update(ctx, profile); err != nil {
+				lc.Error(fmt.Sprintf("Update Device Profile %s in Core Metadata failed: %v", fullPath, err))
+				_ = cache.Profiles().Add(p)
+				return nil
 			}
-			if err = common.VerifyIdFormat(id, "Device Profile"); err != nil {
-				return err
+			_ = cache.Profiles().Add(profile)
+			return nil
+		case ConflictNewVersion:
+			profile.Name = versionName(profile, yamlFile)
+			if v, ok := pMap[profile.Name]; ok {
+				_ = cache.Profiles().Add(v)
+				_ = cache.Profiles().Add(p)
+				return nil
 			}
-
-			profile.Id = id
-			cache.Profiles().Add(profile)
-			CreateDescriptorsFromProfile(
-				&profile,
-				lc,
-				container.GeneralClientFrom(dic.Get),
-				container.CoredataValueDescriptorClientFrom(dic.Get))
+			// the existing Devices keep using the version in Core Metadata
+			_ = cache.Profiles().Add(p)
+		default:
+			_ = cache.Profiles().Add(p)
+			return nil
 		}
 	}
+
+	// add profile to metadata
+	ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
+	id, err := container.MetadataDeviceProfileClientFrom(dic.Get).Add(ctx, &profile)
+	if err != nil {
+		lc.Error(fmt.Sprintf("Add Device Profile %s to Core Metadata failed: %v", fullPath, err))
+		return nil
+	}
+	if err = common.VerifyIdFormat(id, "Device Profile"); err != nil {
+		return err
+	}
+
+	profile.Id = id
+	cache.Profiles().Add(profile)
+	CreateDescriptorsFromProfile(
+		&profile,
+		lc,
+		container.GeneralClientFrom(dic.Get),
+		container.CoredataValueDescriptorClientFrom(dic.Get))
 	return nil
 }
 
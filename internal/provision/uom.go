@@ -0,0 +1,171 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"gopkg.in/yaml.v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	sdkUnits "github.com/edgexfoundry/device-sdk-go/v2/pkg/units"
+)
+
+const (
+	// UnitsValidationWarn logs the units missing from the units of measure definition.
+	UnitsValidationWarn = "warn"
+	// UnitsValidationReject doesn't load the Device Profiles having units missing from
+	// the units of measure definition.
+	UnitsValidationReject = "reject"
+
+	uomFetchTimeout = 10 * time.Second
+)
+
+// UCUMAttribute is the deviceResource attribute declaring the canonical UCUM code of its
+// units, which may be in another notation, e.g. °C. The code must be known to the
+// pkg/units conversions.
+const UCUMAttribute = common.SDKReservedPrefix + "ucum"
+
+// unitsOfMeasure is the units of measure definition, grouping the units by category:
+//
+//	source: reference of the definition
+//	units:
+//	  temperature:
+//	    source: reference of the category
+//	    values: [ "C", "F", "K" ]
+type unitsOfMeasure struct {
+	Source string `yaml:"source"`
+	Units  map[string]struct {
+		Source string   `yaml:"source"`
+		Values []string `yaml:"values"`
+	} `yaml:"units"`
+}
+
+var (
+	units          map[string]bool // nil if the units aren't validated
+	unitsRejection bool
+	unitsMutex     sync.RWMutex
+)
+
+// LoadUnitsOfMeasure loads the units of measure definition the units of the pre-defined
+// Device Profiles are validated against. The units aren't validated if info.Source is empty.
+func LoadUnitsOfMeasure(info common.UnitsOfMeasureInfo, lc logger.LoggingClient) error {
+	var reject bool
+	switch info.Validation {
+	case "", UnitsValidationWarn:
+	case UnitsValidationReject:
+		reject = true
+	default:
+		return fmt.Errorf("invalid Device.UnitsOfMeasure.Validation %s", info.Validation)
+	}
+
+	var defined map[string]bool
+	if info.Source != "" {
+		data, err := readUnitsOfMeasure(info.Source)
+		if err != nil {
+			return fmt.Errorf("failed to read the units of measure definition %s: %v", info.Source, err)
+		}
+		var uom unitsOfMeasure
+		if err = yaml.UnmarshalStrict(data, &uom); err != nil {
+			return fmt.Errorf("invalid units of measure definition %s: %v", info.Source, err)
+		}
+		defined = make(map[string]bool)
+		for _, category := range uom.Units {
+			for _, unit := range category.Values {
+				defined[unit] = true
+			}
+		}
+		lc.Info(fmt.Sprintf("loaded %d units of measure in %d categories from %s", len(defined), len(uom.Units), info.Source))
+	}
+
+	unitsMutex.Lock()
+	defer unitsMutex.Unlock()
+	units = defined
+	unitsRejection = reject
+	return nil
+}
+
+func readUnitsOfMeasure(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return ioutil.ReadFile(source)
+	}
+
+	client := http.Client{Timeout: uomFetchTimeout}
+	res, err := client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request returned status %d", res.StatusCode)
+	}
+	return ioutil.ReadAll(res.Body)
+}
+
+// validateUnits checks that the units of the deviceResources are defined in the units of
+// measure definition, and that their UCUMAttribute, if any, is a canonical UCUM code of
+// the units. It returns an error only if the Device Profile is to be rejected; otherwise
+// the problems are logged.
+func validateUnits(path string, profile contract.DeviceProfile, lc logger.LoggingClient) error {
+	unitsMutex.RLock()
+	defined, reject := units, unitsRejection
+	unitsMutex.RUnlock()
+
+	var problems []string
+	undefined := make(map[string][]string) // key is unit
+	for _, dr := range profile.DeviceResources {
+		unit := dr.Properties.Units.DefaultValue
+		if defined != nil && unit != "" && !defined[unit] {
+			undefined[unit] = append(undefined[unit], dr.Name)
+		}
+		if problem := validateUCUM(dr); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s: deviceResource %s %s", path, dr.Name, problem))
+		}
+	}
+	for unit, resources := range undefined {
+		problems = append(problems, fmt.Sprintf("%s: undefined unit %s of deviceResources %s", path, unit, strings.Join(resources, ", ")))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	msg := fmt.Sprintf("Device Profile %s has invalid units:\n%s", profile.Name, strings.Join(problems, "\n"))
+	if reject {
+		return fmt.Errorf("invalid %s", msg)
+	}
+	lc.Warn(msg)
+	return nil
+}
+
+// validateUCUM returns the problem with the UCUMAttribute of the deviceResource, if any.
+func validateUCUM(dr contract.DeviceResource) string {
+	code, ok := dr.Attributes[UCUMAttribute]
+	if !ok {
+		return ""
+	}
+	canonical, ok := sdkUnits.Canonical(code)
+	if !ok {
+		return fmt.Sprintf("has unknown UCUM code %s", code)
+	}
+	if canonical != code {
+		return fmt.Sprintf("has UCUM code %s instead of canonical %s", code, canonical)
+	}
+	unit := dr.Properties.Units.DefaultValue
+	if unitCode, ok := sdkUnits.Canonical(unit); ok && unitCode != canonical {
+		return fmt.Sprintf("has UCUM code %s inconsistent with its unit %s", code, unit)
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"fmt"
+	"strings"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+// decodeProfile strictly decodes the Device Profile YAML file, reporting the file, line
+// and key of the unknown, misspelled and duplicated keys and the mistyped values.
+func decodeProfile(path string, data []byte) (contract.DeviceProfile, error) {
+	var profile contract.DeviceProfile
+	err := yaml.UnmarshalStrict(data, &profile)
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		problems := make([]string, len(typeErr.Errors))
+		for i, e := range typeErr.Errors {
+			problems[i] = fmt.Sprintf("%s: %s", path, e)
+		}
+		return profile, fmt.Errorf("invalid Device Profile:\n%s", strings.Join(problems, "\n"))
+	} else if err != nil {
+		return profile, fmt.Errorf("invalid Device Profile %s: %v", path, err)
+	}
+	return profile, nil
+}
+
+// validateProfile checks that the deviceResources are uniquely named, that their numeric
+// properties are plain numbers and that the resource operations of the deviceCommands
+// reference existing deviceResources.
+func validateProfile(path string, profile contract.DeviceProfile) error {
+	var problems []string
+	resources := make(map[string]bool, len(profile.DeviceResources))
+	for _, dr := range profile.DeviceResources {
+		if resources[dr.Name] {
+			problems = append(problems, fmt.Sprintf("%s: duplicate deviceResource %s", path, dr.Name))
+		}
+		resources[dr.Name] = true
+		problems = append(problems, numericProblems(path, dr)...)
+	}
+
+	check := func(command string, method string, ros []contract.ResourceOperation) {
+		for i, ro := range ros {
+			if ro.DeviceResource == "" {
+				problems = append(problems, fmt.Sprintf("%s: deviceCommand %s %s[%d] references no deviceResource", path, command, method, i))
+			} else if !resources[ro.DeviceResource] {
+				problems = append(problems, fmt.Sprintf("%s: deviceCommand %s %s[%d] references unknown deviceResource %s", path, command, method, i, ro.DeviceResource))
+			}
+		}
+	}
+	for _, pr := range profile.DeviceCommands {
+		check(pr.Name, "get", pr.Get)
+		check(pr.Name, "set", pr.Set)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid Device Profile %s:\n%s", profile.Name, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// numericProblems returns the numeric properties of the deviceResource which aren't plain
+// numbers, independent from any locale.
+func numericProblems(path string, dr contract.DeviceResource) []string {
+	pv := dr.Properties.Value
+	properties := []struct {
+		name      string
+		value     string
+		valueType string
+	}{
+		{"minimum", pv.Minimum, v2.ValueTypeFloat64},
+		{"maximum", pv.Maximum, v2.ValueTypeFloat64},
+		{"defaultValue", pv.DefaultValue, pv.Type},
+		{"base", pv.Base, v2.ValueTypeFloat64},
+		{"scale", pv.Scale, v2.ValueTypeFloat64},
+		{"offset", pv.Offset, v2.ValueTypeFloat64},
+		{"mask", pv.Mask, v2.ValueTypeUint64},
+		{"shift", pv.Shift, v2.ValueTypeInt64},
+	}
+	var problems []string
+	for _, p := range properties {
+		if p.value == "" {
+			continue
+		}
+		if err := common.CheckNumber(p.value, p.valueType); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: deviceResource %s %s: %v", path, dr.Name, p.name, err))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,133 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package registrymonitor detects that the registry became unavailable after the startup,
+// so that the Device Service keeps operating with its last-known configuration and the
+// configured client endpoints, and re-registers once the registry is back.
+package registrymonitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+const defaultCheckInterval = 10 * time.Second
+
+// registryClient is the part of the registry client the monitor uses.
+type registryClient interface {
+	IsAlive() bool
+	Register() error
+}
+
+var (
+	degraded  bool
+	since     time.Time
+	outages   int
+	recovered []func()
+	mutex     sync.RWMutex
+)
+
+// Degraded returns true while the registry is unavailable, the Device Service operating
+// without it.
+func Degraded() bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return degraded
+}
+
+// Status returns whether the registry is unavailable, since when, and the number of times
+// it became unavailable.
+func Status() (bool, time.Time, int) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return degraded, since, outages
+}
+
+// OnRecovery registers the function called once the registry is available again after an
+// outage, e.g. to restore what was seeded into it if it was restarted without its data.
+func OnRecovery(f func()) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	recovered = append(recovered, f)
+}
+
+// BootstrapHandler checks the registry, if used, every Service.CheckInterval until the
+// Device Service stops.
+func BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	rc := bootstrapContainer.RegistryFrom(dic.Get)
+	if rc == nil {
+		return true
+	}
+	interval, err := time.ParseDuration(container.ConfigurationFrom(dic.Get).Service.CheckInterval)
+	if err != nil || interval <= 0 {
+		interval = defaultCheckInterval
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		monitor(ctx, rc, bootstrapContainer.LoggingClientFrom(dic.Get), interval)
+	}()
+	return true
+}
+
+func monitor(ctx context.Context, rc registryClient, lc logger.LoggingClient, interval time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(interval):
+		}
+		check(rc, lc)
+	}
+}
+
+// check enters the degraded mode once the registry is unavailable and, once it's back,
+// re-registers the Device Service with it to leave the degraded mode.
+func check(rc registryClient, lc logger.LoggingClient) {
+	if !rc.IsAlive() {
+		mutex.Lock()
+		entered := !degraded
+		if entered {
+			degraded = true
+			since = clock.Now()
+			outages++
+		}
+		mutex.Unlock()
+		if entered {
+			lc.Warn("Registry unavailable, operating with the last-known configuration and the configured client endpoints until it's back")
+		}
+		return
+	}
+	if !Degraded() {
+		return
+	}
+
+	// the registry may have lost the registration, e.g. if it was restarted without its data
+	if err := rc.Register(); err != nil {
+		lc.Debug(fmt.Sprintf("Registry available again but the re-registration failed, retrying: %v", err))
+		return
+	}
+	mutex.Lock()
+	degraded = false
+	down := clock.Now().Sub(since)
+	callbacks := append([]func(){}, recovered...)
+	mutex.Unlock()
+	lc.Info(fmt.Sprintf("Registry available again after %v, service re-registered", down.Round(time.Second)))
+	for _, f := range callbacks {
+		f()
+	}
+}
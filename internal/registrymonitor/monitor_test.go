@@ -0,0 +1,135 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registrymonitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+)
+
+type registryMock struct {
+	alive       bool
+	registerErr error
+	registered  int
+	checked     int
+	mutex       sync.Mutex
+}
+
+func (r *registryMock) IsAlive() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.checked++
+	return r.alive
+}
+
+func (r *registryMock) Register() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.registered++
+	return r.registerErr
+}
+
+func (r *registryMock) checks() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.checked
+}
+
+func reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	degraded, outages, recovered = false, 0, nil
+}
+
+func TestCheck(t *testing.T) {
+	reset()
+	defer reset()
+	lc := logger.NewMockClient()
+	rc := &registryMock{alive: true}
+	recoveries := 0
+	OnRecovery(func() { recoveries++ })
+
+	check(rc, lc)
+	assert.False(t, Degraded())
+	assert.Equal(t, 0, rc.registered, "the service isn't re-registered while the registry is available")
+
+	rc.alive = false
+	check(rc, lc)
+	isDegraded, since, count := Status()
+	assert.True(t, isDegraded)
+	assert.False(t, since.IsZero())
+	assert.Equal(t, 1, count)
+	check(rc, lc)
+	_, stillSince, count := Status()
+	assert.Equal(t, 1, count, "the same outage is counted once")
+	assert.Equal(t, since, stillSince)
+
+	rc.alive = true
+	rc.registerErr = errors.New("registration failed")
+	check(rc, lc)
+	assert.True(t, Degraded(), "the degraded mode is kept until the service is re-registered")
+	assert.Equal(t, 0, recoveries)
+
+	rc.registerErr = nil
+	check(rc, lc)
+	assert.False(t, Degraded())
+	assert.Equal(t, 2, rc.registered)
+	assert.Equal(t, 1, recoveries)
+
+	rc.alive = false
+	check(rc, lc)
+	_, _, count = Status()
+	assert.Equal(t, 2, count)
+}
+
+func TestMonitor(t *testing.T) {
+	reset()
+	defer reset()
+	start := time.Now()
+	f := clock.NewFake(start)
+	clock.Set(f)
+	defer clock.Set(nil)
+	rc := &registryMock{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		monitor(ctx, rc, logger.NewMockClient(), time.Minute)
+		close(done)
+	}()
+
+	waitForWaiter := func() {
+		assert.Eventually(t, func() bool { return f.Waiters() == 1 }, time.Second, time.Millisecond)
+	}
+	waitForWaiter()
+	f.Advance(59 * time.Second)
+	assert.Equal(t, 0, rc.checks(), "the registry isn't checked before the interval elapsed")
+	f.Advance(time.Second)
+	assert.Eventually(t, Degraded, time.Second, time.Millisecond)
+	_, since, _ := Status()
+	assert.Equal(t, 1, rc.checks())
+	assert.Equal(t, start.Add(time.Minute), since, "the outage starts at the time of the clock")
+
+	waitForWaiter()
+	f.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return rc.checks() == 2 }, time.Second, time.Millisecond)
+
+	waitForWaiter()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the monitor didn't stop once the context was done")
+	}
+}
@@ -0,0 +1,50 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+// AddMetadataLookups adds the lookups resolving the names from the caches, then from Core
+// Metadata for the Devices, Device Profiles and Provision Watchers the caches don't hold.
+// The Device Service only resolves its own id.
+func AddMetadataLookups(dic *di.Container) {
+	AddLookup(Device, func(_ context.Context, id string) (string, bool, error) {
+		d, ok := cache.Devices().ForId(id)
+		return d.Name, ok, nil
+	})
+	AddLookup(Device, func(ctx context.Context, id string) (string, bool, error) {
+		d, err := container.MetadataDeviceClientFrom(dic.Get).Device(ctx, id)
+		return d.Name, err == nil, err
+	})
+	AddLookup(Profile, func(_ context.Context, id string) (string, bool, error) {
+		p, ok := cache.Profiles().ForId(id)
+		return p.Name, ok, nil
+	})
+	AddLookup(Profile, func(ctx context.Context, id string) (string, bool, error) {
+		p, err := container.MetadataDeviceProfileClientFrom(dic.Get).DeviceProfile(ctx, id)
+		return p.Name, err == nil, err
+	})
+	AddLookup(ProvisionWatcher, func(_ context.Context, id string) (string, bool, error) {
+		pw, ok := cache.ProvisionWatchers().ForId(id)
+		return pw.Name, ok, nil
+	})
+	AddLookup(ProvisionWatcher, func(ctx context.Context, id string) (string, bool, error) {
+		pw, err := container.MetadataProvisionWatcherClientFrom(dic.Get).ProvisionWatcher(ctx, id)
+		return pw.Name, err == nil, err
+	})
+	AddLookup(Service, func(_ context.Context, id string) (string, bool, error) {
+		ds := container.DeviceServiceFrom(dic.Get)
+		return ds.Name, ds.Id == id, nil
+	})
+}
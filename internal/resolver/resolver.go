@@ -0,0 +1,154 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resolver resolves the names of the metadata objects from the ids the callbacks
+// deliver, remembering them so that Core Metadata is looked up once per object.
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Kind is the kind of a metadata object.
+type Kind string
+
+const (
+	Device           Kind = "device"
+	Profile          Kind = "profile"
+	Service          Kind = "service"
+	ProvisionWatcher Kind = "provisionwatcher"
+)
+
+// Lookup returns the name of the object of a kind by its id, and false if it doesn't know
+// the object.
+type Lookup func(ctx context.Context, id string) (string, bool, error)
+
+// Metrics are the counters of the resolutions.
+type Metrics struct {
+	// Hits are the names resolved from the remembered ones.
+	Hits uint64
+	// Misses are the names resolved by the lookups.
+	Misses uint64
+	// Failures are the names none of the lookups resolved.
+	Failures uint64
+}
+
+type resolver struct {
+	names   map[Kind]map[string]string // key is id
+	ids     map[Kind]map[string]string // key is name
+	lookups map[Kind][]Lookup
+	metrics Metrics
+	mutex   sync.RWMutex
+}
+
+var r = newResolver()
+
+func newResolver() *resolver {
+	return &resolver{
+		names:   make(map[Kind]map[string]string),
+		ids:     make(map[Kind]map[string]string),
+		lookups: make(map[Kind][]Lookup),
+	}
+}
+
+// AddLookup appends the lookup to the ones resolving the names of the kind, which are
+// tried in order.
+func AddLookup(kind Kind, lookup Lookup) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.lookups[kind] = append(r.lookups[kind], lookup)
+}
+
+// Name returns the name of the object of the kind by its id, from the remembered names or
+// else the lookups of the kind.
+func Name(ctx context.Context, kind Kind, id string) (string, error) {
+	r.mutex.Lock()
+	if name, ok := r.names[kind][id]; ok {
+		r.metrics.Hits++
+		r.mutex.Unlock()
+		return name, nil
+	}
+	lookups := r.lookups[kind]
+	r.mutex.Unlock()
+
+	for _, lookup := range lookups {
+		name, ok, err := lookup(ctx, id)
+		if err != nil {
+			r.mutex.Lock()
+			r.metrics.Failures++
+			r.mutex.Unlock()
+			return "", err
+		}
+		if ok {
+			r.mutex.Lock()
+			r.metrics.Misses++
+			r.put(kind, id, name)
+			r.mutex.Unlock()
+			return name, nil
+		}
+	}
+
+	r.mutex.Lock()
+	r.metrics.Failures++
+	r.mutex.Unlock()
+	return "", fmt.Errorf("failed to resolve the name of %s %s", kind, id)
+}
+
+// ID returns the id of the object of the kind by its name, if remembered.
+func ID(kind Kind, name string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	id, ok := r.ids[kind][name]
+	return id, ok
+}
+
+// Put remembers the name of the object of the kind, replacing its previous name.
+func Put(kind Kind, id string, name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.put(kind, id, name)
+}
+
+func (r *resolver) put(kind Kind, id string, name string) {
+	if r.names[kind] == nil {
+		r.names[kind] = make(map[string]string)
+		r.ids[kind] = make(map[string]string)
+	}
+	if previous, ok := r.names[kind][id]; ok {
+		delete(r.ids[kind], previous)
+	}
+	r.names[kind][id] = name
+	r.ids[kind][name] = id
+}
+
+// Invalidate forgets the name of the object of the kind, e.g. once it's deleted.
+func Invalidate(kind Kind, id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if name, ok := r.names[kind][id]; ok {
+		delete(r.names[kind], id)
+		delete(r.ids[kind], name)
+	}
+}
+
+// Stats returns the counters of the resolutions.
+func Stats() Metrics {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.metrics
+}
+
+// Reset forgets the lookups, the remembered names and the counters.
+func Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.names = make(map[Kind]map[string]string)
+	r.ids = make(map[Kind]map[string]string)
+	r.lookups = make(map[Kind][]Lookup)
+	r.metrics = Metrics{}
+}
@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	lookups := 0
+	AddLookup(Device, func(_ context.Context, id string) (string, bool, error) {
+		lookups++
+		return "cached-" + id, id == "d1", nil
+	})
+	AddLookup(Device, func(_ context.Context, id string) (string, bool, error) {
+		lookups++
+		if id == "d3" {
+			return "", false, errors.New("metadata unavailable")
+		}
+		return "metadata-" + id, id == "d2", nil
+	})
+
+	name, err := Name(context.Background(), Device, "d1")
+	require.NoError(t, err)
+	assert.Equal(t, "cached-d1", name)
+	name, err = Name(context.Background(), Device, "d2")
+	require.NoError(t, err)
+	assert.Equal(t, "metadata-d2", name)
+	assert.Equal(t, 3, lookups)
+
+	// resolved names are remembered
+	name, err = Name(context.Background(), Device, "d1")
+	require.NoError(t, err)
+	assert.Equal(t, "cached-d1", name)
+	assert.Equal(t, 3, lookups)
+	id, ok := ID(Device, "metadata-d2")
+	assert.True(t, ok)
+	assert.Equal(t, "d2", id)
+
+	_, err = Name(context.Background(), Device, "d3")
+	assert.Error(t, err)
+	_, err = Name(context.Background(), Profile, "d1")
+	assert.Error(t, err, "the lookups are per kind")
+
+	Put(Device, "d1", "renamed")
+	name, err = Name(context.Background(), Device, "d1")
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", name)
+	_, ok = ID(Device, "cached-d1")
+	assert.False(t, ok, "the previous name is forgotten")
+
+	Invalidate(Device, "d1")
+	_, ok = ID(Device, "renamed")
+	assert.False(t, ok)
+
+	assert.Equal(t, Metrics{Hits: 2, Misses: 2, Failures: 2}, Stats())
+}
@@ -0,0 +1,189 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package smoothing smooths the values of noisy numeric deviceResources with an
+// exponential or a simple moving average before the assertions and the publication.
+package smoothing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// SmoothingAttribute is the smoothing of the deviceResource, one of MethodEMA and
+	// MethodSMA.
+	SmoothingAttribute = common.SDKReservedPrefix + "smoothing"
+	// SmoothingAlphaAttribute is the weight of the latest value in the exponential
+	// moving average, in (0, 1]. Default is DefaultAlpha.
+	SmoothingAlphaAttribute = common.SDKReservedPrefix + "smoothingAlpha"
+	// SmoothingWindowAttribute is the number of values of the simple moving average.
+	// Default is DefaultWindow.
+	SmoothingWindowAttribute = common.SDKReservedPrefix + "smoothingWindow"
+
+	MethodEMA = "ema"
+	MethodSMA = "sma"
+
+	DefaultAlpha  = 0.5
+	DefaultWindow = 5
+)
+
+// state is the smoothing state of a deviceResource of a Device.
+type state struct {
+	average float64
+	// values are the last values of the simple moving average, in order of arrival.
+	values []float64
+}
+
+var (
+	states = make(map[string]*state) // key is Device name and deviceResource name
+	mutex  sync.Mutex
+)
+
+// IsSmoothed reports whether the deviceResource attributes declare a smoothing.
+func IsSmoothed(attributes map[string]string) bool {
+	return strings.TrimSpace(attributes[SmoothingAttribute]) != ""
+}
+
+// Process replaces the value of the CommandValue by its moving average. The values
+// which aren't numeric, e.g. after an overflow, are returned unchanged and don't
+// affect the average.
+func Process(deviceName string, cv *dsModels.CommandValue, attributes map[string]string) (*dsModels.CommandValue, error) {
+	if !isNumeric(cv.Type) {
+		return cv, nil
+	}
+	value, err := strconv.ParseFloat(cv.ValueToString(models.ENotation), 64)
+	if err != nil {
+		return cv, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return cv, nil
+	}
+
+	key := deviceName + "/" + cv.DeviceResourceName
+	var average float64
+	switch method := strings.ToLower(strings.TrimSpace(attributes[SmoothingAttribute])); method {
+	case MethodEMA:
+		alpha, err := parseAlpha(attributes[SmoothingAlphaAttribute])
+		if err != nil {
+			return cv, err
+		}
+		mutex.Lock()
+		s, exists := states[key]
+		if !exists {
+			s = &state{average: value}
+			states[key] = s
+		} else {
+			s.average = alpha*value + (1-alpha)*s.average
+		}
+		average = s.average
+		mutex.Unlock()
+	case MethodSMA:
+		window, err := parseWindow(attributes[SmoothingWindowAttribute])
+		if err != nil {
+			return cv, err
+		}
+		mutex.Lock()
+		s, exists := states[key]
+		if !exists {
+			s = &state{}
+			states[key] = s
+		}
+		s.values = append(s.values, value)
+		if len(s.values) > window {
+			s.values = s.values[len(s.values)-window:]
+		}
+		var sum float64
+		for _, v := range s.values {
+			sum += v
+		}
+		average = sum / float64(len(s.values))
+		mutex.Unlock()
+	default:
+		return cv, fmt.Errorf("invalid %s attribute %s, expecting %s or %s", SmoothingAttribute, method, MethodEMA, MethodSMA)
+	}
+
+	return newValue(cv, average)
+}
+
+// Reset discards the smoothing states of the Device, e.g. when it's removed.
+func Reset(deviceName string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for key := range states {
+		if strings.HasPrefix(key, deviceName+"/") {
+			delete(states, key)
+		}
+	}
+}
+
+func parseAlpha(attribute string) (float64, error) {
+	if strings.TrimSpace(attribute) == "" {
+		return DefaultAlpha, nil
+	}
+	alpha, err := strconv.ParseFloat(strings.TrimSpace(attribute), 64)
+	if err != nil || alpha <= 0 || alpha > 1 {
+		return 0, fmt.Errorf("invalid %s attribute %s, expecting a number in (0, 1]", SmoothingAlphaAttribute, attribute)
+	}
+	return alpha, nil
+}
+
+func parseWindow(attribute string) (int, error) {
+	if strings.TrimSpace(attribute) == "" {
+		return DefaultWindow, nil
+	}
+	window, err := strconv.Atoi(strings.TrimSpace(attribute))
+	if err != nil || window <= 0 {
+		return 0, fmt.Errorf("invalid %s attribute %s, expecting a positive integer", SmoothingWindowAttribute, attribute)
+	}
+	return window, nil
+}
+
+func isNumeric(valueType string) bool {
+	switch valueType {
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64,
+		v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64,
+		v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+		return true
+	}
+	return false
+}
+
+// newValue returns a CommandValue of the type of cv holding the value, rounded for the
+// integer types.
+func newValue(cv *dsModels.CommandValue, value float64) (*dsModels.CommandValue, error) {
+	name, origin := cv.DeviceResourceName, cv.Origin
+	switch cv.Type {
+	case v2.ValueTypeUint8:
+		return dsModels.NewUint8Value(name, origin, uint8(math.Round(value)))
+	case v2.ValueTypeUint16:
+		return dsModels.NewUint16Value(name, origin, uint16(math.Round(value)))
+	case v2.ValueTypeUint32:
+		return dsModels.NewUint32Value(name, origin, uint32(math.Round(value)))
+	case v2.ValueTypeUint64:
+		return dsModels.NewUint64Value(name, origin, uint64(math.Round(value)))
+	case v2.ValueTypeInt8:
+		return dsModels.NewInt8Value(name, origin, int8(math.Round(value)))
+	case v2.ValueTypeInt16:
+		return dsModels.NewInt16Value(name, origin, int16(math.Round(value)))
+	case v2.ValueTypeInt32:
+		return dsModels.NewInt32Value(name, origin, int32(math.Round(value)))
+	case v2.ValueTypeInt64:
+		return dsModels.NewInt64Value(name, origin, int64(math.Round(value)))
+	case v2.ValueTypeFloat32:
+		return dsModels.NewFloat32Value(name, origin, float32(value))
+	}
+	return dsModels.NewFloat64Value(name, origin, value)
+}
@@ -0,0 +1,63 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package smoothing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestProcess(t *testing.T) {
+	defer Reset("device")
+
+	tests := []struct {
+		name       string
+		resource   string
+		attributes map[string]string
+		values     []float64
+		expected   []float64
+	}{
+		{"ema", "ema", map[string]string{SmoothingAttribute: "EMA", SmoothingAlphaAttribute: "0.5"}, []float64{10, 20, 20}, []float64{10, 15, 17.5}},
+		{"ema default alpha", "emaDefault", map[string]string{SmoothingAttribute: MethodEMA}, []float64{0, 8}, []float64{0, 4}},
+		{"sma", "sma", map[string]string{SmoothingAttribute: MethodSMA, SmoothingWindowAttribute: "2"}, []float64{10, 20, 40}, []float64{10, 15, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, v := range tt.values {
+				cv, err := dsModels.NewFloat64Value(tt.resource, 1, v)
+				require.NoError(t, err)
+				smoothed, err := Process("device", cv, tt.attributes)
+				require.NoError(t, err)
+				value, err := smoothed.Float64Value()
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected[i], value)
+				assert.Equal(t, int64(1), smoothed.Origin)
+			}
+		})
+	}
+
+	cv, err := dsModels.NewInt16Value("integer", 1, 3)
+	require.NoError(t, err)
+	_, err = Process("device", cv, map[string]string{SmoothingAttribute: MethodSMA})
+	require.NoError(t, err)
+	cv, err = dsModels.NewInt16Value("integer", 1, 4)
+	require.NoError(t, err)
+	smoothed, err := Process("device", cv, map[string]string{SmoothingAttribute: MethodSMA})
+	require.NoError(t, err)
+	value, err := smoothed.Int16Value()
+	require.NoError(t, err)
+	assert.Equal(t, int16(4), value, "the integer averages are rounded")
+
+	_, err = Process("device", cv, map[string]string{SmoothingAttribute: MethodEMA, SmoothingAlphaAttribute: "2"})
+	assert.Error(t, err)
+	_, err = Process("device", cv, map[string]string{SmoothingAttribute: "median"})
+	assert.Error(t, err)
+}
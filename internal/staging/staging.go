@@ -0,0 +1,200 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package staging holds updated Device Profiles which are validated before being
+// applied to the Devices, along with the prior versions they replaced so that an
+// upgrade can be rolled back.
+package staging
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var (
+	staged   = make(map[string]contract.DeviceProfile) // key is Device Profile name
+	previous = make(map[string]contract.DeviceProfile) // key is Device Profile name
+	mutex    sync.Mutex
+)
+
+var valueTypes = map[string]bool{
+	v2.ValueTypeBool: true, v2.ValueTypeString: true, v2.ValueTypeBinary: true,
+	v2.ValueTypeUint8: true, v2.ValueTypeUint16: true, v2.ValueTypeUint32: true, v2.ValueTypeUint64: true,
+	v2.ValueTypeInt8: true, v2.ValueTypeInt16: true, v2.ValueTypeInt32: true, v2.ValueTypeInt64: true,
+	v2.ValueTypeFloat32: true, v2.ValueTypeFloat64: true,
+	v2.ValueTypeBoolArray: true, v2.ValueTypeStringArray: true,
+	v2.ValueTypeUint8Array: true, v2.ValueTypeUint16Array: true, v2.ValueTypeUint32Array: true, v2.ValueTypeUint64Array: true,
+	v2.ValueTypeInt8Array: true, v2.ValueTypeInt16Array: true, v2.ValueTypeInt32Array: true, v2.ValueTypeInt64Array: true,
+	v2.ValueTypeFloat32Array: true, v2.ValueTypeFloat64Array: true,
+}
+
+// Validate checks that the Device Profile is consistent and that the value types and
+// transformation properties of its deviceResources are supported by the SDK.
+func Validate(profile contract.DeviceProfile) error {
+	var problems []string
+	if profile.Name == "" {
+		problems = append(problems, "profile name is empty")
+	}
+
+	resources := make(map[string]bool, len(profile.DeviceResources))
+	for _, dr := range profile.DeviceResources {
+		if resources[dr.Name] {
+			problems = append(problems, fmt.Sprintf("deviceResource %s is defined more than once", dr.Name))
+		}
+		resources[dr.Name] = true
+
+		pv := dr.Properties.Value
+		if !valueTypes[pv.Type] {
+			problems = append(problems, fmt.Sprintf("deviceResource %s has unsupported value type '%s'", dr.Name, pv.Type))
+		}
+		switch pv.ReadWrite {
+		case "", common.DeviceResourceReadOnly, common.DeviceResourceWriteOnly, "RW", "WR":
+		default:
+			problems = append(problems, fmt.Sprintf("deviceResource %s has invalid readWrite '%s'", dr.Name, pv.ReadWrite))
+		}
+		numeric := map[string]string{
+			"scale": pv.Scale, "offset": pv.Offset, "base": pv.Base,
+			"minimum": pv.Minimum, "maximum": pv.Maximum,
+		}
+		for property, value := range numeric {
+			if value == "" {
+				continue
+			}
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				problems = append(problems, fmt.Sprintf("deviceResource %s has non-numeric %s '%s'", dr.Name, property, value))
+			}
+		}
+		if pv.Mask != "" {
+			if _, err := strconv.ParseUint(pv.Mask, 10, 64); err != nil {
+				problems = append(problems, fmt.Sprintf("deviceResource %s has invalid mask '%s', it should be unsigned integer", dr.Name, pv.Mask))
+			}
+		}
+		if pv.Shift != "" {
+			if _, err := strconv.ParseInt(pv.Shift, 10, 64); err != nil {
+				problems = append(problems, fmt.Sprintf("deviceResource %s has invalid shift '%s', it should be integer", dr.Name, pv.Shift))
+			}
+		}
+	}
+
+	for _, pr := range profile.DeviceCommands {
+		for _, ros := range [][]contract.ResourceOperation{pr.Get, pr.Set} {
+			for _, ro := range ros {
+				if !resources[ro.DeviceResource] {
+					problems = append(problems, fmt.Sprintf("deviceCommand %s refers to undefined deviceResource %s", pr.Name, ro.DeviceResource))
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// CanaryRead reads every readable deviceResource of the staged Device Profile from the
+// canary Device and checks that the values returned by the driver can be transformed
+// according to the staged deviceResource properties.
+func CanaryRead(profile contract.DeviceProfile, device contract.Device, driver dsModels.ProtocolDriver, lc logger.LoggingClient) error {
+	reqs := make([]dsModels.CommandRequest, 0, len(profile.DeviceResources))
+	resources := make(map[string]contract.DeviceResource, len(profile.DeviceResources))
+	for _, dr := range profile.DeviceResources {
+		if dr.Properties.Value.ReadWrite == common.DeviceResourceWriteOnly {
+			continue
+		}
+		reqs = append(reqs, dsModels.CommandRequest{
+			DeviceResourceName: dr.Name,
+			Attributes:         dr.Attributes,
+			Type:               dr.Properties.Value.Type,
+		})
+		resources[dr.Name] = dr
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	cvs, err := driver.HandleReadCommands(device.Name, device.Protocols, reqs)
+	if err != nil {
+		return fmt.Errorf("canary Device %s read failed: %v", device.Name, err)
+	}
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		dr, ok := resources[cv.DeviceResourceName]
+		if !ok {
+			return fmt.Errorf("canary Device %s returned unexpected deviceResource %s", device.Name, cv.DeviceResourceName)
+		}
+		if cv.Type != dr.Properties.Value.Type {
+			return fmt.Errorf("canary Device %s returned %s value for deviceResource %s of type %s", device.Name, cv.Type, dr.Name, dr.Properties.Value.Type)
+		}
+		err = transformer.TransformReadResult(cv, dr.Properties.Value, lc)
+		if err != nil && !errors.As(err, &transformer.OverflowError{}) && !errors.As(err, &transformer.NaNError{}) {
+			return fmt.Errorf("canary Device %s value of deviceResource %s cannot be transformed: %v", device.Name, dr.Name, err)
+		}
+	}
+	return nil
+}
+
+// Stage holds the Device Profile until it's applied or discarded, replacing any
+// version staged before.
+func Stage(profile contract.DeviceProfile) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	staged[profile.Name] = profile
+}
+
+// Staged returns the Device Profile staged with the given name.
+func Staged(name string) (contract.DeviceProfile, bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	profile, ok := staged[name]
+	return profile, ok
+}
+
+// Discard removes the Device Profile staged with the given name.
+func Discard(name string) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	_, ok := staged[name]
+	delete(staged, name)
+	return ok
+}
+
+// Promote removes the staged Device Profile and records the prior version it replaces.
+func Promote(name string, prior contract.DeviceProfile) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(staged, name)
+	previous[name] = prior
+}
+
+// Previous returns the prior version of the Device Profile replaced by the last
+// applied upgrade.
+func Previous(name string) (contract.DeviceProfile, bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	profile, ok := previous[name]
+	return profile, ok
+}
+
+// Forget removes the prior version of the Device Profile once it's been restored.
+func Forget(name string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(previous, name)
+}
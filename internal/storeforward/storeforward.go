@@ -0,0 +1,198 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storeforward persists the events which couldn't be posted to Core Data and
+// forwards them once it's reachable again, so that no readings are lost while it's down.
+package storeforward
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
+)
+
+// bucket is the bucket of the Store holding the queued events.
+const bucket = "storeforward"
+
+// SendFunc posts the encoded event of the content type to Core Data. It returns a
+// RejectedError if Core Data rejects the event.
+type SendFunc func(contentType string, data []byte) error
+
+// RejectedError is the error of an event Core Data rejected, such as with a 4xx status,
+// which retrying won't change. The rejected events are discarded so that they don't block
+// the events queued after them.
+type RejectedError struct {
+	Err error
+}
+
+func (e RejectedError) Error() string {
+	return e.Err.Error()
+}
+
+// Queue persists the events in the Store, keyed by their zero-padded sequence number
+// and holding the content type on the first line followed by the encoded event.
+type Queue struct {
+	store    store.Store
+	maxDepth int
+	seqs     []uint64 // sequence numbers of the queued events, oldest first
+	nextSeq  uint64
+	lc       logger.LoggingClient
+	mutex    sync.Mutex
+}
+
+var q *Queue
+
+// NewQueue initiates the store-and-forward queue in the Store, loading the events
+// queued by a previous run. Up to maxDepth events are queued, discarding the oldest ones
+// once it's exceeded; 0 doesn't limit the depth.
+func NewQueue(s store.Store, maxDepth int, lc logger.LoggingClient) error {
+	keys, err := s.Keys(bucket)
+	if err != nil {
+		return fmt.Errorf("failed to load the store-and-forward queue: %v", err)
+	}
+
+	queue := &Queue{store: s, maxDepth: maxDepth, nextSeq: 1, lc: lc}
+	for _, key := range keys {
+		seq, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			continue
+		}
+		queue.seqs = append(queue.seqs, seq)
+		if seq >= queue.nextSeq {
+			queue.nextSeq = seq + 1
+		}
+	}
+	sort.Slice(queue.seqs, func(i, j int) bool { return queue.seqs[i] < queue.seqs[j] })
+	if len(queue.seqs) > 0 {
+		lc.Info(fmt.Sprintf("%d events to forward to Core Data loaded", len(queue.seqs)))
+	}
+	q = queue
+	return nil
+}
+
+// GetQueue returns the store-and-forward queue, which is nil if it's disabled.
+func GetQueue() *Queue {
+	return q
+}
+
+func key(seq uint64) string {
+	return fmt.Sprintf("%020d", seq)
+}
+
+// Depth returns the number of queued events.
+func (q *Queue) Depth() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.seqs)
+}
+
+// Enqueue persists the encoded event of the content type until it's forwarded.
+func (q *Queue) Enqueue(contentType string, data []byte) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var buf bytes.Buffer
+	buf.WriteString(contentType)
+	buf.WriteByte('\n')
+	buf.Write(data)
+	seq := q.nextSeq
+	if err := q.store.Put(bucket, key(seq), buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to store the event: %v", err)
+	}
+	q.nextSeq++
+	q.seqs = append(q.seqs, seq)
+
+	for q.maxDepth > 0 && len(q.seqs) > q.maxDepth {
+		q.lc.Warn(fmt.Sprintf("store-and-forward queue full with %d events, discarding the oldest one", q.maxDepth))
+		_ = q.store.Delete(bucket, key(q.seqs[0]))
+		q.seqs = q.seqs[1:]
+	}
+	return nil
+}
+
+// Forward sends up to batchSize of the oldest events in order, stopping at the first
+// failure other than a rejected event, which is discarded. It returns the number of
+// events forwarded or discarded.
+func (q *Queue) Forward(batchSize int, send SendFunc) (int, error) {
+	q.mutex.Lock()
+	batch := q.seqs
+	if batchSize > 0 && len(batch) > batchSize {
+		batch = batch[:batchSize]
+	}
+	batch = append([]uint64(nil), batch...)
+	q.mutex.Unlock()
+
+	for i, seq := range batch {
+		data, err := q.store.Get(bucket, key(seq))
+		if err == nil {
+			parts := bytes.SplitN(data, []byte{'\n'}, 2)
+			if len(parts) == 2 {
+				if err = send(string(parts[0]), parts[1]); err != nil {
+					if _, ok := err.(RejectedError); !ok {
+						return i, err
+					}
+					q.lc.Error(fmt.Sprintf("discarding stored event %s rejected by Core Data: %v", key(seq), err))
+				}
+			} else {
+				q.lc.Error(fmt.Sprintf("discarding malformed stored event %s", key(seq)))
+			}
+		} else if err != store.ErrNotFound {
+			return i, fmt.Errorf("failed to read the stored event: %v", err)
+		}
+		q.remove(seq)
+	}
+	return len(batch), nil
+}
+
+func (q *Queue) remove(seq uint64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	_ = q.store.Delete(bucket, key(seq))
+	for i, s := range q.seqs {
+		if s == seq {
+			q.seqs = append(q.seqs[:i], q.seqs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Run forwards the queued events every interval, batchSize events at a time, until the
+// context is done.
+func (q *Queue) Run(ctx context.Context, wg *sync.WaitGroup, interval time.Duration, batchSize int, send SendFunc) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+			}
+			// forward the batches back to back while Core Data accepts them
+			for q.Depth() > 0 {
+				n, err := q.Forward(batchSize, send)
+				if n > 0 {
+					q.lc.Info(fmt.Sprintf("forwarded %d stored events to Core Data, %d left", n, q.Depth()))
+				}
+				if err != nil {
+					q.lc.Debug(fmt.Sprintf("failed to forward the stored events to Core Data: %v", err))
+					break
+				}
+			}
+		}
+	}()
+}
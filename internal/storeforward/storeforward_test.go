@@ -0,0 +1,58 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package storeforward
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
+)
+
+func TestForward(t *testing.T) {
+	tests := []struct {
+		name      string
+		failure   error
+		forwarded int
+		sent      []string
+		depth     int
+	}{
+		{"all sent", nil, 3, []string{"1", "2", "3"}, 0},
+		{"rejected event discarded", RejectedError{Err: errors.New("400")}, 3, []string{"1", "3"}, 0},
+		{"stops on transport error", errors.New("connection refused"), 1, []string{"1"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, NewQueue(store.NewMemory(), 0, logger.NewMockClient()))
+			queue := GetQueue()
+			for _, data := range []string{"1", "2", "3"} {
+				require.NoError(t, queue.Enqueue("application/json", []byte(data)))
+			}
+
+			var sent []string
+			n, err := queue.Forward(0, func(contentType string, data []byte) error {
+				if string(data) == "2" && tt.failure != nil {
+					return tt.failure
+				}
+				sent = append(sent, string(data))
+				return nil
+			})
+			if _, rejected := tt.failure.(RejectedError); tt.failure == nil || rejected {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.failure, err)
+			}
+			assert.Equal(t, tt.forwarded, n)
+			assert.Equal(t, tt.sent, sent)
+			assert.Equal(t, tt.depth, queue.Depth())
+		})
+	}
+}
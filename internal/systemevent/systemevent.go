@@ -0,0 +1,77 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package systemevent publishes the SystemEvents of the provisioning changes made by the
+// Device Service onto the MessageBus.
+package systemevent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/messagebus"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const defaultTopicPrefix = "edgex/system-events/"
+
+var (
+	enabled bool
+	topic   string
+	source  string
+	lc      logger.LoggingClient
+	mutex   sync.RWMutex
+)
+
+// Configure enables the publishing of the SystemEvents of the Device Service onto the
+// topic, edgex/system-events/<service name> if it's empty.
+func Configure(enable bool, systemEventTopic string, serviceName string, logger logger.LoggingClient) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	enabled = enable
+	topic = systemEventTopic
+	if topic == "" {
+		topic = defaultTopicPrefix + serviceName
+	}
+	source = serviceName
+	lc = logger
+}
+
+// Publish publishes the SystemEvent of the action on the named entity of the type, if
+// enabled. Failures are logged.
+func Publish(eventType string, action string, name string, details interface{}) {
+	mutex.RLock()
+	enable, t, s, l := enabled, topic, source, lc
+	mutex.RUnlock()
+	publisher := messagebus.GetPublisher()
+	if !enable || publisher == nil {
+		return
+	}
+
+	event := dsModels.SystemEvent{
+		Type:      eventType,
+		Action:    action,
+		Source:    s,
+		Name:      name,
+		Details:   details,
+		Timestamp: clock.Now().UnixNano(),
+	}
+	payload, err := json.Marshal(event)
+	if err == nil {
+		err = publisher.PublishTopic(t, uuid.New().String(), clients.ContentTypeJSON, payload)
+	}
+	if err != nil {
+		l.Error(fmt.Sprintf("failed to publish the %s %s system event of %s: %v", eventType, action, name, err))
+		return
+	}
+	l.Debug(fmt.Sprintf("published the %s %s system event of %s to %s", eventType, action, name, t))
+}
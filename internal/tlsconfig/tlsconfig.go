@@ -0,0 +1,176 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tlsconfig loads the certificates the REST API is served over HTTPS with from the
+// Secret Store, and reloads them once rotated.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+const (
+	// CertKey is the key of the PEM encoded server certificate chain in the secret path.
+	CertKey = "cert"
+	// PrivateKeyKey is the key of the PEM encoded server private key in the secret path.
+	PrivateKeyKey = "key"
+	// ClientCAKey is the key of the PEM encoded CA certificates the client certificates are
+	// verified with in the secret path.
+	ClientCAKey = "clientCA"
+
+	defaultRefreshInterval = time.Minute
+)
+
+// SecretGetter gets the secrets at the path of the Secret Store.
+type SecretGetter interface {
+	GetSecrets(path string, keys ...string) (map[string]string, error)
+}
+
+// Loader holds the certificates loaded from the Secret Store.
+type Loader struct {
+	info        common.TLSInfo
+	secrets     SecretGetter
+	lc          logger.LoggingClient
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	digest      [sha256.Size]byte
+}
+
+// ValidateTLS returns an error if Service.TLS is invalid.
+func ValidateTLS(info common.TLSInfo) error {
+	if !info.Enabled {
+		return nil
+	}
+	if info.SecretPath == "" {
+		return errors.New("Service.TLS.SecretPath is required to serve over HTTPS")
+	}
+	if info.RefreshInterval != "" {
+		if _, err := time.ParseDuration(info.RefreshInterval); err != nil {
+			return fmt.Errorf("invalid Service.TLS.RefreshInterval %s: %v", info.RefreshInterval, err)
+		}
+	}
+	return nil
+}
+
+// NewLoader returns the Loader of the certificates of Service.TLS.
+func NewLoader(info common.TLSInfo, secrets SecretGetter, lc logger.LoggingClient) *Loader {
+	return &Loader{info: info, secrets: secrets, lc: lc}
+}
+
+// Load loads the certificates from the Secret Store, replacing those loaded before if they
+// changed. It returns true if they did.
+func (l *Loader) Load() (bool, error) {
+	keys := []string{CertKey, PrivateKeyKey}
+	if l.info.RequireClientCert {
+		keys = append(keys, ClientCAKey)
+	}
+	secrets, err := l.secrets.GetSecrets(l.info.SecretPath, keys...)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the certificates from %s: %v", l.info.SecretPath, err)
+	}
+
+	digest := sha256.Sum256([]byte(secrets[CertKey] + secrets[PrivateKeyKey] + secrets[ClientCAKey]))
+	l.mutex.RLock()
+	unchanged := l.certificate != nil && digest == l.digest
+	l.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.X509KeyPair([]byte(secrets[CertKey]), []byte(secrets[PrivateKeyKey]))
+	if err != nil {
+		return false, fmt.Errorf("invalid server certificate or key in %s: %v", l.info.SecretPath, err)
+	}
+	var clientCAs *x509.CertPool
+	if l.info.RequireClientCert {
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM([]byte(secrets[ClientCAKey])) {
+			return false, fmt.Errorf("no valid client CA certificate in %s", l.info.SecretPath)
+		}
+	}
+
+	l.mutex.Lock()
+	l.certificate = &certificate
+	l.clientCAs = clientCAs
+	l.digest = digest
+	l.mutex.Unlock()
+	return true, nil
+}
+
+// Config returns the TLS configuration serving the certificates last loaded. The client
+// certificates are verified if given, their requirement being enforced by route with
+// ClientVerified.
+func (l *Loader) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			l.mutex.RLock()
+			defer l.mutex.RUnlock()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*l.certificate},
+			}
+			if l.clientCAs != nil {
+				config.ClientCAs = l.clientCAs
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			return config, nil
+		},
+	}
+}
+
+// Watch reloads the certificates every Service.TLS.RefreshInterval until the context is
+// done, the certificates failing to load being kept until the next attempt.
+func (l *Loader) Watch(ctx context.Context, wg *sync.WaitGroup) {
+	interval, err := time.ParseDuration(l.info.RefreshInterval)
+	if err != nil || interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			changed, err := l.Load()
+			if err != nil {
+				l.lc.Error(fmt.Sprintf("failed to reload the TLS certificates, keeping the current ones: %v", err))
+			} else if changed {
+				l.lc.Info("TLS certificates reloaded")
+			}
+		}
+	}()
+}
+
+// ClientVerified returns true unless the client certificate is required by Service.TLS and
+// the request has no verified one. With TLS enabled, the requests received without TLS are
+// those over the Unix domain socket, which are only let through if Service.TLS.SocketExempt.
+func ClientVerified(info common.TLSInfo, r *http.Request) bool {
+	if !info.Enabled || !info.RequireClientCert {
+		return true
+	}
+	if r.TLS == nil {
+		return info.SocketExempt
+	}
+	return len(r.TLS.VerifiedChains) > 0
+}
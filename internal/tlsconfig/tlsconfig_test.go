@@ -0,0 +1,105 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+type secretsMock map[string]string
+
+func (m secretsMock) GetSecrets(_ string, keys ...string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, k := range keys {
+		result[k] = m[k]
+	}
+	return result, nil
+}
+
+func selfSigned(t *testing.T, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestLoad(t *testing.T) {
+	cert, key := selfSigned(t, "first")
+	secrets := secretsMock{CertKey: cert, PrivateKeyKey: key, ClientCAKey: cert}
+	info := common.TLSInfo{Enabled: true, SecretPath: "tls", RequireClientCert: true}
+	loader := NewLoader(info, secrets, logger.NewMockClient())
+
+	changed, err := loader.Load()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = loader.Load()
+	require.NoError(t, err)
+	assert.False(t, changed, "the same certificates aren't reloaded")
+
+	config, err := loader.Config().GetConfigForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, config.ClientAuth)
+	first := config.Certificates[0].Certificate[0]
+
+	secrets[CertKey], secrets[PrivateKeyKey] = selfSigned(t, "rotated")
+	changed, err = loader.Load()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	config, _ = loader.Config().GetConfigForClient(nil)
+	assert.NotEqual(t, first, config.Certificates[0].Certificate[0], "the rotated certificate is served")
+
+	secrets[PrivateKeyKey] = "invalid"
+	_, err = loader.Load()
+	assert.Error(t, err)
+	config, _ = loader.Config().GetConfigForClient(nil)
+	assert.NotNil(t, config.Certificates[0].PrivateKey, "the current certificate is kept")
+}
+
+func TestClientVerified(t *testing.T) {
+	info := common.TLSInfo{Enabled: true, SecretPath: "tls", RequireClientCert: true}
+	r := httptest.NewRequest("GET", "/", nil)
+	assert.False(t, ClientVerified(info, r), "the requests over the Unix domain socket have no certificate")
+	info.SocketExempt = true
+	assert.True(t, ClientVerified(info, r), "the requests over the Unix domain socket are exempted")
+
+	r.TLS = &tls.ConnectionState{}
+	assert.False(t, ClientVerified(info, r))
+	r.TLS.VerifiedChains = [][]*x509.Certificate{{{}}}
+	assert.True(t, ClientVerified(info, r))
+
+	r.TLS = &tls.ConnectionState{}
+	info.RequireClientCert = false
+	assert.True(t, ClientVerified(info, r))
+}
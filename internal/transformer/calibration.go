@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/calibration"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// CalibrateReadResult applies the calibration of the Device's deviceResource, if any,
+// to the numeric reading. Integer readings are rounded to the nearest integer.
+func CalibrateReadResult(cv *dsModels.CommandValue, deviceName string, lc logger.LoggingClient) error {
+	c, ok := calibration.Get(deviceName, cv.DeviceResourceName)
+	if !ok {
+		return nil
+	}
+	switch cv.Type {
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64,
+		v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64,
+		v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+	default:
+		return nil
+	}
+
+	value, err := commandValueForTransform(cv)
+	if err != nil {
+		return err
+	}
+	calibrated := c.Coefficients.Apply(toFloat64(value))
+	if !checkTransformedValueInRange(value, calibrated, lc) {
+		return NewOverflowError(value, calibrated)
+	}
+
+	var newValue interface{}
+	rounded := math.Round(calibrated)
+	switch value.(type) {
+	case uint8:
+		newValue = uint8(rounded)
+	case uint16:
+		newValue = uint16(rounded)
+	case uint32:
+		newValue = uint32(rounded)
+	case uint64:
+		newValue = uint64(rounded)
+	case int8:
+		newValue = int8(rounded)
+	case int16:
+		newValue = int16(rounded)
+	case int32:
+		newValue = int32(rounded)
+	case int64:
+		newValue = int64(rounded)
+	case float32:
+		newValue = float32(calibrated)
+	case float64:
+		newValue = calibrated
+	default:
+		return fmt.Errorf("wrong data type of CommandValue to calibrate: %s", cv.String())
+	}
+	return replaceNewCommandValue(cv, newValue, lc)
+}
+
+func toFloat64(value interface{}) float64 {
+	switch v := value.(type) {
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// TransformsAttribute is the deviceResource attribute listing, separated by commas, the
+// custom Transforms run in order after the built-in transformations on reads. Writes run
+// them in reverse order before the built-in transformations, so a written value takes the
+// inverse path of a read one.
+const TransformsAttribute = common.SDKReservedPrefix + "transforms"
+
+var (
+	transforms      = make(map[string]dsModels.Transform)
+	transformsMutex sync.RWMutex
+)
+
+// AddTransform adds the custom Transform, or returns an error if one of the same name is
+// already added.
+func AddTransform(transform dsModels.Transform) error {
+	transformsMutex.Lock()
+	defer transformsMutex.Unlock()
+	if _, ok := transforms[transform.Name()]; ok {
+		return fmt.Errorf("Transform %s already added", transform.Name())
+	}
+	transforms[transform.Name()] = transform
+	return nil
+}
+
+// ApplyReadTransforms runs the custom Transforms of the deviceResource on the value read
+// from it. The value is returned unchanged along with the error of the failing Transform.
+func ApplyReadTransforms(deviceName string, dr contract.DeviceResource, cv *dsModels.CommandValue) (*dsModels.CommandValue, error) {
+	return applyTransforms(deviceName, dr, cv, false, dsModels.Transform.TransformRead)
+}
+
+// ApplyWriteTransforms runs the custom Transforms of the deviceResource in reverse order on
+// the value written to it. The value is returned unchanged along with the error of the
+// failing Transform.
+func ApplyWriteTransforms(deviceName string, dr contract.DeviceResource, cv *dsModels.CommandValue) (*dsModels.CommandValue, error) {
+	return applyTransforms(deviceName, dr, cv, true, dsModels.Transform.TransformWrite)
+}
+
+func applyTransforms(
+	deviceName string,
+	dr contract.DeviceResource,
+	cv *dsModels.CommandValue,
+	reverse bool,
+	transform func(dsModels.Transform, string, contract.DeviceResource, *dsModels.CommandValue) (*dsModels.CommandValue, error)) (*dsModels.CommandValue, error) {
+	names := dr.Attributes[TransformsAttribute]
+	if strings.TrimSpace(names) == "" {
+		return cv, nil
+	}
+
+	list := strings.Split(names, ",")
+	if reverse {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+
+	result := cv
+	for _, name := range list {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		transformsMutex.RLock()
+		t, ok := transforms[name]
+		transformsMutex.RUnlock()
+		if !ok {
+			return cv, fmt.Errorf("unknown Transform %s of deviceResource %s", name, dr.Name)
+		}
+		transformed, err := transform(t, deviceName, dr, result)
+		if err != nil {
+			return cv, fmt.Errorf("Transform %s of deviceResource %s failed: %w", name, dr.Name, err)
+		}
+		if transformed != nil {
+			result = transformed
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"errors"
+	"testing"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type addTransform struct {
+	name  string
+	delta int32
+}
+
+func (t addTransform) Name() string {
+	return t.name
+}
+
+func (t addTransform) TransformRead(_ string, _ contract.DeviceResource, cv *dsModels.CommandValue) (*dsModels.CommandValue, error) {
+	v, err := cv.Int32Value()
+	if err != nil {
+		return nil, err
+	}
+	if v < 0 {
+		return nil, errors.New("negative value")
+	}
+	return dsModels.NewInt32Value(cv.DeviceResourceName, cv.Origin, v+t.delta)
+}
+
+func (t addTransform) TransformWrite(_ string, _ contract.DeviceResource, cv *dsModels.CommandValue) (*dsModels.CommandValue, error) {
+	v, err := cv.Int32Value()
+	if err != nil {
+		return nil, err
+	}
+	return dsModels.NewInt32Value(cv.DeviceResourceName, cv.Origin, v-t.delta)
+}
+
+func TestApplyTransforms(t *testing.T) {
+	defer func() {
+		transforms = make(map[string]dsModels.Transform)
+	}()
+	require.NoError(t, AddTransform(addTransform{name: "plusOne", delta: 1}))
+	require.NoError(t, AddTransform(addTransform{name: "plusTen", delta: 10}))
+	assert.Error(t, AddTransform(addTransform{name: "plusOne", delta: 1}))
+
+	dr := contract.DeviceResource{Name: "resource", Attributes: map[string]string{TransformsAttribute: "plusOne, plusTen"}}
+	cv, _ := dsModels.NewInt32Value(dr.Name, 1, 5)
+
+	result, err := ApplyReadTransforms("device", dr, cv)
+	require.NoError(t, err)
+	v, _ := result.Int32Value()
+	assert.Equal(t, int32(16), v)
+
+	result, err = ApplyWriteTransforms("device", dr, cv)
+	require.NoError(t, err)
+	v, _ = result.Int32Value()
+	assert.Equal(t, int32(-6), v)
+
+	negative, _ := dsModels.NewInt32Value(dr.Name, 1, -1)
+	result, err = ApplyReadTransforms("device", dr, negative)
+	assert.Error(t, err)
+	assert.Equal(t, negative, result, "the value is returned unchanged on error")
+
+	_, err = ApplyReadTransforms("device", contract.DeviceResource{Attributes: map[string]string{TransformsAttribute: "unknown"}}, cv)
+	assert.Error(t, err)
+
+	result, err = ApplyReadTransforms("device", contract.DeviceResource{}, cv)
+	require.NoError(t, err)
+	assert.Equal(t, cv, result)
+}
+
+type mulTransform struct {
+	name   string
+	factor int32
+}
+
+func (t mulTransform) Name() string {
+	return t.name
+}
+
+func (t mulTransform) TransformRead(_ string, _ contract.DeviceResource, cv *dsModels.CommandValue) (*dsModels.CommandValue, error) {
+	v, err := cv.Int32Value()
+	if err != nil {
+		return nil, err
+	}
+	return dsModels.NewInt32Value(cv.DeviceResourceName, cv.Origin, v*t.factor)
+}
+
+func (t mulTransform) TransformWrite(_ string, _ contract.DeviceResource, cv *dsModels.CommandValue) (*dsModels.CommandValue, error) {
+	v, err := cv.Int32Value()
+	if err != nil {
+		return nil, err
+	}
+	return dsModels.NewInt32Value(cv.DeviceResourceName, cv.Origin, v/t.factor)
+}
+
+func TestTransformsRoundTrip(t *testing.T) {
+	defer func() {
+		transforms = make(map[string]dsModels.Transform)
+	}()
+	require.NoError(t, AddTransform(addTransform{name: "plusOne", delta: 1}))
+	require.NoError(t, AddTransform(mulTransform{name: "timesThree", factor: 3}))
+
+	dr := contract.DeviceResource{
+		Name:       "resource",
+		Properties: contract.ProfileProperty{Value: contract.PropertyValue{Scale: "2", Offset: "4"}},
+		Attributes: map[string]string{TransformsAttribute: "plusOne, timesThree"},
+	}
+
+	// read: ((5 * 2 + 4) + 1) * 3 = 45
+	raw, _ := dsModels.NewInt32Value(dr.Name, 1, 5)
+	require.NoError(t, TransformReadResult(raw, dr.Properties.Value, lc))
+	read, err := ApplyReadTransforms("device", dr, raw)
+	require.NoError(t, err)
+	v, _ := read.Int32Value()
+	assert.Equal(t, int32(45), v)
+
+	// writing the value read back must reach the device as the raw value it came from
+	written, _ := dsModels.NewInt32Value(dr.Name, 1, v)
+	written, err = ApplyWriteTransforms("device", dr, written)
+	require.NoError(t, err)
+	require.NoError(t, TransformWriteParameter(written, dr.Properties.Value, lc))
+	v, _ = written.Int32Value()
+	assert.Equal(t, int32(5), v)
+}
@@ -0,0 +1,43 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"sync"
+)
+
+var (
+	notReachableThreshold int
+	notReachableCounts    = make(map[string]int)
+	notReachableMutex     sync.Mutex
+)
+
+// SetNotReachableThreshold sets the number of consecutive NotReachable driver errors
+// after which a Device is disabled, 0 leaving the OperatingState to the ProtocolDriver.
+func SetNotReachableThreshold(threshold int) {
+	notReachableMutex.Lock()
+	defer notReachableMutex.Unlock()
+	notReachableThreshold = threshold
+	notReachableCounts = make(map[string]int)
+}
+
+// countNotReachable counts a NotReachable driver error of the Device, or resets the count
+// if the Device responded, and reports whether the threshold is reached.
+func countNotReachable(deviceName string, notReachable bool) bool {
+	notReachableMutex.Lock()
+	defer notReachableMutex.Unlock()
+	if !notReachable || notReachableThreshold <= 0 {
+		delete(notReachableCounts, deviceName)
+		return false
+	}
+	notReachableCounts[deviceName]++
+	if notReachableCounts[deviceName] < notReachableThreshold {
+		return false
+	}
+	delete(notReachableCounts, deviceName)
+	return true
+}
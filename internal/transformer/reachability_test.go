@@ -0,0 +1,58 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestCheckDriverError(t *testing.T) {
+	lc := logger.NewMockClient()
+	dc := &mock.DeviceClientMock{}
+	cache.InitCache("device-sdk-test", lc, &mock.ValueDescriptorMock{}, dc, &mock.ProvisionWatcherClientMock{})
+	defer SetNotReachableThreshold(0)
+	notReachable := dsModels.NewDriverError(dsModels.NotReachable, errors.New("connection refused"))
+	newDevice := func() *contract.Device {
+		device, ok := cache.Devices().ForName("Random-Boolean-Generator01")
+		require.True(t, ok)
+		device.OperatingState = contract.Enabled
+		require.NoError(t, cache.Devices().Update(device))
+		return &device
+	}
+
+	device := newDevice()
+	for i := 0; i < 5; i++ {
+		CheckDriverError(notReachable, device, lc, dc)
+	}
+	assert.Equal(t, contract.OperatingState(contract.Enabled), device.OperatingState, "the OperatingState is left to the ProtocolDriver by default")
+
+	SetNotReachableThreshold(3)
+	CheckDriverError(notReachable, device, lc, dc)
+	CheckDriverError(notReachable, device, lc, dc)
+	CheckDriverError(nil, device, lc, dc)
+	CheckDriverError(notReachable, device, lc, dc)
+	CheckDriverError(errors.New("bad request"), device, lc, dc)
+	CheckDriverError(notReachable, device, lc, dc)
+	CheckDriverError(notReachable, device, lc, dc)
+	assert.Equal(t, contract.OperatingState(contract.Enabled), device.OperatingState, "the count starts over once the device responds")
+
+	CheckDriverError(notReachable, device, lc, dc)
+	assert.Equal(t, contract.OperatingState(contract.Disabled), device.OperatingState)
+	cached, _ := cache.Devices().ForName(device.Name)
+	assert.Equal(t, contract.OperatingState(contract.Disabled), cached.OperatingState)
+	newDevice()
+}
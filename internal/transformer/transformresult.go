@@ -16,6 +16,7 @@ import (
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/metadata"
@@ -607,10 +608,9 @@ func CheckAssertion(
 	lc logger.LoggingClient,
 	dc metadata.DeviceClient) error {
 	if assertion != "" && cv.ValueToString() != assertion {
-		device.OperatingState = contract.Disabled
-		cache.Devices().Update(*device)
-		ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
-		go dc.UpdateOpStateByName(ctx, device.Name, operating.UpdateRequest{OperatingState: contract.Disabled})
+		if !maintenance.Active(*device) {
+			disableDevice(device, dc)
+		}
 		msg := fmt.Sprintf("assertion (%s) failed with value: %s", assertion, cv.ValueToString())
 		lc.Error(msg)
 		return fmt.Errorf(msg)
@@ -618,6 +618,39 @@ func CheckAssertion(
 	return nil
 }
 
+// CheckDriverError counts the consecutive NotReachable errors of the ProtocolDriver for the
+// Device, err being nil once the command succeeds, and disables the Device once they reach
+// the threshold set by SetNotReachableThreshold, unless the Device is in a maintenance
+// window. As the commands of the disabled Device are rejected and its AutoEvents skipped,
+// only the ProtocolDriver can tell it's reachable again, re-enabling it with
+// DeviceService.SetDeviceOpState or ReportConnection. With no threshold set, the
+// OperatingState is left to the ProtocolDriver.
+func CheckDriverError(
+	err error,
+	device *contract.Device,
+	lc logger.LoggingClient,
+	dc metadata.DeviceClient) {
+	notReachable := err != nil && dsModels.DriverErrorKindOf(err) == dsModels.NotReachable
+	if notReachable && maintenance.Active(*device) {
+		lc.Debug(fmt.Sprintf("Device %s in maintenance window is not reachable: %v", device.Name, err))
+		return
+	}
+	if !countNotReachable(device.Name, notReachable) {
+		return
+	}
+	if device.OperatingState != contract.Disabled {
+		disableDevice(device, dc)
+		lc.Warn(fmt.Sprintf("Device %s disabled as it's not reachable, until the ProtocolDriver re-enables it: %v", device.Name, err))
+	}
+}
+
+func disableDevice(device *contract.Device, dc metadata.DeviceClient) {
+	device.OperatingState = contract.Disabled
+	cache.Devices().Update(*device)
+	ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
+	go dc.UpdateOpStateByName(ctx, device.Name, operating.UpdateRequest{OperatingState: contract.Disabled})
+}
+
 func MapCommandValue(value *dsModels.CommandValue, mappings map[string]string) (*dsModels.CommandValue, bool) {
 	newValue, ok := mappings[value.ValueToString()]
 	var result *dsModels.CommandValue
@@ -0,0 +1,74 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	v2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// UnmapWriteValue returns the value written to the deviceResource mapped back by the
+// mappings of its GET ResourceOperation, so that the clients write the values they read,
+// e.g. "ON" rather than "1". It returns false if no mapping maps to the value.
+func UnmapWriteValue(profileName string, resourceName string, value string) (string, bool) {
+	ro, err := cache.Profiles().ResourceOperation(profileName, resourceName, common.GetCmdMethod)
+	if err != nil || len(ro.Mappings) == 0 {
+		return value, false
+	}
+
+	// the smallest of the values mapped to the same one is written
+	keys := make([]string, 0, len(ro.Mappings))
+	for k := range ro.Mappings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if ro.Mappings[k] == value {
+			return k, true
+		}
+	}
+	return value, false
+}
+
+// CheckWriteRange returns an error if the numeric value written to the deviceResource is
+// out of the Minimum and Maximum of its PropertyValue. The unparsable bounds are ignored.
+// The value is redacted from the error if the deviceResource is write-only and
+// Device.RedactWriteOnly is enabled.
+func CheckWriteRange(cv *dsModels.CommandValue, dr contract.DeviceResource) error {
+	pv := dr.Properties.Value
+	if pv.Minimum == "" && pv.Maximum == "" {
+		return nil
+	}
+	switch cv.Type {
+	case v2.ValueTypeUint8, v2.ValueTypeUint16, v2.ValueTypeUint32, v2.ValueTypeUint64,
+		v2.ValueTypeInt8, v2.ValueTypeInt16, v2.ValueTypeInt32, v2.ValueTypeInt64,
+		v2.ValueTypeFloat32, v2.ValueTypeFloat64:
+	default:
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(cv.ValueToString(models.ENotation), 64)
+	if err != nil {
+		return nil
+	}
+	if min, err := strconv.ParseFloat(pv.Minimum, 64); err == nil && value < min {
+		return fmt.Errorf("value %s of deviceResource %s is below its minimum %s", common.WrittenValue(dr, cv.ValueToString()), cv.DeviceResourceName, pv.Minimum)
+	}
+	if max, err := strconv.ParseFloat(pv.Maximum, 64); err == nil && value > max {
+		return fmt.Errorf("value %s of deviceResource %s is above its maximum %s", common.WrittenValue(dr, cv.ValueToString()), cv.DeviceResourceName, pv.Maximum)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestUnmapWriteValue(t *testing.T) {
+	lc := logger.NewMockClient()
+	cache.InitCache("device-sdk-test", lc, &mock.ValueDescriptorMock{}, &mock.DeviceClientMock{}, &mock.ProvisionWatcherClientMock{})
+
+	tests := []struct {
+		name     string
+		resource string
+		value    string
+		expected string
+		mapped   bool
+	}{
+		{"mapped", "ResourceTestMapping_Pass", "Pass", "123", true},
+		{"not mapped", "ResourceTestMapping_Pass", "Fail", "Fail", false},
+		{"no mappings", "RandomValue_Int8", "12", "12", false},
+		{"no resource", "NoSuchResource", "12", "12", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, mapped := UnmapWriteValue("Random-Integer-Generator", tt.resource, tt.value)
+			assert.Equal(t, tt.expected, value)
+			assert.Equal(t, tt.mapped, mapped)
+		})
+	}
+}
+
+func TestCheckWriteRange(t *testing.T) {
+	defer common.SetRedactWriteOnly(false)
+	common.SetRedactWriteOnly(true)
+
+	resource := func(readWrite string, min string, max string) contract.DeviceResource {
+		return contract.DeviceResource{
+			Name:       "Setpoint",
+			Properties: contract.ProfileProperty{Value: contract.PropertyValue{ReadWrite: readWrite, Minimum: min, Maximum: max}},
+		}
+	}
+	int8Value := func(v int8) *dsModels.CommandValue {
+		cv, err := dsModels.NewInt8Value("Setpoint", 0, v)
+		require.NoError(t, err)
+		return cv
+	}
+	stringValue := dsModels.NewStringValue("Setpoint", 0, "high")
+
+	tests := []struct {
+		name     string
+		cv       *dsModels.CommandValue
+		dr       contract.DeviceResource
+		expected string
+	}{
+		{"within the range", int8Value(5), resource("RW", "0", "10"), ""},
+		{"no bounds", int8Value(-5), resource("RW", "", ""), ""},
+		{"unparsable bound", int8Value(-5), resource("RW", "low", "10"), ""},
+		{"not numeric", stringValue, resource("RW", "0", "10"), ""},
+		{"below the minimum", int8Value(-5), resource("RW", "0", "10"), "value -5 of deviceResource Setpoint is below its minimum 0"},
+		{"above the maximum", int8Value(15), resource("RW", "0", "10"), "value 15 of deviceResource Setpoint is above its maximum 10"},
+		{"write-only value redacted", int8Value(15), resource(common.DeviceResourceWriteOnly, "0", "10"), "value <redacted> of deviceResource Setpoint is above its maximum 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckWriteRange(tt.cv, tt.dr)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
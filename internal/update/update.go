@@ -0,0 +1,179 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package update orchestrates the firmware and configuration updates of the Devices
+// applied by the DeviceUpdater ProtocolDrivers, and tracks their progress.
+package update
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// The states of an update job.
+const (
+	StatePending    = "pending"
+	StateApplying   = "applying"
+	StateVerifying  = "verifying"
+	StateCompleted  = "completed"
+	StateFailed     = "failed"
+	StateRolledBack = "rolledBack"
+)
+
+// maxJobs is the number of jobs retained, the oldest finished jobs are discarded
+// once it's exceeded.
+const maxJobs = 1000
+
+// Job is the update of a Device.
+type Job struct {
+	Id      string `json:"id"`
+	Device  string `json:"device"`
+	Kind    string `json:"kind"`
+	Version string `json:"version,omitempty"`
+	State   string `json:"state"`
+	// Progress is the progress, in percent, reported by the DeviceUpdater.
+	Progress int    `json:"progress"`
+	Message  string `json:"message,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Created  int64  `json:"created"`
+	Modified int64  `json:"modified"`
+}
+
+func (j Job) finished() bool {
+	return j.State == StateCompleted || j.State == StateFailed || j.State == StateRolledBack
+}
+
+var (
+	jobs  = make(map[string]*Job) // key is job id
+	order []string
+	mutex sync.RWMutex
+)
+
+// Start starts the update of the Devices and returns their jobs, along with the errors,
+// keyed by Device name, of the Devices not updated as an update of them is in progress.
+func Start(devices []contract.Device, u dsModels.DeviceUpdate, updater dsModels.DeviceUpdater, lc logger.LoggingClient) ([]Job, map[string]error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	inProgress := make(map[string]string) // key is Device name, value is job id
+	for _, job := range jobs {
+		if !job.finished() {
+			inProgress[job.Device] = job.Id
+		}
+	}
+
+	now := time.Now().UnixNano()
+	started := make([]Job, 0, len(devices))
+	errs := make(map[string]error)
+	for _, d := range devices {
+		if id, ok := inProgress[d.Name]; ok {
+			errs[d.Name] = fmt.Errorf("update %s of Device %s is in progress", id, d.Name)
+			continue
+		}
+		job := &Job{
+			Id:       uuid.New().String(),
+			Device:   d.Name,
+			Kind:     u.Kind,
+			Version:  u.Version,
+			State:    StatePending,
+			Created:  now,
+			Modified: now,
+		}
+		jobs[job.Id] = job
+		order = append(order, job.Id)
+		started = append(started, *job)
+		go run(job.Id, d, u, updater, lc)
+	}
+	prune()
+	return started, errs
+}
+
+// Get returns the job with the given id.
+func Get(id string) (Job, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	job, ok := jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// ForDevice returns the jobs of the Device, oldest first.
+func ForDevice(name string) []Job {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	var deviceJobs []Job
+	for _, id := range order {
+		if job := jobs[id]; job.Device == name {
+			deviceJobs = append(deviceJobs, *job)
+		}
+	}
+	return deviceJobs
+}
+
+func run(id string, device contract.Device, u dsModels.DeviceUpdate, updater dsModels.DeviceUpdater, lc logger.LoggingClient) {
+	lc.Info(fmt.Sprintf("update %s of Device %s to %s %s started", id, device.Name, u.Kind, u.Version))
+	set(id, func(job *Job) { job.State = StateApplying })
+	err := updater.StartUpdate(device.Name, device.Protocols, u, func(percent int, message string) {
+		set(id, func(job *Job) {
+			job.Progress = percent
+			job.Message = message
+		})
+	})
+	if err == nil {
+		set(id, func(job *Job) { job.State = StateVerifying })
+		err = updater.VerifyUpdate(device.Name, device.Protocols, u)
+	}
+	if err == nil {
+		set(id, func(job *Job) {
+			job.State = StateCompleted
+			job.Progress = 100
+		})
+		lc.Info(fmt.Sprintf("update %s of Device %s completed", id, device.Name))
+		return
+	}
+
+	lc.Error(fmt.Sprintf("update %s of Device %s failed, rolling back: %v", id, device.Name, err))
+	state := StateRolledBack
+	if rollbackErr := updater.RollbackUpdate(device.Name, device.Protocols, u); rollbackErr != nil {
+		lc.Error(fmt.Sprintf("failed to roll back update %s of Device %s: %v", id, device.Name, rollbackErr))
+		state = StateFailed
+		err = fmt.Errorf("%v; rollback failed: %v", err, rollbackErr)
+	}
+	set(id, func(job *Job) {
+		job.State = state
+		job.Error = err.Error()
+	})
+}
+
+func set(id string, update func(job *Job)) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if job, ok := jobs[id]; ok {
+		update(job)
+		job.Modified = time.Now().UnixNano()
+	}
+}
+
+// prune discards the oldest finished jobs exceeding maxJobs.
+func prune() {
+	for i := 0; len(order) > maxJobs && i < len(order); {
+		if job := jobs[order[i]]; job.finished() {
+			delete(jobs, order[i])
+			order = append(order[:i], order[i+1:]...)
+			continue
+		}
+		i++
+	}
+}
@@ -0,0 +1,80 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/metadata"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/autoevent"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/executor"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/playback"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const testDeviceName = "Random-Boolean-Generator01"
+
+// eventClient is an EventClientMock encoding and accepting the events.
+type eventClient struct {
+	mock.EventClientMock
+}
+
+func (eventClient) MarshalEvent(e contract.Event) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (eventClient) AddBytes(context.Context, []byte) (string, error) {
+	return "", nil
+}
+
+// newTestContainer returns the container of a Device Service running the driver wrapped
+// as the Device Service wraps it, with the caches loaded from the mock data.
+func newTestContainer(driver dsModels.ProtocolDriver, dc metadata.DeviceClient) *di.Container {
+	lc := logger.NewMockClient()
+	if dc == nil {
+		dc = &mock.DeviceClientMock{}
+	}
+	config := &common.ConfigurationStruct{}
+	cache.InitCache("device-sdk-test", lc, &mock.ValueDescriptorMock{}, dc, &mock.ProvisionWatcherClientMock{})
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return config
+		},
+		container.DeviceServiceName: func(get di.Get) interface{} {
+			return &contract.DeviceService{Name: "device-sdk-test", AdminState: contract.Unlocked}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return lc
+		},
+		container.MetadataDeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		container.CoredataEventClientName: func(get di.Get) interface{} {
+			return eventClient{}
+		},
+		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return executor.NewDriver(driver, config)
+		},
+		container.RawProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+	})
+	autoevent.NewManager(context.Background(), &sync.WaitGroup{}, 1, dic)
+	playback.NewManager(context.Background(), &sync.WaitGroup{}, make(chan *dsModels.AsyncValues, 1), lc)
+	return dic
+}
@@ -0,0 +1,120 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+const defaultBatchConcurrency = 8
+
+// BatchCommandResult is the result of a batch command on a device. Event is the event read
+// by a GET command.
+type BatchCommandResult struct {
+	DeviceName string      `json:"deviceName"`
+	Event      *dtos.Event `json:"event,omitempty"`
+}
+
+// BatchCommand executes the GET or SET command on the named Devices and on the Devices
+// having any of the labels or, if none is given, on all the Devices having the GET command;
+// a SET command requires the Devices or the labels to be given. At most Device.BatchConcurrency Devices are commanded at once. The results of the
+// Devices are returned in the order they were selected, and the Devices whose command
+// failed are reported individually.
+func BatchCommand(ctx context.Context, isRead bool, deviceNames []string, labels []string, cmd string, body string, correlationID string, dic *di.Container) ([]BatchCommandResult, []sdkCommon.ItemError, edgexErr.EdgeX) {
+	method := sdkCommon.SetCmdMethod
+	if isRead {
+		method = sdkCommon.GetCmdMethod
+	}
+
+	if !isRead && len(deviceNames) == 0 && len(labels) == 0 {
+		errMsg := fmt.Sprintf("the devices or the labels are required to set %s", cmd)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, nil)
+	}
+
+	var names []string
+	selected := make(map[string]bool)
+	for _, name := range deviceNames {
+		if !selected[name] {
+			selected[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(deviceNames) == 0 || len(labels) > 0 {
+		for _, device := range cache.Devices().All() {
+			if selected[device.Name] || (len(labels) > 0 && !hasAnyLabel(device, labels)) || !hasCommand(device, cmd, method) {
+				continue
+			}
+			selected[device.Name] = true
+			names = append(names, device.Name)
+		}
+	}
+	if len(names) == 0 {
+		errMsg := fmt.Sprintf("no Device selected for the command %s", cmd)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	concurrency := container.ConfigurationFrom(dic.Get).Device.BatchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	events := make([]*dtos.Event, len(names))
+	errs := make([]edgexErr.EdgeX, len(names))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			vars := map[string]string{sdkCommon.NameVar: name, sdkCommon.CommandVar: cmd}
+			res, err := CommandHandler(ctx, isRead, false, true, 0, correlationID, vars, body, dic)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if isRead {
+				events[i] = &res.Event
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	var results []BatchCommandResult
+	var itemErrs []sdkCommon.ItemError
+	for i, name := range names {
+		if errs[i] != nil {
+			itemErrs = append(itemErrs, sdkCommon.NewItemError(name, errs[i]))
+			continue
+		}
+		results = append(results, BatchCommandResult{DeviceName: name, Event: events[i]})
+	}
+	return results, itemErrs, nil
+}
+
+// hasCommand reports whether the profile of the Device has the command with the method or
+// the deviceResource of the same name.
+func hasCommand(device contract.Device, cmd string, method string) bool {
+	if exists, err := cache.Profiles().CommandExists(device.Profile.Name, cmd, method); err == nil && exists {
+		return true
+	}
+	_, ok := cache.Profiles().DeviceResource(device.Profile.Name, cmd)
+	return ok
+}
@@ -0,0 +1,33 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCommandSetRequiresSelector(t *testing.T) {
+	s := &steps{}
+	dic := newTestContainer(writeDriver{steps: s}, nil)
+	body := `{"EnableRandomization_Bool":"false"}`
+
+	_, _, err := BatchCommand(context.Background(), false, nil, nil, "EnableRandomization_Bool", body, "", dic)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Code())
+	assert.Empty(t, s.names, "a SET command isn't fanned out to all the Devices")
+
+	results, itemErrs, err := BatchCommand(context.Background(), false, []string{testDeviceName}, nil, "EnableRandomization_Bool", body, "", dic)
+	require.Nil(t, err)
+	assert.Empty(t, itemErrs)
+	assert.Len(t, results, 1)
+	assert.Equal(t, []string{"write Random-Boolean-Generator01 false"}, s.names)
+}
@@ -0,0 +1,58 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/calibration"
+)
+
+// SetCalibration stores a new version of the calibration coefficients of the Device's
+// deviceResource, applied to its subsequent readings.
+func SetCalibration(deviceName string, resourceName string, coefficients calibration.Coefficients, dic *di.Container) (calibration.Calibration, edgexErr.EdgeX) {
+	device, ok := cache.Devices().ForName(deviceName)
+	if !ok {
+		return calibration.Calibration{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, fmt.Sprintf("Device %s not found", deviceName), nil)
+	}
+	if _, ok := cache.Profiles().DeviceResource(device.Profile.Name, resourceName); !ok {
+		errMsg := fmt.Sprintf("deviceResource %s not found in Device Profile %s", resourceName, device.Profile.Name)
+		return calibration.Calibration{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	c, err := calibration.Set(deviceName, resourceName, coefficients)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to store the calibration of Device %s deviceResource %s", deviceName, resourceName)
+		return calibration.Calibration{}, edgexErr.NewCommonEdgeX(edgexErr.KindIOError, errMsg, err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Info(fmt.Sprintf("Device %s deviceResource %s calibrated, version %d", deviceName, resourceName, c.Version))
+	return c, nil
+}
+
+// RemoveCalibration removes the calibration of the Device's deviceResource.
+func RemoveCalibration(deviceName string, resourceName string, dic *di.Container) edgexErr.EdgeX {
+	ok, err := calibration.Remove(deviceName, resourceName)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to remove the calibration of Device %s deviceResource %s", deviceName, resourceName)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindIOError, errMsg, err)
+	}
+	if !ok {
+		errMsg := fmt.Sprintf("no calibration of Device %s deviceResource %s", deviceName, resourceName)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Info(fmt.Sprintf("calibration of Device %s deviceResource %s removed", deviceName, resourceName))
+	return nil
+}
@@ -19,7 +19,11 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/autoevent"
+	v1cache "github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/delta"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/naming"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/cache"
 )
 
@@ -51,6 +55,12 @@ func AddDevice(addDeviceRequest requests.AddDeviceRequest, dic *di.Container) er
 	//	return errors.NewCommonEdgeX(errors.KindServerError, errMsg, edgexErr)
 	//}
 
+	// the Device taking the name is replaced according to the collision policy
+	if existing, ok := cache.Devices().ForName(device.Name); ok && existing.Id != device.Id && naming.Policy() == naming.CollisionReplace {
+		lc.Info(fmt.Sprintf("replacing device %s with id %s", device.Name, device.Id))
+		_ = cache.Devices().RemoveByName(device.Name)
+	}
+
 	edgexErr := cache.Devices().Add(device)
 	if edgexErr != nil {
 		errMsg := fmt.Sprintf("failed to add device %s", device.Name)
@@ -58,8 +68,8 @@ func AddDevice(addDeviceRequest requests.AddDeviceRequest, dic *di.Container) er
 	}
 	lc.Debug(fmt.Sprintf("device %s added", device.Name))
 
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err := driver.AddDevice(device.Name, transformDeviceProtocols(device.Protocols), contract.AdminState(device.AdminState))
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	err := driver.AddDevice(device.Name, sdkCommon.DriverProtocols(transformDeviceProtocols(device.Protocols)), contract.AdminState(device.AdminState))
 	if err == nil {
 		lc.Debug(fmt.Sprintf("Invoked driver.AddDevice callback for %s", device.Name))
 	} else {
@@ -97,6 +107,7 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 		return DeleteDevice(*updateDeviceRequest.Device.Name, dic)
 	}
 
+	previous := device
 	requests.ReplaceDeviceModelFieldsWithDTO(&device, updateDeviceRequest.Device)
 	// TODO: uncomment when core-contracts v2 client is ready.
 	//edgexErr := updateAssociatedProfile(device.ProfileName, dic)
@@ -112,8 +123,8 @@ func UpdateDevice(updateDeviceRequest requests.UpdateDeviceRequest, dic *di.Cont
 	}
 	lc.Debugf("device %s updated", device.Name)
 
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err := driver.UpdateDevice(device.Name, transformDeviceProtocols(device.Protocols), contract.AdminState(device.AdminState))
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	err := sdkCommon.UpdateDriverDevice(driver, transformDevice(previous), transformDevice(device))
 	if err == nil {
 		lc.Debugf("Invoked driver.UpdateDevice callback for %s", device.Name)
 	} else {
@@ -133,6 +144,7 @@ func DeleteDevice(name string, dic *di.Container) errors.EdgeX {
 	if ok {
 		lc.Debugf("Handler - stopping AutoEvents for device %s", device.Name)
 		autoevent.GetManager().StopForDevice(device.Name)
+		delta.GetFilter().Forget(device.Name)
 	} else {
 		errMsg := fmt.Sprintf("failed to find device %s", name)
 		return errors.NewCommonEdgeX(errors.KindInvalidId, errMsg, nil)
@@ -146,8 +158,8 @@ func DeleteDevice(name string, dic *di.Container) errors.EdgeX {
 	}
 	lc.Debugf("Removed device: %s", device.Name)
 
-	driver := container.ProtocolDriverFrom(dic.Get)
-	err := driver.RemoveDevice(device.Name, transformDeviceProtocols(device.Protocols))
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	err := driver.RemoveDevice(device.Name, sdkCommon.DriverProtocols(transformDeviceProtocols(device.Protocols)))
 	if err == nil {
 		lc.Debugf("Invoked driver.RemoveDevice callback for %s", device.Name)
 	} else {
@@ -170,14 +182,20 @@ func DeleteDevice(name string, dic *di.Container) errors.EdgeX {
 	return nil
 }
 
-func AddProvisionWatcher(addProvisionWatcherRequest requests.AddProvisionWatcherRequest, lc logger.LoggingClient) errors.EdgeX {
+func AddProvisionWatcher(addProvisionWatcherRequest requests.AddProvisionWatcherRequest, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	provisionWatcher := dtos.ToProvisionWatcherModel(addProvisionWatcherRequest.ProvisionWatcher)
-
-	edgexErr := cache.ProvisionWatchers().Add(provisionWatcher)
+	discoveryWatcher, edgexErr := toDiscoveryWatcher(provisionWatcher, dic)
 	if edgexErr != nil {
+		return edgexErr
+	}
+
+	err := cache.ProvisionWatchers().Add(provisionWatcher)
+	if err != nil {
 		errMsg := fmt.Sprintf("failed to add provision watcher %s", provisionWatcher.Name)
-		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, edgexErr)
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
 	}
+	syncDiscoveryWatcher(discoveryWatcher)
 
 	lc.Debugf("provision watcher %s added", provisionWatcher.Name)
 	return nil
@@ -196,7 +214,7 @@ func UpdateProvisionWatcher(updateProvisionWatcherRequest requests.UpdateProvisi
 				BaseRequest:      updateProvisionWatcherRequest.BaseRequest,
 				ProvisionWatcher: dtos.FromProvisionWatcherModelToDTO(newProvisionWatcher),
 			}
-			return AddProvisionWatcher(req, lc)
+			return AddProvisionWatcher(req, dic)
 		} else {
 			errMsg := fmt.Sprintf("failed to find provision watcher %s", *updateProvisionWatcherRequest.ProvisionWatcher.ServiceName)
 			return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
@@ -207,20 +225,27 @@ func UpdateProvisionWatcher(updateProvisionWatcherRequest requests.UpdateProvisi
 	}
 
 	requests.ReplaceProvisionWatcherModelFieldsWithDTO(&provisionWatcher, updateProvisionWatcherRequest.ProvisionWatcher)
-
-	edgexErr := cache.ProvisionWatchers().Update(provisionWatcher)
+	discoveryWatcher, edgexErr := toDiscoveryWatcher(provisionWatcher, dic)
 	if edgexErr != nil {
+		return edgexErr
+	}
+
+	err := cache.ProvisionWatchers().Update(provisionWatcher)
+	if err != nil {
 		errMsg := fmt.Sprintf("failed to update provision watcher %s", provisionWatcher.Name)
-		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, edgexErr)
+		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, err)
 	}
+	syncDiscoveryWatcher(discoveryWatcher)
 
 	lc.Debugf("provision watcher %s updated", provisionWatcher.Name)
 	return nil
 }
 
 func DeleteProvisionWatcher(name string, lc logger.LoggingClient) errors.EdgeX {
+	// the provision watchers retrieved at startup are only in the cache read by the discovery
+	discoveryErr := v1cache.ProvisionWatchers().RemoveByName(name)
 	err := cache.ProvisionWatchers().RemoveByName(name)
-	if err != nil {
+	if err != nil && discoveryErr != nil {
 		errMsg := fmt.Sprintf("failed to remove provision watcher %s", name)
 		return errors.NewCommonEdgeX(errors.KindInvalidId, errMsg, err)
 	}
@@ -229,6 +254,37 @@ func DeleteProvisionWatcher(name string, lc logger.LoggingClient) errors.EdgeX {
 	return nil
 }
 
+// toDiscoveryWatcher returns the ProvisionWatcher as matched by the discovery, having the
+// Device Profile and the Device Service in place of their names.
+func toDiscoveryWatcher(pw models.ProvisionWatcher, dic *di.Container) (contract.ProvisionWatcher, errors.EdgeX) {
+	profile, ok := v1cache.Profiles().ForName(pw.ProfileName)
+	if !ok {
+		errMsg := fmt.Sprintf("failed to find profile %s of provision watcher %s", pw.ProfileName, pw.Name)
+		return contract.ProvisionWatcher{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	return contract.ProvisionWatcher{
+		Id:                  pw.Id,
+		Name:                pw.Name,
+		Identifiers:         pw.Identifiers,
+		BlockingIdentifiers: pw.BlockingIdentifiers,
+		Profile:             profile,
+		Service:             *container.DeviceServiceFrom(dic.Get),
+		AdminState:          contract.AdminState(pw.AdminState),
+	}, nil
+}
+
+// syncDiscoveryWatcher adds the ProvisionWatcher to the cache read by the discovery, or
+// updates it if already cached, so that it applies to the next discovered Devices.
+func syncDiscoveryWatcher(pw contract.ProvisionWatcher) {
+	if existing, ok := v1cache.ProvisionWatchers().ForName(pw.Name); ok && existing.Id != pw.Id {
+		_ = v1cache.ProvisionWatchers().RemoveByName(pw.Name)
+	}
+	if v1cache.ProvisionWatchers().Add(pw) != nil {
+		_ = v1cache.ProvisionWatchers().Update(pw)
+	}
+}
+
 func UpdateDeviceService(updateDeviceServiceRequest requests.UpdateDeviceServiceRequest, dic *di.Container) errors.EdgeX {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	ds := container.DeviceServiceFrom(dic.Get)
@@ -297,3 +353,25 @@ func transformDeviceProtocols(protocols map[string]models.ProtocolProperties) ma
 
 	return res
 }
+
+// TODO: remove this helper function when we fully moving to v2 API
+// transformDevice transforms device from v2 model to v1 model, with only the name of its profile
+func transformDevice(device models.Device) contract.Device {
+	var autoEvents []contract.AutoEvent
+	for _, a := range device.AutoEvents {
+		autoEvents = append(autoEvents, contract.AutoEvent{Frequency: a.Frequency, OnChange: a.OnChange, Resource: a.Resource})
+	}
+	d := contract.Device{
+		Name:           device.Name,
+		AdminState:     contract.AdminState(device.AdminState),
+		OperatingState: contract.OperatingState(device.OperatingState),
+		Protocols:      transformDeviceProtocols(device.Protocols),
+		Labels:         device.Labels,
+		Location:       device.Location,
+		AutoEvents:     autoEvents,
+	}
+	d.Id = device.Id
+	d.Description = device.Description
+	d.Profile.Name = device.ProfileName
+	return d
+}
@@ -7,21 +7,21 @@
 package application
 
 import (
-	"bytes"
-	"encoding/base64"
-	"encoding/binary"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/coalesce"
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/confirmation"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/counter"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/history"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/smoothing"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
@@ -37,34 +37,50 @@ import (
 )
 
 type CommandProcessor struct {
+	ctx            context.Context
 	device         *contract.Device
 	deviceResource *contract.DeviceResource
 	correlationID  string
 	cmd            string
 	params         string
+	waitConfirm    bool
 	dic            *di.Container
 }
 
 func NewCommandProcessor(device *contract.Device, dr *contract.DeviceResource, correlationID string, cmd string, params string, dic *di.Container) *CommandProcessor {
 	return &CommandProcessor{
+		ctx:            context.Background(),
 		device:         device,
 		deviceResource: dr,
 		correlationID:  correlationID,
 		cmd:            cmd,
 		params:         params,
+		waitConfirm:    true,
 		dic:            dic,
 	}
 }
 
-func CommandHandler(isRead bool, sendEvent bool, correlationID string, vars map[string]string, body string, dic *di.Container) (res responses.EventResponse, err edgexErr.EdgeX) {
+// CommandHandler executes the read or write command. For write commands on deviceResources
+// requiring asynchronous confirmation, waitConfirm determines whether the response is held
+// until the device confirms the write; otherwise the confirmation completes in the background.
+// A read command failing because the device is busy or down is served from the last known
+// values if allowStale is positive and none of them is older than it, and is served from
+// them without reaching the device if all of them were read within the read cache TTL.
+// The read commands with query parameters always reach the device and aren't recorded
+// as the last known values, since their values depend on the parameters.
+// The driver gives up the command once ctx is done if it implements ContextCommandHandler.
+func CommandHandler(ctx context.Context, isRead bool, sendEvent bool, waitConfirm bool, allowStale time.Duration, correlationID string, vars map[string]string, body string, dic *di.Container) (res responses.EventResponse, err edgexErr.EdgeX) {
 	var device contract.Device
+	var cached bool
+	start := time.Now()
 	deviceKey := vars[sdkCommon.NameVar]
 	// the device service will perform some operations(e.g. update LastConnected timestamp,
 	// push returning event to core-data) after a device is successfully interacted with if
 	// it has been configured to do so, and those operation apply to every protocol and
 	// need to be finished in the end of application layer before returning to protocol layer.
 	defer func() {
-		if err != nil {
+		recordExecution(start, device.Name, deviceKey, isRead, correlationID, vars, err)
+		if err != nil || cached {
 			return
 		}
 		go sdkCommon.UpdateLastConnected(
@@ -87,15 +103,18 @@ func CommandHandler(isRead bool, sendEvent bool, correlationID string, vars map[
 	}
 
 	// check provided device exists
-	device, exist := cache.Devices().ForName(deviceKey)
+	device, exist := cache.Devices().ForNameOrAlias(deviceKey)
 	if !exist {
 		return res, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, fmt.Sprintf("device %s not found", deviceKey), nil)
 	}
 
-	// check device's AdminState
+	// check device's AdminState and OperatingState
 	if device.AdminState == contract.Locked {
 		return res, edgexErr.NewCommonEdgeX(edgexErr.KindServiceLocked, fmt.Sprintf("device %s locked", device.Name), nil)
 	}
+	if device.OperatingState == contract.Disabled {
+		return res, edgexErr.NewCommonEdgeX(edgexErr.KindServiceLocked, fmt.Sprintf("device %s disabled", device.Name), nil)
+	}
 
 	var method string
 	if isRead {
@@ -110,26 +129,85 @@ func CommandHandler(isRead bool, sendEvent bool, correlationID string, vars map[
 		return res, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, e)
 	}
 
-	helper := NewCommandProcessor(&device, nil, correlationID, cmd, body, dic)
-	if cmdExists {
-		if isRead {
-			return helper.ReadCommand()
-		} else {
-			return res, helper.WriteCommand()
-		}
-	} else {
-		dr, drExists := cache.Profiles().DeviceResource(device.Profile.Name, cmd)
+	var dr *contract.DeviceResource
+	if !cmdExists {
+		deviceResource, drExists := cache.Profiles().DeviceResource(device.Profile.Name, cmd)
 		if !drExists {
 			return res, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "command not found", nil)
 		}
+		dr = &deviceResource
+	}
 
-		helper = NewCommandProcessor(&device, &dr, correlationID, cmd, body, dic)
-		if isRead {
-			return helper.ReadDeviceResource()
-		} else {
-			return res, helper.WriteDeviceResource()
+	helper := NewCommandProcessor(&device, dr, correlationID, cmd, body, dic)
+	helper.ctx = context.WithValue(ctx, sdkCommon.CorrelationHeader, correlationID)
+	helper.waitConfirm = waitConfirm
+	if !isRead {
+		if cmdExists {
+			return res, helper.WriteCommand()
 		}
+		return res, helper.WriteDeviceResource()
+	}
+
+	if body != "" {
+		if cmdExists {
+			return helper.ReadCommand()
+		}
+		return helper.ReadDeviceResource()
+	}
+	if fresh, ok := freshEvent(device, cmd, cmdExists, readCacheTTL(dic)); ok {
+		cached = true
+		return fresh, nil
+	}
+	if cmdExists {
+		res, err = helper.ReadCommand()
+	} else {
+		res, err = helper.ReadDeviceResource()
+	}
+	if err == nil {
+		recordLastValues(res.Event)
+	} else if allowStale > 0 && dsModels.DriverErrorKindOf(err).Retryable() {
+		if stale, ok := staleEvent(device, cmd, cmdExists, allowStale); ok {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			lc.Warn(fmt.Sprintf("serving %s of %s from the last known values: %v", cmd, device.Name, err), sdkCommon.CorrelationHeader, correlationID)
+			cached = true
+			return stale, nil
+		}
+	}
+	return res, err
+}
+
+// readCacheTTL returns the Device.ReadCacheTTL, or 0 if it's blank or invalid.
+func readCacheTTL(dic *di.Container) time.Duration {
+	value := container.ConfigurationFrom(dic.Get).Device.ReadCacheTTL
+	if value == "" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warn(fmt.Sprintf("invalid Device.ReadCacheTTL %s, the reads aren't cached: %v", value, err))
+		return 0
 	}
+	return ttl
+}
+
+// recordExecution records the execution of the command in the history. deviceKey is the
+// name or alias of the Device requested, recorded if it wasn't found.
+func recordExecution(start time.Time, deviceName string, deviceKey string, isRead bool, correlationID string, vars map[string]string, err edgexErr.EdgeX) {
+	if deviceName == "" {
+		deviceName = deviceKey
+	}
+	method := sdkCommon.SetCmdMethod
+	if isRead {
+		method = sdkCommon.GetCmdMethod
+	}
+	code, message := http.StatusOK, ""
+	if err != nil {
+		code, message = err.Code(), err.Error()
+	}
+	execution := history.NewExecution(start, deviceName, vars[sdkCommon.CommandVar], method, history.SourceAPI, code, message)
+	execution.CorrelationID = correlationID
+	history.GetBuffer().Record(execution)
 }
 
 func (c *CommandProcessor) ReadDeviceResource() (res responses.EventResponse, e edgexErr.EdgeX) {
@@ -158,11 +236,11 @@ func (c *CommandProcessor) ReadDeviceResource() (res responses.EventResponse, e
 	reqs = append(reqs, req)
 
 	// execute protocol-specific read operation
-	driver := container.ProtocolDriverFrom(c.dic.Get)
-	results, err := driver.HandleReadCommands(c.device.Name, c.device.Protocols, reqs)
+	results, err := c.handleReadCommands(reqs)
+	transformer.CheckDriverError(err, c.device, lc, container.MetadataDeviceClientFrom(c.dic.Get))
 	if err != nil {
 		errMsg := fmt.Sprintf("error reading DeviceResourece %s for %s: %v", c.deviceResource.Name, c.device.Name, err)
-		return res, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+		return res, sdkCommon.NewDriverEdgeX(errMsg, err)
 	}
 
 	// convert CommandValue to Event
@@ -194,8 +272,8 @@ func (c *CommandProcessor) ReadCommand() (res responses.EventResponse, e edgexEr
 	}
 
 	// prepare CommandRequests
-	reqs := make([]dsModels.CommandRequest, len(ros))
-	for i, op := range ros {
+	reqs := make([]dsModels.CommandRequest, 0, len(ros))
+	for _, op := range ros {
 		drName := op.DeviceResource
 		// check the deviceResource in ResourceOperation actually exist
 		dr, ok := cache.Profiles().DeviceResource(c.device.Profile.Name, drName)
@@ -204,29 +282,36 @@ func (c *CommandProcessor) ReadCommand() (res responses.EventResponse, e edgexEr
 			return res, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
 		}
 
-		// check the deviceResource isn't write-only
+		// the write-only deviceResources of the command aren't read back
 		if dr.Properties.Value.ReadWrite == sdkCommon.DeviceResourceWriteOnly {
-			errMsg := fmt.Sprintf("deviceResource %s in GET command %s is marked as write-only", drName, c.cmd)
-			return res, edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, nil)
+			lc.Debug(fmt.Sprintf("skipping write-only deviceResource %s in GET command %s", drName, c.cmd), sdkCommon.CorrelationHeader, c.correlationID)
+			continue
 		}
 
-		reqs[i].DeviceResourceName = dr.Name
-		reqs[i].Attributes = dr.Attributes
+		req := dsModels.CommandRequest{
+			DeviceResourceName: dr.Name,
+			Attributes:         dr.Attributes,
+			Type:               dr.Properties.Value.Type,
+		}
 		if c.params != "" {
-			if len(reqs[i].Attributes) <= 0 {
-				reqs[i].Attributes = make(map[string]string)
+			if len(req.Attributes) <= 0 {
+				req.Attributes = make(map[string]string)
 			}
-			reqs[i].Attributes[sdkCommon.URLRawQuery] = c.params
+			req.Attributes[sdkCommon.URLRawQuery] = c.params
 		}
-		reqs[i].Type = dr.Properties.Value.Type
+		reqs = append(reqs, req)
+	}
+	if len(reqs) == 0 {
+		errMsg := fmt.Sprintf("all deviceResources in GET command %s are marked as write-only", c.cmd)
+		return res, edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, nil)
 	}
 
 	// execute protocol-specific read operation
-	driver := container.ProtocolDriverFrom(c.dic.Get)
-	results, err := driver.HandleReadCommands(c.device.Name, c.device.Protocols, reqs)
+	results, err := c.handleReadCommands(reqs)
+	transformer.CheckDriverError(err, c.device, lc, container.MetadataDeviceClientFrom(c.dic.Get))
 	if err != nil {
 		errMsg := fmt.Sprintf("error reading DeviceCommand %s for %s: %v", c.cmd, c.device.Name, err)
-		return res, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+		return res, sdkCommon.NewDriverEdgeX(errMsg, err)
 	}
 
 	// convert CommandValue to Event
@@ -243,16 +328,92 @@ func (c *CommandProcessor) WriteDeviceResource() edgexErr.EdgeX {
 	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
 	lc.Debug(fmt.Sprintf("Application - writeDeviceResource: writting deviceResource: %s", c.deviceResource.Name), sdkCommon.CorrelationHeader, c.correlationID)
 
+	reqs, params, e := c.deviceResourceWriteRequests()
+	if e != nil {
+		return e
+	}
+	cv := params[0]
+
+	// register the expected asynchronous confirmation before the driver is invoked
+	pending := confirmation.Expect(c.device.Name, reqs, []*dsModels.CommandValue{cv})
+
+	// execute protocol-specific write operation
+	driver := container.ProtocolDriverFrom(c.dic.Get)
+	written, err := c.handleWriteCommands(reqs, []*dsModels.CommandValue{cv})
+	transformer.CheckDriverError(err, c.device, lc, container.MetadataDeviceClientFrom(c.dic.Get))
+	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
+		errMsg := fmt.Sprintf("error writing DeviceResourece %s for %s: %v", c.deviceResource.Name, c.device.Name, err)
+		return sdkCommon.NewDriverEdgeX(errMsg, err)
+	}
+	pending = supersede(pending, written[0] != cv)
+
+	// read back the written value if the deviceResource requires verification
+	err = sdkCommon.VerifyWrite(driver, c.device, reqs, written)
+	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to verify written value", err)
+	}
+
+	return c.awaitConfirmation(pending)
+}
+
+func (c *CommandProcessor) WriteCommand() edgexErr.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
+	lc.Debug(fmt.Sprintf("Application - writeCmd: writting command: %s", c.cmd), sdkCommon.CorrelationHeader, c.correlationID)
+
+	reqs, cvs, e := c.commandWriteRequests()
+	if e != nil {
+		return e
+	}
+
+	// register the expected asynchronous confirmation before the driver is invoked
+	pending := confirmation.Expect(c.device.Name, reqs, cvs)
+
+	// execute protocol-specific write operation
+	driver := container.ProtocolDriverFrom(c.dic.Get)
+	written, err := c.handleWriteCommands(reqs, cvs)
+	transformer.CheckDriverError(err, c.device, lc, container.MetadataDeviceClientFrom(c.dic.Get))
+	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
+		errMsg := fmt.Sprintf("error writing DeviceResourece for %s: %v", c.device.Name, err)
+		return sdkCommon.NewDriverEdgeX(errMsg, err)
+	}
+	pending = supersede(pending, len(cvs) > 0 && written[0] != cvs[0])
+
+	// read back the written values if any deviceResource requires verification
+	err = sdkCommon.VerifyWrite(driver, c.device, reqs, written)
+	if err != nil {
+		if pending != nil {
+			pending.Cancel()
+		}
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to verify written values", err)
+	}
+
+	return c.awaitConfirmation(pending)
+}
+
+// deviceResourceWriteRequests returns the request and the value writing the deviceResource
+// from the parameters.
+func (c *CommandProcessor) deviceResourceWriteRequests() ([]dsModels.CommandRequest, []*dsModels.CommandValue, edgexErr.EdgeX) {
+	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
+
 	// check provided deviceResource is not read-only
 	if c.deviceResource.Properties.Value.ReadWrite == sdkCommon.DeviceResourceReadOnly {
 		errMsg := fmt.Sprintf("deviceResource %s is marked as read-only", c.deviceResource.Name)
-		return edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, nil)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, nil)
 	}
 
 	// parse request body string
 	paramMap, err := parseParams(c.params)
 	if err != nil {
-		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to parse PUT parameters", err)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to parse PUT parameters", err)
 	}
 
 	// check request body contains provided deviceResource
@@ -262,14 +423,25 @@ func (c *CommandProcessor) WriteDeviceResource() edgexErr.EdgeX {
 			v = c.deviceResource.Properties.Value.DefaultValue
 		} else {
 			errMsg := fmt.Sprintf("deviceResource %s not found in request body and no default value defined", c.deviceResource.Name)
-			return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
+			return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
 		}
 	}
 
+	// reverse read value mapping
+	if unmapped, ok := transformer.UnmapWriteValue(c.device.Profile.Name, c.deviceResource.Name, v); ok {
+		v = unmapped
+	}
+
 	// create CommandValue
-	cv, err := createCommandValueFromDeviceResource(c.deviceResource, v)
+	if err = sdkCommon.CheckNumber(v, c.deviceResource.Properties.Value.Type); err != nil {
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "invalid write value", err)
+	}
+	cv, err := sdkCommon.CreateCommandValueFromDeviceResource(c.deviceResource, v)
 	if err != nil {
-		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to create CommandValue", err)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to create CommandValue", err)
+	}
+	if err = transformer.CheckWriteRange(cv, *c.deviceResource); err != nil {
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "write value out of range", err)
 	}
 
 	// prepare CommandRequest
@@ -281,45 +453,41 @@ func (c *CommandProcessor) WriteDeviceResource() edgexErr.EdgeX {
 	// transform write value
 	configuration := container.ConfigurationFrom(c.dic.Get)
 	if configuration.Device.DataTransform {
-		err = transformer.TransformWriteParameter(cv, c.deviceResource.Properties.Value, lc)
+		cv, err = transformer.ApplyWriteTransforms(c.device.Name, *c.deviceResource, cv)
+		if err == nil {
+			err = transformer.TransformWriteParameter(cv, c.deviceResource.Properties.Value, lc)
+		}
 		if err != nil {
-			return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to transform write value", nil)
+			return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to transform write value", nil)
 		}
 	}
 
-	// execute protocol-specific write operation
-	driver := container.ProtocolDriverFrom(c.dic.Get)
-	err = driver.HandleWriteCommands(c.device.Name, c.device.Protocols, reqs, []*dsModels.CommandValue{cv})
-	if err != nil {
-		errMsg := fmt.Sprintf("error writing DeviceResourece %s for %s: %v", c.deviceResource.Name, c.device.Name, err)
-		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
-	}
-
-	return nil
+	return reqs, []*dsModels.CommandValue{cv}, nil
 }
 
-func (c *CommandProcessor) WriteCommand() edgexErr.EdgeX {
+// commandWriteRequests returns the requests and the values writing the deviceResources of
+// the SET ResourceOperations of the command from the parameters.
+func (c *CommandProcessor) commandWriteRequests() ([]dsModels.CommandRequest, []*dsModels.CommandValue, edgexErr.EdgeX) {
 	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
-	lc.Debug(fmt.Sprintf("Application - writeCmd: writting command: %s", c.cmd), sdkCommon.CorrelationHeader, c.correlationID)
 
 	// check SET ResourceOperation(s) exist for provided command
 	ros, err := cache.Profiles().ResourceOperations(c.device.Profile.Name, c.cmd, sdkCommon.SetCmdMethod)
 	if err != nil {
 		errMsg := fmt.Sprintf("SET ResourceOperation(s) for %s command not found", c.cmd)
-		return edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, err)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, err)
 	}
 
 	// check ResourceOperation count does not exceed MaxCmdOps defined in configuration
 	configuration := container.ConfigurationFrom(c.dic.Get)
 	if len(ros) > configuration.Device.MaxCmdOps {
 		errMsg := fmt.Sprintf("PUT command %s exceed device %s MaxCmdOps (%d)", c.cmd, c.device.Name, configuration.Device.MaxCmdOps)
-		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
 	}
 
 	// parse request body
 	paramMap, err := parseParams(c.params)
 	if err != nil {
-		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to parse PUT parameters", err)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to parse PUT parameters", err)
 	}
 
 	// create CommandValues
@@ -330,13 +498,13 @@ func (c *CommandProcessor) WriteCommand() edgexErr.EdgeX {
 		dr, ok := cache.Profiles().DeviceResource(c.device.Profile.Name, drName)
 		if !ok {
 			errMsg := fmt.Sprintf("deviceResource %s in PUT commnd %s for %s not defined", drName, c.cmd, c.device.Name)
-			return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
+			return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
 		}
 
 		// check the deviceResource isn't read-only
 		if dr.Properties.Value.ReadWrite == sdkCommon.DeviceResourceReadOnly {
 			errMsg := fmt.Sprintf("deviceResource %s in PUT command %s is marked as read-only", drName, c.cmd)
-			return edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, nil)
+			return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, errMsg, nil)
 		}
 
 		// check request body contains the deviceResource
@@ -348,27 +516,31 @@ func (c *CommandProcessor) WriteCommand() edgexErr.EdgeX {
 				value = dr.Properties.Value.DefaultValue
 			} else {
 				errMsg := fmt.Sprintf("deviceResource %s not found in request body and no default value defined", dr.Name)
-				return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
+				return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, nil)
 			}
 		}
 
-		// write value mapping
-		if len(ro.Mappings) > 0 {
-			newValue, ok := ro.Mappings[value]
-			if ok {
-				value = newValue
-			} else {
-				lc.Warn(fmt.Sprintf("ResourceOperation %s mapping value (%s) failed with the mapping table: %v", ro.DeviceResource, value, ro.Mappings))
-			}
+		// write value mapping, or reverse read value mapping
+		if newValue, ok := ro.Mappings[value]; ok {
+			value = newValue
+		} else if unmapped, ok := transformer.UnmapWriteValue(c.device.Profile.Name, ro.DeviceResource, value); ok {
+			value = unmapped
+		} else if len(ro.Mappings) > 0 {
+			lc.Warn(fmt.Sprintf("ResourceOperation %s mapping value (%s) failed with the mapping table: %v", ro.DeviceResource, sdkCommon.WrittenValue(dr, value), ro.Mappings))
 		}
 
 		// create CommandValue
-		cv, err := createCommandValueFromDeviceResource(&dr, value)
-		if err == nil {
-			cvs = append(cvs, cv)
-		} else {
-			return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to create CommandValue", err)
+		if err = sdkCommon.CheckNumber(value, dr.Properties.Value.Type); err != nil {
+			return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "invalid write value", err)
 		}
+		cv, err := sdkCommon.CreateCommandValueFromDeviceResource(&dr, value)
+		if err != nil {
+			return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to create CommandValue", err)
+		}
+		if err = transformer.CheckWriteRange(cv, dr); err != nil {
+			return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "write value out of range", err)
+		}
+		cvs = append(cvs, cv)
 	}
 
 	// prepare CommandRequests
@@ -382,21 +554,83 @@ func (c *CommandProcessor) WriteCommand() edgexErr.EdgeX {
 
 		// transform write value
 		if configuration.Device.DataTransform {
-			err = transformer.TransformWriteParameter(cv, dr.Properties.Value, lc)
+			cvs[i], err = transformer.ApplyWriteTransforms(c.device.Name, dr, cv)
+			if err == nil {
+				err = transformer.TransformWriteParameter(cvs[i], dr.Properties.Value, lc)
+			}
 			if err != nil {
-				return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to transform write values", err)
+				return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to transform write values", err)
 			}
 		}
 	}
 
-	// execute protocol-specific write operation
+	return reqs, cvs, nil
+}
+
+// writeRequests returns the requests and the values of the write command.
+func (c *CommandProcessor) writeRequests() ([]dsModels.CommandRequest, []*dsModels.CommandValue, edgexErr.EdgeX) {
+	if c.deviceResource != nil {
+		return c.deviceResourceWriteRequests()
+	}
+	return c.commandWriteRequests()
+}
+
+// handleReadCommands executes the read commands with the context of the request if the
+// driver supports it.
+func (c *CommandProcessor) handleReadCommands(reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
 	driver := container.ProtocolDriverFrom(c.dic.Get)
-	err = driver.HandleWriteCommands(c.device.Name, c.device.Protocols, reqs, cvs)
-	if err != nil {
-		errMsg := fmt.Sprintf("error writing DeviceResourece for %s: %v", c.device.Name, err)
-		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+	if handler, ok := driver.(dsModels.ContextCommandHandler); ok {
+		return handler.HandleReadCommandsContext(c.ctx, c.device.Name, c.device.Protocols, reqs)
+	}
+	return driver.HandleReadCommands(c.device.Name, c.device.Protocols, reqs)
+}
+
+// handleWriteCommands executes the write commands with the context of the request if the
+// driver supports it, coalescing the bursts of writes to the same deviceResource. It
+// returns the values written, which differ from params if a later write superseded them.
+func (c *CommandProcessor) handleWriteCommands(reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) ([]*dsModels.CommandValue, error) {
+	driver := container.ProtocolDriverFrom(c.dic.Get)
+	return coalesce.Write(c.device.Name, reqs, params, func(reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+		if handler, ok := driver.(dsModels.ContextCommandHandler); ok {
+			return handler.HandleWriteCommandsContext(c.ctx, c.device.Name, c.device.Protocols, reqs, params)
+		}
+		return driver.HandleWriteCommands(c.device.Name, c.device.Protocols, reqs, params)
+	})
+}
+
+// supersede cancels the pending confirmation of the values superseded by a later write
+// coalesced with them, as only the values written by the driver get confirmed.
+func supersede(pending *confirmation.Pending, superseded bool) *confirmation.Pending {
+	if pending != nil && superseded {
+		pending.Cancel()
+		return nil
 	}
+	return pending
+}
 
+// awaitConfirmation waits for the device to confirm the written values, or lets the
+// confirmation complete in the background if the caller chose not to wait for it.
+func (c *CommandProcessor) awaitConfirmation(pending *confirmation.Pending) edgexErr.EdgeX {
+	if pending == nil {
+		return nil
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
+	timeout := confirmation.ParseTimeout(container.ConfigurationFrom(c.dic.Get).Device.WriteConfirmTimeout)
+	if !c.waitConfirm {
+		go func() {
+			if err := pending.Wait(timeout); err != nil {
+				lc.Error(fmt.Sprintf("asynchronous write confirmation failed: %v", err), sdkCommon.CorrelationHeader, c.correlationID)
+			} else {
+				lc.Debug(fmt.Sprintf("write to %s confirmed", c.device.Name), sdkCommon.CorrelationHeader, c.correlationID)
+			}
+		}()
+		return nil
+	}
+
+	if err := pending.Wait(timeout); err != nil {
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to confirm written values", err)
+	}
 	return nil
 }
 
@@ -418,6 +652,12 @@ func (c *CommandProcessor) commandValuesToEvent(cvs []*dsModels.CommandValue, cm
 		// perform data transformation
 		if configuration.Device.DataTransform {
 			err = transformer.TransformReadResult(cv, dr.Properties.Value, lc)
+			if err == nil {
+				err = transformer.CalibrateReadResult(cv, c.device.Name, lc)
+			}
+			if err == nil {
+				cv, err = transformer.ApplyReadTransforms(c.device.Name, dr, cv)
+			}
 			if err != nil {
 				lc.Error(fmt.Sprintf("failed to transform CommandValue (%s): %v", cv.String(), err), sdkCommon.CorrelationHeader, c.correlationID)
 
@@ -431,6 +671,16 @@ func (c *CommandProcessor) commandValuesToEvent(cvs []*dsModels.CommandValue, cm
 			}
 		}
 
+		// smoothing
+		if smoothing.IsSmoothed(dr.Attributes) {
+			smoothed, err := smoothing.Process(c.device.Name, cv, dr.Attributes)
+			if err != nil {
+				lc.Warn(fmt.Sprintf("failed to smooth %s: %v", cv.DeviceResourceName, err), sdkCommon.CorrelationHeader, c.correlationID)
+			} else {
+				cv = smoothed
+			}
+		}
+
 		// assertion
 		dc := container.MetadataDeviceClientFrom(c.dic.Get)
 		err = transformer.CheckAssertion(cv, dr.Properties.Value.Assertion, c.device, lc, dc)
@@ -452,13 +702,16 @@ func (c *CommandProcessor) commandValuesToEvent(cvs []*dsModels.CommandValue, cm
 			}
 		}
 
-		reading := commandValueToReading(cv, c.device.Name, dr.Properties.Value.MediaType, dr.Properties.Value.FloatEncoding)
-		readings = append(readings, reading)
+		// with the derived counter readings
+		for _, cv := range counter.Readings(c.device.Name, cv, dr.Attributes, lc, sdkCommon.CorrelationHeader, c.correlationID) {
+			reading := commandValueToReading(cv, c.device.Name, dr.Properties.Value.MediaType, dr.Properties.Value.FloatEncoding)
+			readings = append(readings, reading)
 
-		if cv.Type == v2.ValueTypeBinary {
-			lc.Debug(fmt.Sprintf("device: %s DeviceResource: %v reading: binary value", c.device.Name, cv.DeviceResourceName), sdkCommon.CorrelationHeader, c.correlationID)
-		} else {
-			lc.Debug(fmt.Sprintf("device: %s DeviceResource: %v reading: %v", c.device.Name, cv.DeviceResourceName, reading), sdkCommon.CorrelationHeader, c.correlationID)
+			if cv.Type == v2.ValueTypeBinary {
+				lc.Debug(fmt.Sprintf("device: %s DeviceResource: %v reading: binary value", c.device.Name, cv.DeviceResourceName), sdkCommon.CorrelationHeader, c.correlationID)
+			} else {
+				lc.Debug(fmt.Sprintf("device: %s DeviceResource: %v reading: %v", c.device.Name, cv.DeviceResourceName, reading), sdkCommon.CorrelationHeader, c.correlationID)
+			}
 		}
 	}
 
@@ -467,7 +720,12 @@ func (c *CommandProcessor) commandValuesToEvent(cvs []*dsModels.CommandValue, cm
 	}
 
 	eventDTO = dtos.Event{DeviceName: c.device.Name, Readings: readings}
-	eventDTO.Origin = sdkCommon.GetUniqueOrigin()
+	if name := sdkCommon.SourceName(c.device.Name, c.device.Profile.Name, cmd, cvs); name != "" {
+		eventDTO.Tags = map[string]string{dsModels.SourceNameTag: name}
+	}
+	filterReadingFields(&eventDTO, c.device.Profile.Name)
+	eventDTO.Id = sdkCommon.NewEventId(c.device.Name)
+	eventDTO.Origin = sdkCommon.NewEventOrigin(c.device.Name)
 
 	return
 }
@@ -486,265 +744,83 @@ func parseParams(params string) (paramMap map[string]string, err error) {
 	return
 }
 
-func createCommandValueFromDeviceResource(dr *contract.DeviceResource, v string) (*dsModels.CommandValue, error) {
-	var err error
-	var result *dsModels.CommandValue
-
-	origin := time.Now().UnixNano()
-	switch strings.ToLower(dr.Properties.Value.Type) {
-	case strings.ToLower(v2.ValueTypeString):
-		result = dsModels.NewStringValue(dr.Name, origin, v)
-	case strings.ToLower(v2.ValueTypeBool):
-		value, err := strconv.ParseBool(v)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewBoolValue(dr.Name, origin, value)
-	case strings.ToLower(v2.ValueTypeBoolArray):
-		var arr []bool
-		err = json.Unmarshal([]byte(v), &arr)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewBoolArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeUint8):
-		n, err := strconv.ParseUint(v, 10, 8)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewUint8Value(dr.Name, origin, uint8(n))
-	case strings.ToLower(v2.ValueTypeUint8Array):
-		var arr []uint8
-		strArr := strings.Split(strings.Trim(v, "[]"), ",")
-		for _, u := range strArr {
-			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 8)
-			if err != nil {
-				return result, err
-			}
-			arr = append(arr, uint8(n))
-		}
-		result, err = dsModels.NewUint8ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeUint16):
-		n, err := strconv.ParseUint(v, 10, 16)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewUint16Value(dr.Name, origin, uint16(n))
-	case strings.ToLower(v2.ValueTypeUint16Array):
-		var arr []uint16
-		strArr := strings.Split(strings.Trim(v, "[]"), ",")
-		for _, u := range strArr {
-			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 16)
-			if err != nil {
-				return result, err
-			}
-			arr = append(arr, uint16(n))
-		}
-		result, err = dsModels.NewUint16ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeUint32):
-		n, err := strconv.ParseUint(v, 10, 32)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewUint32Value(dr.Name, origin, uint32(n))
-	case strings.ToLower(v2.ValueTypeUint32Array):
-		var arr []uint32
-		strArr := strings.Split(strings.Trim(v, "[]"), ",")
-		for _, u := range strArr {
-			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 32)
-			if err != nil {
-				return result, err
-			}
-			arr = append(arr, uint32(n))
-		}
-		result, err = dsModels.NewUint32ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeUint64):
-		n, err := strconv.ParseUint(v, 10, 64)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewUint64Value(dr.Name, origin, n)
-	case strings.ToLower(v2.ValueTypeUint64Array):
-		var arr []uint64
-		strArr := strings.Split(strings.Trim(v, "[]"), ",")
-		for _, u := range strArr {
-			n, err := strconv.ParseUint(strings.Trim(u, " "), 10, 64)
-			if err != nil {
-				return result, err
-			}
-			arr = append(arr, n)
-		}
-		result, err = dsModels.NewUint64ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeInt8):
-		n, err := strconv.ParseInt(v, 10, 8)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt8Value(dr.Name, origin, int8(n))
-	case strings.ToLower(v2.ValueTypeInt8Array):
-		var arr []int8
-		err = json.Unmarshal([]byte(v), &arr)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt8ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeInt16):
-		n, err := strconv.ParseInt(v, 10, 16)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt16Value(dr.Name, origin, int16(n))
-	case strings.ToLower(v2.ValueTypeInt16Array):
-		var arr []int16
-		err = json.Unmarshal([]byte(v), &arr)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt16ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeInt32):
-		n, err := strconv.ParseInt(v, 10, 32)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt32Value(dr.Name, origin, int32(n))
-	case strings.ToLower(v2.ValueTypeInt32Array):
-		var arr []int32
-		err = json.Unmarshal([]byte(v), &arr)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt32ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeInt64):
-		n, err := strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt64Value(dr.Name, origin, n)
-	case strings.ToLower(v2.ValueTypeInt64Array):
-		var arr []int64
-		err = json.Unmarshal([]byte(v), &arr)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewInt64ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeFloat32):
-		n, e := strconv.ParseFloat(v, 32)
-		if e == nil {
-			result, err = dsModels.NewFloat32Value(dr.Name, origin, float32(n))
-			break
-		}
-		if numError, ok := e.(*strconv.NumError); ok {
-			if numError.Err == strconv.ErrRange {
-				err = e
-				break
-			}
-		}
-		var decodedToBytes []byte
-		decodedToBytes, err = base64.StdEncoding.DecodeString(v)
-		if err == nil {
-			var val float32
-			val, err = float32FromBytes(decodedToBytes)
-			if err != nil {
-				break
-			} else if math.IsNaN(float64(val)) {
-				err = fmt.Errorf("fail to parse %v to float32, unexpected result %v", v, val)
-			} else {
-				result, err = dsModels.NewFloat32Value(dr.Name, origin, val)
-			}
-		}
-	case strings.ToLower(v2.ValueTypeFloat32Array):
-		var arr []float32
-		err = json.Unmarshal([]byte(v), &arr)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewFloat32ArrayValue(dr.Name, origin, arr)
-	case strings.ToLower(v2.ValueTypeFloat64):
-		var val float64
-		val, err = strconv.ParseFloat(v, 64)
-		if err == nil {
-			result, err = dsModels.NewFloat64Value(dr.Name, origin, val)
-			break
-		}
-		if numError, ok := err.(*strconv.NumError); ok {
-			if numError.Err == strconv.ErrRange {
-				break
-			}
-		}
-		var decodedToBytes []byte
-		decodedToBytes, err = base64.StdEncoding.DecodeString(v)
-		if err == nil {
-			val, err = float64FromBytes(decodedToBytes)
-			if err != nil {
-				break
-			} else if math.IsNaN(val) {
-				err = fmt.Errorf("fail to parse %v to float64, unexpected result %v", v, val)
-			} else {
-				result, err = dsModels.NewFloat64Value(dr.Name, origin, val)
-			}
-		}
-	case strings.ToLower(v2.ValueTypeFloat64Array):
-		var arr []float64
-		err = json.Unmarshal([]byte(v), &arr)
-		if err != nil {
-			return result, err
-		}
-		result, err = dsModels.NewFloat64ArrayValue(dr.Name, origin, arr)
-	default:
-		err = errors.New("unsupported deviceResource value type")
+// filterReadingFields clears the optional fields of the event and its readings which
+// aren't included for the Device Profile of the source Device. The readings of the DTO
+// have no floatEncoding field, their values being encoded already.
+func filterReadingFields(event *dtos.Event, profileName string) {
+	if !sdkCommon.ReadingFieldIncluded(profileName, sdkCommon.ReadingFieldTags) {
+		event.Tags = nil
 	}
-
-	if err != nil {
-		return result, err
+	if !sdkCommon.ReadingFieldIncluded(profileName, sdkCommon.ReadingFieldMediaType) {
+		for i := range event.Readings {
+			event.Readings[i].MediaType = ""
+		}
 	}
-
-	return result, err
-}
-
-func float32FromBytes(numericValue []byte) (res float32, err error) {
-	reader := bytes.NewReader(numericValue)
-	err = binary.Read(reader, binary.BigEndian, &res)
-	return
-}
-
-func float64FromBytes(numericValue []byte) (res float64, err error) {
-	reader := bytes.NewReader(numericValue)
-	err = binary.Read(reader, binary.BigEndian, &res)
-	return
 }
 
 func commandValueToReading(cv *dsModels.CommandValue, deviceName string, mediaType string, encoding string) dtos.BaseReading {
-	if encoding == "" {
-		encoding = dsModels.DefaultFloatEncoding
-	}
+	encoding = sdkCommon.ReadingFloatEncoding(encoding)
 
 	reading := dtos.BaseReading{ResourceName: cv.DeviceResourceName, DeviceName: deviceName, ValueType: cv.Type}
 	if cv.Type == v2.ValueTypeBinary {
 		reading.BinaryValue = cv.BinValue
 		reading.MediaType = mediaType
 	} else {
-		reading.Value = cv.ValueToString(encoding)
+		reading.Value = sdkCommon.ReadingValue(cv, encoding)
 	}
 
-	// if value has a non-zero Origin, use it
-	if cv.Origin > 0 {
-		reading.Origin = cv.Origin
-	} else {
-		reading.Origin = time.Now().UnixNano()
-	}
+	// the Origin set by the driver, if any, is used according to the origin policy
+	reading.Origin = sdkCommon.ReadingOrigin(deviceName, cv.Origin)
 
 	return reading
 }
 
+// SendEvent sends the event of the command through the same pipeline as the v1 commands,
+// the AutoEvents and the asynchronous readings.
 func SendEvent(event responses.EventResponse, correlationID string, lc logger.LoggingClient, ec coredata.EventClient) {
-	// TODO: comment out until core-contracts(EventClient) supports v2models
-	// TODO: the usage of CBOR encoding for binary reading is under discussion
-	//ctx := context.WithValue(context.Background(), sdkCommon.CorrelationHeader, correlationID)
-	//ctx = context.WithValue(ctx, clients.ContentType, clients.ContentTypeJSON)
-	//responseBody, err := ec.Add(ctx, event)
-	//if err != nil {
-	//	lc.Error("SendEvent: failed to push event to core data", "device", event.DeviceName, "response", responseBody, "error", err)
-	//} else {
-	//	lc.Info("SendEvent: pushed event to core data", clients.ContentType, clients.FromContext(ctx, clients.ContentType), clients.CorrelationHeader, id)
-	//}
+	sdkCommon.SendCorrelatedEvent(eventDTOToModel(event.Event), correlationID, lc, ec)
+}
+
+// eventDTOToModel converts the event DTO into the event model sent by SendEvent, copying
+// the tags the pipeline may modify. The float readings get the encoding of their
+// deviceResource, which the DTO doesn't carry.
+func eventDTOToModel(e dtos.Event) *dsModels.Event {
+	event := &dsModels.Event{Event: contract.Event{ID: e.Id, Device: e.DeviceName, Created: e.Created, Origin: e.Origin}}
+	if len(e.Tags) > 0 {
+		event.Tags = make(map[string]string, len(e.Tags))
+		for k, v := range e.Tags {
+			event.Tags[k] = v
+		}
+	}
+	device, _ := cache.Devices().ForName(e.DeviceName)
+	event.Readings = make([]contract.Reading, len(e.Readings))
+	for i, r := range e.Readings {
+		reading := contract.Reading{
+			Id:          r.Id,
+			Created:     r.Created,
+			Origin:      r.Origin,
+			Device:      r.DeviceName,
+			Name:        r.ResourceName,
+			Value:       r.Value,
+			ValueType:   r.ValueType,
+			BinaryValue: r.BinaryValue,
+			MediaType:   r.MediaType,
+		}
+		if r.ValueType == v2.ValueTypeFloat32 || r.ValueType == v2.ValueTypeFloat64 {
+			dr, _ := cache.Profiles().DeviceResource(device.Profile.Name, r.ResourceName)
+			reading.FloatEncoding = sdkCommon.ReadingFloatEncoding(dr.Properties.Value.FloatEncoding)
+		}
+		event.Readings[i] = reading
+	}
+	return event
+}
+
+// HasBinaryReading reports whether the event has a reading of the Binary ValueType.
+func HasBinaryReading(event dtos.Event) bool {
+	for _, r := range event.Readings {
+		if r.ValueType == v2.ValueTypeBinary {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,189 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/messagebus"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func TestCommandValuesToEventReadingFields(t *testing.T) {
+	require.NoError(t, common.SetSourceNaming(common.SourceNameInfo{Strategy: "command"}))
+	defer func() {
+		_ = common.SetSourceNaming(common.SourceNameInfo{})
+		common.SetReadingFields(common.ReadingFieldsInfo{})
+	}()
+	dic := newTestContainer(mock.DriverMock{}, nil)
+	device, ok := cache.Devices().ForName(testDeviceName)
+	require.True(t, ok)
+	processor := NewCommandProcessor(&device, nil, "", "RandomValue_Bool", "", dic)
+	cv, err := dsModels.NewBoolValue("RandomValue_Bool", 0, true)
+	require.NoError(t, err)
+
+	event, err := processor.commandValuesToEvent([]*dsModels.CommandValue{cv}, "RandomValue_Bool")
+	require.NoError(t, err)
+	assert.Equal(t, "RandomValue_Bool", event.Tags[dsModels.SourceNameTag], "all the optional fields are included")
+
+	common.SetReadingFields(common.ReadingFieldsInfo{Profiles: map[string][]string{device.Profile.Name: {common.ReadingFieldMediaType}}})
+	event, err = processor.commandValuesToEvent([]*dsModels.CommandValue{cv}, "RandomValue_Bool")
+	require.NoError(t, err)
+	assert.Nil(t, event.Tags, "the tags aren't included for the Device Profile")
+	require.Len(t, event.Readings, 1)
+	assert.Equal(t, "true", event.Readings[0].Value)
+}
+
+func TestFilterReadingFields(t *testing.T) {
+	defer common.SetReadingFields(common.ReadingFieldsInfo{})
+	newEvent := func() dtos.Event {
+		return dtos.Event{
+			Tags: map[string]string{dsModels.SourceNameTag: "Image"},
+			Readings: []dtos.BaseReading{
+				{ResourceName: "Image", BinaryReading: dtos.BinaryReading{BinaryValue: []byte{0xff}, MediaType: "image/jpeg"}},
+			},
+		}
+	}
+
+	common.SetReadingFields(common.ReadingFieldsInfo{Include: []string{common.ReadingFieldTags}})
+	event := newEvent()
+	filterReadingFields(&event, "Camera")
+	assert.NotNil(t, event.Tags)
+	assert.Empty(t, event.Readings[0].MediaType)
+
+	common.SetReadingFields(common.ReadingFieldsInfo{Include: []string{common.ReadingFieldMediaType}})
+	event = newEvent()
+	filterReadingFields(&event, "Camera")
+	assert.Nil(t, event.Tags)
+	assert.Equal(t, "image/jpeg", event.Readings[0].MediaType)
+}
+
+// postingClient records the events posted to core data.
+type postingClient struct {
+	eventClient
+	posted [][]byte
+}
+
+func (c *postingClient) AddBytes(_ context.Context, data []byte) (string, error) {
+	c.posted = append(c.posted, data)
+	return "", nil
+}
+
+func TestSendEvent(t *testing.T) {
+	newTestContainer(mock.DriverMock{}, nil)
+	ec := &postingClient{}
+	tags := map[string]string{dsModels.SourceNameTag: "RandomValue_Float32"}
+	event := dtos.Event{
+		Id:         "7a1707f0-166f-4c4b-bc9d-1d54c74e0137",
+		DeviceName: "Random-Float-Generator01",
+		Origin:     1600000000000000000,
+		Tags:       tags,
+		Readings: []dtos.BaseReading{
+			{ResourceName: "RandomValue_Float32", DeviceName: "Random-Float-Generator01", ValueType: v2.ValueTypeFloat32, Origin: 1600000000000000000, SimpleReading: dtos.SimpleReading{Value: "QUgAAA=="}},
+		},
+	}
+
+	SendEvent(responses.NewEventResponse("", "", 0, event), "correlation", logger.NewMockClient(), ec)
+	require.Len(t, ec.posted, 1, "the event is posted to core data through the shared pipeline")
+	var posted contract.Event
+	require.NoError(t, json.Unmarshal(ec.posted[0], &posted))
+	assert.Equal(t, event.Id, posted.ID)
+	assert.Equal(t, event.DeviceName, posted.Device)
+	assert.Equal(t, event.Origin, posted.Origin)
+	assert.Equal(t, tags, posted.Tags)
+	require.Len(t, posted.Readings, 1)
+	assert.Equal(t, "RandomValue_Float32", posted.Readings[0].Name)
+	assert.Equal(t, "QUgAAA==", posted.Readings[0].Value)
+	assert.Equal(t, "Base64", posted.Readings[0].FloatEncoding, "the encoding of the deviceResource")
+}
+
+func TestEventDTOToModelCopiesTags(t *testing.T) {
+	event := dtos.Event{DeviceName: "Random-Boolean-Generator01", Tags: map[string]string{"site": "a"}}
+
+	model := eventDTOToModel(event)
+	model.Tags["site"] = "b"
+	assert.Equal(t, "a", event.Tags["site"], "the pipeline modifies a copy of the tags of the response")
+}
+
+// serveRedis accepts a connection and sends the envelopes appended to the streams.
+func serveRedis(t *testing.T) (messagebus.Config, <-chan messagebus.MessageEnvelope) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	envelopes := make(chan messagebus.MessageEnvelope, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			// the XADD command: *5 $4 XADD $n topic $1 * $8 envelope $n message
+			var args []string
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+			for i := 0; i < n; i++ {
+				line, _ = reader.ReadString('\n')
+				length, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+				data := make([]byte, length+2)
+				if _, err = io.ReadFull(reader, data); err != nil {
+					return
+				}
+				args = append(args, string(data[:length]))
+			}
+			var envelope messagebus.MessageEnvelope
+			_ = json.Unmarshal([]byte(args[len(args)-1]), &envelope)
+			envelopes <- envelope
+			_, _ = conn.Write([]byte("$3\r\n1-0\r\n"))
+		}
+	}()
+	return messagebus.Config{Type: messagebus.TypeRedis, Host: "127.0.0.1", Port: listener.Addr().(*net.TCPAddr).Port}, envelopes
+}
+
+func TestSendEventCodec(t *testing.T) {
+	newTestContainer(mock.DriverMock{}, nil)
+	config, envelopes := serveRedis(t)
+	config.Codec = "protobuf"
+	require.NoError(t, messagebus.NewPublisher(config))
+	defer func() {
+		_ = messagebus.ClosePublisher()
+	}()
+	event := dtos.Event{
+		Id:         "7a1707f0-166f-4c4b-bc9d-1d54c74e0137",
+		DeviceName: testDeviceName,
+		Readings:   []dtos.BaseReading{{ResourceName: "RandomValue_Bool", DeviceName: testDeviceName, ValueType: v2.ValueTypeBool, SimpleReading: dtos.SimpleReading{Value: "true"}}},
+	}
+
+	SendEvent(responses.NewEventResponse("", "", 0, event), "correlation", logger.NewMockClient(), &postingClient{})
+	envelope := <-envelopes
+	codec, err := messagebus.NewCodec("protobuf")
+	require.NoError(t, err)
+	assert.Equal(t, codec.ContentType(), envelope.ContentType, "the v2 events are encoded with the configured codec")
+	assert.Equal(t, "correlation", envelope.CorrelationID)
+}
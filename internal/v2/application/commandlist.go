@@ -0,0 +1,160 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+// CommandParameter describes a deviceResource read or written by a command.
+type CommandParameter struct {
+	ResourceName string `json:"resourceName"`
+	ValueType    string `json:"valueType"`
+	ReadWrite    string `json:"readWrite,omitempty"`
+	Units        string `json:"units,omitempty"`
+	Minimum      string `json:"minimum,omitempty"`
+	Maximum      string `json:"maximum,omitempty"`
+	DefaultValue string `json:"defaultValue,omitempty"`
+	MediaType    string `json:"mediaType,omitempty"`
+	// Mappings are the values accepted by a set command, mapped to the values written.
+	Mappings map[string]string `json:"mappings,omitempty"`
+}
+
+// CommandInfo describes a command of a Device, either a deviceCommand or a deviceResource
+// of its Device Profile, with the URL it is read and written at.
+type CommandInfo struct {
+	Name       string             `json:"name"`
+	Readable   bool               `json:"readable"`
+	Writable   bool               `json:"writable"`
+	URL        string             `json:"url"`
+	Parameters []CommandParameter `json:"parameters"`
+}
+
+// DeviceCommands lists the commands of the Device generated from the cached Device Profile.
+// A command is readable, or writable, only if the command endpoint accepts to read, or
+// write, it.
+func DeviceCommands(deviceName string, dic *di.Container) ([]CommandInfo, edgexErr.EdgeX) {
+	device, ok := cache.Devices().ForNameOrAlias(deviceName)
+	if !ok {
+		return nil, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, fmt.Sprintf("device %s not found", deviceName), nil)
+	}
+	profile, ok := cache.Profiles().ForName(device.Profile.Name)
+	if !ok {
+		errMsg := fmt.Sprintf("Device Profile %s of device %s not found", device.Profile.Name, device.Name)
+		return nil, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	config := container.ConfigurationFrom(dic.Get)
+	baseURL := sdkCommon.BuildAddr(config.Service.Host, strconv.Itoa(config.Service.Port)) +
+		strings.Replace(v2.ApiDeviceByNameRoute, "{"+v2.Name+"}", url.PathEscape(device.Name), 1) + "/"
+
+	resources := make(map[string]contract.DeviceResource, len(profile.DeviceResources))
+	for _, dr := range profile.DeviceResources {
+		resources[dr.Name] = dr
+	}
+
+	commands := make([]CommandInfo, 0, len(profile.DeviceCommands)+len(profile.DeviceResources))
+	listed := make(map[string]bool, len(profile.DeviceCommands))
+	for _, dc := range profile.DeviceCommands {
+		info := CommandInfo{Name: dc.Name, URL: baseURL + url.PathEscape(dc.Name)}
+		parameters := make(map[string]int)
+		for _, ro := range dc.Get {
+			dr, ok := resources[ro.DeviceResource]
+			if !ok {
+				continue
+			}
+			if dr.Properties.Value.ReadWrite != sdkCommon.DeviceResourceWriteOnly {
+				info.Readable = true
+			}
+			addParameter(&info, parameters, dr, nil)
+		}
+		info.Writable = len(dc.Set) > 0
+		for _, ro := range dc.Set {
+			dr, ok := resources[ro.DeviceResource]
+			if !ok {
+				continue
+			}
+			if dr.Properties.Value.ReadWrite == sdkCommon.DeviceResourceReadOnly {
+				info.Writable = false
+			}
+			addParameter(&info, parameters, dr, ro.Mappings)
+		}
+		commands = append(commands, info)
+		listed[dc.Name] = true
+	}
+
+	for _, dr := range profile.DeviceResources {
+		if listed[dr.Name] {
+			continue
+		}
+		info := CommandInfo{
+			Name:     dr.Name,
+			Readable: dr.Properties.Value.ReadWrite != sdkCommon.DeviceResourceWriteOnly,
+			Writable: dr.Properties.Value.ReadWrite != sdkCommon.DeviceResourceReadOnly,
+			URL:      baseURL + url.PathEscape(dr.Name),
+		}
+		addParameter(&info, make(map[string]int), dr, nil)
+		commands = append(commands, info)
+	}
+	return commands, nil
+}
+
+// addParameter adds the deviceResource to the parameters of the command unless it's
+// already there, in which case only the mappings are merged.
+func addParameter(info *CommandInfo, indexes map[string]int, dr contract.DeviceResource, mappings map[string]string) {
+	i, ok := indexes[dr.Name]
+	if !ok {
+		pv := dr.Properties.Value
+		info.Parameters = append(info.Parameters, CommandParameter{
+			ResourceName: dr.Name,
+			ValueType:    pv.Type,
+			ReadWrite:    pv.ReadWrite,
+			Units:        dr.Properties.Units.DefaultValue,
+			Minimum:      pv.Minimum,
+			Maximum:      pv.Maximum,
+			DefaultValue: pv.DefaultValue,
+			MediaType:    pv.MediaType,
+		})
+		i = len(info.Parameters) - 1
+		indexes[dr.Name] = i
+	}
+	if len(mappings) == 0 {
+		return
+	}
+	p := &info.Parameters[i]
+	if p.Mappings == nil {
+		p.Mappings = make(map[string]string, len(mappings))
+	}
+	for k, v := range mappings {
+		p.Mappings[k] = v
+	}
+}
+
+// HasCommand reports whether the Device has a deviceCommand or deviceResource of the name.
+func HasCommand(deviceName string, cmd string) bool {
+	device, ok := cache.Devices().ForNameOrAlias(deviceName)
+	if !ok {
+		return false
+	}
+	if _, ok = cache.Profiles().DeviceResource(device.Profile.Name, cmd); ok {
+		return true
+	}
+	exists, _ := cache.Profiles().CommandExists(device.Profile.Name, cmd, sdkCommon.GetCmdMethod)
+	return exists
+}
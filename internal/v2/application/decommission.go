@@ -0,0 +1,92 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/asyncqueue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/autoevent"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/playback"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	defaultFlushTimeout = 5 * time.Second
+	unspecifiedReason   = "unspecified"
+)
+
+// DecommissionDevice decommissions the Device in order: its AutoEvents and playback are
+// paused, the ProtocolDriver cleans it up if it implements DeviceDecommissioner, the
+// asynchronous readings pending processing are flushed, the Device is removed from Core
+// Metadata, which in turn removes it from the ProtocolDriver, and the decommissioning is
+// published as a reading of the Decommissioned resource. The AutoEvents are resumed if
+// the cleanup fails.
+func DecommissionDevice(name string, reason string, correlationID string, dic *di.Container) edgexErr.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	if reason == "" {
+		reason = unspecifiedReason
+	}
+	device, ok := cache.Devices().ForName(name)
+	if !ok {
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, fmt.Sprintf("Device %s not found", name), nil)
+	}
+
+	lc.Info(fmt.Sprintf("decommissioning Device %s: pausing AutoEvents", name), sdkCommon.CorrelationHeader, correlationID)
+	autoevent.GetManager().StopForDevice(name)
+	playback.GetManager().StopForDevice(name)
+
+	if decommissioner, ok := container.RawProtocolDriverFrom(dic.Get).(dsModels.DeviceDecommissioner); ok {
+		lc.Info(fmt.Sprintf("decommissioning Device %s: cleaning up", name), sdkCommon.CorrelationHeader, correlationID)
+		if err := decommissioner.DecommissionDevice(name, device.Protocols); err != nil {
+			autoevent.GetManager().RestartForDevice(name, dic)
+			errMsg := fmt.Sprintf("failed to clean up Device %s, decommissioning aborted", name)
+			return sdkCommon.NewDriverEdgeX(errMsg, err)
+		}
+	}
+
+	if queue := asyncqueue.GetQueue(); queue != nil {
+		lc.Info(fmt.Sprintf("decommissioning Device %s: flushing pending readings", name), sdkCommon.CorrelationHeader, correlationID)
+		timeout := defaultFlushTimeout
+		if t := container.ConfigurationFrom(dic.Get).Service.Timeout; t > 0 {
+			timeout = time.Duration(t) * time.Millisecond
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := queue.Drain(ctx)
+		cancel()
+		if err != nil {
+			lc.Warn(fmt.Sprintf("decommissioning Device %s: pending readings not flushed within %s", name, timeout), sdkCommon.CorrelationHeader, correlationID)
+		}
+	}
+
+	lc.Info(fmt.Sprintf("decommissioning Device %s: removing from Core Metadata", name), sdkCommon.CorrelationHeader, correlationID)
+	ctx := context.WithValue(context.Background(), sdkCommon.CorrelationHeader, correlationID)
+	if err := container.MetadataDeviceClientFrom(dic.Get).DeleteByName(ctx, name); err != nil {
+		errMsg := fmt.Sprintf("failed to remove Device %s from Core Metadata, its AutoEvents remain paused", name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindCommunicationError, errMsg, err)
+	}
+
+	origin := sdkCommon.NewEventOrigin(name)
+	cv := dsModels.NewStringValue(dsModels.DecommissionedResource, origin, reason)
+	event := &dsModels.Event{Event: contract.Event{Device: name, Readings: []contract.Reading{*sdkCommon.CommandValueToReading(cv, name, "", "")}}}
+	event.ID = sdkCommon.NewEventId(name)
+	event.Origin = origin
+	go sdkCommon.SendEvent(event, lc, container.CoredataEventClientFrom(dic.Get))
+
+	lc.Info(fmt.Sprintf("Device %s decommissioned: %s", name, reason), sdkCommon.CorrelationHeader, correlationID)
+	return nil
+}
@@ -0,0 +1,72 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+)
+
+// steps records the steps of the decommissioning in the order they run.
+type steps struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func (s *steps) add(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.names = append(s.names, name)
+}
+
+type decommissionerDriver struct {
+	mock.DriverMock
+	steps *steps
+	err   error
+}
+
+func (d decommissionerDriver) DecommissionDevice(deviceName string, _ map[string]contract.ProtocolProperties) error {
+	d.steps.add("cleanup " + deviceName)
+	return d.err
+}
+
+type recordingDeviceClient struct {
+	mock.DeviceClientMock
+	steps *steps
+}
+
+func (c *recordingDeviceClient) DeleteByName(_ context.Context, name string) error {
+	c.steps.add("delete " + name)
+	return nil
+}
+
+func TestDecommissionDevice(t *testing.T) {
+	s := &steps{}
+	dic := newTestContainer(decommissionerDriver{steps: s}, &recordingDeviceClient{steps: s})
+
+	err := DecommissionDevice(testDeviceName, "replaced", "", dic)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cleanup " + testDeviceName, "delete " + testDeviceName}, s.names,
+		"the driver cleans up the Device before it's removed from Core Metadata")
+}
+
+func TestDecommissionDeviceCleanupFailed(t *testing.T) {
+	s := &steps{}
+	dic := newTestContainer(decommissionerDriver{steps: s, err: errors.New("unreachable")}, &recordingDeviceClient{steps: s})
+
+	err := DecommissionDevice(testDeviceName, "replaced", "", dic)
+	require.Error(t, err)
+	assert.Equal(t, []string{"cleanup " + testDeviceName}, s.names, "the Device isn't removed once the cleanup failed")
+}
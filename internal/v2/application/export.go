@@ -0,0 +1,112 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/export"
+)
+
+// DeviceExport is the definition of the Devices owned by the Device Service. The Devices
+// use the same layout as the DeviceList configuration and the Device Profiles as the
+// profile files in Device.ProfilesDir, so that they can be imported by another service.
+type DeviceExport struct {
+	Devices  []sdkCommon.DeviceConfig `json:"devices" yaml:"devices"`
+	Profiles []contract.DeviceProfile `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+}
+
+// ExportDevices returns the definition of all the Devices in cache, optionally along
+// with the Device Profiles they use.
+func ExportDevices(embedProfiles bool, dic *di.Container) DeviceExport {
+	devices := cache.Devices().All()
+	devicesExport := DeviceExport{Devices: make([]sdkCommon.DeviceConfig, len(devices))}
+
+	profileNames := make(map[string]bool)
+	for i, d := range devices {
+		devicesExport.Devices[i] = sdkCommon.DeviceConfig{
+			Name:        d.Name,
+			Profile:     d.Profile.Name,
+			Description: d.Description,
+			Labels:      d.Labels,
+			Protocols:   d.Protocols,
+			AutoEvents:  d.AutoEvents,
+		}
+		profileNames[d.Profile.Name] = true
+	}
+
+	if embedProfiles {
+		for name := range profileNames {
+			if profile, ok := cache.Profiles().ForName(name); ok {
+				devicesExport.Profiles = append(devicesExport.Profiles, profile)
+			}
+		}
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debug(fmt.Sprintf("exported %d devices and %d profiles", len(devicesExport.Devices), len(devicesExport.Profiles)))
+	return devicesExport
+}
+
+// ExportEvents exports the retained events as a compressed bundle, returning the
+// bundle id, the compressed data and its signature.
+func ExportEvents(dic *di.Container) (string, []byte, string, edgexErr.EdgeX) {
+	buffer := export.GetBuffer()
+	if buffer == nil {
+		return "", nil, "", edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, "events export is disabled, see Device.Export.BufferSize", nil)
+	}
+
+	ds := container.DeviceServiceFrom(dic.Get)
+	bundle, err := buffer.Export(ds.Name)
+	if err != nil {
+		return "", nil, "", edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "failed to export events", err)
+	}
+
+	var key []byte
+	config := container.ConfigurationFrom(dic.Get)
+	if config.Device.Export.SecretPath != "" {
+		secrets, err := bootstrapContainer.SecretProviderFrom(dic.Get).GetSecrets(config.Device.Export.SecretPath, export.SigningKeySecret)
+		if err != nil {
+			return "", nil, "", edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to get the bundle signing key", err)
+		}
+		key = []byte(secrets[export.SigningKeySecret])
+	}
+
+	data, signature, err := export.Encode(bundle, key)
+	if err != nil {
+		return "", nil, "", edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to encode the events bundle", err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Info(fmt.Sprintf("exported %d events in bundle %s", len(bundle.Events), bundle.Id))
+	return bundle.Id, data, signature, nil
+}
+
+// MarkEventsExported discards the events of the bundle once it has been delivered.
+func MarkEventsExported(bundleId string, dic *di.Container) edgexErr.EdgeX {
+	buffer := export.GetBuffer()
+	if buffer == nil {
+		return edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, "events export is disabled, see Device.Export.BufferSize", nil)
+	}
+
+	err := buffer.MarkExported(bundleId)
+	if err != nil {
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, fmt.Sprintf("failed to mark bundle %s as exported", bundleId), err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debug(fmt.Sprintf("events of bundle %s marked as exported", bundleId))
+	return nil
+}
@@ -0,0 +1,41 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+)
+
+func TestExportDevices(t *testing.T) {
+	dic := newTestContainer(mock.DriverMock{}, &mock.DeviceClientMock{})
+
+	devicesExport := ExportDevices(true, dic)
+	require.NotEmpty(t, devicesExport.Devices)
+	require.NotEmpty(t, devicesExport.Profiles)
+
+	data, err := json.Marshal(devicesExport)
+	require.NoError(t, err)
+	var fields map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Contains(t, fields["devices"][0], "name")
+	assert.Contains(t, fields["devices"][0], "profile")
+	assert.Contains(t, fields["devices"][0], "protocols")
+	assert.Contains(t, fields["profiles"][0], "deviceResources", "the Devices and the Device Profiles use the same key case")
+
+	data, err = yaml.Marshal(devicesExport)
+	require.NoError(t, err)
+	var imported DeviceExport
+	require.NoError(t, yaml.Unmarshal(data, &imported))
+	assert.ElementsMatch(t, devicesExport.Devices, imported.Devices, "the export can be imported back")
+}
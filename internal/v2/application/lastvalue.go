@@ -0,0 +1,191 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/responses"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+)
+
+// The tags marking an event served from the last known values.
+const (
+	// TagCached is "true" for events served from the last known values.
+	TagCached = "cached"
+	// TagCachedAt is when the oldest of the readings was recorded, in nanoseconds.
+	TagCachedAt = "cachedAt"
+	// TagCachedSources lists the sources of the readings, separated by commas.
+	TagCachedSources = "cachedSources"
+	// TagStale is "true" for the cached events served because the device is busy or down,
+	// whose values may be older than the caching policy of the deviceResources allows.
+	TagStale = "stale"
+)
+
+func recordLastValues(event dtos.Event) {
+	readings := make([]contract.Reading, len(event.Readings))
+	for i, r := range event.Readings {
+		readings[i] = contract.Reading{
+			Origin:      r.Origin,
+			Device:      r.DeviceName,
+			Name:        r.ResourceName,
+			Value:       r.Value,
+			ValueType:   r.ValueType,
+			BinaryValue: r.BinaryValue,
+			MediaType:   r.MediaType,
+		}
+	}
+	lastvalue.Record(readings, lastvalue.SourceCommand)
+}
+
+// staleEvent returns the event of the read command built from the last known values of
+// its deviceResources, if all of them were recorded within allowStale.
+func staleEvent(device contract.Device, cmd string, cmdExists bool, allowStale time.Duration) (res responses.EventResponse, ok bool) {
+	resourceNames, ok := commandResourceNames(device, cmd, cmdExists)
+	if !ok {
+		return res, false
+	}
+
+	// the values aren't reused beyond the caching policy of the deviceResources
+	allowStale = resourcesCacheControl(device.Profile.Name, resourceNames).Allows(allowStale)
+	if allowStale <= 0 {
+		return res, false
+	}
+	res, ok = cachedEvent(device, resourceNames, allowStale)
+	if ok {
+		res.Event.Tags[TagStale] = "true"
+	}
+	return res, ok
+}
+
+// freshEvent returns the event of the read command built from the last known values of
+// its deviceResources, if all of them were recorded within the read cache TTL: the
+// shortest ds-cacheMaxAge of the deviceResources, or Device.ReadCacheTTL if none sets it.
+func freshEvent(device contract.Device, cmd string, cmdExists bool, readCacheTTL time.Duration) (res responses.EventResponse, ok bool) {
+	resourceNames, ok := commandResourceNames(device, cmd, cmdExists)
+	if !ok {
+		return res, false
+	}
+
+	cc := resourcesCacheControl(device.Profile.Name, resourceNames)
+	if cc.NoStore {
+		return res, false
+	}
+	if cc.MaxAge >= 0 {
+		readCacheTTL = cc.MaxAge
+	}
+	if readCacheTTL <= 0 {
+		return res, false
+	}
+	return cachedEvent(device, resourceNames, readCacheTTL)
+}
+
+// commandResourceNames returns the deviceResources read by the command, or the
+// deviceResource itself if cmd isn't a command.
+func commandResourceNames(device contract.Device, cmd string, cmdExists bool) ([]string, bool) {
+	if !cmdExists {
+		return []string{cmd}, true
+	}
+	ros, err := cache.Profiles().ResourceOperations(device.Profile.Name, cmd, sdkCommon.GetCmdMethod)
+	if err != nil {
+		return nil, false
+	}
+	resourceNames := make([]string, len(ros))
+	for i, ro := range ros {
+		resourceNames[i] = ro.DeviceResource
+	}
+	return resourceNames, true
+}
+
+// cachedEvent returns the event built from the last known values of the deviceResources,
+// if all of them were recorded within maxAge.
+func cachedEvent(device contract.Device, resourceNames []string, maxAge time.Duration) (res responses.EventResponse, ok bool) {
+	values, ok := lastvalue.Fresh(device.Name, resourceNames, maxAge)
+	if !ok {
+		return res, false
+	}
+
+	cachedAt := values[0].Timestamp
+	sources := make(map[string]bool)
+	readings := make([]dtos.BaseReading, len(values))
+	for i, v := range values {
+		if v.Timestamp < cachedAt {
+			cachedAt = v.Timestamp
+		}
+		sources[v.Source] = true
+		readings[i] = dtos.BaseReading{
+			Origin:        v.Reading.Origin,
+			DeviceName:    device.Name,
+			ResourceName:  v.Reading.Name,
+			ProfileName:   device.Profile.Name,
+			ValueType:     v.Reading.ValueType,
+			BinaryReading: dtos.BinaryReading{BinaryValue: v.Reading.BinaryValue, MediaType: v.Reading.MediaType},
+			SimpleReading: dtos.SimpleReading{Value: v.Reading.Value},
+		}
+	}
+	sourceNames := make([]string, 0, len(sources))
+	for source := range sources {
+		sourceNames = append(sourceNames, source)
+	}
+	sort.Strings(sourceNames)
+
+	event := dtos.Event{
+		DeviceName:  device.Name,
+		ProfileName: device.Profile.Name,
+		Id:          sdkCommon.NewEventId(device.Name),
+		Origin:      sdkCommon.NewEventOrigin(device.Name),
+		Readings:    readings,
+		Tags: map[string]string{
+			TagCached:        "true",
+			TagCachedAt:      strconv.FormatInt(cachedAt, 10),
+			TagCachedSources: strings.Join(sourceNames, ","),
+		},
+	}
+	return responses.NewEventResponse("", "", http.StatusOK, event), true
+}
+
+// CachedAge returns the age of the oldest value of the event served from the last known
+// values, if it was.
+func CachedAge(event dtos.Event) (time.Duration, bool) {
+	if event.Tags[TagCached] != "true" {
+		return 0, false
+	}
+	cachedAt, err := strconv.ParseInt(event.Tags[TagCachedAt], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Since(time.Unix(0, cachedAt)), true
+}
+
+// EventCacheControl returns the caching policy of the values of the event read by a
+// command, from the deviceResources of its readings.
+func EventCacheControl(event dtos.Event) sdkCommon.CacheControl {
+	resourceNames := make([]string, len(event.Readings))
+	for i, r := range event.Readings {
+		resourceNames[i] = r.ResourceName
+	}
+	return resourcesCacheControl(event.ProfileName, resourceNames)
+}
+
+func resourcesCacheControl(profileName string, resourceNames []string) sdkCommon.CacheControl {
+	resources := make([]contract.DeviceResource, 0, len(resourceNames))
+	for _, name := range resourceNames {
+		if dr, ok := cache.Profiles().DeviceResource(profileName, name); ok {
+			resources = append(resources, dr)
+		}
+	}
+	return sdkCommon.ResourcesCacheControl(resources)
+}
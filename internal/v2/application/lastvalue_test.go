@@ -0,0 +1,85 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// int8Driver records the reads and writes, the values read being 1.
+type int8Driver struct {
+	mock.DriverMock
+	steps *steps
+}
+
+func (d int8Driver) HandleReadCommands(deviceName string, _ map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	d.steps.add("read " + deviceName)
+	values := make([]*dsModels.CommandValue, len(reqs))
+	for i, req := range reqs {
+		values[i], _ = dsModels.NewInt8Value(req.DeviceResourceName, 0, 1)
+	}
+	return values, nil
+}
+
+func (d int8Driver) HandleWriteCommands(deviceName string, _ map[string]contract.ProtocolProperties, _ []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	d.steps.add("write " + deviceName + " " + params[0].ValueToString())
+	return nil
+}
+
+func TestReadCache(t *testing.T) {
+	const deviceName = "Random-Integer-Generator01"
+	vars := map[string]string{common.NameVar: deviceName, common.CommandVar: "ResourceTestTransform_Pass"}
+
+	tests := []struct {
+		name  string
+		query string
+		write bool
+		reads int
+	}{
+		{"served from the cache", "", false, 1},
+		{"invalidated by a write", "", true, 2},
+		{"bypassed with query parameters", "scale=2", false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lastvalue.Remove(deviceName)
+			s := &steps{}
+			dic := newTestContainer(int8Driver{steps: s}, nil)
+			container.ConfigurationFrom(dic.Get).Device.ReadCacheTTL = "1m"
+
+			_, err := CommandHandler(context.Background(), true, false, false, 0, "", vars, tt.query, dic)
+			require.Nil(t, err)
+			if tt.write {
+				_, err = CommandHandler(context.Background(), false, false, false, 0, "", vars, `{"ResourceTestTransform_Pass":"5"}`, dic)
+				require.Nil(t, err)
+			}
+			res, err := CommandHandler(context.Background(), true, false, false, 0, "", vars, tt.query, dic)
+			require.Nil(t, err)
+
+			reads := 0
+			for _, name := range s.names {
+				if name == "read "+deviceName {
+					reads++
+				}
+			}
+			assert.Equal(t, tt.reads, reads)
+			_, cached := res.Event.Tags[TagCached]
+			assert.Equal(t, tt.reads == 1, cached)
+		})
+	}
+}
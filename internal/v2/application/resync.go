@@ -0,0 +1,82 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/types"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/autoevent"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/playback"
+)
+
+// ResyncDevice refetches the Device and its Device Profile from Core Metadata, replaces
+// them in the cache and notifies the ProtocolDriver, leaving the other Devices untouched.
+func ResyncDevice(name string, correlationID string, dic *di.Container) edgexErr.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	ctx := context.WithValue(context.Background(), sdkCommon.CorrelationHeader, correlationID)
+
+	device, err := container.MetadataDeviceClientFrom(dic.Get).DeviceForName(ctx, name)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to retrieve Device %s from Core Metadata", name)
+		if errsc, ok := err.(types.ErrServiceClient); ok && errsc.StatusCode == http.StatusNotFound {
+			return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, err)
+		}
+		return edgexErr.NewCommonEdgeX(edgexErr.KindCommunicationError, errMsg, err)
+	}
+	if serviceName := container.DeviceServiceFrom(dic.Get).Name; device.Service.Name != serviceName {
+		errMsg := fmt.Sprintf("Device %s isn't managed by %s", name, serviceName)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, nil)
+	}
+
+	profile, err := container.MetadataDeviceProfileClientFrom(dic.Get).DeviceProfileForName(ctx, device.Profile.Name)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to retrieve Device Profile %s from Core Metadata", device.Profile.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindCommunicationError, errMsg, err)
+	}
+	device.Profile = profile
+
+	if _, ok := cache.Profiles().ForName(profile.Name); ok {
+		err = cache.Profiles().Update(profile)
+	} else {
+		err = cache.Profiles().Add(profile)
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to cache Device Profile %s", profile.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+	}
+
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	if previous, ok := cache.Devices().ForName(name); ok {
+		if err = cache.Devices().Update(device); err == nil {
+			err = sdkCommon.UpdateDriverDevice(driver, previous, device)
+		}
+	} else {
+		if err = cache.Devices().Add(device); err == nil {
+			err = driver.AddDevice(device.Name, sdkCommon.DriverProtocols(device.Protocols), device.AdminState)
+		}
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to resync Device %s", name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+	}
+
+	autoevent.GetManager().RestartForDevice(device.Name, dic)
+	playback.GetManager().RestartForDevice(device.Name)
+
+	lc.Info(fmt.Sprintf("Device %s resynced with Core Metadata", name), sdkCommon.CorrelationHeader, correlationID)
+	return nil
+}
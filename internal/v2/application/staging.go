@@ -0,0 +1,172 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/staging"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/systemevent"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// StageProfile validates the updated Device Profile and, if canaryDevice is specified,
+// test-reads the canary Device with it before holding the profile for being applied.
+func StageProfile(profile contract.DeviceProfile, canaryDevice string, dic *di.Container) edgexErr.EdgeX {
+	if _, ok := cache.Profiles().ForName(profile.Name); !ok {
+		errMsg := fmt.Sprintf("Device Profile %s not found, only existing profiles can be staged", profile.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	err := staging.Validate(profile)
+	if err != nil {
+		errMsg := fmt.Sprintf("Device Profile %s failed validation", profile.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	if canaryDevice != "" {
+		device, ok := cache.Devices().ForName(canaryDevice)
+		if !ok {
+			errMsg := fmt.Sprintf("canary Device %s not found", canaryDevice)
+			return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+		}
+		if device.Profile.Name != profile.Name {
+			errMsg := fmt.Sprintf("canary Device %s doesn't use Device Profile %s", canaryDevice, profile.Name)
+			return edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, nil)
+		}
+		if device.AdminState == contract.Locked {
+			errMsg := fmt.Sprintf("canary Device %s is locked", canaryDevice)
+			return edgexErr.NewCommonEdgeX(edgexErr.KindServiceLocked, errMsg, nil)
+		}
+
+		err = staging.CanaryRead(profile, device, container.ProtocolDriverFrom(dic.Get), lc)
+		if err != nil {
+			errMsg := fmt.Sprintf("Device Profile %s failed the canary read", profile.Name)
+			return edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, err)
+		}
+	}
+
+	staging.Stage(profile)
+	lc.Info(fmt.Sprintf("Device Profile %s staged", profile.Name))
+	return nil
+}
+
+// ApplyStagedProfile applies the staged Device Profile to Core Metadata and all the
+// Devices using it. The prior version is restored if the profile cannot be applied.
+func ApplyStagedProfile(name string, correlationID string, dic *di.Container) edgexErr.EdgeX {
+	profile, ok := staging.Staged(name)
+	if !ok {
+		errMsg := fmt.Sprintf("no staged Device Profile %s", name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+	current, ok := cache.Profiles().ForName(name)
+	if !ok {
+		errMsg := fmt.Sprintf("Device Profile %s not found", name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+	profile.Id = current.Id
+
+	if err := replaceProfile(profile, current, correlationID, dic); err != nil {
+		return err
+	}
+
+	staging.Promote(name, current)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Info(fmt.Sprintf("staged Device Profile %s applied", name), sdkCommon.CorrelationHeader, correlationID)
+	systemevent.Publish(dsModels.SystemEventTypeDeviceProfile, dsModels.SystemEventActionApplied, name, nil)
+	return nil
+}
+
+// RollbackProfile restores the version of the Device Profile replaced by the last
+// applied upgrade.
+func RollbackProfile(name string, correlationID string, dic *di.Container) edgexErr.EdgeX {
+	prior, ok := staging.Previous(name)
+	if !ok {
+		errMsg := fmt.Sprintf("no prior version of Device Profile %s", name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+	current, ok := cache.Profiles().ForName(name)
+	if !ok {
+		errMsg := fmt.Sprintf("Device Profile %s not found", name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	if err := replaceProfile(prior, current, correlationID, dic); err != nil {
+		return err
+	}
+
+	staging.Forget(name)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Info(fmt.Sprintf("Device Profile %s rolled back", name), sdkCommon.CorrelationHeader, correlationID)
+	systemevent.Publish(dsModels.SystemEventTypeDeviceProfile, dsModels.SystemEventActionRolledBack, name, nil)
+	return nil
+}
+
+// replaceProfile updates the Device Profile in Core Metadata and the cache, restoring
+// the current version if the profile cannot be applied to the Devices.
+func replaceProfile(profile contract.DeviceProfile, current contract.DeviceProfile, correlationID string, dic *di.Container) edgexErr.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dpc := container.MetadataDeviceProfileClientFrom(dic.Get)
+	ctx := context.WithValue(context.Background(), sdkCommon.CorrelationHeader, correlationID)
+
+	err := dpc.Update(ctx, profile)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to update Device Profile %s in Core Metadata", profile.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindCommunicationError, errMsg, err)
+	}
+
+	err = applyProfile(profile, dic)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to apply Device Profile %s, restoring the current version: %v", profile.Name, err), sdkCommon.CorrelationHeader, correlationID)
+		if rollbackErr := dpc.Update(ctx, current); rollbackErr != nil {
+			lc.Error(fmt.Sprintf("failed to restore Device Profile %s in Core Metadata: %v", profile.Name, rollbackErr), sdkCommon.CorrelationHeader, correlationID)
+		}
+		_ = applyProfile(current, dic)
+		errMsg := fmt.Sprintf("failed to apply Device Profile %s", profile.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+	}
+	return nil
+}
+
+// applyProfile updates the Device Profile in cache and the Devices using it, returning
+// the first error the ProtocolDriver reports for the updated Devices.
+func applyProfile(profile contract.DeviceProfile, dic *di.Container) error {
+	err := cache.Profiles().Update(profile)
+	if err != nil {
+		return err
+	}
+
+	var driverErr error
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	driver := container.RawProtocolDriverFrom(dic.Get)
+	for _, d := range cache.Devices().All() {
+		if d.Profile.Name != profile.Name {
+			continue
+		}
+		previous := d
+		d.Profile = profile
+		_ = cache.Devices().Update(d)
+		err = sdkCommon.UpdateDriverDevice(driver, previous, d)
+		if err != nil {
+			lc.Error(fmt.Sprintf("failed to update Device %s in ProtocolDriver: %v", d.Name, err))
+			if driverErr == nil {
+				driverErr = fmt.Errorf("failed to update Device %s in ProtocolDriver: %v", d.Name, err)
+			}
+		}
+	}
+	return driverErr
+}
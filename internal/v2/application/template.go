@@ -0,0 +1,123 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+func AddDeviceTemplate(template dsModels.DeviceTemplate, dic *di.Container) edgexErr.EdgeX {
+	if err := template.Validate(); err != nil {
+		return edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "invalid DeviceTemplate", err)
+	}
+
+	if _, ok := cache.Templates().ForName(template.Name); ok {
+		errMsg := fmt.Sprintf("DeviceTemplate %s already exists", template.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindDuplicateName, errMsg, nil)
+	}
+
+	err := cache.Templates().Add(template)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to add DeviceTemplate %s", template.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debug(fmt.Sprintf("DeviceTemplate %s added", template.Name))
+	return nil
+}
+
+func UpdateDeviceTemplate(template dsModels.DeviceTemplate, dic *di.Container) edgexErr.EdgeX {
+	if err := template.Validate(); err != nil {
+		return edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "invalid DeviceTemplate", err)
+	}
+
+	if _, ok := cache.Templates().ForName(template.Name); !ok {
+		errMsg := fmt.Sprintf("DeviceTemplate %s not found", template.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	err := cache.Templates().Update(template)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to update DeviceTemplate %s", template.Name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debug(fmt.Sprintf("DeviceTemplate %s updated", template.Name))
+	return nil
+}
+
+func DeleteDeviceTemplate(name string, dic *di.Container) edgexErr.EdgeX {
+	err := cache.Templates().RemoveByName(name)
+	if err != nil {
+		errMsg := fmt.Sprintf("DeviceTemplate %s not found", name)
+		return edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debug(fmt.Sprintf("DeviceTemplate %s removed", name))
+	return nil
+}
+
+// AddDeviceFromTemplate renders the named DeviceTemplate with the given placeholder values
+// and adds the resulting Device to Core Metadata, returning the id of the new Device.
+func AddDeviceFromTemplate(templateName string, deviceName string, values map[string]string, correlationID string, dic *di.Container) (string, edgexErr.EdgeX) {
+	template, ok := cache.Templates().ForName(templateName)
+	if !ok {
+		errMsg := fmt.Sprintf("DeviceTemplate %s not found", templateName)
+		return "", edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+	}
+
+	if _, ok := cache.Devices().ForName(deviceName); ok {
+		errMsg := fmt.Sprintf("device %s already exists", deviceName)
+		return "", edgexErr.NewCommonEdgeX(edgexErr.KindDuplicateName, errMsg, nil)
+	}
+
+	device, err := template.Render(deviceName, values)
+	if err != nil {
+		return "", edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "failed to render DeviceTemplate", err)
+	}
+
+	ctx := context.WithValue(context.Background(), sdkCommon.CorrelationHeader, correlationID)
+	profile, ok := cache.Profiles().ForName(template.ProfileName)
+	if !ok {
+		profile, err = container.MetadataDeviceProfileClientFrom(dic.Get).DeviceProfileForName(ctx, template.ProfileName)
+		if err != nil {
+			errMsg := fmt.Sprintf("Device Profile %s of DeviceTemplate %s not found", template.ProfileName, templateName)
+			return "", edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, err)
+		}
+	}
+
+	device.Profile = profile
+	device.Service = *container.DeviceServiceFrom(dic.Get)
+	device.Origin = time.Now().UnixNano() / int64(time.Millisecond)
+
+	id, err := container.MetadataDeviceClientFrom(dic.Get).Add(ctx, &device)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to add device %s to Core Metadata", deviceName)
+		return "", edgexErr.NewCommonEdgeX(edgexErr.KindCommunicationError, errMsg, err)
+	}
+	if err = sdkCommon.VerifyIdFormat(id, "Device"); err != nil {
+		return "", edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "invalid id returned by Core Metadata", err)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debug(fmt.Sprintf("device %s created from DeviceTemplate %s", deviceName, templateName), sdkCommon.CorrelationHeader, correlationID)
+	return id, nil
+}
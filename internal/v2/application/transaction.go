@@ -0,0 +1,282 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/executor"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// The statuses of a transaction.
+const (
+	// TransactionCommitted means all the writes were applied.
+	TransactionCommitted = "committed"
+	// TransactionAborted means the writes weren't applied as one couldn't be prepared.
+	TransactionAborted = "aborted"
+	// TransactionRolledBack means the writes applied were undone after one failed.
+	TransactionRolledBack = "rolledBack"
+	// TransactionPartial means some writes remain applied while others failed.
+	TransactionPartial = "partial"
+)
+
+// The statuses of the writes to the devices of a transaction.
+const (
+	WriteCommitted      = "committed"
+	WriteFailed         = "failed"
+	WriteAborted        = "aborted"
+	WriteRolledBack     = "rolledBack"
+	WriteRollbackFailed = "rollbackFailed"
+	WriteSkipped        = "skipped"
+)
+
+// TransactionWrite is the write of a command or deviceResource of a device in a transaction.
+type TransactionWrite struct {
+	DeviceName string            `json:"deviceName"`
+	Command    string            `json:"command"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+// TransactionOutcome reports the outcome of the write to a device in a transaction. Code
+// is the status code of the failed write.
+type TransactionOutcome struct {
+	DeviceName string `json:"deviceName"`
+	Command    string `json:"command"`
+	Status     string `json:"status"`
+	Code       int    `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// TransactionResult reports the outcome of a transaction and of each of its writes.
+type TransactionResult struct {
+	Id       string               `json:"id"`
+	Status   string               `json:"status"`
+	Outcomes []TransactionOutcome `json:"outcomes"`
+}
+
+// StatusCode returns the status code of the transaction: 200 if it's committed, 207
+// Multi-Status if it's partially applied, and the code of the failed write otherwise.
+func (r TransactionResult) StatusCode() int {
+	switch r.Status {
+	case TransactionCommitted:
+		return http.StatusOK
+	case TransactionPartial:
+		return http.StatusMultiStatus
+	}
+	for _, o := range r.Outcomes {
+		if o.Status == WriteFailed {
+			return o.Code
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// transactionWrite is a write of a transaction ready to be executed.
+type transactionWrite struct {
+	processor *CommandProcessor
+	reqs      []dsModels.CommandRequest
+	params    []*dsModels.CommandValue
+}
+
+// ExecuteTransaction executes the writes to several devices as a coordinated unit. All the
+// writes are checked first, and none is executed if any is invalid. The writes are then
+// prepared and committed by the ProtocolDriver if it's a TransactionalDriver; otherwise
+// they're executed one by one, after reading the current values of their deviceResources,
+// and the writes already executed are rolled back with these values if one fails.
+func ExecuteTransaction(ctx context.Context, writes []TransactionWrite, correlationID string, dic *di.Container) (TransactionResult, edgexErr.EdgeX) {
+	if len(writes) == 0 {
+		return TransactionResult{}, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "no writes in the transaction", nil)
+	}
+	ds := container.DeviceServiceFrom(dic.Get)
+	if ds.AdminState == contract.Locked {
+		return TransactionResult{}, edgexErr.NewCommonEdgeX(edgexErr.KindServiceLocked, "service locked", nil)
+	}
+
+	prepared := make([]transactionWrite, 0, len(writes))
+	devices := make(map[string]bool, len(writes))
+	for _, w := range writes {
+		tw, err := newTransactionWrite(ctx, w, correlationID, dic)
+		if err != nil {
+			return TransactionResult{}, err
+		}
+		if devices[tw.processor.device.Name] {
+			errMsg := fmt.Sprintf("device %s written more than once in the transaction", tw.processor.device.Name)
+			return TransactionResult{}, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, nil)
+		}
+		devices[tw.processor.device.Name] = true
+		prepared = append(prepared, tw)
+	}
+
+	result := TransactionResult{Id: uuid.New().String()}
+	if handler, ok := executor.Transactional(container.ProtocolDriverFrom(dic.Get)); ok {
+		result.Status, result.Outcomes = executeTransactional(ctx, handler, result.Id, prepared)
+	} else {
+		result.Status, result.Outcomes = executeBestEffort(prepared)
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Info(fmt.Sprintf("transaction %s of %d writes %s", result.Id, len(prepared), result.Status), sdkCommon.CorrelationHeader, correlationID)
+	return result, nil
+}
+
+// newTransactionWrite resolves the device and the command or deviceResource of the write
+// and creates its requests and values.
+func newTransactionWrite(ctx context.Context, w TransactionWrite, correlationID string, dic *di.Container) (transactionWrite, edgexErr.EdgeX) {
+	device, ok := cache.Devices().ForNameOrAlias(w.DeviceName)
+	if !ok {
+		return transactionWrite{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, fmt.Sprintf("device %s not found", w.DeviceName), nil)
+	}
+	if device.AdminState == contract.Locked {
+		return transactionWrite{}, edgexErr.NewCommonEdgeX(edgexErr.KindServiceLocked, fmt.Sprintf("device %s locked", device.Name), nil)
+	}
+	if device.OperatingState == contract.Disabled {
+		return transactionWrite{}, edgexErr.NewCommonEdgeX(edgexErr.KindServiceLocked, fmt.Sprintf("device %s disabled", device.Name), nil)
+	}
+
+	cmdExists, err := cache.Profiles().CommandExists(device.Profile.Name, w.Command, sdkCommon.SetCmdMethod)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to identify command %s in cache", w.Command)
+		return transactionWrite{}, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, errMsg, err)
+	}
+	var dr *contract.DeviceResource
+	if !cmdExists {
+		deviceResource, ok := cache.Profiles().DeviceResource(device.Profile.Name, w.Command)
+		if !ok {
+			errMsg := fmt.Sprintf("command %s of device %s not found", w.Command, device.Name)
+			return transactionWrite{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, errMsg, nil)
+		}
+		dr = &deviceResource
+	}
+
+	params, err := json.Marshal(w.Parameters)
+	if err != nil {
+		return transactionWrite{}, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "failed to encode the parameters", err)
+	}
+	processor := NewCommandProcessor(&device, dr, correlationID, w.Command, string(params), dic)
+	processor.ctx = ctx
+	reqs, cvs, e := processor.writeRequests()
+	if e != nil {
+		return transactionWrite{}, edgexErr.NewCommonEdgeX(edgexErr.Kind(e), fmt.Sprintf("invalid write of %s to device %s", w.Command, device.Name), e)
+	}
+	return transactionWrite{processor: processor, reqs: reqs, params: cvs}, nil
+}
+
+func (w transactionWrite) outcome(status string) TransactionOutcome {
+	return TransactionOutcome{DeviceName: w.processor.device.Name, Command: w.processor.cmd, Status: status}
+}
+
+func (w transactionWrite) failure(status string, msg string, err error) TransactionOutcome {
+	o := w.outcome(status)
+	o.Code = sdkCommon.DriverErrorStatus(err)
+	o.Message = fmt.Sprintf("%s: %v", msg, err)
+	return o
+}
+
+// executeTransactional prepares the writes with the TransactionalDriver, then commits them
+// if all are prepared or aborts them otherwise. Only the preparation is given up once the
+// context is done, the commits and aborts running to completion so that no write is left
+// prepared.
+func executeTransactional(ctx context.Context, handler executor.TransactionHandler, id string, writes []transactionWrite) (string, []TransactionOutcome) {
+	outcomes := make([]TransactionOutcome, len(writes))
+	failed := false
+	for i, w := range writes {
+		if failed {
+			outcomes[i] = w.outcome(WriteSkipped)
+			continue
+		}
+		d := w.processor.device
+		if err := handler.PrepareWriteContext(ctx, id, d.Name, d.Protocols, w.reqs, w.params); err != nil {
+			outcomes[i] = w.failure(WriteFailed, "failed to prepare the write", err)
+			failed = true
+		}
+	}
+
+	if failed {
+		for i, w := range writes {
+			if outcomes[i].Status != "" {
+				continue
+			}
+			if err := handler.AbortWriteContext(context.Background(), id, w.processor.device.Name, w.reqs); err != nil {
+				outcomes[i] = w.failure(WriteAborted, "failed to abort the write", err)
+			} else {
+				outcomes[i] = w.outcome(WriteAborted)
+			}
+		}
+		return TransactionAborted, outcomes
+	}
+
+	status := TransactionCommitted
+	for i, w := range writes {
+		if err := handler.CommitWriteContext(context.Background(), id, w.processor.device.Name, w.reqs); err != nil {
+			outcomes[i] = w.failure(WriteFailed, "failed to commit the write", err)
+			status = TransactionPartial
+		} else {
+			outcomes[i] = w.outcome(WriteCommitted)
+		}
+	}
+	return status, outcomes
+}
+
+// executeBestEffort executes the writes one by one, and rolls back the ones executed with
+// the values read beforehand if one fails.
+func executeBestEffort(writes []transactionWrite) (string, []TransactionOutcome) {
+	outcomes := make([]TransactionOutcome, len(writes))
+	previous := make([][]*dsModels.CommandValue, len(writes))
+	failed := -1
+	for i, w := range writes {
+		if failed >= 0 {
+			outcomes[i] = w.outcome(WriteSkipped)
+			continue
+		}
+		// the current values are needed only if a later write fails
+		if i < len(writes)-1 {
+			if values, err := w.processor.handleReadCommands(w.reqs); err == nil && len(values) == len(w.reqs) {
+				previous[i] = values
+			}
+		}
+		if _, err := w.processor.handleWriteCommands(w.reqs, w.params); err != nil {
+			outcomes[i] = w.failure(WriteFailed, "failed to write", err)
+			failed = i
+			continue
+		}
+		outcomes[i] = w.outcome(WriteCommitted)
+	}
+	if failed < 0 {
+		return TransactionCommitted, outcomes
+	}
+
+	status := TransactionRolledBack
+	for i := failed - 1; i >= 0; i-- {
+		w := writes[i]
+		if previous[i] == nil {
+			outcomes[i].Status = WriteRollbackFailed
+			outcomes[i].Message = "the values prior to the write couldn't be read"
+			status = TransactionPartial
+			continue
+		}
+		if _, err := w.processor.handleWriteCommands(w.reqs, previous[i]); err != nil {
+			outcomes[i] = w.failure(WriteRollbackFailed, "failed to roll back the write", err)
+			status = TransactionPartial
+			continue
+		}
+		outcomes[i].Status = WriteRolledBack
+	}
+	return status, outcomes
+}
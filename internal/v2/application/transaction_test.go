@@ -0,0 +1,186 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/inflight"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+var transactionWrites = []TransactionWrite{
+	{DeviceName: "Random-Boolean-Generator01", Command: "EnableRandomization_Bool", Parameters: map[string]string{"EnableRandomization_Bool": "false"}},
+	{DeviceName: "Random-Integer-Generator01", Command: "EnableRandomization_Int8", Parameters: map[string]string{"EnableRandomization_Int8": "false"}},
+	{DeviceName: "Random-UnsignedInteger-Generator01", Command: "EnableRandomization_Uint8", Parameters: map[string]string{"EnableRandomization_Uint8": "false"}},
+}
+
+// writeDriver records the reads and writes, the values read being true and the writes to
+// the failing device failing.
+type writeDriver struct {
+	mock.DriverMock
+	steps   *steps
+	failing string
+}
+
+func (d writeDriver) HandleReadCommands(deviceName string, _ map[string]contract.ProtocolProperties, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, error) {
+	d.steps.add("read " + deviceName)
+	values := make([]*dsModels.CommandValue, len(reqs))
+	for i, req := range reqs {
+		values[i], _ = dsModels.NewBoolValue(req.DeviceResourceName, 0, true)
+	}
+	return values, nil
+}
+
+func (d writeDriver) HandleWriteCommands(deviceName string, _ map[string]contract.ProtocolProperties, _ []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	d.steps.add("write " + deviceName + " " + params[0].ValueToString())
+	if deviceName == d.failing {
+		return errors.New("write failed")
+	}
+	return nil
+}
+
+// transactionalDriver records the phases of the transactions, the preparation of the
+// writes to the failing device failing or, if blocked is set, signalling it and waiting
+// for release to be closed.
+type transactionalDriver struct {
+	writeDriver
+	blocked chan<- struct{}
+	release <-chan struct{}
+}
+
+func (d transactionalDriver) PrepareWrite(_ string, deviceName string, _ map[string]contract.ProtocolProperties, _ []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	d.steps.add("prepare " + deviceName + " " + params[0].ValueToString())
+	if d.blocked != nil && deviceName == d.failing {
+		d.blocked <- struct{}{}
+		<-d.release
+		return nil
+	}
+	if deviceName == d.failing {
+		return errors.New("prepare failed")
+	}
+	return nil
+}
+
+func (d transactionalDriver) CommitWrite(_ string, deviceName string) error {
+	d.steps.add("commit " + deviceName)
+	return nil
+}
+
+func (d transactionalDriver) AbortWrite(_ string, deviceName string) error {
+	d.steps.add("abort " + deviceName)
+	return nil
+}
+
+func statuses(outcomes []TransactionOutcome) []string {
+	result := make([]string, len(outcomes))
+	for i, o := range outcomes {
+		result[i] = o.Status
+	}
+	return result
+}
+
+func TestExecuteTransactionCommitted(t *testing.T) {
+	s := &steps{}
+	dic := newTestContainer(transactionalDriver{writeDriver: writeDriver{steps: s}}, nil)
+
+	result, err := ExecuteTransaction(context.Background(), transactionWrites, "", dic)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionCommitted, result.Status)
+	assert.Equal(t, http.StatusOK, result.StatusCode())
+	assert.Equal(t, []string{WriteCommitted, WriteCommitted, WriteCommitted}, statuses(result.Outcomes))
+	assert.Equal(t, []string{
+		"prepare Random-Boolean-Generator01 false",
+		"prepare Random-Integer-Generator01 false",
+		"prepare Random-UnsignedInteger-Generator01 false",
+		"commit Random-Boolean-Generator01",
+		"commit Random-Integer-Generator01",
+		"commit Random-UnsignedInteger-Generator01",
+	}, s.names, "the writes are committed once all are prepared")
+}
+
+func TestExecuteTransactionAborted(t *testing.T) {
+	s := &steps{}
+	dic := newTestContainer(transactionalDriver{writeDriver: writeDriver{steps: s, failing: "Random-Integer-Generator01"}}, nil)
+
+	result, err := ExecuteTransaction(context.Background(), transactionWrites, "", dic)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionAborted, result.Status)
+	assert.Equal(t, http.StatusInternalServerError, result.StatusCode())
+	assert.Equal(t, []string{WriteAborted, WriteFailed, WriteSkipped}, statuses(result.Outcomes))
+	assert.Equal(t, []string{
+		"prepare Random-Boolean-Generator01 false",
+		"prepare Random-Integer-Generator01 false",
+		"abort Random-Boolean-Generator01",
+	}, s.names, "the writes prepared are aborted and the others skipped")
+}
+
+func TestExecuteTransactionCancelled(t *testing.T) {
+	s := &steps{}
+	blocked, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	dic := newTestContainer(transactionalDriver{writeDriver: writeDriver{steps: s, failing: "Random-Integer-Generator01"}, blocked: blocked, release: release}, nil)
+
+	go func() {
+		<-blocked
+		for _, op := range inflight.Operations() {
+			if op.DeviceName == "Random-Integer-Generator01" {
+				inflight.Cancel(op.Id)
+			}
+		}
+	}()
+	result, err := ExecuteTransaction(context.Background(), transactionWrites, "", dic)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionAborted, result.Status)
+	assert.Equal(t, []string{WriteAborted, WriteFailed, WriteSkipped}, statuses(result.Outcomes))
+	assert.Contains(t, result.Outcomes[1].Message, context.Canceled.Error(), "the preparation is cancelled through the in-flight registry")
+	assert.Equal(t, []string{
+		"prepare Random-Boolean-Generator01 false",
+		"prepare Random-Integer-Generator01 false",
+		"abort Random-Boolean-Generator01",
+	}, s.names)
+}
+
+func TestExecuteTransactionRolledBack(t *testing.T) {
+	s := &steps{}
+	dic := newTestContainer(writeDriver{steps: s, failing: "Random-UnsignedInteger-Generator01"}, nil)
+
+	result, err := ExecuteTransaction(context.Background(), transactionWrites, "", dic)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionRolledBack, result.Status)
+	assert.Equal(t, []string{WriteRolledBack, WriteRolledBack, WriteFailed}, statuses(result.Outcomes))
+	assert.Equal(t, []string{
+		"read Random-Boolean-Generator01",
+		"write Random-Boolean-Generator01 false",
+		"read Random-Integer-Generator01",
+		"write Random-Integer-Generator01 false",
+		"write Random-UnsignedInteger-Generator01 false",
+		"write Random-Integer-Generator01 true",
+		"write Random-Boolean-Generator01 true",
+	}, s.names, "the writes executed are rolled back with the values read beforehand, latest first")
+}
+
+func TestExecuteTransactionInvalid(t *testing.T) {
+	s := &steps{}
+	dic := newTestContainer(writeDriver{steps: s}, nil)
+
+	_, err := ExecuteTransaction(context.Background(), nil, "", dic)
+	assert.Error(t, err, "no writes")
+	_, err = ExecuteTransaction(context.Background(), append(transactionWrites, transactionWrites[0]), "", dic)
+	assert.Error(t, err, "device written twice")
+	_, err = ExecuteTransaction(context.Background(), []TransactionWrite{transactionWrites[0], {DeviceName: "unknown", Command: "Foo"}}, "", dic)
+	assert.Error(t, err, "unknown device")
+	assert.Empty(t, s.names, "nothing is written unless all the writes are valid")
+}
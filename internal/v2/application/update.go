@@ -0,0 +1,91 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/update"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// StartDeviceUpdate starts the update of the named Devices and of the Devices having
+// any of the labels, returning the jobs tracking them and the errors of the Devices
+// which couldn't be updated.
+func StartDeviceUpdate(deviceNames []string, labels []string, u dsModels.DeviceUpdate, dic *di.Container) ([]update.Job, []sdkCommon.ItemError, edgexErr.EdgeX) {
+	updater, ok := container.RawProtocolDriverFrom(dic.Get).(dsModels.DeviceUpdater)
+	if !ok {
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindNotImplemented, "the ProtocolDriver doesn't support device updates", nil)
+	}
+	if u.Kind != dsModels.UpdateKindFirmware && u.Kind != dsModels.UpdateKindConfig {
+		errMsg := fmt.Sprintf("invalid update kind %s, should be '%s' or '%s'", u.Kind, dsModels.UpdateKindFirmware, dsModels.UpdateKindConfig)
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, nil)
+	}
+
+	var devices []contract.Device
+	var itemErrs []sdkCommon.ItemError
+	selected := make(map[string]bool)
+	for _, name := range deviceNames {
+		device, ok := cache.Devices().ForName(name)
+		if !ok {
+			err := edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, fmt.Sprintf("Device %s not found", name), nil)
+			itemErrs = append(itemErrs, sdkCommon.NewItemError(name, err))
+			continue
+		}
+		if !selected[device.Name] {
+			selected[device.Name] = true
+			devices = append(devices, device)
+		}
+	}
+	if len(labels) > 0 {
+		for _, device := range cache.Devices().All() {
+			if !selected[device.Name] && hasAnyLabel(device, labels) {
+				selected[device.Name] = true
+				devices = append(devices, device)
+			}
+		}
+	}
+	if len(devices) == 0 && len(itemErrs) == 0 {
+		return nil, nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "no Device selected for the update", nil)
+	}
+	unlocked := make([]contract.Device, 0, len(devices))
+	for _, device := range devices {
+		if device.AdminState == contract.Locked {
+			err := edgexErr.NewCommonEdgeX(edgexErr.KindServiceLocked, fmt.Sprintf("Device %s locked", device.Name), nil)
+			itemErrs = append(itemErrs, sdkCommon.NewItemError(device.Name, err))
+			continue
+		}
+		unlocked = append(unlocked, device)
+	}
+
+	jobs, errs := update.Start(unlocked, u, updater, bootstrapContainer.LoggingClientFrom(dic.Get))
+	for _, device := range unlocked {
+		if err, ok := errs[device.Name]; ok {
+			itemErrs = append(itemErrs, sdkCommon.NewItemError(device.Name, edgexErr.NewCommonEdgeX(edgexErr.KindNotAllowed, "failed to start the update", err)))
+		}
+	}
+	return jobs, itemErrs, nil
+}
+
+func hasAnyLabel(device contract.Device, labels []string) bool {
+	for _, l := range device.Labels {
+		for _, label := range labels {
+			if l == label {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"testing"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/mock"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/update"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+type updaterDriver struct {
+	mock.DriverMock
+	started chan string
+}
+
+func (d updaterDriver) StartUpdate(deviceName string, _ map[string]contract.ProtocolProperties, _ dsModels.DeviceUpdate, progress dsModels.UpdateProgress) error {
+	progress(100, "applied")
+	d.started <- deviceName
+	return nil
+}
+
+func (d updaterDriver) VerifyUpdate(string, map[string]contract.ProtocolProperties, dsModels.DeviceUpdate) error {
+	return nil
+}
+
+func (d updaterDriver) RollbackUpdate(string, map[string]contract.ProtocolProperties, dsModels.DeviceUpdate) error {
+	return nil
+}
+
+func TestStartDeviceUpdate(t *testing.T) {
+	driver := updaterDriver{started: make(chan string, 1)}
+	dic := newTestContainer(driver, nil)
+
+	jobs, itemErrs, err := StartDeviceUpdate([]string{testDeviceName}, nil, dsModels.DeviceUpdate{Kind: dsModels.UpdateKindFirmware, Version: "2.0"}, dic)
+	require.NoError(t, err)
+	assert.Empty(t, itemErrs)
+	require.Len(t, jobs, 1)
+
+	select {
+	case name := <-driver.started:
+		assert.Equal(t, testDeviceName, name)
+	case <-time.After(time.Second):
+		t.Fatal("the update wasn't started by the DeviceUpdater")
+	}
+	assert.Eventually(t, func() bool {
+		job, ok := update.Get(jobs[0].Id)
+		return ok && job.State == update.StateCompleted
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStartDeviceUpdateNotImplemented(t *testing.T) {
+	dic := newTestContainer(&mock.DriverMock{}, nil)
+
+	_, _, err := StartDeviceUpdate([]string{testDeviceName}, nil, dsModels.DeviceUpdate{Kind: dsModels.UpdateKindFirmware}, dic)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't support device updates")
+}
@@ -40,6 +40,9 @@ func (p *provisionWatcherCache) ForName(name string) (models.ProvisionWatcher, b
 	defer p.mutex.Unlock()
 
 	watcher, ok := p.pwMap[name]
+	if !ok {
+		return models.ProvisionWatcher{}, ok
+	}
 	return *watcher, ok
 }
 
@@ -54,6 +57,9 @@ func (p *provisionWatcherCache) ForId(id string) (models.ProvisionWatcher, bool)
 	}
 
 	watcher, ok := p.pwMap[name]
+	if !ok {
+		return models.ProvisionWatcher{}, ok
+	}
 	return *watcher, ok
 }
 
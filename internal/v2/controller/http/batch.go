@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+type batchCommandRequest struct {
+	common.BaseRequest `json:",inline"`
+	// Method is 'get' or 'set'.
+	Method     string            `json:"method"`
+	Devices    []string          `json:"devices,omitempty"`
+	Labels     []string          `json:"labels,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+type batchCommandResponse struct {
+	common.BaseResponse `json:",inline"`
+	Results             []application.BatchCommandResult `json:"results,omitempty"`
+	batchErrors         `json:",inline"`
+}
+
+// BatchCommand handles the request to execute a GET or SET command on many Devices at once.
+// The Devices whose command failed are reported individually, with 207 Multi-Status if the
+// command succeeded on others.
+func (c *V2HttpController) BatchCommand(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	var batchRequest batchCommandRequest
+	err := json.NewDecoder(request.Body).Decode(&batchRequest)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2BatchCommandRoute)
+		return
+	}
+
+	var isRead bool
+	var body string
+	switch strings.ToLower(batchRequest.Method) {
+	case sdkCommon.GetCmdMethod:
+		isRead = true
+	case sdkCommon.SetCmdMethod:
+		params, err := json.Marshal(batchRequest.Parameters)
+		if err != nil {
+			edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode the parameters", err)
+			c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2BatchCommandRoute)
+			return
+		}
+		body = string(params)
+	default:
+		errMsg := fmt.Sprintf("invalid method %s, should be '%s' or '%s'", batchRequest.Method, sdkCommon.GetCmdMethod, sdkCommon.SetCmdMethod)
+		c.sendEdgexError(writer, request, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil), sdkCommon.APIV2BatchCommandRoute)
+		return
+	}
+
+	httpMethod := http.MethodPut
+	if isRead {
+		httpMethod = http.MethodGet
+	}
+	if maintenance.CommandRejected(httpMethod) {
+		edgexErr := errors.NewCommonEdgeX(errors.KindServiceUnavailable, "service in maintenance mode", nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2BatchCommandRoute)
+		return
+	}
+
+	cmd := mux.Vars(request)[sdkCommon.CommandVar]
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+	results, itemErrs, edgexErr := application.BatchCommand(request.Context(), isRead, batchRequest.Devices, batchRequest.Labels, cmd, body, correlationID, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2BatchCommandRoute)
+		return
+	}
+
+	statusCode := sdkCommon.BatchStatusCode(len(results), itemErrs, http.StatusOK)
+	res := batchCommandResponse{
+		BaseResponse: common.NewBaseResponse(batchRequest.RequestId, batchMessage(itemErrs), statusCode),
+		Results:      results,
+		batchErrors:  batchErrors{Errors: itemErrs},
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2BatchCommandRoute, res, statusCode)
+}
@@ -0,0 +1,110 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/calibration"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+type calibrationRequest struct {
+	common.BaseRequest `json:",inline"`
+	calibration.Coefficients
+}
+
+type calibrationResponse struct {
+	common.BaseResponse `json:",inline"`
+	Calibration         calibration.Calibration   `json:"calibration"`
+	History             []calibration.Calibration `json:"history,omitempty"`
+}
+
+type calibrationsResponse struct {
+	common.BaseResponse `json:",inline"`
+	Calibrations        []calibration.Calibration `json:"calibrations"`
+}
+
+// CalibrationsByName handles the request to get the current calibrations of a Device.
+func (c *V2HttpController) CalibrationsByName(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+
+	res := calibrationsResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Calibrations: calibration.ForDevice(name),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2CalibrationByNameRoute, res, http.StatusOK)
+}
+
+// CalibrationByResource handles the request to get the calibration of a Device's
+// deviceResource along with its prior versions.
+func (c *V2HttpController) CalibrationByResource(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	name, resource := vars[v2.Name], vars[v2.ResourceName]
+
+	current, ok := calibration.Get(name, resource)
+	if !ok {
+		edgexErr := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("no calibration of Device %s deviceResource %s", name, resource), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CalibrationByResourceRoute)
+		return
+	}
+
+	res := calibrationResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Calibration:  current,
+		History:      calibration.History(name, resource),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2CalibrationByResourceRoute, res, http.StatusOK)
+}
+
+// SetCalibration handles the request to recalibrate a Device's deviceResource.
+func (c *V2HttpController) SetCalibration(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+	vars := mux.Vars(request)
+
+	var calibrationReq calibrationRequest
+	err := json.NewDecoder(request.Body).Decode(&calibrationReq)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CalibrationByResourceRoute)
+		return
+	}
+
+	calibrated, edgexErr := application.SetCalibration(vars[v2.Name], vars[v2.ResourceName], calibrationReq.Coefficients, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CalibrationByResourceRoute)
+		return
+	}
+
+	res := calibrationResponse{
+		BaseResponse: common.NewBaseResponse(calibrationReq.RequestId, "", http.StatusOK),
+		Calibration:  calibrated,
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2CalibrationByResourceRoute, res, http.StatusOK)
+}
+
+// RemoveCalibration handles the request to remove the calibration of a Device's deviceResource.
+func (c *V2HttpController) RemoveCalibration(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+
+	edgexErr := application.RemoveCalibration(vars[v2.Name], vars[v2.ResourceName], c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CalibrationByResourceRoute)
+		return
+	}
+
+	res := common.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2CalibrationByResourceRoute, res, http.StatusOK)
+}
@@ -121,7 +121,7 @@ func (c *V2HttpController) AddProvisionWatcher(writer http.ResponseWriter, reque
 		return
 	}
 
-	edgexErr := application.AddProvisionWatcher(addProvisionWatcherRequest, c.lc)
+	edgexErr := application.AddProvisionWatcher(addProvisionWatcherRequest, c.dic)
 	if edgexErr == nil {
 		res := common.NewBaseResponse(addProvisionWatcherRequest.RequestId, "", http.StatusOK)
 		c.sendResponse(writer, request, v2.ApiWatcherCallbackRoute, res, http.StatusOK)
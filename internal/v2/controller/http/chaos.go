@@ -0,0 +1,87 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/chaos"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+)
+
+type faultRequest struct {
+	common.BaseRequest `json:",inline"`
+	Point              string `json:"point"`
+	chaos.Fault        `json:",inline"`
+}
+
+type faultsResponse struct {
+	common.BaseResponse `json:",inline"`
+	// Faults are the injected faults keyed by point.
+	Faults map[string]chaos.Fault `json:"faults"`
+}
+
+// Faults handles the request to get the faults injected for resilience testing.
+func (c *V2HttpController) Faults(writer http.ResponseWriter, request *http.Request) {
+	if !c.checkFailureInjection(writer, request) {
+		return
+	}
+	res := faultsResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Faults:       chaos.Faults(),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2ChaosRoute, res, http.StatusOK)
+}
+
+// SetFault handles the request to inject a fault at a point, replacing its previous fault.
+func (c *V2HttpController) SetFault(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+	if !c.checkFailureInjection(writer, request) {
+		return
+	}
+
+	var faultReq faultRequest
+	err := json.NewDecoder(request.Body).Decode(&faultReq)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2ChaosRoute)
+		return
+	}
+	if err = chaos.SetFault(faultReq.Point, faultReq.Fault); err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid fault", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2ChaosRoute)
+		return
+	}
+	c.lc.Warn(fmt.Sprintf("injecting fault at %s: latency %s, drop rate %v, error rate %v", faultReq.Point, faultReq.Latency, faultReq.DropRate, faultReq.ErrorRate))
+
+	res := common.NewBaseResponse(faultReq.RequestId, "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2ChaosRoute, res, http.StatusOK)
+}
+
+// ClearFaults handles the request to stop injecting the faults.
+func (c *V2HttpController) ClearFaults(writer http.ResponseWriter, request *http.Request) {
+	if !c.checkFailureInjection(writer, request) {
+		return
+	}
+	chaos.ClearFaults()
+	c.lc.Info("injected faults cleared")
+	c.sendResponse(writer, request, sdkCommon.APIV2ChaosRoute, common.NewBaseResponse("", "", http.StatusOK), http.StatusOK)
+}
+
+func (c *V2HttpController) checkFailureInjection(writer http.ResponseWriter, request *http.Request) bool {
+	if chaos.Enabled() {
+		return true
+	}
+	edgexErr := errors.NewCommonEdgeX(errors.KindServiceUnavailable, "failure injection disabled by configuration", nil)
+	c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2ChaosRoute)
+	return false
+}
@@ -7,21 +7,30 @@
 package http
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
 	"github.com/gorilla/mux"
 
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
 )
 
 const SDKPostEventReserved = "ds-pushevent"
 const SDKReturnEventReserved = "ds-returnevent"
+const SDKWaitConfirmReserved = "ds-waitconfirm"
+const SDKAllowStaleReserved = "ds-allowstale"
+const SDKTimeoutReserved = "ds-timeout"
 const QueryParameterValueYes = "yes"
 const QueryParameterValueNo = "no"
 
@@ -30,14 +39,24 @@ func (c *V2HttpController) Command(writer http.ResponseWriter, request *http.Req
 
 	var body string
 	var sendEvent bool
+	var waitConfirm = true
 	var err edgexErr.EdgeX
 	var reserved url.Values
 	vars := mux.Vars(request)
 	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
 
+	if maintenance.CommandRejected(request.Method) {
+		err = edgexErr.NewCommonEdgeX(edgexErr.KindServiceUnavailable, "service in maintenance mode", nil)
+		c.sendEdgexError(writer, request, err, v2.ApiDeviceNameCommandNameRoute)
+		return
+	}
+
 	// read request body for PUT command, or parse query parameters for GET command.
 	if request.Method == http.MethodPut {
 		body, err = readBodyAsString(request)
+		if err == nil {
+			_, reserved, err = filterQueryParams(request.URL.RawQuery)
+		}
 	} else if request.Method == http.MethodGet {
 		body, reserved, err = filterQueryParams(request.URL.RawQuery)
 	}
@@ -50,16 +69,80 @@ func (c *V2HttpController) Command(writer http.ResponseWriter, request *http.Req
 	if ok, exist := reserved[SDKPostEventReserved]; exist && ok[0] == QueryParameterValueYes {
 		sendEvent = true
 	}
+	// wait for asynchronous write confirmation if required by the deviceResource (default yes)
+	if ok, exist := reserved[SDKWaitConfirmReserved]; exist && ok[0] == QueryParameterValueNo {
+		waitConfirm = false
+	}
+	// serve the read from the last known values no older than the duration if the device is busy or down
+	var allowStale time.Duration
+	if value, exist := reserved[SDKAllowStaleReserved]; exist {
+		d, parseErr := time.ParseDuration(value[0])
+		if parseErr != nil {
+			err = edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, fmt.Sprintf("invalid %s duration %s", SDKAllowStaleReserved, value[0]), parseErr)
+			c.sendEdgexError(writer, request, err, v2.ApiDeviceNameCommandNameRoute)
+			return
+		}
+		allowStale = d
+	}
+	// shape the response of read commands if the client selects fields or the compact format
+	shape, err := parseResponseShape(reserved)
+	if err != nil {
+		c.sendEdgexError(writer, request, err, v2.ApiDeviceNameCommandNameRoute)
+		return
+	}
+	// abort the command once the client disconnects or the timeout, if any, expires
+	ctx := request.Context()
+	if value, exist := reserved[SDKTimeoutReserved]; exist {
+		d, parseErr := time.ParseDuration(value[0])
+		if parseErr != nil || d <= 0 {
+			err = edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, fmt.Sprintf("invalid %s duration %s", SDKTimeoutReserved, value[0]), parseErr)
+			c.sendEdgexError(writer, request, err, v2.ApiDeviceNameCommandNameRoute)
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
 	isRead := request.Method == http.MethodGet
-	event, edgexErr := application.CommandHandler(isRead, sendEvent, correlationID, vars, body, c.dic)
-	if edgexErr != nil {
-		c.sendEdgexError(writer, request, edgexErr, v2.ApiDeviceNameCommandNameRoute)
+	event, err := application.CommandHandler(ctx, isRead, sendEvent, waitConfirm, allowStale, correlationID, vars, body, c.dic)
+	if err != nil {
+		c.sendEdgexError(writer, request, err, v2.ApiDeviceNameCommandNameRoute)
+		return
+	}
+	if event.Event.Tags[application.TagStale] == "true" {
+		writer.Header().Set("Warning", `110 - "Response is Stale"`)
+	} else if isRead {
+		if age, ok := application.CachedAge(event.Event); ok {
+			writer.Header().Set("Age", strconv.FormatInt(int64(age.Seconds()), 10))
+		}
+		if cacheControl := application.EventCacheControl(event.Event).Header(); cacheControl != "" {
+			writer.Header().Set(sdkCommon.CacheControlHeader, cacheControl)
+		}
+	}
+
+	// the write has been accepted but its confirmation, if any, is still outstanding
+	if !isRead && !waitConfirm {
+		response := common.NewBaseResponse("", "", http.StatusAccepted)
+		c.sendResponse(writer, request, v2.ApiDeviceNameCommandNameRoute, response, http.StatusAccepted)
 		return
 	}
 
 	// return event in http response if specified (default yes)
 	if ok, exist := reserved[SDKReturnEventReserved]; !exist || ok[0] == QueryParameterValueYes {
-		// TODO: the usage of CBOR encoding for binary reading is under discussion
+		if shape != nil {
+			shaped, shapeErr := shape.apply(event.Event)
+			if shapeErr != nil {
+				err = edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to shape the command response", shapeErr)
+				c.sendEdgexError(writer, request, err, v2.ApiDeviceNameCommandNameRoute)
+				return
+			}
+			c.sendResponse(writer, request, v2.ApiDeviceNameCommandNameRoute, shaped, http.StatusOK)
+			return
+		}
+		if sdkCommon.PreferCBOR(request.Header.Get(sdkCommon.AcceptHeader), application.HasBinaryReading(event.Event)) {
+			c.sendCBORResponse(writer, request, v2.ApiDeviceNameCommandNameRoute, event, http.StatusOK)
+			return
+		}
 		c.sendResponse(writer, request, v2.ApiDeviceNameCommandNameRoute, event, http.StatusOK)
 	}
 }
@@ -0,0 +1,50 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+type deviceCommandsResponse struct {
+	common.BaseResponse `json:",inline"`
+	DeviceName          string                    `json:"deviceName"`
+	Commands            []application.CommandInfo `json:"commands"`
+}
+
+// DeviceCommands handles the request to list the commands of a Device. As the route is
+// also the one of a command named "commands", the request is handled as a read command
+// if the Device has such a command.
+func (c *V2HttpController) DeviceCommands(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	name := vars[v2.Name]
+	if application.HasCommand(name, sdkCommon.DeviceCommandsName) {
+		vars[v2.Command] = sdkCommon.DeviceCommandsName
+		c.Command(writer, mux.SetURLVars(request, vars))
+		return
+	}
+
+	commands, edgexErr := application.DeviceCommands(name, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceCommandsRoute)
+		return
+	}
+
+	res := deviceCommandsResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		DeviceName:   name,
+		Commands:     commands,
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceCommandsRoute, res, http.StatusOK)
+}
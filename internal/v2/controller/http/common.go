@@ -8,27 +8,134 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/asyncqueue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/breaker"
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/health"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 
 	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
 )
 
+type pingResponse struct {
+	common.PingResponse `json:",inline"`
+	Maintenance         bool `json:"maintenance,omitempty"`
+}
+
 // Ping handles the request to /ping endpoint. Is used to test if the service is working
-// It returns a response as specified by the V2 API swagger in openapi/v2
+// It returns a response as specified by the V2 API swagger in openapi/v2, flagging the
+// maintenance mode.
 func (c *V2HttpController) Ping(writer http.ResponseWriter, request *http.Request) {
-	response := common.NewPingResponse()
+	response := pingResponse{
+		PingResponse: common.NewPingResponse(),
+		Maintenance:  maintenance.ServiceMode(),
+	}
 	c.sendResponse(writer, request, contractsV2.ApiPingRoute, response, http.StatusOK)
 }
 
+type probeResponse struct {
+	common.BaseResponse `json:",inline"`
+	Checks              map[string]string `json:"checks,omitempty"`
+}
+
+// Ready handles the request to /ready endpoint. It performs the readiness checks configured
+// by Service.ReadinessChecks and responds 503 Service Unavailable unless all of them pass.
+func (c *V2HttpController) Ready(writer http.ResponseWriter, request *http.Request) {
+	checks, ready := health.Ready(container.ConfigurationFrom(c.dic.Get))
+	statusCode := http.StatusOK
+	if !ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	response := probeResponse{
+		BaseResponse: common.NewBaseResponse("", "", statusCode),
+		Checks:       checks,
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2ReadyRoute, response, statusCode)
+}
+
+// Live handles the request to /live endpoint. It responds 503 Service Unavailable once
+// the service is shutting down.
+func (c *V2HttpController) Live(writer http.ResponseWriter, request *http.Request) {
+	statusCode := http.StatusOK
+	if !health.Live() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	response := common.NewBaseResponse("", "", statusCode)
+	c.sendResponse(writer, request, sdkCommon.APIV2LiveRoute, response, statusCode)
+}
+
+type statusResponse struct {
+	common.BaseResponse `json:",inline"`
+	Startup             health.StartupStatus `json:"startup"`
+	AsyncQueue          *asyncqueue.Metrics  `json:"asyncQueue,omitempty"`
+	Maintenance         bool                 `json:"maintenance"`
+	// OriginSkew is the skew of the reading origins set by the ProtocolDriver, keyed by Device name.
+	OriginSkew map[string]sdkCommon.OriginSkew `json:"originSkew,omitempty"`
+	// Breakers is the state of the circuit breakers of the core service clients, keyed by
+	// client name.
+	Breakers map[string]breaker.Status `json:"breakers,omitempty"`
+}
+
+type capabilitiesResponse struct {
+	common.BaseResponse `json:",inline"`
+	Capabilities        dsModels.Capabilities `json:"capabilities"`
+}
+
+// batchErrors reports the failed items in the responses of the batch operations.
+type batchErrors struct {
+	Errors []sdkCommon.ItemError `json:"errors,omitempty"`
+}
+
+// batchMessage summarizes the failed items of a batch operation for the response message.
+func batchMessage(errs []sdkCommon.ItemError) string {
+	if len(errs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d item(s) failed", len(errs))
+}
+
+// Status handles the request to /status endpoint. It reports the progress of the startup phases,
+// the asynchronous readings queue, the maintenance mode and the skew of the reading origins.
+func (c *V2HttpController) Status(writer http.ResponseWriter, request *http.Request) {
+	response := statusResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Startup:      health.Status(),
+		Maintenance:  maintenance.ServiceMode(),
+		OriginSkew:   sdkCommon.OriginSkews(),
+		Breakers:     breaker.All(),
+	}
+	if queue := asyncqueue.GetQueue(); queue != nil {
+		metrics := queue.Metrics()
+		response.AsyncQueue = &metrics
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2StatusRoute, response, http.StatusOK)
+}
+
+// Capabilities handles the request to /capabilities endpoint. Is used to request the
+// features supported by the ProtocolDriver.
+func (c *V2HttpController) Capabilities(writer http.ResponseWriter, request *http.Request) {
+	response := capabilitiesResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Capabilities: sdkCommon.DriverCapabilities(),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2CapabilitiesRoute, response, http.StatusOK)
+}
+
 // Version handles the request to /version endpoint. Is used to request the service's versions
 // It returns a response as specified by the V2 API swagger in openapi/v2
 func (c *V2HttpController) Version(writer http.ResponseWriter, request *http.Request) {
@@ -61,6 +168,16 @@ func (c *V2HttpController) Metrics(writer http.ResponseWriter, request *http.Req
 	c.sendResponse(writer, request, contractsV2.ApiMetricsRoute, response, http.StatusOK)
 }
 
+// PrometheusMetrics handles the request to the /metrics endpoint, exposing the metrics of
+// the commands, the asynchronous readings, the published events, the core service clients
+// and the caches in the Prometheus text format.
+func (c *V2HttpController) PrometheusMetrics(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set(clients.ContentType, metrics.ContentType)
+	if err := metrics.Write(writer); err != nil {
+		c.lc.Error("Unable to write the metrics", "error", err.Error(), sdkCommon.CorrelationHeader, request.Header.Get(sdkCommon.CorrelationHeader))
+	}
+}
+
 // Secret handles the request to add Device Service exclusive secret to the Secret Store
 // It returns a response as specified by the V2 API swagger in openapi/v2
 func (c *V2HttpController) Secret(writer http.ResponseWriter, request *http.Request) {
@@ -44,6 +44,7 @@ import (
 
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/health"
 )
 
 var expectedCorrelationId = uuid.New().String()
@@ -163,6 +164,43 @@ func TestConfigRequest(t *testing.T) {
 	assert.Equal(t, expectedConfig, actualConfig)
 }
 
+func TestReadyRequest(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &sdkCommon.ConfigurationStruct{
+				Service: sdkCommon.ServiceInfo{ReadinessChecks: []string{health.CheckCache, health.CheckDriver}},
+			}
+		},
+	})
+	target := NewV2HttpController(dic)
+
+	lc := logger.NewMockClient()
+	health.StartupBegan()
+	health.CompletePhase(health.PhaseCacheLoaded, lc)
+	req, err := http.NewRequest(http.MethodGet, sdkCommon.APIV2ReadyRoute, nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(target.Ready).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	actual := probeResponse{}
+	err = json.Unmarshal(recorder.Body.Bytes(), &actual)
+	require.NoError(t, err)
+	assert.Equal(t, health.StatusOK, actual.Checks[health.CheckCache])
+	assert.NotEqual(t, health.StatusOK, actual.Checks[health.CheckDriver])
+
+	health.CompletePhase(health.PhaseDriverInitialized, lc)
+	recorder = doRequest(t, http.MethodGet, sdkCommon.APIV2ReadyRoute, target.Ready, nil)
+	actual = probeResponse{}
+	err = json.Unmarshal(recorder.Body.Bytes(), &actual)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, actual.StatusCode)
+	assert.Len(t, actual.Checks, 2)
+}
+
 func TestSecretRequest(t *testing.T) {
 	expectedRequestId := "82eb2e26-0f24-48aa-ae4c-de9dac3fb9bc"
 	config := &sdkCommon.ConfigurationStruct{}
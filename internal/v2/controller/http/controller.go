@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
@@ -20,6 +21,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/fxamacker/cbor/v2"
 )
 
 // V2HttpController controller for V2 REST APIs
@@ -68,6 +70,30 @@ func (c *V2HttpController) sendResponse(
 	}
 }
 
+// sendCBORResponse puts the response encoded in CBOR into the http.ResponseWriter.
+func (c *V2HttpController) sendCBORResponse(
+	writer http.ResponseWriter,
+	request *http.Request,
+	api string,
+	response interface{},
+	statusCode int) {
+
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+	data, err := cbor.Marshal(response)
+	if err != nil {
+		c.lc.Error(fmt.Sprintf("Unable to encode %s response in CBOR", api), "error", err.Error(), clients.CorrelationHeader, correlationID)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set(sdkCommon.CorrelationHeader, correlationID)
+	writer.Header().Set(clients.ContentType, clients.ContentTypeCBOR)
+	writer.WriteHeader(statusCode)
+	if _, err = writer.Write(data); err != nil {
+		c.lc.Error(fmt.Sprintf("Unable to write %s response", api), "error", err.Error(), clients.CorrelationHeader, correlationID)
+	}
+}
+
 func (c *V2HttpController) sendEdgexError(
 	writer http.ResponseWriter,
 	request *http.Request,
@@ -77,5 +103,8 @@ func (c *V2HttpController) sendEdgexError(
 	c.lc.Error(err.Error(), sdkCommon.CorrelationHeader, correlationID)
 	c.lc.Debug(err.DebugMessages(), sdkCommon.CorrelationHeader, correlationID)
 	response := common.NewBaseResponse("", err.Error(), err.Code())
+	if sdkCommon.Throttled(err) {
+		writer.Header().Set(sdkCommon.RetryAfterHeader, sdkCommon.RetryAfter(container.ConfigurationFrom(c.dic.Get).Writable.CommandRetryAfter))
+	}
 	c.sendResponse(writer, request, api, response, err.Code())
 }
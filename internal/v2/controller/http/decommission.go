@@ -0,0 +1,53 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+type decommissionRequest struct {
+	common.BaseRequest `json:",inline"`
+	Reason             string `json:"reason,omitempty"`
+}
+
+// DecommissionDevice handles the request to decommission a Device. The body, with the
+// reason of the decommissioning, is optional.
+func (c *V2HttpController) DecommissionDevice(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	name := mux.Vars(request)[v2.Name]
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+
+	var decommission decommissionRequest
+	if request.ContentLength != 0 {
+		err := json.NewDecoder(request.Body).Decode(&decommission)
+		if err != nil {
+			edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+			c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceDecommissionRoute)
+			return
+		}
+	}
+
+	edgexErr := application.DecommissionDevice(name, decommission.Reason, correlationID, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceDecommissionRoute)
+		return
+	}
+
+	res := common.NewBaseResponse(decommission.RequestId, "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceDecommissionRoute, res, http.StatusOK)
+}
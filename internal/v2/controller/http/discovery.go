@@ -10,12 +10,20 @@ import (
 	"net/http"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/autodiscovery"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
 	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
 )
 
+type discoveryStatusResponse struct {
+	common.BaseResponse `json:",inline"`
+	autodiscovery.Status
+}
+
 func (c *V2HttpController) Discovery(writer http.ResponseWriter, request *http.Request) {
 	ds := container.DeviceServiceFrom(c.dic.Get)
 	if ds.AdminState == contract.Locked {
@@ -30,9 +38,14 @@ func (c *V2HttpController) Discovery(writer http.ResponseWriter, request *http.R
 		c.sendEdgexError(writer, request, err, v2.ApiDiscoveryRoute)
 		return
 	}
+	if maintenance.ServiceMode() {
+		err := edgexErr.NewCommonEdgeX(edgexErr.KindServiceUnavailable, "device discovery paused in maintenance mode", nil)
+		c.sendEdgexError(writer, request, err, v2.ApiDiscoveryRoute)
+		return
+	}
 
 	discovery := container.ProtocolDiscoveryFrom(c.dic.Get)
-	if discovery == nil {
+	if discovery == nil && !autodiscovery.HasProviders() {
 		err := edgexErr.NewCommonEdgeX(edgexErr.KindNotImplemented, "protocolDiscovery not implemented", nil)
 		c.sendEdgexError(writer, request, err, v2.ApiDiscoveryRoute)
 		return
@@ -41,3 +54,31 @@ func (c *V2HttpController) Discovery(writer http.ResponseWriter, request *http.R
 	go autodiscovery.DiscoveryWrapper(discovery, c.lc)
 	c.sendResponse(writer, request, v2.ApiDiscoveryRoute, nil, http.StatusAccepted)
 }
+
+// StopDiscovery handles the request to stop the running device discovery.
+func (c *V2HttpController) StopDiscovery(writer http.ResponseWriter, request *http.Request) {
+	switch err := autodiscovery.Stop(); err {
+	case nil:
+	case autodiscovery.ErrNotRunning:
+		c.sendEdgexError(writer, request, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, err.Error(), nil), sdkCommon.APIV2DiscoveryStopRoute)
+		return
+	case autodiscovery.ErrNotStoppable:
+		c.sendEdgexError(writer, request, edgexErr.NewCommonEdgeX(edgexErr.KindNotImplemented, err.Error(), nil), sdkCommon.APIV2DiscoveryStopRoute)
+		return
+	default:
+		c.sendEdgexError(writer, request, edgexErr.NewCommonEdgeX(edgexErr.KindServerError, "failed to stop device discovery", err), sdkCommon.APIV2DiscoveryStopRoute)
+		return
+	}
+	c.lc.Info("device discovery stopped")
+	c.sendResponse(writer, request, sdkCommon.APIV2DiscoveryStopRoute, nil, http.StatusAccepted)
+}
+
+// DiscoveryStatus handles the request to get the status of the device discovery,
+// including the result of the last scan.
+func (c *V2HttpController) DiscoveryStatus(writer http.ResponseWriter, request *http.Request) {
+	res := discoveryStatusResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Status:       autodiscovery.GetStatus(),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2DiscoveryStatusRoute, res, http.StatusOK)
+}
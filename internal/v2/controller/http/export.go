@@ -0,0 +1,120 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v2"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+const (
+	// BundleIdHeader carries the id of the exported bundle, used to mark it as exported.
+	BundleIdHeader = "X-Bundle-Id"
+	// BundleSignatureHeader carries the signature of the exported bundle data.
+	BundleSignatureHeader = "X-Bundle-Signature"
+
+	// FormatQueryParam selects the encoding of the exported Devices, JSON or YAML.
+	FormatQueryParam = "format"
+	// EmbedProfilesQueryParam embeds the Device Profiles in the exported Devices.
+	EmbedProfilesQueryParam = "embedProfiles"
+
+	formatJSON      = "json"
+	formatYAML      = "yaml"
+	contentTypeGzip = "application/gzip"
+	contentTypeYAML = "application/x-yaml"
+)
+
+// ExportDevices handles the request to export the definition of the Devices owned by
+// the Device Service, so that they can be imported by another Device Service.
+func (c *V2HttpController) ExportDevices(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	format := strings.ToLower(query.Get(FormatQueryParam))
+	if format == "" {
+		format = formatJSON
+	}
+	if format != formatJSON && format != formatYAML {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported export format %s", format), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceExportRoute)
+		return
+	}
+	var embedProfiles bool
+	if v := query.Get(EmbedProfilesQueryParam); v != "" {
+		var err error
+		embedProfiles, err = strconv.ParseBool(v)
+		if err != nil {
+			edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s query parameter", EmbedProfilesQueryParam), err)
+			c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceExportRoute)
+			return
+		}
+	}
+
+	devicesExport := application.ExportDevices(embedProfiles, c.dic)
+	if format == formatJSON {
+		c.sendResponse(writer, request, sdkCommon.APIV2DeviceExportRoute, devicesExport, http.StatusOK)
+		return
+	}
+
+	data, err := yaml.Marshal(devicesExport)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindServerError, "failed to encode the exported devices", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceExportRoute)
+		return
+	}
+	writer.Header().Set(sdkCommon.CorrelationHeader, request.Header.Get(sdkCommon.CorrelationHeader))
+	writer.Header().Set(clients.ContentType, contentTypeYAML)
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write(data); err != nil {
+		c.lc.Error(fmt.Sprintf("Unable to write %s response", sdkCommon.APIV2DeviceExportRoute), "error", err.Error())
+	}
+}
+
+// ExportEvents handles the request to export the retained events as a compressed bundle.
+// The bundle id and signature are returned in the BundleIdHeader and BundleSignatureHeader.
+func (c *V2HttpController) ExportEvents(writer http.ResponseWriter, request *http.Request) {
+	id, data, signature, edgexErr := application.ExportEvents(c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2EventExportRoute)
+		return
+	}
+
+	writer.Header().Set(sdkCommon.CorrelationHeader, request.Header.Get(sdkCommon.CorrelationHeader))
+	writer.Header().Set(clients.ContentType, contentTypeGzip)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.json.gz\"", id))
+	writer.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	writer.Header().Set(BundleIdHeader, id)
+	writer.Header().Set(BundleSignatureHeader, signature)
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write(data); err != nil {
+		c.lc.Error(fmt.Sprintf("Unable to write %s response", sdkCommon.APIV2EventExportRoute), "error", err.Error())
+	}
+}
+
+// MarkEventsExported handles the request to mark the events of a bundle as exported.
+func (c *V2HttpController) MarkEventsExported(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)[v2.Id]
+
+	edgexErr := application.MarkEventsExported(id, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2EventExportByIdRoute)
+		return
+	}
+
+	res := common.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2EventExportByIdRoute, res, http.StatusOK)
+}
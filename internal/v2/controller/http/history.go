@@ -0,0 +1,89 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/history"
+)
+
+// The query parameters filtering the command history.
+const (
+	HistoryDeviceQueryParam        = "device"
+	HistoryCommandQueryParam       = "command"
+	HistoryMethodQueryParam        = "method"
+	HistoryStatusQueryParam        = "status"
+	HistoryCorrelationIdQueryParam = "correlationId"
+	// HistorySinceQueryParam selects the executions within the duration, e.g. '5m'.
+	HistorySinceQueryParam = "since"
+	HistoryLimitQueryParam = "limit"
+)
+
+type commandHistoryResponse struct {
+	common.BaseResponse `json:",inline"`
+	Executions          []history.Execution `json:"executions"`
+}
+
+// CommandHistory handles the request to query the recent command executions, the most
+// recent first.
+func (c *V2HttpController) CommandHistory(writer http.ResponseWriter, request *http.Request) {
+	buffer := history.GetBuffer()
+	if buffer == nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindServiceUnavailable, "command history disabled", nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CommandHistoryRoute)
+		return
+	}
+
+	query := request.URL.Query()
+	filter := history.Filter{
+		DeviceName:    query.Get(HistoryDeviceQueryParam),
+		Command:       query.Get(HistoryCommandQueryParam),
+		Method:        strings.ToLower(query.Get(HistoryMethodQueryParam)),
+		Status:        query.Get(HistoryStatusQueryParam),
+		CorrelationID: query.Get(HistoryCorrelationIdQueryParam),
+	}
+	switch filter.Status {
+	case "", history.StatusSuccess, history.StatusError:
+	default:
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s %s", HistoryStatusQueryParam, filter.Status), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CommandHistoryRoute)
+		return
+	}
+	if v := query.Get(HistorySinceQueryParam); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s duration %s", HistorySinceQueryParam, v), err)
+			c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CommandHistoryRoute)
+			return
+		}
+		filter.Since = time.Now().Add(-d).UnixNano()
+	}
+	if v := query.Get(HistoryLimitQueryParam); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s %s", HistoryLimitQueryParam, v), err)
+			c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2CommandHistoryRoute)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	response := commandHistoryResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Executions:   buffer.Query(filter),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2CommandHistoryRoute, response, http.StatusOK)
+}
@@ -0,0 +1,46 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/inflight"
+)
+
+type inflightResponse struct {
+	common.BaseResponse `json:",inline"`
+	Operations          []inflight.Operation `json:"operations"`
+}
+
+// InflightOperations handles the request to get the driver operations being executed.
+func (c *V2HttpController) InflightOperations(writer http.ResponseWriter, request *http.Request) {
+	res := inflightResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Operations:   inflight.Operations(),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2InflightRoute, res, http.StatusOK)
+}
+
+// CancelInflightOperation handles the request to cancel a driver operation being executed.
+func (c *V2HttpController) CancelInflightOperation(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)[v2.Id]
+	if !inflight.Cancel(id) {
+		edgexErr := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("operation %s not in flight", id), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2InflightByIdRoute)
+		return
+	}
+	c.lc.Warn(fmt.Sprintf("in-flight operation %s cancelled", id))
+	c.sendResponse(writer, request, sdkCommon.APIV2InflightByIdRoute, common.NewBaseResponse("", "", http.StatusOK), http.StatusOK)
+}
@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+)
+
+type maintenanceRequest struct {
+	common.BaseRequest `json:",inline"`
+	Enabled            *bool `json:"enabled"`
+}
+
+type maintenanceResponse struct {
+	common.BaseResponse `json:",inline"`
+	Maintenance         bool `json:"maintenance"`
+	RejectReads         bool `json:"rejectReads"`
+}
+
+// Maintenance handles the request to get the maintenance mode of the Device Service.
+func (c *V2HttpController) Maintenance(writer http.ResponseWriter, request *http.Request) {
+	res := maintenanceResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Maintenance:  maintenance.ServiceMode(),
+		RejectReads:  maintenance.ReadsRejected(),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2MaintenanceRoute, res, http.StatusOK)
+}
+
+// SetMaintenance handles the request to put the Device Service in maintenance mode or out
+// of it. The mode holds until Writable.Maintenance.Enabled changes.
+func (c *V2HttpController) SetMaintenance(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	var maintenanceReq maintenanceRequest
+	err := json.NewDecoder(request.Body).Decode(&maintenanceReq)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2MaintenanceRoute)
+		return
+	}
+	if maintenanceReq.Enabled == nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "enabled is required", nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2MaintenanceRoute)
+		return
+	}
+
+	maintenance.SetServiceMode(*maintenanceReq.Enabled)
+	c.lc.Info(fmt.Sprintf("maintenance mode set to %v", *maintenanceReq.Enabled))
+
+	res := maintenanceResponse{
+		BaseResponse: common.NewBaseResponse(maintenanceReq.RequestId, "", http.StatusOK),
+		Maintenance:  *maintenanceReq.Enabled,
+		RejectReads:  maintenance.ReadsRejected(),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2MaintenanceRoute, res, http.StatusOK)
+}
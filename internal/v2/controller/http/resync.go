@@ -0,0 +1,32 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+func (c *V2HttpController) ResyncDevice(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+
+	edgexErr := application.ResyncDevice(name, correlationID, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceResyncRoute)
+		return
+	}
+
+	res := common.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceResyncRoute, res, http.StatusOK)
+}
@@ -0,0 +1,106 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos"
+)
+
+const SDKFieldsReserved = "ds-fields"
+const SDKFormatReserved = "ds-format"
+
+// FormatCompact responds with an object mapping the deviceResource names to the reading values.
+const FormatCompact = "compact"
+
+// readingFields are the reading fields which can be selected through ds-fields.
+var readingFields = map[string]bool{
+	"id":           true,
+	"created":      true,
+	"origin":       true,
+	"deviceName":   true,
+	"resourceName": true,
+	"profileName":  true,
+	"valueType":    true,
+	"value":        true,
+	"binaryValue":  true,
+	"mediaType":    true,
+}
+
+// responseShape is the selection of the reading fields and the format of a command response.
+type responseShape struct {
+	fields  []string
+	compact bool
+}
+
+// parseResponseShape returns the shape requested through ds-fields and ds-format, or nil
+// for the full event response.
+func parseResponseShape(reserved url.Values) (*responseShape, edgexErr.EdgeX) {
+	var shape responseShape
+	if format := reserved.Get(SDKFormatReserved); format != "" {
+		if format != FormatCompact {
+			errMsg := fmt.Sprintf("unsupported %s %s", SDKFormatReserved, format)
+			return nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, nil)
+		}
+		shape.compact = true
+	}
+	if fields := reserved.Get(SDKFieldsReserved); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			if !readingFields[field] {
+				errMsg := fmt.Sprintf("unknown reading field %s in %s", field, SDKFieldsReserved)
+				return nil, edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, errMsg, nil)
+			}
+			shape.fields = append(shape.fields, field)
+		}
+	}
+	if !shape.compact && len(shape.fields) == 0 {
+		return nil, nil
+	}
+	return &shape, nil
+}
+
+// apply returns the readings of the event in the shape. The compact format maps the
+// deviceResource names to the values, or to the binary values of binary readings;
+// otherwise the readings are listed with the selected fields only.
+func (s *responseShape) apply(event dtos.Event) (interface{}, error) {
+	if s.compact {
+		values := make(map[string]interface{}, len(event.Readings))
+		for _, r := range event.Readings {
+			if len(r.BinaryValue) > 0 {
+				values[r.ResourceName] = r.BinaryValue
+			} else {
+				values[r.ResourceName] = r.Value
+			}
+		}
+		return values, nil
+	}
+
+	readings := make([]map[string]interface{}, len(event.Readings))
+	for i, r := range event.Readings {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]interface{}
+		if err = json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		readings[i] = make(map[string]interface{}, len(s.fields))
+		for _, field := range s.fields {
+			if v, ok := all[field]; ok {
+				readings[i][field] = v
+			}
+		}
+	}
+	return readings, nil
+}
@@ -0,0 +1,113 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/staging"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+type stageProfileRequest struct {
+	common.BaseRequest `json:",inline"`
+	Profile            contract.DeviceProfile `json:"profile"`
+	CanaryDevice       string                 `json:"canaryDevice,omitempty"`
+}
+
+type stagedProfileResponse struct {
+	common.BaseResponse `json:",inline"`
+	Profile             contract.DeviceProfile `json:"profile"`
+}
+
+func (c *V2HttpController) StageProfile(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	var stageRequest stageProfileRequest
+	err := json.NewDecoder(request.Body).Decode(&stageRequest)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2ProfileStagingRoute)
+		return
+	}
+
+	edgexErr := application.StageProfile(stageRequest.Profile, stageRequest.CanaryDevice, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2ProfileStagingRoute)
+		return
+	}
+
+	res := common.NewBaseResponse(stageRequest.RequestId, "", http.StatusCreated)
+	c.sendResponse(writer, request, sdkCommon.APIV2ProfileStagingRoute, res, http.StatusCreated)
+}
+
+func (c *V2HttpController) StagedProfileByName(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+
+	profile, ok := staging.Staged(name)
+	if !ok {
+		edgexErr := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("no staged Device Profile %s", name), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2StagedProfileByNameRoute)
+		return
+	}
+
+	res := stagedProfileResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Profile:      profile,
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2StagedProfileByNameRoute, res, http.StatusOK)
+}
+
+func (c *V2HttpController) DiscardStagedProfile(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+
+	if !staging.Discard(name) {
+		edgexErr := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("no staged Device Profile %s", name), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2StagedProfileByNameRoute)
+		return
+	}
+
+	res := common.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2StagedProfileByNameRoute, res, http.StatusOK)
+}
+
+func (c *V2HttpController) ApplyStagedProfile(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+
+	edgexErr := application.ApplyStagedProfile(name, correlationID, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2ApplyStagedProfileRoute)
+		return
+	}
+
+	res := common.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2ApplyStagedProfileRoute, res, http.StatusOK)
+}
+
+func (c *V2HttpController) RollbackProfile(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+
+	edgexErr := application.RollbackProfile(name, correlationID, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2RollbackProfileRoute)
+		return
+	}
+
+	res := common.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2RollbackProfileRoute, res, http.StatusOK)
+}
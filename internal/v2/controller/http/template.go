@@ -0,0 +1,155 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+type deviceTemplateRequest struct {
+	common.BaseRequest `json:",inline"`
+	Template           dsModels.DeviceTemplate `json:"template"`
+}
+
+type deviceFromTemplateRequest struct {
+	common.BaseRequest `json:",inline"`
+	DeviceName         string            `json:"deviceName"`
+	Values             map[string]string `json:"values"`
+}
+
+type deviceTemplateResponse struct {
+	common.BaseResponse `json:",inline"`
+	Template            dsModels.DeviceTemplate `json:"template"`
+}
+
+type multiDeviceTemplatesResponse struct {
+	common.BaseResponse `json:",inline"`
+	Templates           []dsModels.DeviceTemplate `json:"templates"`
+}
+
+func (c *V2HttpController) AddDeviceTemplate(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	var templateRequest deviceTemplateRequest
+	err := json.NewDecoder(request.Body).Decode(&templateRequest)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceTemplateRoute)
+		return
+	}
+
+	edgexErr := application.AddDeviceTemplate(templateRequest.Template, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceTemplateRoute)
+		return
+	}
+
+	res := common.NewBaseResponse(templateRequest.RequestId, "", http.StatusCreated)
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceTemplateRoute, res, http.StatusCreated)
+}
+
+func (c *V2HttpController) UpdateDeviceTemplate(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	var templateRequest deviceTemplateRequest
+	err := json.NewDecoder(request.Body).Decode(&templateRequest)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceTemplateRoute)
+		return
+	}
+
+	edgexErr := application.UpdateDeviceTemplate(templateRequest.Template, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceTemplateRoute)
+		return
+	}
+
+	res := common.NewBaseResponse(templateRequest.RequestId, "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceTemplateRoute, res, http.StatusOK)
+}
+
+func (c *V2HttpController) AllDeviceTemplates(writer http.ResponseWriter, request *http.Request) {
+	res := multiDeviceTemplatesResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Templates:    cache.Templates().All(),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2AllDeviceTemplateRoute, res, http.StatusOK)
+}
+
+func (c *V2HttpController) DeviceTemplateByName(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+
+	template, ok := cache.Templates().ForName(name)
+	if !ok {
+		edgexErr := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("DeviceTemplate %s not found", name), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceTemplateByNameRoute)
+		return
+	}
+
+	res := deviceTemplateResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Template:     template,
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceTemplateByNameRoute, res, http.StatusOK)
+}
+
+func (c *V2HttpController) DeleteDeviceTemplate(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+
+	edgexErr := application.DeleteDeviceTemplate(name, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceTemplateByNameRoute)
+		return
+	}
+
+	res := common.NewBaseResponse("", "", http.StatusOK)
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceTemplateByNameRoute, res, http.StatusOK)
+}
+
+// AddDeviceFromTemplate handles the request to create a Device from a DeviceTemplate,
+// only requiring the device name and the values of the template placeholders.
+func (c *V2HttpController) AddDeviceFromTemplate(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	name := mux.Vars(request)[v2.Name]
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+
+	var deviceRequest deviceFromTemplateRequest
+	err := json.NewDecoder(request.Body).Decode(&deviceRequest)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceFromTemplateRoute)
+		return
+	}
+	if deviceRequest.DeviceName == "" {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "deviceName is required", nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceFromTemplateRoute)
+		return
+	}
+
+	id, edgexErr := application.AddDeviceFromTemplate(name, deviceRequest.DeviceName, deviceRequest.Values, correlationID, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceFromTemplateRoute)
+		return
+	}
+
+	res := common.NewBaseWithIdResponse(deviceRequest.RequestId, "", http.StatusCreated, id)
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceFromTemplateRoute, res, http.StatusCreated)
+}
@@ -0,0 +1,68 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+)
+
+type transactionRequest struct {
+	common.BaseRequest `json:",inline"`
+	Writes             []application.TransactionWrite `json:"writes"`
+}
+
+type transactionResponse struct {
+	common.BaseResponse `json:",inline"`
+	Transaction         application.TransactionResult `json:"transaction"`
+}
+
+// ExecuteTransaction handles the request to write to several devices as a coordinated unit.
+// The outcome of the write to each device is reported, with 207 Multi-Status if some writes
+// remain applied while others failed.
+func (c *V2HttpController) ExecuteTransaction(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	if maintenance.CommandRejected(http.MethodPut) {
+		edgexErr := errors.NewCommonEdgeX(errors.KindServiceUnavailable, "service in maintenance mode", nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2TransactionRoute)
+		return
+	}
+
+	var transaction transactionRequest
+	err := json.NewDecoder(request.Body).Decode(&transaction)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2TransactionRoute)
+		return
+	}
+
+	correlationID := request.Header.Get(sdkCommon.CorrelationHeader)
+	result, edgexErr := application.ExecuteTransaction(request.Context(), transaction.Writes, correlationID, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2TransactionRoute)
+		return
+	}
+
+	statusCode := result.StatusCode()
+	message := ""
+	if result.Status != application.TransactionCommitted {
+		message = "transaction " + result.Status
+	}
+	res := transactionResponse{
+		BaseResponse: common.NewBaseResponse(transaction.RequestId, message, statusCode),
+		Transaction:  result,
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2TransactionRoute, res, statusCode)
+}
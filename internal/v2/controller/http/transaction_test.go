@@ -0,0 +1,43 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+)
+
+func TestExecuteTransactionInMaintenance(t *testing.T) {
+	maintenance.SetServiceMode(true)
+	defer maintenance.SetServiceMode(false)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &sdkCommon.ConfigurationStruct{}
+		},
+	})
+	target := NewV2HttpController(dic)
+
+	body := `{"writes":[{"deviceName":"Simple-Device01","command":"SwitchButton","parameters":{"SwitchButton":"true"}}]}`
+	req := httptest.NewRequest(http.MethodPost, sdkCommon.APIV2TransactionRoute, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	target.ExecuteTransaction(recorder, req)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code, "the writes are rejected in maintenance mode")
+}
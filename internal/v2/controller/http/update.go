@@ -0,0 +1,100 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+	"github.com/gorilla/mux"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/update"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/v2/application"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+type deviceUpdateRequest struct {
+	common.BaseRequest `json:",inline"`
+	Devices            []string              `json:"devices,omitempty"`
+	Labels             []string              `json:"labels,omitempty"`
+	Update             dsModels.DeviceUpdate `json:"update"`
+}
+
+type deviceUpdateJobsResponse struct {
+	common.BaseResponse `json:",inline"`
+	Jobs                []update.Job `json:"jobs"`
+	batchErrors         `json:",inline"`
+}
+
+type deviceUpdateJobResponse struct {
+	common.BaseResponse `json:",inline"`
+	Job                 update.Job `json:"job"`
+}
+
+// StartDeviceUpdate handles the request to update the firmware or configuration of the
+// Devices selected by name or label. The update is applied asynchronously, its progress
+// is tracked by the returned jobs. The Devices which couldn't be updated are reported
+// individually, with 207 Multi-Status if others were.
+func (c *V2HttpController) StartDeviceUpdate(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	var updateRequest deviceUpdateRequest
+	err := json.NewDecoder(request.Body).Decode(&updateRequest)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode JSON", err)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceUpdateRoute)
+		return
+	}
+
+	jobs, itemErrs, edgexErr := application.StartDeviceUpdate(updateRequest.Devices, updateRequest.Labels, updateRequest.Update, c.dic)
+	if edgexErr != nil {
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceUpdateRoute)
+		return
+	}
+
+	statusCode := sdkCommon.BatchStatusCode(len(jobs), itemErrs, http.StatusAccepted)
+	res := deviceUpdateJobsResponse{
+		BaseResponse: common.NewBaseResponse(updateRequest.RequestId, batchMessage(itemErrs), statusCode),
+		Jobs:         jobs,
+		batchErrors:  batchErrors{Errors: itemErrs},
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceUpdateRoute, res, statusCode)
+}
+
+// DeviceUpdateById handles the request to track an update job.
+func (c *V2HttpController) DeviceUpdateById(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)[v2.Id]
+
+	job, ok := update.Get(id)
+	if !ok {
+		edgexErr := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("no update job %s", id), nil)
+		c.sendEdgexError(writer, request, edgexErr, sdkCommon.APIV2DeviceUpdateByIdRoute)
+		return
+	}
+
+	res := deviceUpdateJobResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Job:          job,
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceUpdateByIdRoute, res, http.StatusOK)
+}
+
+// DeviceUpdatesByName handles the request to track the update jobs of a Device.
+func (c *V2HttpController) DeviceUpdatesByName(writer http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)[v2.Name]
+
+	res := deviceUpdateJobsResponse{
+		BaseResponse: common.NewBaseResponse("", "", http.StatusOK),
+		Jobs:         update.ForDevice(name),
+	}
+	c.sendResponse(writer, request, sdkCommon.APIV2DeviceUpdateByNameRoute, res, http.StatusOK)
+}
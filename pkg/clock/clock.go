@@ -0,0 +1,96 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clock abstracts the time used by the AutoEvents, the retries of the driver
+// commands and the expiry of the cached values, so that tests can advance it
+// deterministically with a Fake clock instead of sleeping.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time and creates the timers.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel receiving the time once the duration elapsed.
+	After(d time.Duration) <-chan time.Time
+	// NewTicker returns a Ticker sending the time every period.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker sends the time on its channel every period until it's stopped.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is the Clock of the system time.
+var Real Clock = realClock{}
+
+var (
+	current = Real
+	mutex   sync.RWMutex
+)
+
+// Set replaces the Clock used by the SDK. A nil Clock restores the Real one.
+func Set(c Clock) {
+	if c == nil {
+		c = Real
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	current = c
+}
+
+// Get returns the Clock used by the SDK.
+func Get() Clock {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// Now returns the time of the Clock used by the SDK.
+func Now() time.Time {
+	return Get().Now()
+}
+
+// After waits for the duration on the Clock used by the SDK.
+func After(d time.Duration) <-chan time.Time {
+	return Get().After(d)
+}
+
+// NewTicker creates a Ticker on the Clock used by the SDK.
+func NewTicker(d time.Duration) Ticker {
+	return Get().NewTicker(d)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t realTicker) Stop() {
+	t.ticker.Stop()
+}
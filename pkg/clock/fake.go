@@ -0,0 +1,123 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when it's advanced, firing the timers and
+// tickers due by then.
+type Fake struct {
+	now     time.Time
+	waiters []*fakeWaiter
+	mutex   sync.Mutex
+}
+
+type fakeWaiter struct {
+	at      time.Time
+	period  time.Duration // 0 for the timers created by After
+	ch      chan time.Time
+	stopped bool
+}
+
+// NewFake returns a Fake clock starting at the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the time the Fake clock was advanced to.
+func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+// After returns a channel receiving the time once the Fake clock is advanced by the duration.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	w := &fakeWaiter{at: f.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.ch <- f.now
+		return w.ch
+	}
+	f.waiters = append(f.waiters, w)
+	return w.ch
+}
+
+// NewTicker returns a Ticker sending the time every period the Fake clock is advanced by.
+// As with time.Ticker, the ticks are dropped if the receiver doesn't keep up.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for Fake.NewTicker")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	w := &fakeWaiter{at: f.now.Add(d), period: d, ch: make(chan time.Time, 1)}
+	f.waiters = append(f.waiters, w)
+	return &fakeTicker{clock: f, waiter: w}
+}
+
+// Advance moves the time forward by the duration and fires the timers and tickers due.
+func (f *Fake) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(d)
+
+	pending := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.stopped {
+			continue
+		}
+		if w.at.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		select {
+		case w.ch <- f.now:
+		default:
+		}
+		if w.period > 0 {
+			for !w.at.After(f.now) {
+				w.at = w.at.Add(w.period)
+			}
+			pending = append(pending, w)
+		}
+	}
+	f.waiters = pending
+}
+
+// Waiters returns the number of the timers and tickers pending, so that tests can wait for
+// the code under test to start waiting before advancing the Fake clock.
+func (f *Fake) Waiters() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	n := 0
+	for _, w := range f.waiters {
+		if !w.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+type fakeTicker struct {
+	clock  *Fake
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	t.waiter.stopped = true
+}
@@ -0,0 +1,71 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func received(ch <-chan time.Time) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestFakeAfter(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	ch := f.After(time.Second)
+	assert.Equal(t, 1, f.Waiters())
+	f.Advance(999 * time.Millisecond)
+	assert.False(t, received(ch))
+	f.Advance(time.Millisecond)
+	assert.True(t, received(ch))
+	assert.Equal(t, 0, f.Waiters())
+	assert.Equal(t, start.Add(time.Second), f.Now())
+
+	assert.True(t, received(f.After(0)))
+}
+
+func TestFakeTicker(t *testing.T) {
+	f := NewFake(time.Now())
+	ticker := f.NewTicker(time.Minute)
+
+	f.Advance(time.Minute)
+	assert.True(t, received(ticker.C()))
+	f.Advance(30 * time.Second)
+	assert.False(t, received(ticker.C()))
+	f.Advance(30 * time.Second)
+	assert.True(t, received(ticker.C()))
+
+	// the ticks missed by the receiver are dropped
+	f.Advance(3 * time.Minute)
+	assert.True(t, received(ticker.C()))
+	assert.False(t, received(ticker.C()))
+
+	ticker.Stop()
+	f.Advance(time.Minute)
+	assert.False(t, received(ticker.C()))
+	assert.Equal(t, 0, f.Waiters())
+}
+
+func TestSet(t *testing.T) {
+	f := NewFake(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	Set(f)
+	defer Set(nil)
+
+	assert.Equal(t, f.Now(), Now())
+	Set(nil)
+	assert.Equal(t, Real, Get())
+}
@@ -0,0 +1,424 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package integration runs a Device Service against the EdgeX core services started in
+// containers, so that the driver repositories can run end-to-end tests of their drivers in
+// their own test suites.
+//
+// The Device Service runs in a process of its own, the test binary run again, so that the
+// bootstrap exiting on a failed startup fails Harness.Start rather than the whole test
+// binary. The TestMain of the test suite hands the Device Service over to Main:
+//
+//	var config = integration.Config{ServiceName: "device-modbus", Driver: &driver.Driver{}, ...}
+//
+//	func TestMain(m *testing.M) {
+//		integration.Main(config)
+//		os.Exit(m.Run())
+//	}
+//
+// The containers are started by a Runtime, to be implemented with the container library of
+// the test suite, e.g. testcontainers-go, so that the SDK doesn't depend on it:
+//
+//	func (r *testcontainersRuntime) Start(ctx context.Context, req integration.ContainerRequest) (integration.Endpoint, integration.StopFunc, error) {
+//		port := fmt.Sprintf("%d/tcp", req.Port)
+//		c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+//			ContainerRequest: testcontainers.ContainerRequest{
+//				Image:          req.Image,
+//				Env:            req.Env,
+//				ExposedPorts:   []string{port},
+//				Networks:       []string{r.network},
+//				NetworkAliases: map[string][]string{r.network: {req.Name}},
+//				WaitingFor:     wait.ForListeningPort(nat.Port(port)),
+//			},
+//			Started: true,
+//		})
+//		...
+//	}
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/gorilla/mux"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/health"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/service"
+)
+
+// The containers started by the Harness, named by their host name on the network they share.
+const (
+	Redis        = "edgex-redis"
+	CoreData     = "edgex-core-data"
+	CoreMetadata = "edgex-core-metadata"
+)
+
+// DefaultImages are the images of the containers started by the Harness, unless overridden
+// by Config.Images.
+var DefaultImages = map[string]string{
+	Redis:        "redis:6.0.9-alpine",
+	CoreData:     "edgexfoundry/docker-core-data-go:1.3.0",
+	CoreMetadata: "edgexfoundry/docker-core-metadata-go:1.3.0",
+}
+
+var ports = map[string]int{
+	Redis:        6379,
+	CoreData:     48080,
+	CoreMetadata: 48081,
+}
+
+const defaultStartTimeout = time.Minute
+
+const (
+	// serviceArgsEnv holds, in the process of the Device Service, its command line
+	// arguments encoded in JSON.
+	serviceArgsEnv = "EDGEX_HARNESS_SERVICE_ARGS"
+	// statusPrefix starts the line the process of the Device Service writes to its output
+	// once started and seeded, followed by the error of its startup if any.
+	statusPrefix = "edgex-harness-status:"
+)
+
+// ContainerRequest describes a container the Runtime starts.
+type ContainerRequest struct {
+	// Name is the host name of the container on the network shared by the containers.
+	Name  string
+	Image string
+	// Port is the port the container listens on, to be exposed to the test.
+	Port int
+	Env  map[string]string
+}
+
+// Endpoint is the address where the test reaches a container or the Device Service.
+type Endpoint struct {
+	Host string
+	Port int
+}
+
+// StopFunc stops and removes a container.
+type StopFunc func(ctx context.Context) error
+
+// Runtime starts the containers.
+type Runtime interface {
+	// Start starts the container on the network shared with the containers started before,
+	// and returns once it listens on its port, exposed at the returned Endpoint.
+	Start(ctx context.Context, req ContainerRequest) (Endpoint, StopFunc, error)
+}
+
+// Config is the Device Service the Harness runs and the Device Profiles and Devices it's
+// seeded with. The same Config is passed to New and Main.
+type Config struct {
+	ServiceName    string
+	ServiceVersion string
+	// Driver is the ProtocolDriver, as passed to startup.Bootstrap.
+	Driver interface{}
+	// Args are the command line arguments of the Device Service, e.g. '--confdir' to set
+	// the directory of its configuration. The Clients and the Service port are overridden
+	// to reach the containers and the Device Service.
+	Args []string
+	// Profiles and Devices are added to the Device Service once it started.
+	Profiles []contract.DeviceProfile
+	Devices  []contract.Device
+	// Images overrides DefaultImages.
+	Images map[string]string
+	// StartTimeout is the time to wait for the Device Service to start, a minute by default.
+	StartTimeout time.Duration
+}
+
+// Harness runs a Device Service against the EdgeX core services.
+type Harness struct {
+	runtime   Runtime
+	config    Config
+	endpoints map[string]Endpoint
+	stops     []StopFunc
+	service   Endpoint
+	cmd       *exec.Cmd
+	status    chan string
+	done      chan struct{}
+}
+
+// New returns the Harness starting its containers with the runtime.
+func New(runtime Runtime, config Config) *Harness {
+	if config.StartTimeout <= 0 {
+		config.StartTimeout = defaultStartTimeout
+	}
+	return &Harness{
+		runtime:   runtime,
+		config:    config,
+		endpoints: make(map[string]Endpoint),
+	}
+}
+
+// Start starts Redis, Core Data and Core Metadata, then the Device Service, seeded with the
+// Device Profiles and Devices. Everything started is stopped if it fails.
+//
+// The Device Service runs the test binary again, its configuration overridden through the
+// environment variables of its process, and listens on a free port of the localhost. Core
+// Metadata doesn't reach the Device Service to call it back, the Devices being managed
+// through its API at ServiceEndpoint.
+func (h *Harness) Start(ctx context.Context) error {
+	if err := h.start(ctx); err != nil {
+		_ = h.Stop(context.Background())
+		return err
+	}
+	return nil
+}
+
+func (h *Harness) start(ctx context.Context) error {
+	containers := []ContainerRequest{
+		{Name: Redis},
+		{Name: CoreMetadata, Env: coreServiceEnv(CoreMetadata)},
+		{Name: CoreData, Env: coreServiceEnv(CoreData)},
+	}
+	for _, req := range containers {
+		req.Image = h.image(req.Name)
+		req.Port = ports[req.Name]
+		endpoint, stop, err := h.runtime.Start(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to start %s: %v", req.Name, err)
+		}
+		h.stops = append(h.stops, stop)
+		h.endpoints[req.Name] = endpoint
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return fmt.Errorf("failed to find a port for the Device Service: %v", err)
+	}
+	h.service = Endpoint{Host: "localhost", Port: port}
+	args, err := json.Marshal(h.config.Args)
+	if err != nil {
+		return err
+	}
+	// the tests are skipped if the TestMain doesn't hand the process over to Main
+	h.cmd = exec.Command(os.Args[0], "-test.run=^$")
+	h.cmd.Env = append(os.Environ(),
+		serviceArgsEnv+"="+string(args),
+		"EDGEX_SECURITY_SECRET_STORE=false",
+		"SERVICE_HOST="+h.service.Host,
+		"SERVICE_PORT="+strconv.Itoa(h.service.Port),
+		"CLIENTS_METADATA_HOST="+h.endpoints[CoreMetadata].Host,
+		"CLIENTS_METADATA_PORT="+strconv.Itoa(h.endpoints[CoreMetadata].Port),
+		"CLIENTS_DATA_HOST="+h.endpoints[CoreData].Host,
+		"CLIENTS_DATA_PORT="+strconv.Itoa(h.endpoints[CoreData].Port),
+	)
+	h.cmd.Stderr = os.Stderr
+	output, err := h.cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = h.cmd.Start(); err != nil {
+		h.cmd = nil
+		return fmt.Errorf("failed to run the Device Service: %v", err)
+	}
+	h.status = make(chan string, 1)
+	h.done = make(chan struct{})
+	go h.forward(bufio.NewReader(output))
+
+	return h.waitForService(ctx)
+}
+
+// forward copies the output of the Device Service to the one of the test, but for its
+// status, until it exits.
+func (h *Harness) forward(output *bufio.Reader) {
+	defer close(h.done)
+	for {
+		line, err := output.ReadString('\n')
+		if strings.HasPrefix(line, statusPrefix) {
+			h.status <- strings.TrimSpace(strings.TrimPrefix(line, statusPrefix))
+		} else if line != "" {
+			fmt.Fprint(os.Stdout, line)
+		}
+		if err != nil {
+			break
+		}
+	}
+	_ = h.cmd.Wait()
+}
+
+// waitForService waits until the Device Service is started and seeded.
+func (h *Harness) waitForService(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case status := <-h.status:
+		return statusError(status)
+	case <-h.done:
+		select {
+		case status := <-h.status:
+			return statusError(status)
+		default:
+			return errors.New("the Device Service stopped during its startup, see its log; is integration.Main called by the TestMain?")
+		}
+	case <-time.After(h.config.StartTimeout):
+		return fmt.Errorf("the Device Service didn't start within %v", h.config.StartTimeout)
+	}
+}
+
+func statusError(status string) error {
+	if status != "" {
+		return fmt.Errorf("the Device Service failed to start: %s", status)
+	}
+	return nil
+}
+
+// ServiceEndpoint returns where the test reaches the API of the Device Service.
+func (h *Harness) ServiceEndpoint() Endpoint {
+	return h.service
+}
+
+// Endpoint returns where the test reaches the container, e.g. CoreData to query the events
+// the Device Service posted.
+func (h *Harness) Endpoint(name string) (Endpoint, bool) {
+	endpoint, ok := h.endpoints[name]
+	return endpoint, ok
+}
+
+// Stop stops the Device Service, killing it if it doesn't stop before the context is done,
+// and stops the containers. It returns the first error met, stopping the containers
+// nonetheless.
+func (h *Harness) Stop(ctx context.Context) error {
+	var result error
+	if h.cmd != nil {
+		_ = h.cmd.Process.Signal(os.Interrupt)
+		select {
+		case <-h.done:
+		case <-ctx.Done():
+			_ = h.cmd.Process.Kill()
+			<-h.done
+			result = errors.New("the Device Service didn't stop in time")
+		}
+		h.cmd = nil
+	}
+
+	for i := len(h.stops) - 1; i >= 0; i-- {
+		if err := h.stops[i](ctx); err != nil && result == nil {
+			result = err
+		}
+	}
+	h.stops = nil
+	h.endpoints = make(map[string]Endpoint)
+	return result
+}
+
+func (h *Harness) image(name string) string {
+	if image, ok := h.config.Images[name]; ok {
+		return image
+	}
+	return DefaultImages[name]
+}
+
+// Main runs the Device Service of the config, then exits, if the process was started by
+// Harness.Start, and returns otherwise. It's called first by the TestMain of the test suite.
+func Main(config Config) {
+	encoded, ok := os.LookupEnv(serviceArgsEnv)
+	if !ok {
+		return
+	}
+	var args []string
+	if err := json.Unmarshal([]byte(encoded), &args); err != nil {
+		reportStatus(fmt.Errorf("invalid command line arguments: %v", err))
+		os.Exit(1)
+	}
+	os.Exit(runService(config, args))
+}
+
+// runService runs the Device Service until it's interrupted, and returns the exit code of
+// its process.
+func runService(config Config, args []string) int {
+	if config.StartTimeout <= 0 {
+		config.StartTimeout = defaultStartTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		service.MainWithArgs(config.ServiceName, config.ServiceVersion, config.Driver, ctx, cancel, mux.NewRouter(), args)
+	}()
+
+	err := waitForDriver(done, config.StartTimeout)
+	if err == nil {
+		err = seed(config)
+	}
+	reportStatus(err)
+	if err != nil {
+		cancel()
+		<-done
+		return 1
+	}
+	<-done
+	return 0
+}
+
+// waitForDriver waits until the driver of the Device Service is initialized.
+func waitForDriver(done <-chan struct{}, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for !health.PhaseCompleted(health.PhaseDriverInitialized) {
+		select {
+		case <-done:
+			return errors.New("the Device Service stopped during its startup")
+		case <-deadline:
+			return fmt.Errorf("the Device Service didn't start within %v", timeout)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// seed adds the Device Profiles then the Devices to the Device Service.
+func seed(config Config) error {
+	ds := service.RunningService()
+	for _, profile := range config.Profiles {
+		if _, err := ds.AddDeviceProfile(profile); err != nil {
+			return fmt.Errorf("failed to seed Device Profile %s: %v", profile.Name, err)
+		}
+	}
+	for _, device := range config.Devices {
+		if _, err := ds.AddDevice(device); err != nil {
+			return fmt.Errorf("failed to seed Device %s: %v", device.Name, err)
+		}
+	}
+	return nil
+}
+
+// reportStatus writes the status of the startup of the Device Service for Harness.Start.
+func reportStatus(err error) {
+	status := ""
+	if err != nil {
+		status = strings.ReplaceAll(err.Error(), "\n", " ")
+	}
+	fmt.Fprintln(os.Stdout, statusPrefix+status)
+}
+
+// freePort returns a port of the localhost no one listens on.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// coreServiceEnv returns the environment of the core service, reaching Redis and Core
+// Metadata by their host names, without the secret store.
+func coreServiceEnv(name string) map[string]string {
+	return map[string]string{
+		"EDGEX_SECURITY_SECRET_STORE": "false",
+		"SERVICE_HOST":                name,
+		"DATABASES_PRIMARY_HOST":      Redis,
+		"CLIENTS_METADATA_HOST":       CoreMetadata,
+	}
+}
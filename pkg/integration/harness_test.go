@@ -0,0 +1,113 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/example/driver"
+)
+
+var testConfig = Config{
+	ServiceName:    "device-harness",
+	ServiceVersion: "0.0.0",
+	Driver:         &driver.SimpleDriver{},
+	Args:           []string{"--confdir", "testdata"},
+	StartTimeout:   20 * time.Second,
+}
+
+func TestMain(m *testing.M) {
+	Main(testConfig)
+	os.Exit(m.Run())
+}
+
+// fakeRuntime serves Core Data and Core Metadata with a fake core service accepting any
+// request, and records the containers started and stopped.
+type fakeRuntime struct {
+	mutex   sync.Mutex
+	started []string
+	stopped []string
+}
+
+func (r *fakeRuntime) Start(_ context.Context, req ContainerRequest) (Endpoint, StopFunc, error) {
+	r.mutex.Lock()
+	r.started = append(r.started, req.Name)
+	r.mutex.Unlock()
+
+	server := httptest.NewServer(http.HandlerFunc(serveCore))
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return Endpoint{Host: host, Port: p}, func(context.Context) error {
+		server.Close()
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.stopped = append(r.stopped, req.Name)
+		return nil
+	}, nil
+}
+
+// serveCore answers the pings, finds nothing by name, lists nothing and adds anything.
+func serveCore(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/ping"):
+		fmt.Fprint(w, "pong")
+	case r.Method == http.MethodPost:
+		fmt.Fprint(w, uuid.New().String())
+	case r.Method != http.MethodGet:
+	case strings.Contains(r.URL.Path, "/name/"):
+		http.NotFound(w, r)
+	default:
+		fmt.Fprint(w, "[]")
+	}
+}
+
+func TestHarness(t *testing.T) {
+	runtime := &fakeRuntime{}
+	h := New(runtime, testConfig)
+	require.NoError(t, h.Start(context.Background()))
+
+	endpoint := h.ServiceEndpoint()
+	resp, err := http.Get(fmt.Sprintf("http://%s:%d/api/v1/ping", endpoint.Host, endpoint.Port))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_, ok := h.Endpoint(CoreData)
+	assert.True(t, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, h.Stop(ctx))
+	assert.Equal(t, []string{Redis, CoreMetadata, CoreData}, runtime.started)
+	assert.Equal(t, []string{CoreData, CoreMetadata, Redis}, runtime.stopped)
+}
+
+func TestHarnessStartFailed(t *testing.T) {
+	config := testConfig
+	config.Args = []string{"--confdir", "missing"}
+	runtime := &fakeRuntime{}
+	h := New(runtime, config)
+
+	// the bootstrap exits the process of the Device Service, not the one of the test
+	err := h.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stopped during its startup")
+	assert.Equal(t, []string{CoreData, CoreMetadata, Redis}, runtime.stopped, "the containers are stopped")
+}
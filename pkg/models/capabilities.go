@@ -0,0 +1,37 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// Capabilities are the features supported by a ProtocolDriver.
+type Capabilities struct {
+	// Discovery is the support of dynamic device discovery, see ProtocolDiscovery.
+	Discovery bool `json:"discovery"`
+	// AsyncReadings is the support of pushing readings to the AsyncValues channel.
+	AsyncReadings bool `json:"asyncReadings"`
+	// WriteConfirmation is the support of asynchronously confirming the writes of the
+	// deviceResources requiring it.
+	WriteConfirmation bool `json:"writeConfirmation"`
+	// DeviceConnect is the support of connecting each device when it's added and
+	// disconnecting it when it's removed, rather than per command.
+	DeviceConnect bool `json:"deviceConnect"`
+}
+
+// AllCapabilities are assumed for the ProtocolDrivers which don't implement
+// CapabilityAdvertiser.
+var AllCapabilities = Capabilities{
+	Discovery:         true,
+	AsyncReadings:     true,
+	WriteConfirmation: true,
+	DeviceConnect:     true,
+}
+
+// CapabilityAdvertiser is implemented by ProtocolDrivers declaring their supported
+// features. The Device Service rejects the configurations requiring unsupported ones
+// and doesn't serve the routes of unsupported features.
+type CapabilityAdvertiser interface {
+	Capabilities() Capabilities
+}
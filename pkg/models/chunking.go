@@ -0,0 +1,17 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// The tags of the events an event exceeding the Device.Chunking limits is split into.
+const (
+	// ChunkCorrelationTag is the event tag shared by the parts of the same event.
+	ChunkCorrelationTag = "ChunkCorrelation"
+	// ChunkIndexTag is the event tag of the index of the part, from 1.
+	ChunkIndexTag = "ChunkIndex"
+	// ChunkCountTag is the event tag of the number of parts of the event.
+	ChunkCountTag = "ChunkCount"
+)
@@ -28,6 +28,9 @@ const (
 	// DefaultFoloatEncoding indicates the representation of floating value of reading.
 	// It would be configurable in system level in the future
 	DefaultFloatEncoding = models.Base64Encoding
+	// DecimalNotation represents the floating value of reading in plain decimal notation
+	// without exponent, using the fewest digits that represent the value exactly.
+	DecimalNotation = "decimal"
 )
 
 // CommandValue is the struct to represent the reading value of a Get command coming
@@ -406,6 +409,10 @@ func (cv *CommandValue) ValueToString(encoding ...string) (str string) {
 			var res float32
 			binary.Read(reader, binary.BigEndian, &res)
 			str = fmt.Sprintf("%e", res)
+		} else if floatEncoding == DecimalNotation {
+			var res float32
+			binary.Read(reader, binary.BigEndian, &res)
+			str = strconv.FormatFloat(float64(res), 'f', -1, 32)
 		} else if floatEncoding == models.Base64Encoding {
 			str = base64.StdEncoding.EncodeToString(cv.NumericValue)
 		}
@@ -416,6 +423,10 @@ func (cv *CommandValue) ValueToString(encoding ...string) (str string) {
 			var res float64
 			binary.Read(reader, binary.BigEndian, &res)
 			str = fmt.Sprintf("%e", res)
+		} else if floatEncoding == DecimalNotation {
+			var res float64
+			binary.Read(reader, binary.BigEndian, &res)
+			str = strconv.FormatFloat(float64(res), 'f', -1, 64)
 		} else if floatEncoding == models.Base64Encoding {
 			str = base64.StdEncoding.EncodeToString(cv.NumericValue)
 		}
@@ -436,6 +447,8 @@ func getFloatEncoding(encoding []string) string {
 			return models.Base64Encoding
 		} else if encoding[0] == models.ENotation {
 			return models.ENotation
+		} else if encoding[0] == DecimalNotation {
+			return DecimalNotation
 		}
 	}
 
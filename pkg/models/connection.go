@@ -0,0 +1,32 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// ConnectionEvent is the change of the connection to a device the ProtocolDriver reports.
+type ConnectionEvent string
+
+const (
+	// ConnectionEstablished reports the device is connected. The Device is enabled.
+	ConnectionEstablished ConnectionEvent = "Established"
+	// ConnectionLost reports the connection to the device is lost. The Device is disabled
+	// unless it's in a maintenance window.
+	ConnectionLost ConnectionEvent = "Lost"
+)
+
+// The resource and tags of the system events published when the ProtocolDriver reports
+// a ConnectionEvent.
+const (
+	// ConnectionResource is the resource of the readings of the ConnectionEvents.
+	ConnectionResource = "Connection"
+	// SystemEventTag is the event tag identifying the system events, whose value is the
+	// kind of system event.
+	SystemEventTag = "SystemEvent"
+	// SystemEventConnection is the SystemEventTag value of the ConnectionEvents.
+	SystemEventConnection = "connection"
+	// ConnectionReasonTag is the event tag of the reason reported with the ConnectionEvent.
+	ConnectionReasonTag = "ConnectionReason"
+)
@@ -0,0 +1,22 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"context"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// ContextCommandHandler is implemented by ProtocolDrivers able to abort the commands
+// once the context is done, i.e. when the HTTP client disconnects or the deadline of
+// the request expires. The Device Service calls these methods rather than
+// HandleReadCommands and HandleWriteCommands if the ProtocolDriver implements them.
+type ContextCommandHandler interface {
+	HandleReadCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []CommandRequest) ([]*CommandValue, error)
+	HandleWriteCommandsContext(ctx context.Context, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []CommandRequest, params []*CommandValue) error
+}
@@ -0,0 +1,23 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// UpdatableConfig is a strongly-typed custom configuration section of the ProtocolDriver,
+// loaded from the configuration file, or from the Configuration Provider if used, with
+// DeviceService.LoadCustomConfig instead of the flat Driver string map. It must be
+// implemented by a pointer to a struct. The changes of the section are applied on another
+// goroutine than the ProtocolDriver's: if the configuration implements sync.Locker, e.g. by
+// embedding a sync.RWMutex, UpdateFromRaw is called with it locked, so the ProtocolDriver
+// reads the configuration consistently under the same lock.
+type UpdatableConfig interface {
+	// UpdateFromRaw overwrites the configuration with rawConfig, a pointer to a value of
+	// the same type decoded from the section. It returns false if rawConfig has another type.
+	UpdateFromRaw(rawConfig interface{}) bool
+	// Validate returns an error if the configuration decoded from the section is invalid,
+	// in which case it isn't applied.
+	Validate() error
+}
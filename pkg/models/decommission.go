@@ -0,0 +1,23 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// DecommissionedResource is the resource of the reading published when a device is
+// decommissioned. Its value is the reason of the decommissioning.
+const DecommissionedResource = "Decommissioned"
+
+// DeviceDecommissioner is implemented by ProtocolDrivers which clean up a device being
+// decommissioned, e.g. clearing the credentials provisioned on it. DecommissionDevice is
+// called once the AutoEvents of the device are paused and before it's removed from Core
+// Metadata; the decommissioning is aborted if it returns an error.
+type DeviceDecommissioner interface {
+	DecommissionDevice(deviceName string, protocols map[string]contract.ProtocolProperties) error
+}
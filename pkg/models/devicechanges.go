@@ -0,0 +1,132 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"reflect"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// DeviceChanges tells which parts of a Device changed when it's updated.
+type DeviceChanges struct {
+	Protocols bool
+	// Profile is set if the Device Profile is replaced or if its resources or commands changed.
+	Profile     bool
+	AdminState  bool
+	AutoEvents  bool
+	Labels      bool
+	Description bool
+	Location    bool
+}
+
+// Cosmetic reports whether only the Labels, the Description or the Location of the Device
+// changed, which doesn't affect the communication with the device.
+func (c DeviceChanges) Cosmetic() bool {
+	return !c.Protocols && !c.Profile && !c.AdminState && !c.AutoEvents
+}
+
+// CompareDevices returns the changes from the previous definition of the Device. An empty
+// map or slice is the same as a missing one, as Core Metadata may return either.
+func CompareDevices(previous contract.Device, device contract.Device) DeviceChanges {
+	return DeviceChanges{
+		Protocols: !same(previous.Protocols, device.Protocols),
+		Profile: previous.Profile.Name != device.Profile.Name ||
+			!same(previous.Profile.DeviceResources, device.Profile.DeviceResources) ||
+			!same(previous.Profile.DeviceCommands, device.Profile.DeviceCommands) ||
+			!same(previous.Profile.CoreCommands, device.Profile.CoreCommands),
+		AdminState:  previous.AdminState != device.AdminState,
+		AutoEvents:  !same(previous.AutoEvents, device.AutoEvents),
+		Labels:      !same(previous.Labels, device.Labels),
+		Description: previous.Description != device.Description,
+		Location:    !same(previous.Location, device.Location),
+	}
+}
+
+// same reports whether a and b are deeply equal, an empty map or slice being the same as a
+// nil one.
+func same(a interface{}, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return va.IsValid() == vb.IsValid()
+	}
+	return va.Type() == vb.Type() && sameValue(va, vb)
+}
+
+// sameValue compares values of the same type like reflect.DeepEqual, reading the unexported
+// fields by kind as they can't be converted to interfaces.
+func sameValue(a reflect.Value, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			value := b.MapIndex(key)
+			if !value.IsValid() || !sameValue(a.MapIndex(key), value) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !sameValue(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !sameValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Elem().Type() != b.Elem().Type() {
+			return false
+		}
+		return sameValue(a.Elem(), b.Elem())
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	default:
+		// like reflect.DeepEqual, functions and channels are only the same if nil
+		return a.IsNil() && b.IsNil()
+	}
+}
+
+// DeviceChangeHandler is implemented by ProtocolDrivers deciding how to apply the update
+// of a Device from what changed, e.g. to reconnect only if the protocol properties
+// changed. The Device Service calls UpdateDeviceWithChanges rather than UpdateDevice if
+// the ProtocolDriver implements it.
+type DeviceChangeHandler interface {
+	UpdateDeviceWithChanges(previous contract.Device, device contract.Device, changes DeviceChanges) error
+}
+
+// OperatingStateHandler is implemented by ProtocolDrivers notified when Core Metadata
+// marks a Device up or down, e.g. to open or close its protocol session. The Device
+// Service calls UpdateDeviceOperatingState after UpdateDevice or UpdateDeviceWithChanges
+// when the OperatingState of the updated Device changed.
+type OperatingStateHandler interface {
+	UpdateDeviceOperatingState(deviceName string, state contract.OperatingState) error
+}
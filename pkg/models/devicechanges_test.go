@@ -0,0 +1,76 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDevice() contract.Device {
+	return contract.Device{
+		Name:       "device",
+		AdminState: contract.Unlocked,
+		Protocols:  map[string]contract.ProtocolProperties{"modbus-tcp": {"Address": "localhost", "Port": "502"}},
+		Labels:     []string{"label"},
+		Profile: contract.DeviceProfile{
+			Name: "profile",
+			DeviceResources: []contract.DeviceResource{
+				{Name: "temperature", Attributes: map[string]string{"register": "1"}},
+			},
+		},
+		AutoEvents: []contract.AutoEvent{{Resource: "temperature", Frequency: "1s"}},
+	}
+}
+
+func TestCompareDevices(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(d *contract.Device)
+		changes DeviceChanges
+	}{
+		{"unchanged", func(d *contract.Device) {}, DeviceChanges{}},
+		{"nil labels", func(d *contract.Device) { d.Labels = nil }, DeviceChanges{Labels: true}},
+		{"protocols", func(d *contract.Device) { d.Protocols["modbus-tcp"]["Port"] = "503" }, DeviceChanges{Protocols: true}},
+		{"admin state", func(d *contract.Device) { d.AdminState = contract.Locked }, DeviceChanges{AdminState: true}},
+		{"profile resources", func(d *contract.Device) { d.Profile.DeviceResources[0].Attributes["register"] = "2" }, DeviceChanges{Profile: true}},
+		{"profile name", func(d *contract.Device) { d.Profile.Name = "other" }, DeviceChanges{Profile: true}},
+		{"autoevents", func(d *contract.Device) { d.AutoEvents[0].Frequency = "2s" }, DeviceChanges{AutoEvents: true}},
+		{"description", func(d *contract.Device) { d.Description = "described" }, DeviceChanges{Description: true}},
+		{"location", func(d *contract.Device) { d.Location = "here" }, DeviceChanges{Location: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := testDevice()
+			tt.update(&device)
+			changes := CompareDevices(testDevice(), device)
+			assert.Equal(t, tt.changes, changes)
+			assert.Equal(t, tt.changes.Labels || tt.changes.Description || tt.changes.Location || tt.changes == DeviceChanges{},
+				changes.Cosmetic())
+		})
+	}
+}
+
+func TestCompareDevicesEmpty(t *testing.T) {
+	previous := contract.Device{
+		Protocols: map[string]contract.ProtocolProperties{"other": nil},
+		Profile:   contract.DeviceProfile{DeviceResources: []contract.DeviceResource{{Name: "temperature"}}},
+	}
+	device := contract.Device{
+		Protocols:  map[string]contract.ProtocolProperties{"other": {}},
+		Labels:     []string{},
+		AutoEvents: []contract.AutoEvent{},
+		Profile: contract.DeviceProfile{
+			DeviceResources: []contract.DeviceResource{{Name: "temperature", Attributes: map[string]string{}}},
+			DeviceCommands:  []contract.ProfileResource{},
+			CoreCommands:    []contract.Command{},
+		},
+	}
+	assert.Equal(t, DeviceChanges{}, CompareDevices(previous, device), "an empty map or slice is the same as a missing one")
+}
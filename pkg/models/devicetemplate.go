@@ -0,0 +1,122 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// TemplateDeviceNameKey is the placeholder which is always replaced by the name
+// of the Device created from a DeviceTemplate.
+const TemplateDeviceNameKey = "deviceName"
+
+var placeholderPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_.\-]+)\}`)
+
+// DeviceTemplate describes a family of similar Devices. Protocol properties,
+// labels and description may contain placeholders in the form ${key} which are
+// filled in when a Device is created from the template.
+type DeviceTemplate struct {
+	Name        string                                 `json:"name"`
+	Description string                                 `json:"description,omitempty"`
+	ProfileName string                                 `json:"profileName"`
+	Protocols   map[string]contract.ProtocolProperties `json:"protocols,omitempty"`
+	AutoEvents  []contract.AutoEvent                   `json:"autoEvents,omitempty"`
+	Labels      []string                               `json:"labels,omitempty"`
+}
+
+// Validate checks that the DeviceTemplate defines the fields required to create a Device.
+func (t DeviceTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("DeviceTemplate name is required")
+	}
+	if strings.TrimSpace(t.ProfileName) == "" {
+		return fmt.Errorf("DeviceTemplate %s doesn't specify a Device Profile", t.Name)
+	}
+	return nil
+}
+
+// Placeholders returns the sorted keys of all placeholders used by the template,
+// excluding TemplateDeviceNameKey.
+func (t DeviceTemplate) Placeholders() []string {
+	keys := make(map[string]bool)
+	collect := func(s string) {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+			if m[1] != TemplateDeviceNameKey {
+				keys[m[1]] = true
+			}
+		}
+	}
+	collect(t.Description)
+	for _, l := range t.Labels {
+		collect(l)
+	}
+	for _, pp := range t.Protocols {
+		for _, v := range pp {
+			collect(v)
+		}
+	}
+
+	result := make([]string, 0, len(keys))
+	for k := range keys {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Render creates a Device named deviceName from the template, replacing every
+// placeholder with its value. An error is returned if any placeholder has no value.
+func (t DeviceTemplate) Render(deviceName string, values map[string]string) (contract.Device, error) {
+	var missing []string
+	for _, k := range t.Placeholders() {
+		if _, ok := values[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		return contract.Device{}, fmt.Errorf("missing values for placeholders %v of DeviceTemplate %s", missing, t.Name)
+	}
+
+	fill := func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(p string) string {
+			key := placeholderPattern.FindStringSubmatch(p)[1]
+			if key == TemplateDeviceNameKey {
+				return deviceName
+			}
+			return values[key]
+		})
+	}
+
+	device := contract.Device{
+		DescribedObject: contract.DescribedObject{Description: fill(t.Description)},
+		Name:            deviceName,
+		AdminState:      contract.Unlocked,
+		OperatingState:  contract.Enabled,
+		Protocols:       make(map[string]contract.ProtocolProperties, len(t.Protocols)),
+		Labels:          make([]string, len(t.Labels)),
+		AutoEvents:      make([]contract.AutoEvent, len(t.AutoEvents)),
+		Profile:         contract.DeviceProfile{Name: t.ProfileName},
+	}
+	for protocol, properties := range t.Protocols {
+		pp := make(contract.ProtocolProperties, len(properties))
+		for k, v := range properties {
+			pp[k] = fill(v)
+		}
+		device.Protocols[protocol] = pp
+	}
+	for i, l := range t.Labels {
+		device.Labels[i] = fill(l)
+	}
+	copy(device.AutoEvents, t.AutoEvents)
+
+	return device, nil
+}
@@ -0,0 +1,61 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeviceTemplate() DeviceTemplate {
+	return DeviceTemplate{
+		Name:        "modbus-meter",
+		Description: "meter ${location}",
+		ProfileName: "meter-profile",
+		Protocols: map[string]contract.ProtocolProperties{
+			"modbus-tcp": {"Address": "${address}", "Port": "502", "UnitID": "${unitId}"},
+		},
+		AutoEvents: []contract.AutoEvent{{Frequency: "10s", Resource: "energy"}},
+		Labels:     []string{"meter", "${deviceName}"},
+	}
+}
+
+func TestDeviceTemplatePlaceholders(t *testing.T) {
+	assert.Equal(t, []string{"address", "location", "unitId"}, testDeviceTemplate().Placeholders())
+}
+
+func TestDeviceTemplateRender(t *testing.T) {
+	template := testDeviceTemplate()
+
+	device, err := template.Render("meter-01", map[string]string{"address": "10.0.0.1", "unitId": "1", "location": "hall"})
+	require.NoError(t, err)
+	assert.Equal(t, "meter-01", device.Name)
+	assert.Equal(t, "meter hall", device.Description)
+	assert.Equal(t, "meter-profile", device.Profile.Name)
+	assert.Equal(t, contract.ProtocolProperties{"Address": "10.0.0.1", "Port": "502", "UnitID": "1"}, device.Protocols["modbus-tcp"])
+	assert.Equal(t, []string{"meter", "meter-01"}, device.Labels)
+	assert.Equal(t, template.AutoEvents, device.AutoEvents)
+	assert.Equal(t, "${address}", template.Protocols["modbus-tcp"]["Address"], "template must not be modified")
+
+	_, err = template.Render("meter-02", map[string]string{"address": "10.0.0.2"})
+	assert.Error(t, err, "missing placeholder values should fail")
+}
+
+func TestDeviceTemplateValidate(t *testing.T) {
+	valid := testDeviceTemplate()
+	noName := testDeviceTemplate()
+	noName.Name = ""
+	noProfile := testDeviceTemplate()
+	noProfile.ProfileName = " "
+
+	assert.NoError(t, valid.Validate())
+	assert.Error(t, noName.Validate())
+	assert.Error(t, noProfile.Validate())
+}
@@ -0,0 +1,44 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+// The kinds of device updates.
+const (
+	UpdateKindFirmware = "firmware"
+	UpdateKindConfig   = "config"
+)
+
+// DeviceUpdate describes a firmware or configuration update of a device.
+type DeviceUpdate struct {
+	// Kind is UpdateKindFirmware or UpdateKindConfig.
+	Kind    string `json:"kind"`
+	Version string `json:"version,omitempty"`
+	// Uri locates the firmware image or configuration, unless it's carried by Payload.
+	Uri     string `json:"uri,omitempty"`
+	Payload []byte `json:"payload,omitempty"`
+	// Parameters are the protocol-specific parameters of the update.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// UpdateProgress is called by the DeviceUpdater to report the progress, in percent, of
+// the update being applied.
+type UpdateProgress func(percent int, message string)
+
+// DeviceUpdater is implemented by ProtocolDrivers able to update the firmware or the
+// configuration of the devices. The Device Service applies an update by calling
+// StartUpdate then VerifyUpdate, and calls RollbackUpdate if either of them fails.
+type DeviceUpdater interface {
+	// StartUpdate applies the update to the device, reporting its progress, and returns
+	// once the update is applied.
+	StartUpdate(deviceName string, protocols map[string]contract.ProtocolProperties, update DeviceUpdate, progress UpdateProgress) error
+	// VerifyUpdate checks that the device runs with the applied update.
+	VerifyUpdate(deviceName string, protocols map[string]contract.ProtocolProperties, update DeviceUpdate) error
+	// RollbackUpdate restores the device to its state prior to the failed update.
+	RollbackUpdate(deviceName string, protocols map[string]contract.ProtocolProperties, update DeviceUpdate) error
+}
@@ -0,0 +1,73 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DriverErrorKind categorizes the errors returned by a ProtocolDriver, so that the SDK can
+// pick the response status, decide whether to retry the command and update the Device
+// OperatingState accordingly.
+type DriverErrorKind string
+
+const (
+	// NotReachable indicates the device cannot be connected. After Device.NotReachableThreshold
+	// consecutive ones, if set, the Device is disabled, its commands rejected and its
+	// AutoEvents skipped, until the ProtocolDriver re-enables it with
+	// DeviceService.SetDeviceOpState or DeviceService.ReportConnection once the device is
+	// reachable again: the SDK doesn't probe the disabled Devices.
+	NotReachable DriverErrorKind = "NotReachable"
+	// Timeout indicates the device didn't respond in time.
+	Timeout DriverErrorKind = "Timeout"
+	// Unauthorized indicates the device rejected the credentials of the Device Service.
+	Unauthorized DriverErrorKind = "Unauthorized"
+	// BadRequest indicates the device rejected the command or its parameters.
+	BadRequest DriverErrorKind = "BadRequest"
+	// Busy indicates the device cannot handle the command at the moment.
+	Busy DriverErrorKind = "Busy"
+	// Unsupported indicates the command isn't supported by the device or the ProtocolDriver.
+	Unsupported DriverErrorKind = "Unsupported"
+)
+
+// DriverError is an error returned by a ProtocolDriver along with its kind.
+type DriverError struct {
+	Kind DriverErrorKind
+	Err  error
+}
+
+// NewDriverError wraps the error with the kind.
+func NewDriverError(kind DriverErrorKind, err error) error {
+	return &DriverError{Kind: kind, Err: err}
+}
+
+func (e *DriverError) Error() string {
+	if e.Err == nil {
+		return string(e.Kind)
+	}
+	return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+}
+
+func (e *DriverError) Unwrap() error {
+	return e.Err
+}
+
+// DriverErrorKindOf returns the kind of the first DriverError in the chain of err, or an
+// empty kind if the error isn't wrapped in a DriverError.
+func DriverErrorKindOf(err error) DriverErrorKind {
+	var driverErr *DriverError
+	if errors.As(err, &driverErr) {
+		return driverErr.Kind
+	}
+	return ""
+}
+
+// Retryable reports whether a command failed with the kind of error may succeed if retried.
+func (k DriverErrorKind) Retryable() bool {
+	return k == NotReachable || k == Timeout || k == Busy
+}
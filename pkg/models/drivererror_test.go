@@ -0,0 +1,45 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriverErrorKindOf(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedKind  DriverErrorKind
+		expectedRetry bool
+	}{
+		{"untyped", cause, "", false},
+		{"NotReachable", NewDriverError(NotReachable, cause), NotReachable, true},
+		{"wrapped Timeout", fmt.Errorf("read failed: %w", NewDriverError(Timeout, cause)), Timeout, true},
+		{"Busy without cause", NewDriverError(Busy, nil), Busy, true},
+		{"Unauthorized", NewDriverError(Unauthorized, cause), Unauthorized, false},
+		{"BadRequest", NewDriverError(BadRequest, cause), BadRequest, false},
+		{"Unsupported", NewDriverError(Unsupported, cause), Unsupported, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			kind := DriverErrorKindOf(testCase.err)
+			assert.Equal(t, testCase.expectedKind, kind)
+			assert.Equal(t, testCase.expectedRetry, kind.Retryable())
+		})
+	}
+
+	err := NewDriverError(NotReachable, cause)
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, "NotReachable: connection refused", err.Error())
+}
@@ -0,0 +1,152 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventIdGenerator generates the ids and origins of the events created by the Device
+// Service, replacing the default random UUIDs and nanosecond timestamps.
+type EventIdGenerator interface {
+	// EventId returns the id of a new event of the device, which must be a UUID as the
+	// event ids are.
+	EventId(deviceName string) string
+	// Origin returns the origin of a new event of the device.
+	Origin(deviceName string) int64
+}
+
+// monotonicClock returns strictly increasing nanosecond timestamps.
+type monotonicClock struct {
+	previous int64
+}
+
+func (c *monotonicClock) now() int64 {
+	now := time.Now().UnixNano()
+	if now <= c.previous {
+		now = c.previous + 1
+	}
+	c.previous = now
+	return now
+}
+
+// newUUID returns the UUID of the version with the 48 bits of ms, the 12 bits of a and the
+// 62 bits of b, in this order so that the UUIDs sort as their fields do.
+func newUUID(version byte, ms int64, a uint16, b uint64) string {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[0:8], uint64(ms)<<16|uint64(version)<<12|uint64(a&0xfff))
+	binary.BigEndian.PutUint64(id[8:16], 0x2<<62|b&(1<<62-1))
+	s := hex.EncodeToString(id[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
+
+type sequenceGenerator struct {
+	startMs   int64
+	sequences map[string]uint64 // key is device name
+	clock     monotonicClock
+	mutex     sync.Mutex
+}
+
+// sequenceBits is the size of the sequence numbers in the ids of the sequence generator,
+// the remaining bits of the UUID holding the start time and a hash of the device name.
+const sequenceBits = 42
+
+// NewSequenceEventIdGenerator returns an EventIdGenerator numbering the events of each
+// device from 1. The ids are version 8 UUIDs made of the start time of the generator in
+// milliseconds, a 32-bit hash of the device name and the sequence number, so that the ids
+// of a device sort in the order of its events even though the sequences restart with the
+// Device Service.
+func NewSequenceEventIdGenerator() EventIdGenerator {
+	return &sequenceGenerator{startMs: time.Now().UnixNano() / int64(time.Millisecond), sequences: make(map[string]uint64)}
+}
+
+func (g *sequenceGenerator) EventId(deviceName string) string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.sequences[deviceName]++
+	h := fnv.New32a()
+	h.Write([]byte(deviceName))
+	hash := h.Sum32()
+	return newUUID(8, g.startMs, uint16(hash>>20), uint64(hash&0xfffff)<<sequenceBits|g.sequences[deviceName]&(1<<sequenceBits-1))
+}
+
+// EventSequence returns the sequence number of the event id generated by the sequence generator.
+func EventSequence(eventId string) (uint64, error) {
+	id, err := hex.DecodeString(strings.ReplaceAll(eventId, "-", ""))
+	if err != nil || len(id) != 16 || id[6]>>4 != 8 {
+		return 0, fmt.Errorf("event id %s isn't a sequence id", eventId)
+	}
+	return binary.BigEndian.Uint64(id[8:16]) & (1<<sequenceBits - 1), nil
+}
+
+func (g *sequenceGenerator) Origin(_ string) int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.clock.now()
+}
+
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxNode      = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+type snowflakeGenerator struct {
+	node     int64
+	lastMs   int64
+	sequence int64
+	random   *rand.Rand
+	clock    monotonicClock
+	mutex    sync.Mutex
+}
+
+// NewSnowflakeEventIdGenerator returns an EventIdGenerator of time-ordered ids, version 7
+// UUIDs made of a millisecond timestamp, a sequence number, the node number and random
+// bits. The node, from 0 to 1023, should be unique among the Device Services.
+func NewSnowflakeEventIdGenerator(node int64) (EventIdGenerator, error) {
+	if node < 0 || node > snowflakeMaxNode {
+		return nil, fmt.Errorf("snowflake node %d out of range [0, %d]", node, snowflakeMaxNode)
+	}
+	return &snowflakeGenerator{node: node, random: rand.New(rand.NewSource(time.Now().UnixNano()))}, nil
+}
+
+func (g *snowflakeGenerator) EventId(_ string) string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	ms := time.Now().UnixNano() / int64(time.Millisecond)
+	if ms < g.lastMs {
+		ms = g.lastMs
+	}
+	if ms == g.lastMs {
+		g.sequence = (g.sequence + 1) & snowflakeMaxSequence
+		if g.sequence == 0 {
+			// the sequence is exhausted, borrow the next millisecond
+			ms++
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastMs = ms
+
+	randomBits := 62 - snowflakeNodeBits
+	return newUUID(7, ms, uint16(g.sequence), uint64(g.node)<<randomBits|uint64(g.random.Int63())&(1<<randomBits-1))
+}
+
+func (g *snowflakeGenerator) Origin(_ string) int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.clock.now()
+}
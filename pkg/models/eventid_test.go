@@ -0,0 +1,62 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceEventIdGenerator(t *testing.T) {
+	g := NewSequenceEventIdGenerator()
+
+	sequence := func(id string) uint64 {
+		_, err := uuid.Parse(id)
+		require.NoError(t, err, "the ids are UUIDs")
+		n, err := EventSequence(id)
+		require.NoError(t, err)
+		return n
+	}
+	first := g.EventId("d1")
+	second := g.EventId("d1")
+	other := g.EventId("d2")
+	assert.Equal(t, uint64(1), sequence(first))
+	assert.Equal(t, uint64(2), sequence(second))
+	assert.Equal(t, uint64(1), sequence(other))
+	assert.Less(t, first, second, "the ids of a device sort in order")
+	assert.NotEqual(t, first, other)
+	assert.Less(t, g.Origin("d1"), g.Origin("d1"))
+
+	restarted := NewSequenceEventIdGenerator().(*sequenceGenerator)
+	restarted.startMs++
+	assert.Less(t, second, restarted.EventId("d1"), "the ids keep increasing once the sequence restarts")
+
+	_, err := EventSequence(uuid.New().String())
+	assert.Error(t, err)
+}
+
+func TestSnowflakeEventIdGenerator(t *testing.T) {
+	_, err := NewSnowflakeEventIdGenerator(snowflakeMaxNode + 1)
+	assert.Error(t, err)
+
+	g, err := NewSnowflakeEventIdGenerator(7)
+	require.NoError(t, err)
+	var previous string
+	for i := 0; i < 5000; i++ {
+		eventId := g.EventId("d1")
+		id, err := uuid.Parse(eventId)
+		require.NoError(t, err, "the ids are UUIDs")
+		assert.Equal(t, uuid.Version(7), id.Version())
+		require.Greater(t, eventId, previous, "ids are increasing")
+		assert.Equal(t, uint64(7), binary.BigEndian.Uint64(id[8:16])>>(62-snowflakeNodeBits)&snowflakeMaxNode)
+		previous = eventId
+	}
+}
@@ -0,0 +1,48 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestImports checks the package only depends on the standard library and
+// go-mod-core-contracts, keeping the driver interfaces free of the SDK dependencies.
+func TestImports(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	allowed := []string{"github.com/edgexfoundry/go-mod-core-contracts/"}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+		require.NoError(t, err)
+		for _, spec := range f.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			require.NoError(t, err)
+			if !strings.Contains(strings.Split(path, "/")[0], ".") {
+				continue
+			}
+			ok := false
+			for _, prefix := range allowed {
+				ok = ok || strings.HasPrefix(path, prefix)
+			}
+			if !ok {
+				t.Errorf("%s imports %s", file, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// MetricsReporter registers the custom metrics of the ProtocolDriver, e.g. the CRC errors
+// of a Modbus line, which the Device Service exposes on the /metrics endpoint along with
+// its own metrics. The names of the metrics are prefixed with edgex_device_driver_.
+type MetricsReporter interface {
+	// RegisterCounter registers the counter of the name and labels. Registering it again
+	// with the same labels returns the same counter.
+	RegisterCounter(name string, help string, labels ...string) (MetricCounter, error)
+	// RegisterGauge registers the gauge of the name and labels. Registering it again with
+	// the same labels returns the same gauge.
+	RegisterGauge(name string, help string, labels ...string) (MetricGauge, error)
+}
+
+// MetricCounter is a counter registered by the ProtocolDriver. The label values are given
+// in the order of the labels registered, and a value of other label values is ignored.
+type MetricCounter interface {
+	Inc(labelValues ...string)
+	Add(delta float64, labelValues ...string)
+}
+
+// MetricGauge is a gauge registered by the ProtocolDriver. The label values are given in
+// the order of the labels registered, and a value of other label values is ignored.
+type MetricGauge interface {
+	Set(value float64, labelValues ...string)
+}
+
+// MetricsReportingDriver is implemented by ProtocolDrivers reporting custom metrics. The
+// Device Service calls SetMetricsReporter before ProtocolDriver.Initialize.
+type MetricsReportingDriver interface {
+	SetMetricsReporter(reporter MetricsReporter)
+}
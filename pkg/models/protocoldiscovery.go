@@ -7,7 +7,11 @@
 
 package models
 
-import contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+import (
+	"context"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
 
 // ProtocolDiscovery is a low-level device-specific interface implemented
 // by device services that support dynamic device discovery.
@@ -19,6 +23,13 @@ type ProtocolDiscovery interface {
 	Discover()
 }
 
+// ContextDiscovery is implemented by ProtocolDiscoveries able to stop a scan once the
+// context is done, i.e. when the discovery is stopped through the API. The Device Service
+// calls DiscoverContext rather than Discover if the ProtocolDiscovery implements it.
+type ContextDiscovery interface {
+	DiscoverContext(ctx context.Context)
+}
+
 // DiscoveredDevice defines the required information for a found device.
 type DiscoveredDevice struct {
 	Name        string
@@ -26,3 +37,15 @@ type DiscoveredDevice struct {
 	Description string
 	Labels      []string
 }
+
+// DiscoveryProvider is a supplemental discovery mechanism, e.g. an mDNS or SSDP scan, run
+// alongside the ProtocolDiscovery of the ProtocolDriver. DiscoveryProviders are added with
+// DeviceService.AddDiscoveryProvider, and their results are merged with those of the
+// ProtocolDiscovery, the devices found more than once in a scan being added once.
+type DiscoveryProvider interface {
+	// Name identifies the DiscoveryProvider in the configuration.
+	Name() string
+	// Discover scans for devices until the scan completes or the context is done, i.e.
+	// when the discovery is stopped through the API.
+	Discover(ctx context.Context) ([]DiscoveredDevice, error)
+}
@@ -10,6 +10,10 @@
 // or protocol specific logic of a Device Service, and the structs represents request
 // and response data format used by the protocol driver.
 //
+// The package only depends on go-mod-core-contracts, not on the internals of the SDK
+// nor on go-mod-bootstrap, so that it can be published as a module of its own for the
+// out-of-tree drivers.
+//
 package models
 
 import (
@@ -0,0 +1,28 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// ProvisioningSourceLabelPrefix prefixes the name of the ProvisioningSource in the label of
+// the Devices synchronized from it.
+const ProvisioningSourceLabelPrefix = "source:"
+
+// ProvisioningSource is an external device registry, such as a CMDB or a provisioning
+// service, the Devices of the Device Service are synchronized from in addition to the
+// Core Metadata callbacks. The ProtocolDriver may implement it, or ProvisioningSources
+// may be added with DeviceService.AddProvisioningSource.
+type ProvisioningSource interface {
+	// Name identifies the ProvisioningSource. The Devices synchronized from it are
+	// labeled with ProvisioningSourceLabelPrefix followed by the name.
+	Name() string
+	// Devices returns the Devices the ProvisioningSource provisions to the Device Service.
+	// Only their Name, Description, Labels, Protocols, AdminState and Profile name are used.
+	Devices() ([]contract.Device, error)
+}
@@ -0,0 +1,12 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// SourceNameTag is the event tag naming the source of the event, i.e. the deviceCommand or
+// deviceResource read, as configured by Device.SourceName. It's omitted when no naming
+// strategy is configured.
+const SourceNameTag = "SourceName"
@@ -0,0 +1,45 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// SystemEvent is published onto the MessageBus when the Device Service changes the
+// provisioning, if MessageQueue.SystemEvents is enabled, so that orchestration tools can
+// react without polling Core Metadata.
+type SystemEvent struct {
+	// Type is the kind of entity the event is about, e.g. SystemEventTypeDevice.
+	Type string `json:"type"`
+	// Action is what happened to the entity, e.g. SystemEventActionAdd.
+	Action string `json:"action"`
+	// Source is the name of the Device Service.
+	Source string `json:"source"`
+	// Name is the name of the Device or Device Profile, if any.
+	Name string `json:"name,omitempty"`
+	// Details holds the data specific to the Type and Action.
+	Details interface{} `json:"details,omitempty"`
+	// Timestamp is the time of the event in nanoseconds.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// The types of the SystemEvents.
+const (
+	SystemEventTypeDevice        = "device"
+	SystemEventTypeDeviceProfile = "deviceprofile"
+	SystemEventTypeDiscovery     = "discovery"
+)
+
+// The actions of the SystemEvents.
+const (
+	SystemEventActionAdd    = "add"
+	SystemEventActionUpdate = "update"
+	SystemEventActionDelete = "delete"
+	// SystemEventActionApplied is a staged Device Profile applied to the Devices using it.
+	SystemEventActionApplied = "applied"
+	// SystemEventActionRolledBack is a Device Profile restored to its prior version.
+	SystemEventActionRolledBack = "rolledback"
+	// SystemEventActionCompleted is a discovery scan finished, whose result is the Details.
+	SystemEventActionCompleted = "completed"
+)
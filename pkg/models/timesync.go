@@ -0,0 +1,30 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// The resources of the time synchronization status readings published when the
+// ProtocolDriver reports the clock of a device.
+const (
+	// ClockOffsetResource is the offset, in milliseconds, of the device clock to
+	// the Device Service's. It's positive if the device clock is ahead.
+	ClockOffsetResource = "ClockOffset"
+	// ClockSyncedResource reports whether the device clock is synchronized.
+	ClockSyncedResource = "ClockSynced"
+)
+
+// TimeSetter is implemented by ProtocolDrivers able to set the clock of the devices.
+// SetDeviceTime is called when the reported clock offset of a device exceeds
+// Device.TimeSync.MaxSkew and Device.TimeSync.AutoSet is enabled.
+type TimeSetter interface {
+	SetDeviceTime(deviceName string, protocols map[string]contract.ProtocolProperties, t time.Time) error
+}
@@ -0,0 +1,25 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// TransactionalDriver is implemented by ProtocolDrivers able to apply the writes to several
+// devices as a coordinated unit. The Device Service prepares the writes to all the devices
+// of a transaction, then commits them if every one was prepared, or aborts the prepared
+// ones otherwise. Without it, the writes are applied one by one and the ones applied are
+// rolled back on a best-effort basis if a later one fails.
+type TransactionalDriver interface {
+	// PrepareWrite checks and stages the write to the device without applying it.
+	PrepareWrite(transactionId string, deviceName string, protocols map[string]contract.ProtocolProperties, reqs []CommandRequest, params []*CommandValue) error
+	// CommitWrite applies the write prepared for the device.
+	CommitWrite(transactionId string, deviceName string) error
+	// AbortWrite discards the write prepared for the device.
+	AbortWrite(transactionId string, deviceName string) error
+}
@@ -0,0 +1,28 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// Transform is a custom transformation of the values of the deviceResources, e.g. a CRC
+// validation or a unit conversion table. Transforms are added with DeviceService.AddTransform
+// and run after the built-in transformations on the deviceResources listing them, in order,
+// in their ds-transforms attribute, e.g. "crc16,fahrenheit". Writes take the inverse path:
+// the TransformWrites run in reverse order before the built-in transformations.
+// Device.DataTransform disables them along with the built-in transformations.
+type Transform interface {
+	// Name identifies the Transform in the ds-transforms attribute.
+	Name() string
+	// TransformRead transforms the value read from the deviceResource of the Device. The
+	// errors fail the read like those of the built-in transformations.
+	TransformRead(deviceName string, resource contract.DeviceResource, cv *CommandValue) (*CommandValue, error)
+	// TransformWrite transforms the value written to the deviceResource of the Device
+	// before it's passed to the ProtocolDriver. The errors fail the write.
+	TransformWrite(deviceName string, resource contract.DeviceResource, cv *CommandValue) (*CommandValue, error)
+}
@@ -0,0 +1,16 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// WritableConfigUpdater is implemented by ProtocolDrivers adjusting their settings, e.g. the
+// poll rates, credentials or thresholds, when the Writable.Driver configuration changes in
+// the Configuration Provider, without restarting the Device Service.
+type WritableConfigUpdater interface {
+	// UpdateWritableConfig is called with the whole Writable.Driver section once it changed.
+	// It shouldn't block as further configuration changes wait for it to return.
+	UpdateWritableConfig(raw map[string]string)
+}
@@ -15,8 +15,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/asyncqueue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/autodiscovery"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/confirmation"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/counter"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/smoothing"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
@@ -39,11 +46,12 @@ func (s *DeviceService) processAsyncResults(ctx context.Context, wg *sync.WaitGr
 	}()
 
 	working := make(chan bool, s.config.Service.AsyncBufferSize)
+	queue := asyncqueue.GetQueue().Out()
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case acv := <-s.asyncCh:
+		case acv := <-queue:
 			go s.sendAsyncValues(acv, working)
 		}
 	}
@@ -63,6 +71,11 @@ func (s *DeviceService) sendAsyncValues(acv *dsModels.AsyncValues, working chan
 		return
 	}
 
+	metrics.AsyncReadingsReceived(device.Name, len(acv.CommandValues))
+
+	// resolve write commands awaiting confirmation with the raw values pushed by the driver
+	confirmation.Match(acv.DeviceName, acv.CommandValues)
+
 	for _, cv := range acv.CommandValues {
 		// get the device resource associated with the rsp.RO
 		dr, ok := cache.Profiles().DeviceResource(device.Profile.Name, cv.DeviceResourceName)
@@ -73,6 +86,12 @@ func (s *DeviceService) sendAsyncValues(acv *dsModels.AsyncValues, working chan
 
 		if s.config.Device.DataTransform {
 			err := transformer.TransformReadResult(cv, dr.Properties.Value, s.LoggingClient)
+			if err == nil {
+				err = transformer.CalibrateReadResult(cv, device.Name, s.LoggingClient)
+			}
+			if err == nil {
+				cv, err = transformer.ApplyReadTransforms(device.Name, dr, cv)
+			}
 			if err != nil {
 				s.LoggingClient.Error(fmt.Sprintf("processAsyncResults - CommandValue (%s) transformed failed: %v", cv.String(), err))
 
@@ -86,6 +105,15 @@ func (s *DeviceService) sendAsyncValues(acv *dsModels.AsyncValues, working chan
 			}
 		}
 
+		if smoothing.IsSmoothed(dr.Attributes) {
+			smoothed, err := smoothing.Process(device.Name, cv, dr.Attributes)
+			if err != nil {
+				s.LoggingClient.Warn(fmt.Sprintf("processAsyncResults - failed to smooth %s: %v", cv.DeviceResourceName, err))
+			} else {
+				cv = smoothed
+			}
+		}
+
 		err := transformer.CheckAssertion(cv, dr.Properties.Value.Assertion, &device, s.LoggingClient, s.edgexClients.DeviceClient)
 		if err != nil {
 			s.LoggingClient.Error(fmt.Sprintf("processAsyncResults - Assertion failed for device resource: %s, with value: %s and assertion: %s, %v", cv.DeviceResourceName, cv.String(), dr.Properties.Value.Assertion, err))
@@ -104,14 +132,27 @@ func (s *DeviceService) sendAsyncValues(acv *dsModels.AsyncValues, working chan
 			}
 		}
 
-		reading := common.CommandValueToReading(cv, device.Name, dr.Properties.Value.MediaType, dr.Properties.Value.FloatEncoding)
-		readings = append(readings, *reading)
+		for _, cv := range counter.Readings(device.Name, cv, dr.Attributes, s.LoggingClient) {
+			reading := common.CommandValueToReading(cv, device.Name, dr.Properties.Value.MediaType, dr.Properties.Value.FloatEncoding)
+			readings = append(readings, *reading)
+		}
 	}
 
 	// push to Core Data
+	lastvalue.Record(readings, lastvalue.SourceAsync)
 	cevent := contract.Event{Device: device.Name, Readings: readings}
+	// the values pushed asynchronously aren't read with a command; their source is named
+	// after the deviceResource of the first one
+	if len(acv.CommandValues) > 0 {
+		cmd := acv.CommandValues[0].DeviceResourceName
+		if name := common.SourceName(device.Name, device.Profile.Name, cmd, acv.CommandValues); name != "" {
+			cevent.Tags = map[string]string{dsModels.SourceNameTag: name}
+		}
+	}
+	common.FilterReadingFields(&cevent, device.Profile.Name)
 	event := &dsModels.Event{Event: cevent}
-	event.Origin = common.GetUniqueOrigin()
+	event.ID = common.NewEventId(device.Name)
+	event.Origin = common.NewEventOrigin(device.Name)
 	common.SendEvent(event, s.LoggingClient, s.edgexClients.EventClient)
 }
 
@@ -127,20 +168,27 @@ func (s *DeviceService) processAsyncFilterAndAdd(ctx context.Context, wg *sync.W
 		case <-ctx.Done():
 			return
 		case devices := <-s.deviceCh:
+			// the devices found by the ProtocolDiscovery and the DiscoveryProviders are merged
+			devices = autodiscovery.Deduplicate(devices)
 			ctx := context.Background()
 			pws := cache.ProvisionWatchers().All()
 			for _, d := range devices {
 				for _, pw := range pws {
 					if whitelistPass(d, pw, s.LoggingClient) && blacklistPass(d, pw, s.LoggingClient) {
-						if _, ok := cache.Devices().ForName(d.Name); ok {
+						name, existing, err := s.discoveredDeviceName(d, pw.Profile.Name)
+						if err != nil {
+							s.LoggingClient.Debug(fmt.Sprintf("Candidate discovered device %s rejected: %v", d.Name, err))
+							break
+						}
+						if existing != nil {
 							s.LoggingClient.Debug(fmt.Sprintf("Candidate discovered device %s already existed", d.Name))
 							break
 						}
 
-						s.LoggingClient.Info(fmt.Sprintf("Adding discovered device %s to Edgex", d.Name))
+						s.LoggingClient.Info(fmt.Sprintf("Adding discovered device %s to Edgex", name))
 						millis := time.Now().UnixNano() / int64(time.Millisecond)
 						device := &contract.Device{
-							Name:           d.Name,
+							Name:           name,
 							Profile:        pw.Profile,
 							Protocols:      d.Protocols,
 							Labels:         d.Labels,
@@ -152,7 +200,7 @@ func (s *DeviceService) processAsyncFilterAndAdd(ctx context.Context, wg *sync.W
 						device.Origin = millis
 						device.Description = d.Description
 
-						_, err := s.edgexClients.DeviceClient.Add(ctx, device)
+						_, err = s.edgexClients.DeviceClient.Add(ctx, device)
 						if err != nil {
 							s.LoggingClient.Error(fmt.Sprintf("failed to create discovered device %s: %v", device.Name, err))
 						} else {
@@ -0,0 +1,144 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+)
+
+// The points of the startup sequence where the custom bootstrap handlers added with
+// AddBootstrapHandler run.
+const (
+	// BeforeClients runs the handlers once the secrets are available and the HTTP server
+	// started, before the clients of the EdgeX services are created.
+	BeforeClients = "beforeClients"
+	// AfterCache runs the handlers once the Device Service is initialized and its caches
+	// loaded, before the discovery starts.
+	AfterCache = "afterCache"
+)
+
+// The optional bootstrap handlers, which can be disabled with DisableBootstrapHandler.
+const (
+	// RegistryHandler registers the Device Service with the registry, if enabled by the
+	// --registry flag or the EDGEX_USE_REGISTRY environment variable, and monitors it.
+	// Disabled, the Device Service runs without the registry whatever the flag and the
+	// environment.
+	RegistryHandler = "registry"
+	// ProbesHandler serves the probe routes while the dependencies are awaited.
+	ProbesHandler = "probes"
+	// AutoDiscoveryHandler runs the discovery of the devices at startup and on schedule.
+	AutoDiscoveryHandler = "autodiscovery"
+	// StartMessageHandler logs the start message.
+	StartMessageHandler = "startMessage"
+)
+
+// namedHandler is a bootstrap handler of the startup sequence, named if it's optional or
+// an insertion point.
+type namedHandler struct {
+	name    string
+	handler interfaces.BootstrapHandler
+}
+
+var (
+	customHandlers   = make(map[string][]interfaces.BootstrapHandler)
+	disabledHandlers = make(map[string]bool)
+	handlersMutex    sync.Mutex
+)
+
+// AddBootstrapHandler adds a custom bootstrap handler run at the point of the startup
+// sequence, BeforeClients or AfterCache, after the handlers added before it there. It must
+// be called before the Device Service is bootstrapped.
+func AddBootstrapHandler(point string, handler interfaces.BootstrapHandler) error {
+	if point != BeforeClients && point != AfterCache {
+		return fmt.Errorf("unknown bootstrap handler point %s, should be '%s' or '%s'", point, BeforeClients, AfterCache)
+	}
+	handlersMutex.Lock()
+	defer handlersMutex.Unlock()
+	customHandlers[point] = append(customHandlers[point], handler)
+	return nil
+}
+
+// DisableBootstrapHandler removes the optional bootstrap handler, RegistryHandler,
+// ProbesHandler, AutoDiscoveryHandler or StartMessageHandler, from the startup sequence. It
+// must be called before the Device Service is bootstrapped.
+func DisableBootstrapHandler(name string) error {
+	switch name {
+	case RegistryHandler, ProbesHandler, AutoDiscoveryHandler, StartMessageHandler:
+	default:
+		return fmt.Errorf("bootstrap handler %s can't be disabled, should be '%s', '%s', '%s' or '%s'",
+			name, RegistryHandler, ProbesHandler, AutoDiscoveryHandler, StartMessageHandler)
+	}
+	handlersMutex.Lock()
+	defer handlersMutex.Unlock()
+	disabledHandlers[name] = true
+	return nil
+}
+
+// bootstrapHandlers returns the handlers of the startup sequence without the disabled ones,
+// with the custom handlers inserted at their points.
+func bootstrapHandlers(sequence []namedHandler) []interfaces.BootstrapHandler {
+	handlersMutex.Lock()
+	defer handlersMutex.Unlock()
+	result := make([]interfaces.BootstrapHandler, 0, len(sequence))
+	for _, h := range sequence {
+		if h.name == BeforeClients || h.name == AfterCache {
+			result = append(result, customHandlers[h.name]...)
+		}
+		if h.handler != nil && !disabledHandlers[h.name] {
+			result = append(result, h.handler)
+		}
+	}
+	return result
+}
+
+// resetBootstrapHandlers forgets the custom handlers and the disabled ones once the Device
+// Service stopped, so that they don't leak into the next one run by the process.
+func resetBootstrapHandlers() {
+	handlersMutex.Lock()
+	defer handlersMutex.Unlock()
+	customHandlers = make(map[string][]interfaces.BootstrapHandler)
+	disabledHandlers = make(map[string]bool)
+}
+
+// envUseRegistry is the environment variable enabling the registry, overriding the
+// --registry flag.
+const envUseRegistry = "EDGEX_USE_REGISTRY"
+
+// noRegistryFlags are the command-line flags with the --registry flag ignored.
+type noRegistryFlags struct {
+	flags.Common
+}
+
+func (noRegistryFlags) UseRegistry() bool {
+	return false
+}
+
+// registryFlags returns the command-line flags for the bootstrap and, if RegistryHandler is
+// disabled, overrides the --registry flag and EDGEX_USE_REGISTRY until the returned function
+// restores the latter.
+func registryFlags(commonFlags flags.Common) (flags.Common, func()) {
+	handlersMutex.Lock()
+	disabled := disabledHandlers[RegistryHandler]
+	handlersMutex.Unlock()
+	if !disabled {
+		return commonFlags, func() {}
+	}
+	previous, set := os.LookupEnv(envUseRegistry)
+	_ = os.Setenv(envUseRegistry, "false")
+	return noRegistryFlags{commonFlags}, func() {
+		if set {
+			_ = os.Setenv(envUseRegistry, previous)
+		} else {
+			_ = os.Unsetenv(envUseRegistry)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler returns a bootstrap handler appending its name to the calls.
+func recordingHandler(name string, calls *[]string) interfaces.BootstrapHandler {
+	return func(context.Context, *sync.WaitGroup, startup.Timer, *di.Container) bool {
+		*calls = append(*calls, name)
+		return true
+	}
+}
+
+func TestBootstrapHandlers(t *testing.T) {
+	defer resetBootstrapHandlers()
+	var calls []string
+	sequence := []namedHandler{
+		{handler: recordingHandler("config", &calls)},
+		{name: ProbesHandler, handler: recordingHandler(ProbesHandler, &calls)},
+		{name: BeforeClients},
+		{handler: recordingHandler("clients", &calls)},
+		{name: AfterCache},
+		{name: RegistryHandler, handler: recordingHandler(RegistryHandler, &calls)},
+		{name: StartMessageHandler, handler: recordingHandler(StartMessageHandler, &calls)},
+	}
+
+	require.NoError(t, AddBootstrapHandler(AfterCache, recordingHandler("custom1", &calls)))
+	require.NoError(t, AddBootstrapHandler(BeforeClients, recordingHandler("custom2", &calls)))
+	require.NoError(t, AddBootstrapHandler(AfterCache, recordingHandler("custom3", &calls)))
+	assert.Error(t, AddBootstrapHandler("unknown", recordingHandler("custom4", &calls)))
+	require.NoError(t, DisableBootstrapHandler(ProbesHandler))
+	require.NoError(t, DisableBootstrapHandler(RegistryHandler))
+	assert.Error(t, DisableBootstrapHandler(BeforeClients), "the insertion points aren't handlers")
+	assert.Error(t, DisableBootstrapHandler("unknown"))
+
+	for _, handler := range bootstrapHandlers(sequence) {
+		handler(context.Background(), &sync.WaitGroup{}, startup.Timer{}, nil)
+	}
+	assert.Equal(t, []string{"config", "custom2", "clients", "custom1", "custom3", StartMessageHandler}, calls)
+
+	resetBootstrapHandlers()
+	calls = nil
+	for _, handler := range bootstrapHandlers(sequence) {
+		handler(context.Background(), &sync.WaitGroup{}, startup.Timer{}, nil)
+	}
+	assert.Equal(t, []string{"config", ProbesHandler, "clients", RegistryHandler, StartMessageHandler}, calls,
+		"the handlers of a Device Service don't leak into the next one")
+}
+
+func TestRegistryFlags(t *testing.T) {
+	defer resetBootstrapHandlers()
+	sdkFlags := flags.New()
+	sdkFlags.Parse([]string{"--registry"})
+	require.NoError(t, os.Setenv(envUseRegistry, "true"))
+	defer os.Unsetenv(envUseRegistry)
+
+	bootstrapFlags, restore := registryFlags(sdkFlags)
+	assert.True(t, bootstrapFlags.UseRegistry())
+	restore()
+
+	require.NoError(t, DisableBootstrapHandler(RegistryHandler))
+	bootstrapFlags, restore = registryFlags(sdkFlags)
+	assert.False(t, bootstrapFlags.UseRegistry())
+	assert.Equal(t, "false", os.Getenv(envUseRegistry), "the environment doesn't enable the registry")
+	restore()
+	assert.Equal(t, "true", os.Getenv(envUseRegistry))
+}
@@ -0,0 +1,399 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-configuration/v2/configuration"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/registrymonitor"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// configFlags are the command-line flags locating the configuration file and the
+// Configuration Provider, set by Main.
+var configFlags flags.Common
+
+// customConfigMutex serializes the updates of the custom configuration sections.
+var customConfigMutex sync.Mutex
+
+// LoadCustomConfig loads the custom configuration section sectionName into config. With the
+// Configuration Provider, the section is loaded from it if present. Otherwise it's decoded
+// from the configuration file, and seeded into the Configuration Provider if used, as the
+// bootstrap does for the rest of the configuration. The section is decoded into a new value
+// of the type of config, which is rejected if it has keys unknown to the type or if its
+// Validate method fails, and applied with the UpdateFromRaw method.
+func (s *DeviceService) LoadCustomConfig(config dsModels.UpdatableConfig, sectionName string) error {
+	if _, err := newCustomConfig(config, sectionName); err != nil {
+		return err
+	}
+	client, err := s.customConfigClient(sectionName)
+	if err != nil {
+		return err
+	}
+	var raw dsModels.UpdatableConfig
+	if client != nil {
+		if raw, err = loadCustomConfigFromProvider(client, config, sectionName); err != nil {
+			return err
+		}
+	}
+	if raw == nil {
+		if raw, err = s.decodeCustomConfig(config, sectionName); err != nil {
+			return err
+		}
+		if client != nil {
+			if err = client.PutConfiguration(raw, false); err != nil {
+				return fmt.Errorf("could not push custom configuration section %s into the Configuration Provider: %v", sectionName, err)
+			}
+		}
+	}
+	if !updateCustomConfig(config, raw) {
+		return fmt.Errorf("custom configuration section %s failed the type check", sectionName)
+	}
+	s.LoggingClient.Info(fmt.Sprintf("loaded custom configuration section %s", sectionName))
+	return nil
+}
+
+// ListenForCustomConfigChanges watches the custom configuration section sectionName in the
+// Configuration Provider until the Device Service stops and applies its changes to config,
+// once validated, as LoadCustomConfig does. changedCallback, if not nil, is called with
+// config once the changed section is applied. The changes are ignored while the registry is
+// unavailable, and the section is pushed back once it's available again if it was lost. The
+// changes aren't listened for without the Configuration Provider.
+func (s *DeviceService) ListenForCustomConfigChanges(config dsModels.UpdatableConfig, sectionName string, changedCallback func(config interface{})) error {
+	if s.ctx == nil {
+		return errors.New("custom configuration changes can't be listened for before the Device Service starts")
+	}
+	if _, err := newCustomConfig(config, sectionName); err != nil {
+		return err
+	}
+	client, err := s.customConfigClient(sectionName)
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		s.LoggingClient.Warn(fmt.Sprintf("custom configuration section %s not listened for changes without the Configuration Provider", sectionName))
+		return nil
+	}
+	watchCustomConfig(s.ctx, s.wg, client, config, sectionName, changedCallback, s.LoggingClient)
+	return nil
+}
+
+// customConfigClient returns the client of the custom configuration section in the
+// Configuration Provider, or nil if the Configuration Provider isn't used.
+func (s *DeviceService) customConfigClient(sectionName string) (configuration.Client, error) {
+	if configFlags == nil {
+		return nil, errors.New("configuration unknown, the Device Service isn't started by Main")
+	}
+	providerInfo, err := bootstrapConfig.NewProviderInfo(environment.NewVariables(s.LoggingClient), configFlags.ConfigProviderUrl())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Configuration Provider: %v", err)
+	}
+	if !providerInfo.UseProvider() {
+		return nil, nil
+	}
+	providerConfig := providerInfo.ServiceConfig()
+	providerConfig.BasePath = common.ConfigStemDevice + common.ConfigMajorVersion + s.ServiceName + "/" + sectionName
+	client, err := configuration.NewConfigurationClient(providerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Configuration Provider client of custom configuration section %s: %v", sectionName, err)
+	}
+	return client, nil
+}
+
+// loadCustomConfigFromProvider returns a pointer to a new value of the type of config loaded
+// from the custom configuration section of the Configuration Provider and validated, or nil
+// if the section is absent.
+func loadCustomConfigFromProvider(client configuration.Client, config dsModels.UpdatableConfig, sectionName string) (dsModels.UpdatableConfig, error) {
+	exists, err := client.HasConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("could not check custom configuration section %s in the Configuration Provider: %v", sectionName, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	target, err := newCustomConfig(config, sectionName)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := client.GetConfiguration(target)
+	if err != nil {
+		return nil, fmt.Errorf("could not get custom configuration section %s from the Configuration Provider: %v", sectionName, err)
+	}
+	provided, ok := stored.(dsModels.UpdatableConfig)
+	if !ok || reflect.TypeOf(provided) != reflect.TypeOf(config) {
+		return nil, fmt.Errorf("custom configuration section %s from the Configuration Provider failed the type check", sectionName)
+	}
+	if err = checkProviderKeys(client, config, sectionName); err != nil {
+		return nil, err
+	}
+	if err = provided.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid custom configuration section %s in the Configuration Provider: %v", sectionName, err)
+	}
+	return provided, nil
+}
+
+// checkProviderKeys returns an error if the custom configuration section in the
+// Configuration Provider has keys unknown to the type of config, which the Configuration
+// Provider silently drops when decoding the section.
+func checkProviderKeys(client configuration.Client, config dsModels.UpdatableConfig, sectionName string) error {
+	stored, err := client.GetConfiguration(&map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("could not get custom configuration section %s from the Configuration Provider: %v", sectionName, err)
+	}
+	keys, ok := stored.(*map[string]interface{})
+	if !ok {
+		return fmt.Errorf("custom configuration section %s from the Configuration Provider failed the type check", sectionName)
+	}
+	if unknown := unknownKeys(*keys, reflect.TypeOf(config).Elem(), sectionName); len(unknown) > 0 {
+		return fmt.Errorf("invalid custom configuration section %s in the Configuration Provider: unknown keys %s", sectionName, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// unknownKeys returns the keys, prefixed with their path, of the section matching no field of
+// the struct type t the way the Configuration Provider decodes them: by consul tag, or else by
+// field name ignoring case.
+func unknownKeys(section map[string]interface{}, t reflect.Type, prefix string) []string {
+	var unknown []string
+	for key, value := range section {
+		field, ok := sectionField(t, key)
+		if !ok {
+			unknown = append(unknown, prefix+"."+key)
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if nested, ok := value.(map[string]interface{}); ok && fieldType.Kind() == reflect.Struct {
+			unknown = append(unknown, unknownKeys(nested, fieldType, prefix+"."+key)...)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// sectionField returns the exported field of the struct type t the key is decoded into.
+func sectionField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("consul"), ",")[0]; tag != "" {
+			name = tag
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// updateCustomConfig applies raw to config with UpdateFromRaw, holding config locked if it
+// implements sync.Locker so the ProtocolDriver reading it under the same lock never sees a
+// partial update.
+func updateCustomConfig(config dsModels.UpdatableConfig, raw dsModels.UpdatableConfig) bool {
+	customConfigMutex.Lock()
+	defer customConfigMutex.Unlock()
+	if locker, ok := config.(sync.Locker); ok {
+		locker.Lock()
+		defer locker.Unlock()
+	}
+	return config.UpdateFromRaw(raw)
+}
+
+// sameCustomConfig returns whether raw has the exported values of config. The values are
+// compared encoded, as a lock embedded in config differs from the zero one of raw.
+func sameCustomConfig(config dsModels.UpdatableConfig, raw dsModels.UpdatableConfig) bool {
+	customConfigMutex.Lock()
+	defer customConfigMutex.Unlock()
+	current, err := json.Marshal(config)
+	if err != nil {
+		return false
+	}
+	changed, err := json.Marshal(raw)
+	return err == nil && bytes.Equal(current, changed)
+}
+
+// watchCustomConfig applies the changes of the custom configuration section sent by the
+// Configuration Provider to config until the context is done.
+func watchCustomConfig(ctx context.Context, wg *sync.WaitGroup, client configuration.Client, config dsModels.UpdatableConfig,
+	sectionName string, changedCallback func(config interface{}), lc logger.LoggingClient) {
+	target, _ := newCustomConfig(config, sectionName)
+	updates := make(chan interface{})
+	errs := make(chan error)
+	client.WatchForChanges(updates, errs, target, "")
+	recovered := make(chan struct{}, 1)
+	registrymonitor.OnRecovery(func() {
+		select {
+		case recovered <- struct{}{}:
+		default:
+		}
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				// the watch fails until the registry is back, which the monitor already reports
+				if registrymonitor.Degraded() {
+					lc.Debug(fmt.Sprintf("failed to watch custom configuration section %s while the registry is unavailable: %v", sectionName, err))
+				} else {
+					lc.Error(fmt.Sprintf("failed to watch custom configuration section %s: %v", sectionName, err))
+				}
+			case <-recovered:
+				reseedCustomConfig(client, config, sectionName, lc)
+			case update := <-updates:
+				if registrymonitor.Degraded() {
+					lc.Debug(fmt.Sprintf("ignoring the change of custom configuration section %s while the registry is unavailable", sectionName))
+					continue
+				}
+				raw, ok := update.(dsModels.UpdatableConfig)
+				if !ok || reflect.TypeOf(raw) != reflect.TypeOf(config) {
+					lc.Error(fmt.Sprintf("ignoring the change of custom configuration section %s which failed the type check", sectionName))
+					continue
+				}
+				// the Configuration Provider sends the section as soon as the watch is set up
+				if sameCustomConfig(config, raw) {
+					continue
+				}
+				if err := checkProviderKeys(client, config, sectionName); err != nil {
+					lc.Error(fmt.Sprintf("ignoring the change of custom configuration section %s: %v", sectionName, err))
+					continue
+				}
+				if err := raw.Validate(); err != nil {
+					lc.Error(fmt.Sprintf("ignoring the invalid change of custom configuration section %s: %v", sectionName, err))
+					continue
+				}
+				if !updateCustomConfig(config, raw) {
+					lc.Error(fmt.Sprintf("ignoring the change of custom configuration section %s which failed the type check", sectionName))
+					continue
+				}
+				lc.Info(fmt.Sprintf("custom configuration section %s changed", sectionName))
+				if changedCallback != nil {
+					changedCallback(config)
+				}
+			}
+		}
+	}()
+}
+
+// reseedCustomConfig pushes config into the Configuration Provider if it lost the custom
+// configuration section, e.g. if it was restarted without its data during an outage.
+func reseedCustomConfig(client configuration.Client, config dsModels.UpdatableConfig, sectionName string, lc logger.LoggingClient) {
+	exists, err := client.HasConfiguration()
+	if err != nil || exists {
+		return
+	}
+	customConfigMutex.Lock()
+	err = client.PutConfiguration(config, false)
+	customConfigMutex.Unlock()
+	if err != nil {
+		lc.Error(fmt.Sprintf("could not push custom configuration section %s back into the Configuration Provider: %v", sectionName, err))
+		return
+	}
+	lc.Info(fmt.Sprintf("custom configuration section %s pushed back into the Configuration Provider", sectionName))
+}
+
+// newCustomConfig returns a pointer to a new zero value of the type of config, which must
+// be a pointer to a struct.
+func newCustomConfig(config dsModels.UpdatableConfig, sectionName string) (dsModels.UpdatableConfig, error) {
+	t := reflect.TypeOf(config)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("custom configuration of section %s must be a pointer to a struct", sectionName)
+	}
+	return reflect.New(t.Elem()).Interface().(dsModels.UpdatableConfig), nil
+}
+
+// decodeCustomConfig returns a pointer to a new value of the type of config decoded from the
+// custom configuration section of the configuration file and validated.
+func (s *DeviceService) decodeCustomConfig(config dsModels.UpdatableConfig, sectionName string) (dsModels.UpdatableConfig, error) {
+	if _, err := newCustomConfig(config, sectionName); err != nil {
+		return nil, err
+	}
+	path, err := s.configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not load configuration file (%s): %v", path, err)
+	}
+	raw, err := decodeCustomSection(string(contents), config, sectionName)
+	if err != nil {
+		return nil, fmt.Errorf("%v in %s", err, path)
+	}
+	return raw, nil
+}
+
+// decodeCustomSection returns a pointer to a new value of the type of config decoded from the
+// custom configuration section of the TOML contents and validated.
+func decodeCustomSection(contents string, config dsModels.UpdatableConfig, sectionName string) (dsModels.UpdatableConfig, error) {
+	raw, err := newCustomConfig(config, sectionName)
+	if err != nil {
+		return nil, err
+	}
+	var sections map[string]toml.Primitive
+	md, err := toml.Decode(contents, &sections)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+	section, ok := sections[sectionName]
+	if !ok {
+		return nil, fmt.Errorf("custom configuration section %s not found", sectionName)
+	}
+	if err = md.PrimitiveDecode(section, raw); err != nil {
+		return nil, fmt.Errorf("invalid custom configuration section %s: %v", sectionName, err)
+	}
+	var unknown []string
+	for _, key := range md.Undecoded() {
+		if len(key) > 1 && key[0] == sectionName {
+			unknown = append(unknown, key.String())
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("invalid custom configuration section %s: unknown keys %s", sectionName, strings.Join(unknown, ", "))
+	}
+	if err = raw.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid custom configuration section %s: %v", sectionName, err)
+	}
+	return raw, nil
+}
+
+// configFilePath returns the path of the configuration file as the bootstrap locates it.
+func (s *DeviceService) configFilePath() (string, error) {
+	if configFlags == nil {
+		return "", errors.New("configuration file unknown, the Device Service isn't started by Main")
+	}
+	return environment.GetConfDir(s.LoggingClient, configFlags.ConfigDirectory()) + "/" +
+		environment.GetProfileDir(s.LoggingClient, configFlags.Profile()) +
+		environment.GetConfigFileName(s.LoggingClient, configFlags.ConfigFileName()), nil
+}
@@ -0,0 +1,242 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v2/configuration"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCustomConfig struct {
+	Address string
+	Retries int
+}
+
+func (c *testCustomConfig) UpdateFromRaw(rawConfig interface{}) bool {
+	raw, ok := rawConfig.(*testCustomConfig)
+	if ok {
+		*c = *raw
+	}
+	return ok
+}
+
+func (c *testCustomConfig) Validate() error {
+	if c.Address == "" {
+		return errors.New("Address is required")
+	}
+	return nil
+}
+
+type mapConfig map[string]string
+
+func (c mapConfig) UpdateFromRaw(_ interface{}) bool { return true }
+
+func (c mapConfig) Validate() error { return nil }
+
+// providerMock is the Configuration Provider holding the custom configuration section.
+type providerMock struct {
+	configuration.Client
+	section *testCustomConfig
+	unknown map[string]interface{}
+	pushed  bool
+	updates chan<- interface{}
+	errs    chan<- error
+	watched chan struct{}
+}
+
+func (p *providerMock) HasConfiguration() (bool, error) {
+	return p.section != nil, nil
+}
+
+func (p *providerMock) PutConfiguration(configStruct interface{}, _ bool) error {
+	p.section = configStruct.(*testCustomConfig)
+	p.pushed = true
+	return nil
+}
+
+func (p *providerMock) GetConfiguration(target interface{}) (interface{}, error) {
+	if _, ok := target.(*map[string]interface{}); ok {
+		keys := map[string]interface{}{"Address": "", "Retries": ""}
+		for key, value := range p.unknown {
+			keys[key] = value
+		}
+		return &keys, nil
+	}
+	section := *p.section
+	return &section, nil
+}
+
+func (p *providerMock) WatchForChanges(updates chan<- interface{}, errs chan<- error, _ interface{}, _ string) {
+	p.updates, p.errs = updates, errs
+	close(p.watched)
+}
+
+func TestDecodeCustomSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected *testCustomConfig
+		errMsg   string
+	}{
+		{"valid", "[Custom]\nAddress = 'host:502'\nRetries = 3\n", &testCustomConfig{Address: "host:502", Retries: 3}, ""},
+		{"other sections ignored", "[Driver]\nFoo = 'bar'\n[Custom]\nAddress = 'host:502'\n", &testCustomConfig{Address: "host:502"}, ""},
+		{"section not found", "[Driver]\nFoo = 'bar'\n", nil, "not found"},
+		{"unknown key", "[Custom]\nAddress = 'host:502'\nRetry = 3\n", nil, "unknown keys Custom.Retry"},
+		{"wrong type", "[Custom]\nAddress = 'host:502'\nRetries = 'three'\n", nil, "invalid custom configuration section"},
+		{"invalid", "[Custom]\nRetries = 3\n", nil, "Address is required"},
+		{"invalid TOML", "[Custom\n", nil, "invalid configuration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := decodeCustomSection(tt.contents, &testCustomConfig{}, "Custom")
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, raw)
+		})
+	}
+
+	_, err := decodeCustomSection("[Custom]\n", mapConfig{}, "Custom")
+	assert.Error(t, err, "the configuration isn't a pointer to a struct")
+}
+
+func TestLoadCustomConfigFromProvider(t *testing.T) {
+	config := &testCustomConfig{}
+
+	provider := &providerMock{}
+	loaded, err := loadCustomConfigFromProvider(provider, config, "Custom")
+	require.NoError(t, err)
+	assert.Nil(t, loaded, "the section absent from the provider falls back to the file")
+
+	provider = &providerMock{section: &testCustomConfig{Address: "provider:502", Retries: 2}}
+	loaded, err = loadCustomConfigFromProvider(provider, config, "Custom")
+	require.NoError(t, err)
+	assert.False(t, provider.pushed)
+	assert.Equal(t, &testCustomConfig{Address: "provider:502", Retries: 2}, loaded)
+
+	provider = &providerMock{section: &testCustomConfig{Retries: 2}}
+	_, err = loadCustomConfigFromProvider(provider, config, "Custom")
+	assert.Error(t, err, "the section of the provider is validated")
+
+	provider = &providerMock{section: &testCustomConfig{Address: "provider:502"}, unknown: map[string]interface{}{"Retry": "3"}}
+	_, err = loadCustomConfigFromProvider(provider, config, "Custom")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown keys Custom.Retry")
+}
+
+type nestedCustomConfig struct {
+	Address string `consul:"Host"`
+	Timeout struct {
+		Read  string
+		Write string
+	}
+	Labels map[string]string
+	hidden string
+}
+
+func TestUnknownKeys(t *testing.T) {
+	section := map[string]interface{}{
+		"Host":    "host:502",
+		"address": "host:503",
+		"timeout": map[string]interface{}{"Read": "1s", "Connect": "2s"},
+		"Labels":  map[string]interface{}{"any": "label"},
+		"hidden":  "value",
+	}
+	unknown := unknownKeys(section, reflect.TypeOf(nestedCustomConfig{}), "Custom")
+	assert.Equal(t, []string{"Custom.address", "Custom.hidden", "Custom.timeout.Connect"}, unknown)
+}
+
+type heldLock struct {
+	held bool
+}
+
+func (l *heldLock) Lock() { l.held = true }
+
+func (l *heldLock) Unlock() { l.held = false }
+
+type lockedCustomConfig struct {
+	heldLock
+	Address string
+	locked  bool
+}
+
+func (c *lockedCustomConfig) UpdateFromRaw(rawConfig interface{}) bool {
+	raw, ok := rawConfig.(*lockedCustomConfig)
+	if ok {
+		c.locked = c.held
+		c.Address = raw.Address
+	}
+	return ok
+}
+
+func (c *lockedCustomConfig) Validate() error { return nil }
+
+func TestUpdateCustomConfig(t *testing.T) {
+	config := &lockedCustomConfig{heldLock: heldLock{held: true}, Address: "host:502"}
+	assert.True(t, sameCustomConfig(config, &lockedCustomConfig{Address: "host:502"}))
+	assert.False(t, sameCustomConfig(config, &lockedCustomConfig{Address: "host:503"}))
+	config.held = false
+
+	require.True(t, updateCustomConfig(config, &lockedCustomConfig{Address: "host:503"}))
+	assert.Equal(t, "host:503", config.Address)
+	assert.True(t, config.locked, "the configuration is updated locked")
+	assert.False(t, updateCustomConfig(config, &testCustomConfig{}))
+}
+
+func TestWatchCustomConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	config := &testCustomConfig{Address: "host:502"}
+	provider := &providerMock{watched: make(chan struct{})}
+	changed := make(chan *testCustomConfig, 1)
+	watchCustomConfig(ctx, wg, provider, config, "Custom", func(c interface{}) {
+		changed <- c.(*testCustomConfig)
+	}, logger.NewMockClient())
+	<-provider.watched
+
+	// the first update is the section as the watch is set up, which changes nothing
+	provider.updates <- &testCustomConfig{Address: "host:502"}
+	provider.updates <- &testCustomConfig{Retries: 5}
+	provider.errs <- errors.New("watch failed")
+	provider.updates <- &testCustomConfig{Address: "host:503", Retries: 5}
+
+	select {
+	case c := <-changed:
+		assert.Equal(t, &testCustomConfig{Address: "host:503", Retries: 5}, c, "only the valid change is applied")
+	case <-time.After(time.Second):
+		t.Fatal("the change wasn't applied")
+	}
+}
+
+func TestReseedCustomConfig(t *testing.T) {
+	config := &testCustomConfig{Address: "host:502"}
+	provider := &providerMock{section: &testCustomConfig{Address: "provider:502"}}
+	reseedCustomConfig(provider, config, "Custom", logger.NewMockClient())
+	assert.False(t, provider.pushed, "the section of the provider is kept")
+
+	provider = &providerMock{}
+	reseedCustomConfig(provider, config, "Custom", logger.NewMockClient())
+	assert.True(t, provider.pushed, "the lost section is pushed back")
+	assert.Equal(t, config, provider.section)
+}
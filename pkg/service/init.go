@@ -13,14 +13,41 @@ import (
 	"sync"
 	"time"
 
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/asyncqueue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/autodiscovery"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/autoevent"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/calibration"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/chaos"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/clients"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/controller"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/dedup"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/delta"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/executor"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/export"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/federation"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/health"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/history"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/messagebus"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/naming"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/playback"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/properties"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/provision"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/resolver"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/storeforward"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/systemevent"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
 	v2cache "github.com/edgexfoundry/device-sdk-go/v2/internal/v2/cache"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/store"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/gorilla/mux"
 )
 
@@ -36,9 +63,202 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 	}
 }
 
+// ProbesBootstrapHandler serves the readiness, liveness and startup status routes while
+// the subsequent bootstrap handlers wait for the dependencies.
+func (b *Bootstrap) ProbesBootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	ds.controller = controller.NewRestController(b.router, dic)
+	ds.controller.InitProbeRoutes()
+	return true
+}
+
 func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) (success bool) {
 	ds.UpdateFromContainer(b.router, dic)
+	ds.ctx, ds.wg = ctx, wg
+	if err := common.ValidateCapabilities(ds.config); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	if err := controller.ValidateCallbackAuth(ds.config.Service.CallbackAuth); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	chaos.SetEnabled(ds.config.Service.FailureInjection)
+	if ds.config.Service.FailureInjection {
+		ds.LoggingClient.Warn("failure injection enabled, not to be used in production")
+	}
+	if info := ds.config.Device.Store; info.Backend != "" || store.Get() != nil {
+		s, err := newStore(info.Backend, info.Path)
+		if err != nil {
+			ds.LoggingClient.Error(fmt.Sprintf("invalid Device.Store: %v", err))
+			return false
+		}
+		dic.Update(di.ServiceConstructorMap{
+			container.StoreName: func(get di.Get) interface{} {
+				return s
+			},
+		})
+	}
 	autoevent.NewManager(ctx, wg, ds.config.Service.AsyncBufferSize, dic)
+	wg.Add(1)
+	go telemetry.StartCpuUsageAverage(wg, ctx, ds.LoggingClient)
+	export.NewBuffer(ds.config.Device.Export.BufferSize)
+	if err := history.NewBuffer(ds.config.Device.CommandHistorySize, container.StoreFrom(dic.Get), ds.LoggingClient); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	history.GetBuffer().Run(ctx, wg, history.FlushInterval)
+	if info := ds.config.MessageQueue; info.Enabled {
+		err := messagebus.NewPublisher(messagebus.Config{
+			Type:               info.Type,
+			Protocol:           info.Protocol,
+			Host:               info.Host,
+			Port:               info.Port,
+			PublishTopicPrefix: info.PublishTopicPrefix,
+			Optional:           info.Optional,
+			Codec:              info.Codec,
+			StreamMaxLength:    info.StreamMaxLength,
+		})
+		if err != nil {
+			ds.LoggingClient.Error(err.Error())
+			return false
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			_ = messagebus.ClosePublisher()
+		}()
+	}
+	if ds.config.MessageQueue.SystemEvents && !ds.config.MessageQueue.Enabled {
+		ds.LoggingClient.Error("MessageQueue.SystemEvents requires the MessageQueue to be enabled")
+		return false
+	}
+	systemevent.Configure(ds.config.MessageQueue.SystemEvents, ds.config.MessageQueue.SystemEventTopic, ds.ServiceName, ds.LoggingClient)
+	if info := ds.config.Device.StoreForward; info.Enabled {
+		interval, err := time.ParseDuration(info.Interval)
+		if err != nil || interval <= 0 {
+			ds.LoggingClient.Error(fmt.Sprintf("invalid Device.StoreForward.Interval %s", info.Interval))
+			return false
+		}
+		s, err := newStore(info.Backend, info.Path)
+		if err != nil {
+			ds.LoggingClient.Error(fmt.Sprintf("invalid Device.StoreForward: %v", err))
+			return false
+		}
+		if err = storeforward.NewQueue(s, info.MaxDepth, ds.LoggingClient); err != nil {
+			ds.LoggingClient.Error(err.Error())
+			return false
+		}
+		storeforward.GetQueue().Run(ctx, wg, interval, info.BatchSize, func(contentType string, data []byte) error {
+			return common.ForwardEvent(container.CoredataEventClientFrom(dic.Get), contentType, data)
+		})
+	}
+	common.SetNumericEncoding(ds.config.Device.NumericEncoding)
+	common.SetNumericParsing(ds.config.Device.NumericParsing)
+	common.SetChunking(ds.config.Device.Chunking)
+	common.SetReadingFields(ds.config.Device.ReadingFields)
+	if err := common.SetSourceNaming(ds.config.Device.SourceName); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	common.SetRedactWriteOnly(ds.config.Device.RedactWriteOnly)
+	transformer.SetNotReachableThreshold(ds.config.Device.NotReachableThreshold)
+	if err := common.SetOriginPolicy(ds.config.Device.Origin); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	properties.SetLookup(func(deviceName string) (contract.Device, bool) {
+		return cache.Devices().ForName(deviceName)
+	})
+	if err := properties.SetEventTags(ds.config.Device.EventTags); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	maintenance.SetWindows(ds.config.Device.MaintenanceWindows, ds.LoggingClient)
+	maintenance.SetModeSource(func() common.MaintenanceModeInfo {
+		return ds.config.Writable.Maintenance
+	})
+	cache.SetAliases(ds.config.Device.Aliases)
+	cache.SetLimits(ds.config.Device.CacheLimits)
+	if err := calibration.Load(ds.config.Device.CalibrationFile); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	if err := naming.SetRules(ds.config.Device.Naming, ds.ServiceName); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+	if ds.config.Device.DedupWindow != "" {
+		window, err := time.ParseDuration(ds.config.Device.DedupWindow)
+		if err != nil {
+			ds.LoggingClient.Error(fmt.Sprintf("invalid Device.DedupWindow %s, duplicate events aren't suppressed: %v", ds.config.Device.DedupWindow, err))
+		}
+		dedup.NewFilter(window)
+	}
+	if info := ds.config.Device.Delta; len(info.Devices) > 0 || len(info.Labels) > 0 {
+		var interval time.Duration
+		if info.SnapshotInterval != "" {
+			d, err := time.ParseDuration(info.SnapshotInterval)
+			if err != nil {
+				ds.LoggingClient.Error(fmt.Sprintf("invalid Device.Delta.SnapshotInterval %s: %v", info.SnapshotInterval, err))
+				return false
+			}
+			interval = d
+		}
+		delta.NewFilter(deltaDevices(info), interval, info.SnapshotEvents)
+	}
+	common.SetDeviceDestinations(func(deviceName string) map[string]string {
+		d, ok := cache.Devices().ForName(deviceName)
+		if !ok {
+			return nil
+		}
+		return d.Protocols[common.DestinationProtocol]
+	})
+
+	if ds.AsyncReadings() {
+		ds.asyncCh = make(chan *dsModels.AsyncValues, ds.config.Service.AsyncBufferSize)
+		capacity := ds.config.Service.AsyncQueueCapacity
+		if capacity <= 0 {
+			capacity = ds.config.Service.AsyncBufferSize
+		}
+		queue, err := asyncqueue.NewQueue(capacity, ds.config.Service.AsyncOverflowPolicy,
+			ds.config.Service.AsyncRateLimit, ds.config.Service.AsyncRateBurst, ds.LoggingClient)
+		if err != nil {
+			ds.LoggingClient.Error(err.Error())
+			return false
+		}
+		go queue.Pump(ctx, wg, ds.asyncCh)
+	}
+	if ds.DeviceDiscovery() {
+		ds.deviceCh = make(chan []dsModels.DiscoveredDevice, 1)
+		autodiscovery.ConfigureProviders(ds.config.Device.Discovery.Providers, ds.deviceCh)
+	}
+
+	// resolve the names of the metadata objects the callbacks deliver by id
+	resolver.AddMetadataLookups(dic)
+
+	if ds.config.Service.DeferredStartup {
+		// the DeviceService is registered to Core Metadata once it's available
+		ds.deviceService = &contract.DeviceService{
+			Name:           ds.ServiceName,
+			Labels:         ds.config.Service.Labels,
+			OperatingState: contract.Enabled,
+			AdminState:     contract.Unlocked,
+		}
+		cache.InitEmptyCache()
+		v2cache.InitV2Cache()
+		registerMetricGauges()
+		if !initializeDriver(dic) {
+			return false
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			synchronize(ctx, wg, startupTimer, dic)
+		}()
+		return true
+	}
 
 	err := ds.selfRegister()
 	if err != nil {
@@ -54,22 +274,42 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 		container.MetadataDeviceClientFrom(dic.Get),
 		container.MetadataProvisionWatcherClientFrom(dic.Get))
 	v2cache.InitV2Cache()
+	registerMetricGauges()
+	health.CompletePhase(health.PhaseCacheLoaded, ds.LoggingClient)
 
-	if ds.AsyncReadings() {
-		ds.asyncCh = make(chan *dsModels.AsyncValues, ds.config.Service.AsyncBufferSize)
-		go ds.processAsyncResults(ctx, wg)
-	}
-	if ds.DeviceDiscovery() {
-		ds.deviceCh = make(chan []dsModels.DiscoveredDevice, 1)
-		go ds.processAsyncFilterAndAdd(ctx, wg)
+	processAsync(ctx, wg)
+	if !initializeDriver(dic) {
+		return false
 	}
+	return provisionDevices(ctx, wg, dic)
+}
 
-	err = ds.driver.Initialize(ds.LoggingClient, ds.asyncCh, ds.deviceCh)
+// initializeDriver initializes the ProtocolDriver and serves the REST API.
+func initializeDriver(dic *di.Container) bool {
+	if reporting, ok := ds.driver.(dsModels.MetricsReportingDriver); ok {
+		reporting.SetMetricsReporter(metrics.NewDriverReporter())
+	}
+	err := ds.driver.Initialize(ds.LoggingClient, ds.asyncCh, ds.deviceCh)
 	if err != nil {
 		ds.LoggingClient.Error(fmt.Sprintf("Driver.Initialize failed: %v\n", err))
 		return false
 	}
 	ds.initialized = true
+	if updater, ok := ds.driver.(dsModels.WritableConfigUpdater); ok {
+		common.SetWritableDriverHandler(func(raw map[string]string) {
+			ds.LoggingClient.Info("Writable.Driver configuration changed")
+			updater.UpdateWritableConfig(raw)
+		})
+	}
+	health.CompletePhase(health.PhaseDriverInitialized, ds.LoggingClient)
+
+	// execute the commands of the federated deviceResources through Core Command
+	driver := ds.driver
+	if cc := clients.NewCommandClient(ds.config); cc != nil {
+		driver = federation.NewDriver(driver, cc, time.Duration(ds.config.Service.Timeout)*time.Millisecond, ds.LoggingClient)
+	}
+	// bound the simultaneous driver invocations of commands issued by the SDK
+	driver = executor.NewDriver(driver, ds.config)
 
 	dic.Update(di.ServiceConstructorMap{
 		container.DeviceServiceName: func(get di.Get) interface{} {
@@ -79,12 +319,34 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 			return ds.discovery
 		},
 		container.ProtocolDriverName: func(get di.Get) interface{} {
+			return driver
+		},
+		container.RawProtocolDriverName: func(get di.Get) interface{} {
 			return ds.driver
 		},
 	})
 
 	ds.controller.InitRestRoutes()
+	return true
+}
+
+// processAsync starts processing the asynchronous readings and discovered Devices.
+func processAsync(ctx context.Context, wg *sync.WaitGroup) {
+	if ds.AsyncReadings() {
+		go ds.processAsyncResults(ctx, wg)
+	}
+	if ds.DeviceDiscovery() {
+		go ds.processAsyncFilterAndAdd(ctx, wg)
+	}
+}
 
+// provisionDevices creates the pre-defined Device Profiles and Devices and starts their AutoEvents.
+func provisionDevices(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) bool {
+	err := provision.LoadUnitsOfMeasure(ds.config.Device.UnitsOfMeasure, ds.LoggingClient)
+	if err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
 	err = provision.LoadProfiles(ds.config.Device.ProfilesDir, dic)
 	if err != nil {
 		ds.LoggingClient.Error(fmt.Sprintf("Failed to create the pre-defined device profiles: %v\n", err))
@@ -97,8 +359,106 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 		return false
 	}
 
+	if err = ds.startProvisioningSources(ctx, wg); err != nil {
+		ds.LoggingClient.Error(err.Error())
+		return false
+	}
+
 	autoevent.GetManager().StartAutoEvents(dic)
+	playback.NewManager(ctx, wg, ds.asyncCh, ds.LoggingClient)
+	playback.GetManager().StartPlayback()
+	health.CompletePhase(health.PhaseDevicesConnected, ds.LoggingClient)
 	http.TimeoutHandler(nil, time.Millisecond*time.Duration(ds.config.Service.Timeout), "Request timed out")
 
 	return true
 }
+
+// synchronize waits for Core Data and Core Metadata in deferred startup, then registers the
+// DeviceService, loads the caches, adds the retrieved Devices to the ProtocolDriver and
+// provisions the pre-defined Devices.
+func synchronize(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) {
+	for !clients.WaitForDependencies(ctx, startupTimer, dic) {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			ds.LoggingClient.Warn("Core Data or Core Metadata still unavailable, retrying")
+			startupTimer = startup.NewStartUpTimer(ds.ServiceName)
+		}
+	}
+
+	err := ds.selfRegister()
+	if err != nil {
+		ds.LoggingClient.Error(fmt.Sprintf("Couldn't register to metadata service: %v\n", err))
+		return
+	}
+	dic.Update(di.ServiceConstructorMap{
+		container.DeviceServiceName: func(get di.Get) interface{} {
+			return ds.deviceService
+		},
+	})
+
+	devices := cache.SyncCache(
+		ds.ServiceName,
+		ds.LoggingClient,
+		container.CoredataValueDescriptorClientFrom(dic.Get),
+		container.MetadataDeviceClientFrom(dic.Get),
+		container.MetadataProvisionWatcherClientFrom(dic.Get))
+	health.CompletePhase(health.PhaseCacheLoaded, ds.LoggingClient)
+
+	for _, d := range devices {
+		err = ds.driver.AddDevice(d.Name, common.DriverProtocols(d.Protocols), d.AdminState)
+		if err != nil {
+			ds.LoggingClient.Error(fmt.Sprintf("Driver.AddDevice failed for Device %s: %v", d.Name, err))
+		}
+	}
+
+	processAsync(ctx, wg)
+	if provisionDevices(ctx, wg, dic) {
+		ds.LoggingClient.Info("Deferred startup synchronized with Core Data and Core Metadata")
+	}
+}
+
+// deltaDevices returns whether a Device is in delta mode, by name or by label.
+func deltaDevices(info common.DeltaInfo) func(deviceName string) bool {
+	names := make(map[string]bool, len(info.Devices))
+	for _, name := range info.Devices {
+		names[name] = true
+	}
+	return func(deviceName string) bool {
+		if names[deviceName] {
+			return true
+		}
+		if len(info.Labels) == 0 {
+			return false
+		}
+		device, ok := cache.Devices().ForName(deviceName)
+		if !ok {
+			return false
+		}
+		for _, label := range device.Labels {
+			for _, l := range info.Labels {
+				if label == l {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// newStore returns the Store set with store.Set, if any, or the Store of the configured
+// backend: 'file' (default) persisting the values in the directory at path, or 'memory'.
+func newStore(backend string, path string) (store.Store, error) {
+	if s := store.Get(); s != nil {
+		return s, nil
+	}
+	switch backend {
+	case "", common.StoreBackendFile:
+		return store.NewFile(path)
+	case common.StoreBackendMemory:
+		return store.NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %s, should be '%s' or '%s'", backend, common.StoreBackendFile, common.StoreBackendMemory)
+	}
+}
@@ -14,11 +14,12 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/clients"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/health"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/registrymonitor"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/flags"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/handlers"
-	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
@@ -28,7 +29,14 @@ import (
 var instanceName string
 
 func Main(serviceName string, serviceVersion string, proto interface{}, ctx context.Context, cancel context.CancelFunc, router *mux.Router) {
+	MainWithArgs(serviceName, serviceVersion, proto, ctx, cancel, router, os.Args[1:])
+}
+
+// MainWithArgs runs the Device Service like Main with the command line arguments args rather
+// than those of the process, e.g. to run it from a test.
+func MainWithArgs(serviceName string, serviceVersion string, proto interface{}, ctx context.Context, cancel context.CancelFunc, router *mux.Router, args []string) {
 	startupTimer := startup.NewStartUpTimer(serviceName)
+	health.StartupBegan()
 
 	additionalUsage :=
 		"    -i, --instance                  Provides a service name suffix which allows unique instance to be created\n" +
@@ -36,7 +44,8 @@ func Main(serviceName string, serviceVersion string, proto interface{}, ctx cont
 	sdkFlags := flags.NewWithUsage(additionalUsage)
 	sdkFlags.FlagSet.StringVar(&instanceName, "instance", "", "")
 	sdkFlags.FlagSet.StringVar(&instanceName, "i", "", "")
-	sdkFlags.Parse(os.Args[1:])
+	sdkFlags.Parse(args)
+	configFlags = sdkFlags
 
 	serviceName = setServiceName(serviceName)
 	ds = &DeviceService{}
@@ -49,24 +58,38 @@ func Main(serviceName string, serviceVersion string, proto interface{}, ctx cont
 	})
 
 	httpServer := handlers.NewHttpServer(router, true)
+	sdkBootstrap := NewBootstrap(router)
+	defer resetBootstrapHandlers()
+	// the last-known Writable section is kept until the registry is back
+	common.SetWritableFrozen(registrymonitor.Degraded)
+	defer common.SetWritableFrozen(nil)
+	bootstrapFlags, restoreRegistry := registryFlags(sdkFlags)
+	defer restoreRegistry()
 
 	bootstrap.Run(
 		ctx,
 		cancel,
-		sdkFlags,
+		bootstrapFlags,
 		ds.ServiceName,
 		common.ConfigStemDevice+common.ConfigMajorVersion,
 		ds.config,
 		startupTimer,
 		dic,
-		[]interfaces.BootstrapHandler{
-			handlers.SecureProviderBootstrapHandler,
-			httpServer.BootstrapHandler,
-			clients.NewClients().BootstrapHandler,
-			NewBootstrap(router).BootstrapHandler,
-			autodiscovery.BootstrapHandler,
-			handlers.NewStartMessage(serviceName, serviceVersion).BootstrapHandler,
-		})
+		bootstrapHandlers([]namedHandler{
+			{handler: health.PhaseBootstrapHandler(health.PhaseConfigLoaded)},
+			{handler: handlers.SecureProviderBootstrapHandler},
+			{handler: health.PhaseBootstrapHandler(health.PhaseSecretsReady)},
+			{name: ProbesHandler, handler: sdkBootstrap.ProbesBootstrapHandler},
+			{handler: sdkBootstrap.HttpServerBootstrapHandler(httpServer)},
+			{handler: sdkBootstrap.SocketBootstrapHandler},
+			{name: BeforeClients},
+			{handler: clients.NewClients().BootstrapHandler},
+			{handler: sdkBootstrap.BootstrapHandler},
+			{name: AfterCache},
+			{name: RegistryHandler, handler: registrymonitor.BootstrapHandler},
+			{name: AutoDiscoveryHandler, handler: autodiscovery.BootstrapHandler},
+			{name: StartMessageHandler, handler: handlers.NewStartMessage(serviceName, serviceVersion).BootstrapHandler},
+		}))
 
 	ds.Stop(false)
 }
@@ -0,0 +1,68 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/maintenance"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// ReportConnection publishes the ConnectionEvent of the Device as a reading of the
+// Connection resource, tagged as a connection system event along with the reason if any,
+// and updates the OperatingState of the Device accordingly: it's disabled when the
+// connection is lost, unless it's in a maintenance window, and enabled when it's established.
+func (s *DeviceService) ReportConnection(deviceName string, connection dsModels.ConnectionEvent, reason error) error {
+	var state contract.OperatingState
+	switch connection {
+	case dsModels.ConnectionEstablished:
+		state = contract.Enabled
+	case dsModels.ConnectionLost:
+		state = contract.Disabled
+	default:
+		return fmt.Errorf("invalid ConnectionEvent %s", connection)
+	}
+	device, ok := cache.Devices().ForName(deviceName)
+	if !ok {
+		return fmt.Errorf("Device %s cannot be found in cache", deviceName)
+	}
+
+	tags := map[string]string{dsModels.SystemEventTag: dsModels.SystemEventConnection}
+	msg := fmt.Sprintf("Device %s connection %s", deviceName, connection)
+	if reason != nil {
+		tags[dsModels.ConnectionReasonTag] = reason.Error()
+		msg = fmt.Sprintf("%s: %v", msg, reason)
+	}
+	if connection == dsModels.ConnectionLost && !maintenance.Active(device) {
+		s.LoggingClient.Warn(msg)
+	} else {
+		s.LoggingClient.Info(msg)
+	}
+
+	origin := common.NewEventOrigin(deviceName)
+	cv := dsModels.NewStringValue(dsModels.ConnectionResource, origin, string(connection))
+	readings := []contract.Reading{*common.CommandValueToReading(cv, deviceName, "", "")}
+	event := &dsModels.Event{Event: contract.Event{Device: deviceName, Readings: readings, Tags: tags}}
+	event.ID = common.NewEventId(deviceName)
+	event.Origin = origin
+	go common.SendEvent(event, s.LoggingClient, s.edgexClients.EventClient)
+
+	if device.OperatingState == state {
+		return nil
+	}
+	if state == contract.Disabled && maintenance.Active(device) {
+		return nil
+	}
+	device.OperatingState = state
+	cache.Devices().Update(device)
+	return s.UpdateDeviceOperatingState(deviceName, string(state))
+}
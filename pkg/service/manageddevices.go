@@ -10,15 +10,24 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/systemevent"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/requests/states/operating"
 	"github.com/google/uuid"
 )
 
+// The OperatingStates of the later Core Metadata versions, accepted by SetDeviceOpState.
+const (
+	operatingStateUp   = "UP"
+	operatingStateDown = "DOWN"
+)
+
 // AddDevice adds a new Device to the Device Service and Core Metadata
 // Returns new Device id or non-nil error.
 func (s *DeviceService) AddDevice(device contract.Device) (id string, err error) {
@@ -54,6 +63,7 @@ func (s *DeviceService) AddDevice(device contract.Device) (id string, err error)
 		return "", err
 	}
 	device.Id = id
+	systemevent.Publish(dsModels.SystemEventTypeDevice, dsModels.SystemEventActionAdd, device.Name, device)
 
 	return id, nil
 }
@@ -89,6 +99,8 @@ func (s *DeviceService) RemoveDevice(id string) error {
 	err := s.edgexClients.DeviceClient.Delete(ctx, id)
 	if err != nil {
 		s.LoggingClient.Error(fmt.Sprintf("Delete Device %s from Core Metadata failed", id))
+	} else {
+		systemevent.Publish(dsModels.SystemEventTypeDevice, dsModels.SystemEventActionDelete, device.Name, nil)
 	}
 
 	return err
@@ -109,6 +121,8 @@ func (s *DeviceService) RemoveDeviceByName(name string) error {
 	err := s.edgexClients.DeviceClient.DeleteByName(ctx, name)
 	if err != nil {
 		s.LoggingClient.Error(fmt.Sprintf("Delete Device %s from Core Metadata failed", name))
+	} else {
+		systemevent.Publish(dsModels.SystemEventTypeDevice, dsModels.SystemEventActionDelete, name, nil)
 	}
 
 	return err
@@ -129,6 +143,8 @@ func (s *DeviceService) UpdateDevice(device contract.Device) error {
 	err := s.edgexClients.DeviceClient.Update(ctx, device)
 	if err != nil {
 		s.LoggingClient.Error(fmt.Sprintf("Update Device %s from Core Metadata failed: %v", device.Name, err))
+	} else {
+		systemevent.Publish(dsModels.SystemEventTypeDevice, dsModels.SystemEventActionUpdate, device.Name, device)
 	}
 
 	return err
@@ -153,3 +169,40 @@ func (s *DeviceService) UpdateDeviceOperatingState(deviceName string, state stri
 
 	return err
 }
+
+// SetDeviceOpState sets the OperatingState of the Device, e.g. to DISABLED (or DOWN) when
+// the ProtocolDriver detects the device went offline and back to ENABLED (or UP) once it's
+// reachable again. The Device Service applies it at once, rejecting the commands of the
+// disabled Device with 423 Locked and skipping its AutoEvents, and updates Core Metadata.
+func (s *DeviceService) SetDeviceOpState(deviceName string, state string) error {
+	opState, ok := contract.GetOperatingState(state)
+	if !ok {
+		switch strings.ToUpper(state) {
+		case operatingStateUp:
+			opState = contract.Enabled
+		case operatingStateDown:
+			opState = contract.Disabled
+		default:
+			return fmt.Errorf("invalid OperatingState %s", state)
+		}
+	}
+	d, ok := cache.Devices().ForName(deviceName)
+	if !ok {
+		return fmt.Errorf("Device %s cannot be found in cache", deviceName)
+	}
+	if d.OperatingState == opState {
+		return nil
+	}
+
+	s.LoggingClient.Info(fmt.Sprintf("Device %s OperatingState set to %s", d.Name, opState))
+	d.OperatingState = opState
+	if err := cache.Devices().Update(d); err != nil {
+		return err
+	}
+	ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.New().String())
+	err := s.edgexClients.DeviceClient.UpdateOpStateByName(ctx, d.Name, operating.UpdateRequest{OperatingState: opState})
+	if err != nil {
+		s.LoggingClient.Error(fmt.Sprintf("Update Device %s OperatingState from Core Metadata failed: %v", d.Name, err))
+	}
+	return err
+}
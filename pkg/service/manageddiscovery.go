@@ -0,0 +1,19 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/autodiscovery"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// AddDiscoveryProvider adds a supplemental discovery mechanism, e.g. an mDNS or SSDP
+// scan, run alongside the ProtocolDiscovery by the following scans. It can be disabled
+// in Device.Discovery.Providers.
+func (s *DeviceService) AddDiscoveryProvider(provider dsModels.DiscoveryProvider) error {
+	return autodiscovery.AddProvider(provider)
+}
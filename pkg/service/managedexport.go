@@ -0,0 +1,47 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/export"
+)
+
+// ExportEvents exports the events retained by the Device Service as a compressed
+// bundle, e.g. to be written on removable media by a device-specific tool. It returns
+// the bundle id, the compressed data and its signature. The events are exported again
+// by subsequent calls until MarkEventsExported is called with the bundle id.
+func (s *DeviceService) ExportEvents() (string, []byte, string, error) {
+	bundle, err := export.GetBuffer().Export(s.ServiceName)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	var key []byte
+	if s.config.Device.Export.SecretPath != "" {
+		secrets, err := s.SecretProvider.GetSecrets(s.config.Device.Export.SecretPath, export.SigningKeySecret)
+		if err != nil {
+			return "", nil, "", fmt.Errorf("failed to get the bundle signing key: %v", err)
+		}
+		key = []byte(secrets[export.SigningKeySecret])
+	}
+
+	data, signature, err := export.Encode(bundle, key)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	s.LoggingClient.Info(fmt.Sprintf("Exported %d events in bundle %s", len(bundle.Events), bundle.Id))
+	return bundle.Id, data, signature, nil
+}
+
+// MarkEventsExported discards the events of the specified bundle from the Device
+// Service once the bundle has been delivered.
+func (s *DeviceService) MarkEventsExported(bundleId string) error {
+	return export.GetBuffer().MarkExported(bundleId)
+}
@@ -0,0 +1,160 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/naming"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const defaultRegistrationInterval = 30 * time.Second
+
+var (
+	registrationAttempts = make(map[string]time.Time) // key is device name
+	registrationMutex    sync.Mutex
+)
+
+// RegisterDevice registers an unknown Device the driver received unsolicited traffic from.
+// The Device is created with the profile chosen by the driver or, if profileName is empty,
+// with the profile of the first Provision Watcher matching it; the AdminState is taken from
+// the matching Provision Watcher as well. A Device whose blocking identifiers match a
+// Provision Watcher is rejected.
+// The name is sanitized as the discovered Devices' and the existing Device is returned if
+// one has the same protocol properties; the Device.Naming.CollisionPolicy applies if the
+// name is taken by another Device. Attempts to register the same Device are limited to
+// one per Device.RegistrationInterval.
+func (s *DeviceService) RegisterDevice(d dsModels.DiscoveredDevice, profileName string) (contract.Device, error) {
+	device := contract.Device{
+		Labels:         d.Labels,
+		Protocols:      d.Protocols,
+		AdminState:     contract.Unlocked,
+		OperatingState: contract.Enabled,
+	}
+	device.Description = d.Description
+	device.Profile.Name = profileName
+	for _, pw := range cache.ProvisionWatchers().All() {
+		if !whitelistPass(d, pw, s.LoggingClient) {
+			continue
+		}
+		if !blacklistPass(d, pw, s.LoggingClient) {
+			return contract.Device{}, fmt.Errorf("Device %s is blocked by Provision Watcher %s", d.Name, pw.Name)
+		}
+		if device.Profile.Name == "" {
+			device.Profile.Name = pw.Profile.Name
+		}
+		if pw.AdminState != "" {
+			device.AdminState = pw.AdminState
+		}
+		break
+	}
+	if device.Profile.Name == "" {
+		return contract.Device{}, fmt.Errorf("no Device Profile chosen nor Provision Watcher matching Device %s", d.Name)
+	}
+
+	name, existing, err := s.discoveredDeviceName(d, device.Profile.Name)
+	if err != nil {
+		return contract.Device{}, err
+	}
+	if existing != nil {
+		return *existing, nil
+	}
+	if err = s.allowRegistration(name); err != nil {
+		return contract.Device{}, err
+	}
+	device.Name = name
+
+	s.LoggingClient.Info(fmt.Sprintf("Registering Device %s with Device Profile %s", name, device.Profile.Name))
+	id, err := s.AddDevice(device)
+	if err != nil {
+		return contract.Device{}, err
+	}
+	device.Id = id
+	device.Service = *s.deviceService
+
+	return device, nil
+}
+
+// SetNameSanitizer registers a function transforming the names of the discovered Devices
+// before the Device.Naming rules apply.
+func (s *DeviceService) SetNameSanitizer(sanitizer func(name string) string) {
+	naming.SetSanitizer(sanitizer)
+}
+
+// discoveredDeviceName returns the rendered or sanitized name of the discovered Device,
+// or the existing Device with the same protocol properties. The Device taking the name
+// is removed if the collision policy is to replace it.
+func (s *DeviceService) discoveredDeviceName(d dsModels.DiscoveredDevice, profileName string) (string, *contract.Device, error) {
+	// a name taken by the Device itself is reused
+	name, err := naming.Name(d, profileName, func(n string) bool {
+		existing, ok := cache.Devices().ForName(n)
+		return ok && !reflect.DeepEqual(existing.Protocols, d.Protocols)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if existing, ok := cache.Devices().ForName(name); ok && reflect.DeepEqual(existing.Protocols, d.Protocols) {
+		return name, &existing, nil
+	}
+	if len(d.Protocols) > 0 {
+		for _, existing := range cache.Devices().All() {
+			if reflect.DeepEqual(existing.Protocols, d.Protocols) {
+				s.LoggingClient.Debug(fmt.Sprintf("Device %s is already registered as %s", d.Name, existing.Name))
+				return existing.Name, &existing, nil
+			}
+		}
+	}
+
+	resolved, replace, err := naming.Resolve(name, func(n string) bool {
+		_, ok := cache.Devices().ForName(n)
+		return ok
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if replace {
+		s.LoggingClient.Info(fmt.Sprintf("Replacing Device %s with the discovered Device %s", name, d.Name))
+		if err = s.RemoveDeviceByName(name); err != nil {
+			return "", nil, err
+		}
+	}
+	return resolved, nil, nil
+}
+
+// allowRegistration records the registration attempt of the Device, or returns an error
+// if the previous attempt is more recent than Device.RegistrationInterval.
+func (s *DeviceService) allowRegistration(name string) error {
+	interval := defaultRegistrationInterval
+	if s.config.Device.RegistrationInterval != "" {
+		if d, err := time.ParseDuration(s.config.Device.RegistrationInterval); err == nil {
+			interval = d
+		} else {
+			s.LoggingClient.Warn(fmt.Sprintf("invalid Device.RegistrationInterval %s, using %s: %v", s.config.Device.RegistrationInterval, interval, err))
+		}
+	}
+
+	registrationMutex.Lock()
+	defer registrationMutex.Unlock()
+	now := time.Now()
+	if last, ok := registrationAttempts[name]; ok && now.Sub(last) < interval {
+		return fmt.Errorf("registration of Device %s was attempted %s ago, retry after %s", name, now.Sub(last).Round(time.Millisecond), interval)
+	}
+	for n, last := range registrationAttempts {
+		if now.Sub(last) >= interval {
+			delete(registrationAttempts, n)
+		}
+	}
+	registrationAttempts[name] = now
+	return nil
+}
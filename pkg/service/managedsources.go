@@ -0,0 +1,199 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+const (
+	// ConflictPolicyMetadata keeps the existing Device when a ProvisioningSource provisions
+	// a Device with its name.
+	ConflictPolicyMetadata = "metadata"
+	// ConflictPolicySource lets the ProvisioningSource take over the existing Device.
+	ConflictPolicySource = "source"
+
+	defaultSyncInterval = 5 * time.Minute
+)
+
+var (
+	provisioningSources []dsModels.ProvisioningSource
+	sourcesMutex        sync.Mutex
+)
+
+// AddProvisioningSource adds an external device registry the Devices are synchronized from,
+// every Device.ProvisioningSources.SyncInterval once the pre-defined Devices are provisioned.
+func (s *DeviceService) AddProvisioningSource(source dsModels.ProvisioningSource) error {
+	sourcesMutex.Lock()
+	defer sourcesMutex.Unlock()
+	for _, existing := range provisioningSources {
+		if existing.Name() == source.Name() {
+			return fmt.Errorf("ProvisioningSource %s already added", source.Name())
+		}
+	}
+	provisioningSources = append(provisioningSources, source)
+	return nil
+}
+
+// startProvisioningSources synchronizes the Devices from the ProvisioningSources until
+// the context is done.
+func (s *DeviceService) startProvisioningSources(ctx context.Context, wg *sync.WaitGroup) error {
+	info := s.config.Device.ProvisioningSources
+	switch info.ConflictPolicy {
+	case "", ConflictPolicyMetadata, ConflictPolicySource:
+	default:
+		return fmt.Errorf("invalid Device.ProvisioningSources.ConflictPolicy %s", info.ConflictPolicy)
+	}
+	interval := defaultSyncInterval
+	if info.SyncInterval != "" {
+		d, err := time.ParseDuration(info.SyncInterval)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid Device.ProvisioningSources.SyncInterval %s", info.SyncInterval)
+		}
+		interval = d
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.syncProvisioningSources()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *DeviceService) syncProvisioningSources() {
+	sourcesMutex.Lock()
+	sources := make([]dsModels.ProvisioningSource, len(provisioningSources))
+	copy(sources, provisioningSources)
+	sourcesMutex.Unlock()
+
+	for _, source := range sources {
+		devices, err := source.Devices()
+		if err != nil {
+			s.LoggingClient.Error(fmt.Sprintf("failed to get the Devices of ProvisioningSource %s: %v", source.Name(), err))
+			continue
+		}
+		s.syncProvisioningSource(source.Name(), devices)
+	}
+}
+
+// syncProvisioningSource adds and updates the Devices provisioned by the ProvisioningSource,
+// applying Device.ProvisioningSources.ConflictPolicy to the Devices of the same name not
+// synchronized from it, and removes the Devices it no longer provisions if
+// Device.ProvisioningSources.RemoveMissing is enabled.
+func (s *DeviceService) syncProvisioningSource(sourceName string, devices []contract.Device) {
+	info := s.config.Device.ProvisioningSources
+	label := dsModels.ProvisioningSourceLabelPrefix + sourceName
+
+	provisioned := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		provisioned[d.Name] = true
+		d.Labels = sourceLabels(d.Labels, label)
+
+		existing, ok := cache.Devices().ForName(d.Name)
+		if !ok {
+			s.LoggingClient.Info(fmt.Sprintf("adding Device %s from ProvisioningSource %s", d.Name, sourceName))
+			if d.AdminState == "" {
+				d.AdminState = contract.Unlocked
+			}
+			d.OperatingState = contract.Enabled
+			if _, err := s.AddDevice(d); err != nil {
+				s.LoggingClient.Error(fmt.Sprintf("failed to add Device %s from ProvisioningSource %s: %v", d.Name, sourceName, err))
+			}
+			continue
+		}
+
+		if !hasLabel(existing.Labels, label) && info.ConflictPolicy != ConflictPolicySource {
+			s.LoggingClient.Warn(fmt.Sprintf("Device %s of ProvisioningSource %s conflicts with an existing Device, which is kept", d.Name, sourceName))
+			continue
+		}
+		if sourceDeviceEqual(existing, d) {
+			continue
+		}
+
+		s.LoggingClient.Info(fmt.Sprintf("updating Device %s from ProvisioningSource %s", d.Name, sourceName))
+		existing.Description = d.Description
+		existing.Labels = d.Labels
+		existing.Protocols = d.Protocols
+		if d.AdminState != "" {
+			existing.AdminState = d.AdminState
+		}
+		if d.Profile.Name != existing.Profile.Name {
+			if profile, ok := cache.Profiles().ForName(d.Profile.Name); ok {
+				existing.Profile = profile
+			} else {
+				existing.Profile = contract.DeviceProfile{Name: d.Profile.Name}
+			}
+		}
+		if err := s.UpdateDevice(existing); err != nil {
+			s.LoggingClient.Error(fmt.Sprintf("failed to update Device %s from ProvisioningSource %s: %v", d.Name, sourceName, err))
+		}
+	}
+
+	for _, existing := range cache.Devices().All() {
+		if provisioned[existing.Name] || !hasLabel(existing.Labels, label) {
+			continue
+		}
+		if !info.RemoveMissing {
+			s.LoggingClient.Debug(fmt.Sprintf("Device %s is no longer provisioned by ProvisioningSource %s", existing.Name, sourceName))
+			continue
+		}
+		s.LoggingClient.Info(fmt.Sprintf("removing Device %s no longer provisioned by ProvisioningSource %s", existing.Name, sourceName))
+		if err := s.RemoveDeviceByName(existing.Name); err != nil {
+			s.LoggingClient.Error(fmt.Sprintf("failed to remove Device %s of ProvisioningSource %s: %v", existing.Name, sourceName, err))
+		}
+	}
+}
+
+// sourceLabels returns the labels with the label of the ProvisioningSource, replacing the
+// label of any other ProvisioningSource.
+func sourceLabels(labels []string, label string) []string {
+	result := make([]string, 0, len(labels)+1)
+	for _, l := range labels {
+		if !strings.HasPrefix(l, dsModels.ProvisioningSourceLabelPrefix) {
+			result = append(result, l)
+		}
+	}
+	return append(result, label)
+}
+
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func sourceDeviceEqual(existing contract.Device, d contract.Device) bool {
+	return existing.Description == d.Description &&
+		existing.Profile.Name == d.Profile.Name &&
+		(d.AdminState == "" || existing.AdminState == d.AdminState) &&
+		common.CompareStrings(existing.Labels, d.Labels) &&
+		reflect.DeepEqual(existing.Protocols, d.Protocols)
+}
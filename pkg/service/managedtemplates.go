@@ -0,0 +1,68 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// AddDeviceTemplate adds a new DeviceTemplate to the Device Service.
+func (s *DeviceService) AddDeviceTemplate(template dsModels.DeviceTemplate) error {
+	if err := template.Validate(); err != nil {
+		s.LoggingClient.Error(err.Error())
+		return err
+	}
+
+	s.LoggingClient.Debug(fmt.Sprintf("Adding managed DeviceTemplate: %s", template.Name))
+	return cache.Templates().Add(template)
+}
+
+// UpdateDeviceTemplate updates an existing DeviceTemplate of the Device Service.
+func (s *DeviceService) UpdateDeviceTemplate(template dsModels.DeviceTemplate) error {
+	if err := template.Validate(); err != nil {
+		s.LoggingClient.Error(err.Error())
+		return err
+	}
+
+	s.LoggingClient.Debug(fmt.Sprintf("Updating managed DeviceTemplate: %s", template.Name))
+	return cache.Templates().Update(template)
+}
+
+// RemoveDeviceTemplate removes the specified DeviceTemplate by name. Devices already
+// created from the template are not affected.
+func (s *DeviceService) RemoveDeviceTemplate(name string) error {
+	s.LoggingClient.Debug(fmt.Sprintf("Removing managed DeviceTemplate: %s", name))
+	return cache.Templates().RemoveByName(name)
+}
+
+// DeviceTemplates returns all DeviceTemplates of the Device Service.
+func (s *DeviceService) DeviceTemplates() []dsModels.DeviceTemplate {
+	return cache.Templates().All()
+}
+
+// AddDeviceFromTemplate creates a Device named deviceName from the specified DeviceTemplate,
+// filling its placeholders with values, and adds it to the Device Service and Core Metadata.
+// Returns new Device id or non-nil error.
+func (s *DeviceService) AddDeviceFromTemplate(templateName string, deviceName string, values map[string]string) (string, error) {
+	template, ok := cache.Templates().ForName(templateName)
+	if !ok {
+		msg := fmt.Sprintf("DeviceTemplate %s cannot be found in cache", templateName)
+		s.LoggingClient.Error(msg)
+		return "", fmt.Errorf(msg)
+	}
+
+	device, err := template.Render(deviceName, values)
+	if err != nil {
+		s.LoggingClient.Error(err.Error())
+		return "", err
+	}
+
+	return s.AddDevice(device)
+}
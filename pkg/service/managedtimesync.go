@@ -0,0 +1,74 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+	"time"
+
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/lastvalue"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// ReportTimeSync publishes the clock offset and synchronization status of the Device as
+// readings of the ClockOffset and ClockSynced resources. If the offset exceeds
+// Device.TimeSync.MaxSkew and Device.TimeSync.AutoSet is enabled, the clock of the
+// Device is set by the ProtocolDriver if it implements TimeSetter.
+func (s *DeviceService) ReportTimeSync(deviceName string, offset time.Duration, synced bool) error {
+	device, ok := cache.Devices().ForName(deviceName)
+	if !ok {
+		return fmt.Errorf("Device %s cannot be found in cache", deviceName)
+	}
+
+	origin := common.NewEventOrigin(deviceName)
+	offsetValue, err := dsModels.NewInt64Value(dsModels.ClockOffsetResource, origin, offset.Milliseconds())
+	if err != nil {
+		return err
+	}
+	syncedValue, err := dsModels.NewBoolValue(dsModels.ClockSyncedResource, origin, synced)
+	if err != nil {
+		return err
+	}
+	readings := []contract.Reading{
+		*common.CommandValueToReading(offsetValue, deviceName, "", ""),
+		*common.CommandValueToReading(syncedValue, deviceName, "", ""),
+	}
+	lastvalue.Record(readings, lastvalue.SourceAsync)
+	event := &dsModels.Event{Event: contract.Event{Device: deviceName, Readings: readings}}
+	event.ID = common.NewEventId(deviceName)
+	event.Origin = origin
+	go common.SendEvent(event, s.LoggingClient, s.edgexClients.EventClient)
+
+	if s.config.Device.TimeSync.MaxSkew == "" {
+		return nil
+	}
+	maxSkew, err := time.ParseDuration(s.config.Device.TimeSync.MaxSkew)
+	if err != nil {
+		return fmt.Errorf("invalid Device.TimeSync.MaxSkew %s: %v", s.config.Device.TimeSync.MaxSkew, err)
+	}
+	if offset <= maxSkew && offset >= -maxSkew {
+		return nil
+	}
+
+	s.LoggingClient.Warn(fmt.Sprintf("Device %s clock is off by %s", deviceName, offset))
+	setter, ok := s.driver.(dsModels.TimeSetter)
+	if !s.config.Device.TimeSync.AutoSet || !ok {
+		return nil
+	}
+	go func() {
+		if err := setter.SetDeviceTime(device.Name, device.Protocols, time.Now()); err != nil {
+			s.LoggingClient.Error(fmt.Sprintf("failed to set Device %s clock: %v", device.Name, err))
+			return
+		}
+		s.LoggingClient.Info(fmt.Sprintf("Device %s clock set", device.Name))
+	}()
+	return nil
+}
@@ -0,0 +1,18 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/transformer"
+	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
+)
+
+// AddTransform adds a custom transformation of the values read from and written to the
+// deviceResources listing it in their ds-transforms attribute.
+func (s *DeviceService) AddTransform(transform dsModels.Transform) error {
+	return transformer.AddTransform(transform)
+}
@@ -0,0 +1,28 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/confirmation"
+)
+
+// ConfirmWrite completes a write command awaiting asynchronous confirmation of the
+// given deviceResource. Drivers use it for protocols whose acknowledgement doesn't
+// carry the written value; a non-nil writeErr fails the pending write command.
+// Writes may also be confirmed by pushing the written value to AsyncValues channel.
+func (s *DeviceService) ConfirmWrite(deviceName string, resourceName string, writeErr error) error {
+	if !confirmation.Confirm(deviceName, resourceName, writeErr) {
+		msg := fmt.Sprintf("no write awaiting confirmation for Device %s Resource %s", deviceName, resourceName)
+		s.LoggingClient.Debug(msg)
+		return errors.New(msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/asyncqueue"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/metrics"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/registrymonitor"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/resolver"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/telemetry"
+)
+
+// registerMetricGauges registers the gauges of the cache sizes, of the asynchronous
+// readings queue, of the system usage and of the registry availability exposed on the
+// /metrics endpoint. The caches must be initialized.
+func registerMetricGauges() {
+	metrics.RegisterGauge("edgex_device_cache_devices", "Devices in the cache.", func() float64 {
+		return float64(len(cache.Devices().All()))
+	})
+	metrics.RegisterGauge("edgex_device_cache_profiles", "Device Profiles in the cache.", func() float64 {
+		return float64(len(cache.Profiles().All()))
+	})
+	metrics.RegisterGauge("edgex_device_cache_provision_watchers", "Provision Watchers in the cache.", func() float64 {
+		return float64(len(cache.ProvisionWatchers().All()))
+	})
+	metrics.RegisterGauge("edgex_device_async_queue_length", "Asynchronous readings pending processing.", func() float64 {
+		return float64(asyncQueueMetrics().Length)
+	})
+	metrics.RegisterGauge("edgex_device_async_dropped", "Asynchronous readings dropped as the queue was full.", func() float64 {
+		return float64(asyncQueueMetrics().Dropped)
+	})
+	metrics.RegisterGauge("edgex_device_async_throttled", "Asynchronous readings dropped over the rate limit.", func() float64 {
+		return float64(asyncQueueMetrics().Throttled)
+	})
+	metrics.RegisterGauge("edgex_device_resolver_hits", "Metadata object names resolved from the remembered ones.", func() float64 {
+		return float64(resolver.Stats().Hits)
+	})
+	metrics.RegisterGauge("edgex_device_resolver_misses", "Metadata object names resolved by a lookup.", func() float64 {
+		return float64(resolver.Stats().Misses)
+	})
+	metrics.RegisterGauge("edgex_device_resolver_failures", "Metadata object names that couldn't be resolved.", func() float64 {
+		return float64(resolver.Stats().Failures)
+	})
+	metrics.RegisterGauge("edgex_device_memory_alloc_bytes", "Bytes of allocated heap objects.", func() float64 {
+		return float64(telemetry.NewSystemUsage().Memory.Alloc)
+	})
+	metrics.RegisterGauge("edgex_device_memory_sys_bytes", "Bytes of memory obtained from the OS.", func() float64 {
+		return float64(telemetry.NewSystemUsage().Memory.Sys)
+	})
+	metrics.RegisterGauge("edgex_device_memory_live_objects", "Live heap objects.", func() float64 {
+		return float64(telemetry.NewSystemUsage().Memory.LiveObjects)
+	})
+	metrics.RegisterGauge("edgex_device_cpu_busy_avg", "Average CPU usage in percent.", func() float64 {
+		return telemetry.NewSystemUsage().CpuBusyAvg
+	})
+	metrics.RegisterGauge("edgex_device_registry_degraded", "1 while the registry is unavailable, 0 otherwise.", func() float64 {
+		if registrymonitor.Degraded() {
+			return 1
+		}
+		return 0
+	})
+	metrics.RegisterGauge("edgex_device_registry_outages", "Times the registry became unavailable.", func() float64 {
+		_, _, outages := registrymonitor.Status()
+		return float64(outages)
+	})
+}
+
+// asyncQueueMetrics returns the counters of the asynchronous readings queue, which are
+// zero if the asynchronous readings are disabled.
+func asyncQueueMetrics() asyncqueue.Metrics {
+	if queue := asyncqueue.GetQueue(); queue != nil {
+		return queue.Metrics()
+	}
+	return asyncqueue.Metrics{}
+}
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
@@ -25,6 +26,9 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
 	"github.com/edgexfoundry/device-sdk-go/v2/internal/controller"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/health"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/playback"
+	"github.com/edgexfoundry/device-sdk-go/v2/pkg/clock"
 	dsModels "github.com/edgexfoundry/device-sdk-go/v2/pkg/models"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -59,6 +63,8 @@ type DeviceService struct {
 	asyncCh        chan *dsModels.AsyncValues
 	deviceCh       chan []dsModels.DiscoveredDevice
 	initialized    bool
+	ctx            context.Context
+	wg             *sync.WaitGroup
 }
 
 func (s *DeviceService) Initialize(serviceName, serviceVersion string, proto interface{}) {
@@ -81,6 +87,14 @@ func (s *DeviceService) Initialize(serviceName, serviceVersion string, proto int
 		os.Exit(1)
 	}
 
+	if advertiser, ok := proto.(dsModels.CapabilityAdvertiser); ok {
+		common.SetDriverCapabilities(advertiser.Capabilities())
+	}
+
+	if source, ok := proto.(dsModels.ProvisioningSource); ok {
+		_ = s.AddProvisioningSource(source)
+	}
+
 	if discovery, ok := proto.(dsModels.ProtocolDiscovery); ok {
 		s.discovery = discovery
 	} else {
@@ -103,7 +117,9 @@ func (s *DeviceService) UpdateFromContainer(r *mux.Router, dic *di.Container) {
 	s.edgexClients.EventClient = container.CoredataEventClientFrom(dic.Get)
 	s.edgexClients.ValueDescriptorClient = container.CoredataValueDescriptorClientFrom(dic.Get)
 	s.config = container.ConfigurationFrom(dic.Get)
-	s.controller = controller.NewRestController(r, dic)
+	if s.controller == nil {
+		s.controller = controller.NewRestController(r, dic)
+	}
 }
 
 // Name returns the name of this Device Service
@@ -130,12 +146,28 @@ func (s *DeviceService) AddRoute(route string, handler func(http.ResponseWriter,
 	return s.controller.AddRoute(route, handler, methods...)
 }
 
+// SetEventIdGenerator replaces the random UUIDs and timestamps used as ids and origins of
+// the events created by the Device Service, e.g. with dsModels.NewSnowflakeEventIdGenerator.
+// The ids generated must be UUIDs. nil restores the default generator.
+func (s *DeviceService) SetEventIdGenerator(generator dsModels.EventIdGenerator) {
+	common.SetEventIdGenerator(generator)
+}
+
+// SetClock replaces the clock of the AutoEvents, the retries of the driver commands and
+// the expiry of the cached values, e.g. with a clock.Fake in tests. nil restores the
+// system clock.
+func (s *DeviceService) SetClock(c clock.Clock) {
+	clock.Set(c)
+}
+
 // Stop shuts down the Service
 func (s *DeviceService) Stop(force bool) {
+	health.SetStopping()
 	if s.initialized {
 		_ = s.driver.Stop(false)
 	}
 	autoevent.GetManager().StopAutoEvents()
+	playback.GetManager().StopPlayback()
 }
 
 // selfRegister register device service itself onto metadata.
@@ -247,3 +279,9 @@ func RunningService() *DeviceService {
 func DriverConfigs() map[string]string {
 	return ds.config.Driver
 }
+
+// WritableDriverConfigs retrieves the driver specific configuration which can be changed in
+// the Registry while the Device Service runs.
+func WritableDriverConfigs() map[string]string {
+	return ds.config.Writable.Driver
+}
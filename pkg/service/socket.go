@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+const defaultSocketMode = 0660
+
+// SocketBootstrapHandler serves the REST API on the Unix domain socket of Service.SocketPath,
+// if set, in addition to the TCP port. The socket gets the file mode of Service.SocketMode
+// once created, having the mode of the umask until then, so its directory should restrict
+// the access too.
+func (b *Bootstrap) SocketBootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	config := container.ConfigurationFrom(dic.Get)
+	path := config.Service.SocketPath
+	if path == "" {
+		return true
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	mode, err := socketMode(config.Service.SocketMode)
+	if err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+
+	// the socket left by a previous run which didn't shut down cleanly is replaced
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		_ = os.Remove(path)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to listen on Unix domain socket %s: %v", path, err))
+		return false
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		_ = listener.Close()
+		lc.Error(fmt.Sprintf("failed to set the mode of Unix domain socket %s: %v", path, err))
+		return false
+	}
+
+	timeout := time.Duration(config.Service.Timeout) * time.Millisecond
+	server := &http.Server{
+		Handler:      b.router,
+		WriteTimeout: timeout,
+		ReadTimeout:  timeout,
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	lc.Info(fmt.Sprintf("Web server starting (unix:%s)", path))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// closing the listener removes the socket file
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			lc.Error(fmt.Sprintf("Web server on Unix domain socket %s failed: %v", path, err))
+		}
+	}()
+	return true
+}
+
+// socketMode parses the octal Service.SocketMode, defaultSocketMode if unset.
+func socketMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		return defaultSocketMode, nil
+	}
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || m > 0777 {
+		return 0, fmt.Errorf("invalid Service.SocketMode %s, should be an octal file mode such as '0660'", mode)
+	}
+	return os.FileMode(m), nil
+}
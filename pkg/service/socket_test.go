@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+)
+
+func socketContainer(service common.ServiceInfo) *di.Container {
+	config := &common.ConfigurationStruct{Service: service}
+	return di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return config
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+}
+
+func TestSocketBootstrapHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.sock")
+	// the socket left by a previous run which didn't shut down cleanly
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	router := mux.NewRouter()
+	router.HandleFunc("/ping", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	dic := socketContainer(common.ServiceInfo{SocketPath: path, Timeout: 5000})
+	require.True(t, NewBootstrap(router).SocketBootstrapHandler(ctx, wg, startup.Timer{}, dic))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(defaultSocketMode), info.Mode().Perm())
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://unix/ping")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	wg.Wait()
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "the socket is removed once the server shuts down")
+}
+
+func TestSocketBootstrapHandlerMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	dic := socketContainer(common.ServiceInfo{SocketPath: path, SocketMode: "0600"})
+	require.True(t, NewBootstrap(mux.NewRouter()).SocketBootstrapHandler(ctx, wg, startup.Timer{}, dic))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	cancel()
+	wg.Wait()
+
+	for _, mode := range []string{"rw-rw----", "0888", "01777"} {
+		dic = socketContainer(common.ServiceInfo{SocketPath: path, SocketMode: mode})
+		assert.False(t, NewBootstrap(mux.NewRouter()).SocketBootstrapHandler(context.Background(), wg, startup.Timer{}, dic), mode)
+	}
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "no socket is created with an invalid mode")
+}
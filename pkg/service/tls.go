@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/handlers"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v2/internal/tlsconfig"
+)
+
+// HttpServerBootstrapHandler returns the handler serving the REST API over HTTPS if
+// Service.TLS is enabled, and over HTTP by the httpServer otherwise.
+func (b *Bootstrap) HttpServerBootstrapHandler(httpServer *handlers.HttpServer) interfaces.BootstrapHandler {
+	return func(ctx context.Context, wg *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+		if !container.ConfigurationFrom(dic.Get).Service.TLS.Enabled {
+			return httpServer.BootstrapHandler(ctx, wg, startupTimer, dic)
+		}
+		return b.httpsServer(ctx, wg, dic)
+	}
+}
+
+func (b *Bootstrap) httpsServer(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) bool {
+	config := container.ConfigurationFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	if err := tlsconfig.ValidateTLS(config.Service.TLS); err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	provider := bootstrapContainer.SecretProviderFrom(dic.Get)
+	if provider == nil {
+		lc.Error("no Secret Provider to get the TLS certificates from")
+		return false
+	}
+	loader := tlsconfig.NewLoader(config.Service.TLS, provider, lc)
+	if _, err := loader.Load(); err != nil {
+		lc.Error(err.Error())
+		return false
+	}
+	loader.Watch(ctx, wg)
+
+	// the Host is bound to unless ServerBindAddr is set, as done by the HTTP server
+	addr := config.Service.ServerBindAddr
+	if addr == "" {
+		addr = config.Service.Host
+	}
+	addr += ":" + strconv.Itoa(config.Service.Port)
+	timeout := time.Duration(config.Service.Timeout) * time.Millisecond
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      b.router,
+		TLSConfig:    loader.Config(),
+		WriteTimeout: timeout,
+		ReadTimeout:  timeout,
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		lc.Info("Web server shutting down")
+		_ = server.Shutdown(context.Background())
+		lc.Info("Web server shut down")
+	}()
+
+	lc.Info(fmt.Sprintf("Web server starting (https://%s)", addr))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// the certificates are served by the TLS configuration rather than read from files
+		if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			lc.Error(fmt.Sprintf("Web server failed: %v", err))
+			cancel := bootstrapContainer.CancelFuncFrom(dic.Get)
+			cancel()
+		} else {
+			lc.Info("Web server stopped")
+		}
+	}()
+	return true
+}
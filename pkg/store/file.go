@@ -0,0 +1,105 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const tempFileSuffix = ".tmp"
+
+// fileStore persists the values in a directory, one subdirectory per bucket and one file
+// per key named after the key in hexadecimal. The files are written to a temporary file
+// first and renamed, so that a value is never partially written.
+type fileStore struct {
+	dir   string
+	mutex sync.RWMutex
+}
+
+// NewFile returns a Store persisting the values in the directory, which is created if
+// it doesn't exist.
+func NewFile(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the store directory %s: %v", dir, err)
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (s *fileStore) path(bucket string, key string) string {
+	return filepath.Join(s.dir, hex.EncodeToString([]byte(bucket)), hex.EncodeToString([]byte(key)))
+}
+
+func (s *fileStore) Put(bucket string, key string, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	path := s.path(bucket, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create the bucket %s: %v", bucket, err)
+	}
+	if err := ioutil.WriteFile(path+tempFileSuffix, value, 0600); err != nil {
+		return fmt.Errorf("failed to store the key %s in bucket %s: %v", key, bucket, err)
+	}
+	if err := os.Rename(path+tempFileSuffix, path); err != nil {
+		return fmt.Errorf("failed to store the key %s in bucket %s: %v", key, bucket, err)
+	}
+	return nil
+}
+
+func (s *fileStore) Get(bucket string, key string) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	value, err := ioutil.ReadFile(s.path(bucket, key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the key %s of bucket %s: %v", key, bucket, err)
+	}
+	return value, nil
+}
+
+func (s *fileStore) Delete(bucket string, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := os.Remove(s.path(bucket, key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete the key %s of bucket %s: %v", key, bucket, err)
+	}
+	return nil
+}
+
+func (s *fileStore) Keys(bucket string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	dir := filepath.Join(s.dir, hex.EncodeToString([]byte(bucket)))
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the bucket %s: %v", bucket, err)
+	}
+	keys := make([]string, 0, len(files))
+	for _, f := range files {
+		// the temporary files are left over by an interrupted Put
+		if strings.HasSuffix(f.Name(), tempFileSuffix) {
+			_ = os.Remove(filepath.Join(dir, f.Name()))
+			continue
+		}
+		key, err := hex.DecodeString(f.Name())
+		if err != nil {
+			continue
+		}
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
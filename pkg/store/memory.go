@@ -0,0 +1,64 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"sort"
+	"sync"
+)
+
+// memoryStore keeps the values in memory until the Device Service stops.
+type memoryStore struct {
+	buckets map[string]map[string][]byte
+	mutex   sync.RWMutex
+}
+
+// NewMemory returns a Store keeping the values in memory, which are lost when the Device
+// Service stops.
+func NewMemory() Store {
+	return &memoryStore{buckets: make(map[string]map[string][]byte)}
+}
+
+func (s *memoryStore) Put(bucket string, key string, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, ok := s.buckets[bucket]
+	if !ok {
+		b = make(map[string][]byte)
+		s.buckets[bucket] = b
+	}
+	b[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *memoryStore) Get(bucket string, key string) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	value, ok := s.buckets[bucket][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (s *memoryStore) Delete(bucket string, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.buckets[bucket], key)
+	return nil
+}
+
+func (s *memoryStore) Keys(bucket string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	keys := make([]string, 0, len(s.buckets[bucket]))
+	for key := range s.buckets[bucket] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,52 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package store abstracts the persistence of the state of the Device Service, i.e. the
+// store-and-forward queue, the command history and the last readings of the AutoEvents
+// with OnChange, so that integrators can persist it in SQLite or in a platform-specific
+// store by calling Set before the Device Service starts.
+package store
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotFound is returned by Get for a key absent from the bucket.
+var ErrNotFound = errors.New("key not found")
+
+// Store persists values by key in buckets, each subsystem using its own bucket. It must
+// be safe for concurrent use.
+type Store interface {
+	// Put stores the value of the key in the bucket, replacing the previous one.
+	Put(bucket string, key string, value []byte) error
+	// Get returns the value of the key in the bucket, or ErrNotFound.
+	Get(bucket string, key string) ([]byte, error)
+	// Delete removes the key from the bucket. Deleting an absent key isn't an error.
+	Delete(bucket string, key string) error
+	// Keys returns the keys of the bucket in ascending order.
+	Keys(bucket string) ([]string, error)
+}
+
+var (
+	current Store
+	mutex   sync.RWMutex
+)
+
+// Set replaces the Store used by the SDK instead of the one configured. A nil Store
+// restores the configured one.
+func Set(s Store) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	current = s
+}
+
+// Get returns the Store set with Set, or nil.
+func Get() Store {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
@@ -0,0 +1,49 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file, err := NewFile(dir)
+	require.NoError(t, err)
+
+	for name, s := range map[string]Store{"memory": NewMemory(), "file": file} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, s.Put("events", "00000000000000000002", []byte("b")))
+			require.NoError(t, s.Put("events", "00000000000000000001", []byte("a")))
+			require.NoError(t, s.Put("other", "key/with spaces", []byte("c")))
+
+			keys, err := s.Keys("events")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"00000000000000000001", "00000000000000000002"}, keys)
+
+			value, err := s.Get("other", "key/with spaces")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("c"), value)
+
+			require.NoError(t, s.Delete("events", "00000000000000000001"))
+			require.NoError(t, s.Delete("events", "absent"))
+			_, err = s.Get("events", "00000000000000000001")
+			assert.Equal(t, ErrNotFound, err)
+
+			keys, err = s.Keys("empty")
+			require.NoError(t, err)
+			assert.Empty(t, keys)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package units converts values between the common units of measure of temperature,
+// pressure and flow, identified by their UCUM codes (https://ucum.org).
+package units
+
+import (
+	"fmt"
+)
+
+// The quantities of the units.
+const (
+	Temperature = "temperature"
+	Pressure    = "pressure"
+	Flow        = "flow"
+)
+
+// unit converts values to the base unit of its quantity as value*scale + offset.
+type unit struct {
+	quantity string
+	scale    float64
+	offset   float64
+}
+
+// units are keyed by canonical UCUM code. The base units are K, Pa and m3/s.
+var units = map[string]unit{
+	"K":      {Temperature, 1, 0},
+	"Cel":    {Temperature, 1, 273.15},
+	"[degF]": {Temperature, 5.0 / 9, 273.15 - 32*5.0/9},
+
+	"Pa":        {Pressure, 1, 0},
+	"hPa":       {Pressure, 1e2, 0},
+	"kPa":       {Pressure, 1e3, 0},
+	"MPa":       {Pressure, 1e6, 0},
+	"mbar":      {Pressure, 1e2, 0},
+	"bar":       {Pressure, 1e5, 0},
+	"atm":       {Pressure, 101325, 0},
+	"[psi]":     {Pressure, 6894.757293168, 0},
+	"mm[Hg]":    {Pressure, 133.322387415, 0},
+	"[in_i'Hg]": {Pressure, 3386.388640341, 0},
+
+	"m3/s":         {Flow, 1, 0},
+	"m3/h":         {Flow, 1.0 / 3600, 0},
+	"L/s":          {Flow, 1e-3, 0},
+	"L/min":        {Flow, 1e-3 / 60, 0},
+	"L/h":          {Flow, 1e-3 / 3600, 0},
+	"[gal_us]/min": {Flow, 3.785411784e-3 / 60, 0},
+	"[cft_i]/min":  {Flow, 0.028316846592 / 60, 0},
+}
+
+// aliases are the common notations of the units, keyed by notation with the canonical
+// UCUM code as value.
+var aliases = map[string]string{
+	"C":       "Cel",
+	"°C":      "Cel",
+	"degC":    "Cel",
+	"Celsius": "Cel",
+	"F":       "[degF]",
+	"°F":      "[degF]",
+	"degF":    "[degF]",
+	"Kelvin":  "K",
+	"psi":     "[psi]",
+	"mmHg":    "mm[Hg]",
+	"inHg":    "[in_i'Hg]",
+	"m³/s":    "m3/s",
+	"m³/h":    "m3/h",
+	"l/s":     "L/s",
+	"l/min":   "L/min",
+	"lpm":     "L/min",
+	"l/h":     "L/h",
+	"gpm":     "[gal_us]/min",
+	"cfm":     "[cft_i]/min",
+}
+
+// Canonical returns the canonical UCUM code of the unit, given either as UCUM code or in
+// a common notation, e.g. Cel for °C.
+func Canonical(code string) (string, bool) {
+	if _, ok := units[code]; ok {
+		return code, true
+	}
+	canonical, ok := aliases[code]
+	return canonical, ok
+}
+
+// Quantity returns the quantity measured in the unit, e.g. temperature for Cel.
+func Quantity(code string) (string, bool) {
+	canonical, ok := Canonical(code)
+	if !ok {
+		return "", false
+	}
+	return units[canonical].quantity, true
+}
+
+// Convert converts the value from a unit to another one of the same quantity.
+func Convert(value float64, from string, to string) (float64, error) {
+	fromCode, ok := Canonical(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %s", from)
+	}
+	toCode, ok := Canonical(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %s", to)
+	}
+	if fromCode == toCode {
+		return value, nil
+	}
+	fromUnit, toUnit := units[fromCode], units[toCode]
+	if fromUnit.quantity != toUnit.quantity {
+		return 0, fmt.Errorf("cannot convert %s of %s to %s of %s", from, fromUnit.quantity, to, toUnit.quantity)
+	}
+	base := value*fromUnit.scale + fromUnit.offset
+	return (base - toUnit.offset) / toUnit.scale, nil
+}
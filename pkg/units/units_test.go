@@ -0,0 +1,64 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2021 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Value    float64
+		From     string
+		To       string
+		Expected float64
+	}{
+		{"Celsius to Fahrenheit", 100, "Cel", "[degF]", 212},
+		{"Fahrenheit to Kelvin", 32, "°F", "K", 273.15},
+		{"Kelvin to Celsius", 0, "K", "degC", -273.15},
+		{"bar to psi", 1, "bar", "psi", 14.503773773},
+		{"atm to kPa", 1, "atm", "kPa", 101.325},
+		{"mmHg to hPa", 760, "mmHg", "hPa", 1013.250144354},
+		{"m3/h to L/min", 6, "m3/h", "lpm", 100},
+		{"gpm to L/s", 1, "gpm", "L/s", 0.0630901964},
+		{"same unit", 42, "Pa", "Pa", 42},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			converted, err := Convert(test.Value, test.From, test.To)
+			require.NoError(t, err)
+			assert.InDelta(t, test.Expected, converted, 1e-6)
+		})
+	}
+}
+
+func TestConvertError(t *testing.T) {
+	_, err := Convert(1, "Cel", "bar")
+	assert.Error(t, err, "different quantities")
+	_, err = Convert(1, "furlong", "m")
+	assert.Error(t, err, "unknown unit")
+}
+
+func TestCanonical(t *testing.T) {
+	code, ok := Canonical("°C")
+	assert.True(t, ok)
+	assert.Equal(t, "Cel", code)
+	code, ok = Canonical("[psi]")
+	assert.True(t, ok)
+	assert.Equal(t, "[psi]", code)
+	_, ok = Canonical("%")
+	assert.False(t, ok)
+
+	quantity, ok := Quantity("cfm")
+	assert.True(t, ok)
+	assert.Equal(t, Flow, quantity)
+}